@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"swap/client"
+	"swap/config"
+	"swap/wallet"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// options 各子命令共用的参数；优先级从高到低为显式传入的参数、配置文件、环境变量、默认配置
+type options struct {
+	configFile string
+	rpc        string
+	commitment string
+	keystore   string
+	wallet     string
+	wallets    string
+	slippageBP uint
+	urgency    string
+	mevNode    string
+	dryRun     bool
+	json       bool
+	debug      bool
+	timeout    time.Duration
+
+	set map[string]bool // 命令行中显式传入的参数
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.configFile, "config", os.Getenv("SWAP_CONFIG"), "SwapConfig JSON 配置文件（环境变量 SWAP_CONFIG）")
+	fs.StringVar(&o.rpc, "rpc", "", "RPC 节点（未传入且配置文件没有设置时取环境变量 RPC_ENDPOINT）")
+	fs.StringVar(&o.commitment, "commitment", "", "确认级别：processed、confirmed、finalized")
+	fs.StringVar(&o.keystore, "keystore", os.Getenv("KEYSTORE_DIR"), "加密钱包目录（环境变量 KEYSTORE_DIR）")
+	fs.StringVar(&o.wallet, "wallet", "", "签名使用的钱包名（未传入且配置文件没有设置时取环境变量 WALLET_NAME）")
+	fs.StringVar(&o.wallets, "wallets", os.Getenv("WALLETS"), "要解锁的钱包名，逗号分隔，默认只解锁 --wallet（环境变量 WALLETS）")
+	fs.UintVar(&o.slippageBP, "slippage-bp", 0, "滑点，10000 = 100%")
+	fs.StringVar(&o.urgency, "urgency", "", "优先费紧急程度：low、medium、high、urgent")
+	fs.StringVar(&o.mevNode, "mev-node", "", "Jito Block Engine 地址，设置后以 bundle 提交")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "只模拟交易，不发送")
+	fs.BoolVar(&o.json, "json", false, "以 JSON 输出结果")
+	fs.BoolVar(&o.debug, "debug", false, "输出调试日志")
+	fs.DurationVar(&o.timeout, "timeout", 2*time.Minute, "命令超时时间")
+}
+
+// parse 解析参数，允许参数和位置参数交替出现，返回位置参数
+func (o *options) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	o.set = make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { o.set[f.Name] = true })
+	return positional, nil
+}
+
+// swapConfig 按默认配置、环境变量、配置文件、命令行参数的顺序生成 SwapConfig
+func (o *options) swapConfig() (*config.SwapConfig, error) {
+	cfg := config.DefaultConfig()
+	if endpoint := os.Getenv("RPC_ENDPOINT"); endpoint != "" {
+		cfg.RPCEndpoint = endpoint
+	}
+	if name := os.Getenv("WALLET_NAME"); name != "" {
+		cfg.WalletName = name
+	}
+	if o.configFile != "" {
+		if err := config.MergeFile(cfg, o.configFile); err != nil {
+			return nil, err
+		}
+	}
+	if o.rpc != "" {
+		cfg.RPCEndpoint = o.rpc
+	}
+	if o.commitment != "" {
+		cfg.Commitment = rpc.CommitmentType(o.commitment)
+	}
+	if o.wallet != "" {
+		cfg.WalletName = o.wallet
+	}
+	if o.set["slippage-bp"] {
+		cfg.SlippageBP = uint32(o.slippageBP)
+	}
+	if o.urgency != "" {
+		cfg.PriorityUrgency = o.urgency
+	}
+	if o.mevNode != "" {
+		cfg.MEVNodeUrl = o.mevNode
+	}
+	if o.dryRun {
+		cfg.DryRun = true
+	}
+	if o.debug {
+		cfg.EnableDebugLog = true
+	}
+	return cfg, nil
+}
+
+// newClient 创建客户端：需要签名时加载钱包，否则创建只读客户端，不需要解锁钱包
+func (o *options) newClient(needWallet bool) (*client.PumpSwapClient, func(), error) {
+	cfg, err := o.swapConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !needWallet {
+		c, err := client.NewReadOnlyClient(cfg)
+		return c, func() {}, err
+	}
+
+	wallets, err := loadWallets(cfg, o.keystore, o.wallets)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load wallets: %w", err)
+	}
+	c, err := client.NewPumpSwapClientWithWallets(cfg, wallets)
+	if err != nil {
+		wallets.Lock()
+		return nil, nil, err
+	}
+	return c, wallets.Lock, nil
+}
+
+// printer 按 --json 选择输出格式：JSON 写到 stdout 便于脚本处理，提示信息写到 stderr
+type printer struct {
+	json bool
+}
+
+func (p printer) result(v any, human func()) {
+	if !p.json {
+		human()
+		return
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
+
+// info 提示信息，JSON 模式下不输出
+func (p printer) info(format string, args ...any) {
+	if !p.json {
+		fmt.Fprintf(os.Stderr, format, args...)
+	}
+}
+
+// fail 输出错误并退出，JSON 模式下带上错误分类
+func (p printer) fail(err error) {
+	if p.json {
+		_ = json.NewEncoder(os.Stdout).Encode(map[string]string{"error": err.Error(), "kind": errorKind(err)})
+	} else {
+		fmt.Fprintf(os.Stderr, "❌ %s：%v.\n", errorTitle(err), err)
+	}
+	os.Exit(1)
+}
+
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, client.ErrSlippageExceeded):
+		return "slippage_exceeded"
+	case errors.Is(err, client.ErrInsufficientFunds):
+		return "insufficient_funds"
+	case errors.Is(err, client.ErrPoolDisabled):
+		return "pool_disabled"
+	case errors.Is(err, client.ErrPoolNotFound):
+		return "pool_not_found"
+	case errors.Is(err, client.ErrNodeBehind):
+		return "node_behind"
+	case errors.Is(err, client.ErrBlockhashExpired):
+		return "blockhash_expired"
+	case errors.Is(err, client.ErrNoWallet), errors.Is(err, wallet.ErrWalletNotFound):
+		return "wallet"
+	case errors.Is(err, wallet.ErrWrongPassphrase):
+		return "wrong_passphrase"
+	case errors.Is(err, errUsage):
+		return "usage"
+	}
+	return "error"
+}
+
+func errorTitle(err error) string {
+	switch errorKind(err) {
+	case "slippage_exceeded":
+		return "交易失败，超出滑点"
+	case "insufficient_funds":
+		return "交易失败，余额不足"
+	case "pool_disabled":
+		return "交易失败，池子已禁用"
+	case "pool_not_found":
+		return "没有找到池子"
+	case "usage":
+		return "参数错误"
+	}
+	return "执行失败"
+}
+
+var errUsage = errors.New("invalid arguments")
+
+func usageError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+func parsePublicKey(name, value string) (solana.PublicKey, error) {
+	if value == "" {
+		return solana.PublicKey{}, usageError("--%s is required", name)
+	}
+	key, err := solana.PublicKeyFromBase58(value)
+	if err != nil {
+		return solana.PublicKey{}, usageError("invalid --%s %s: %v", name, value, err)
+	}
+	return key, nil
+}
+
+func parseUint(name, value string) (uint64, error) {
+	amount, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, usageError("invalid --%s %s, must be an integer in raw units", name, value)
+	}
+	return amount, nil
+}
+
+func parseAmount(name, value string) (uint64, error) {
+	if value == "" {
+		return 0, usageError("--%s is required", name)
+	}
+	amount, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || amount == 0 {
+		return 0, usageError("invalid --%s %s, must be a positive integer in raw units", name, value)
+	}
+	return amount, nil
+}
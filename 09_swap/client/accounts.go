@@ -0,0 +1,303 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"swap/quote"
+
+	pump_amm "github.com/gagliardetto/anchor-go/generated"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+var (
+	// PumpProgramID pump.fun 内盘程序，内盘毕业迁移的池子 creator 是它的 pool-authority PDA
+	PumpProgramID = solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
+	// PumpFeeProgramID 手续费配置程序，fee_config 账户由它持有
+	PumpFeeProgramID = solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")
+
+	ErrPoolNotFound = errors.New("pump swap pool not found")
+)
+
+const (
+	// Pool 账户布局：discriminator(8) + pool_bump(1) + index(2) + creator(32) + base_mint(32) + quote_mint(32) ...
+	poolBaseMintOffset  = 8 + 1 + 2 + 32
+	poolQuoteMintOffset = poolBaseMintOffset + 32
+
+	// GlobalConfig.DisableFlags 各个 bit 的含义
+	disableFlagBuy  = 1 << 3
+	disableFlagSell = 1 << 4
+)
+
+// PoolInfo 交易需要的池子信息：池子账户、两边的 token program 以及金库余额
+type PoolInfo struct {
+	Address           solana.PublicKey
+	Pool              *pump_amm.Pool
+	BaseTokenProgram  solana.PublicKey
+	QuoteTokenProgram solana.PublicKey
+	BaseReserves      uint64 // base（代币）金库余额
+	QuoteReserves     uint64 // quote（SOL）金库余额
+	BaseMintSupply    uint64 // 代币总供应量
+}
+
+// Canonical 是否为内盘毕业迁移创建的池子
+func (p *PoolInfo) Canonical() bool {
+	return p.Pool.Creator.Equals(pumpPoolAuthorityAddress(p.Pool.BaseMint))
+}
+
+// State 报价使用的池子状态
+func (p *PoolInfo) State() *quote.PoolState {
+	return &quote.PoolState{
+		BaseReserves:   p.BaseReserves,
+		QuoteReserves:  p.QuoteReserves,
+		CoinCreator:    p.Pool.CoinCreator,
+		BaseMintSupply: p.BaseMintSupply,
+		Canonical:      p.Canonical(),
+	}
+}
+
+// findPDA 在 programID 下推导 PDA
+func findPDA(programID solana.PublicKey, seeds ...[]byte) solana.PublicKey {
+	address, _, err := solana.FindProgramAddress(seeds, programID)
+	if err != nil {
+		// 种子长度都是固定的，推导失败只可能是代码写错
+		panic(fmt.Sprintf("find program address err: %v", err))
+	}
+	return address
+}
+
+// findAssociatedTokenAddress 推导 ATA 地址，支持 Token 和 Token-2022
+func findAssociatedTokenAddress(owner, mint, tokenProgram solana.PublicKey) solana.PublicKey {
+	return findPDA(solana.SPLAssociatedTokenAccountProgramID, owner[:], tokenProgram[:], mint[:])
+}
+
+func globalConfigAddress() solana.PublicKey {
+	return findPDA(pump_amm.ProgramID, []byte("global_config"))
+}
+
+func eventAuthorityAddress() solana.PublicKey {
+	return findPDA(pump_amm.ProgramID, []byte("__event_authority"))
+}
+
+func coinCreatorVaultAuthorityAddress(coinCreator solana.PublicKey) solana.PublicKey {
+	return findPDA(pump_amm.ProgramID, []byte("creator_vault"), coinCreator[:])
+}
+
+func globalVolumeAccumulatorAddress() solana.PublicKey {
+	return findPDA(pump_amm.ProgramID, []byte("global_volume_accumulator"))
+}
+
+func userVolumeAccumulatorAddress(user solana.PublicKey) solana.PublicKey {
+	return findPDA(pump_amm.ProgramID, []byte("user_volume_accumulator"), user[:])
+}
+
+func feeConfigAddress() solana.PublicKey {
+	return findPDA(PumpFeeProgramID, []byte("fee_config"), pump_amm.ProgramID[:])
+}
+
+// pumpPoolAuthorityAddress 内盘迁移时创建池子使用的 pool-authority
+func pumpPoolAuthorityAddress(mint solana.PublicKey) solana.PublicKey {
+	return findPDA(PumpProgramID, []byte("pool-authority"), mint[:])
+}
+
+// canonicalPoolAddress 内盘毕业迁移创建的池子地址：index 为 0，creator 为内盘的 pool-authority
+func canonicalPoolAddress(mint solana.PublicKey) solana.PublicKey {
+	poolAuthority := pumpPoolAuthorityAddress(mint)
+	index := make([]byte, 2)
+	binary.LittleEndian.PutUint16(index, 0)
+	return findPDA(pump_amm.ProgramID, []byte("pool"), index, poolAuthority[:], mint[:], solana.WrappedSol[:])
+}
+
+// ResolvePool 查找代币对应的 SOL 池子：优先使用迁移池子地址，找不到时按 base/quote mint 扫描程序账户，取 LP 供应量最大的池子
+func (c *PumpSwapClient) ResolvePool(ctx context.Context, mint solana.PublicKey) (*PoolInfo, error) {
+	address := canonicalPoolAddress(mint)
+	pool, err := c.getPool(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		address, pool, err = c.findPoolByMint(ctx, mint)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !pool.QuoteMint.Equals(solana.WrappedSol) {
+		return nil, fmt.Errorf("pool %s quote mint is %s, only SOL pools are supported", address, pool.QuoteMint)
+	}
+	c.logger.Debugf("resolved pool %s for mint %s", address, mint)
+
+	// 一次查询两个金库和两个 mint：金库给出储备，mint 的 owner 就是对应的 token program
+	accounts, err := c.getMultipleAccounts(ctx, pool.PoolBaseTokenAccount, pool.PoolQuoteTokenAccount, pool.BaseMint, pool.QuoteMint)
+	if err != nil {
+		return nil, fmt.Errorf("get pool %s vault accounts: %w", address, err)
+	}
+	for i, account := range accounts {
+		if account == nil {
+			return nil, fmt.Errorf("pool %s account #%d not found", address, i)
+		}
+	}
+	baseReserves, err := tokenAccountAmount(accounts[0])
+	if err != nil {
+		return nil, fmt.Errorf("pool %s base vault: %w", address, err)
+	}
+	quoteReserves, err := tokenAccountAmount(accounts[1])
+	if err != nil {
+		return nil, fmt.Errorf("pool %s quote vault: %w", address, err)
+	}
+	baseMintSupply, err := mintSupply(accounts[2])
+	if err != nil {
+		return nil, fmt.Errorf("pool %s base mint: %w", address, err)
+	}
+	return &PoolInfo{
+		Address:           address,
+		Pool:              pool,
+		BaseTokenProgram:  accounts[2].Owner,
+		QuoteTokenProgram: accounts[3].Owner,
+		BaseReserves:      baseReserves,
+		QuoteReserves:     quoteReserves,
+		BaseMintSupply:    baseMintSupply,
+	}, nil
+}
+
+// getPool 读取池子账户，账户不存在时返回 nil
+func (c *PumpSwapClient) getPool(ctx context.Context, address solana.PublicKey) (*pump_amm.Pool, error) {
+	accounts, err := c.getMultipleAccounts(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("get pool %s: %w", address, err)
+	}
+	if accounts[0] == nil {
+		return nil, nil
+	}
+	pool, err := pump_amm.ParseAccount_Pool(accounts[0].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("parse pool %s: %w", address, err)
+	}
+	return pool, nil
+}
+
+func (c *PumpSwapClient) findPoolByMint(ctx context.Context, mint solana.PublicKey) (solana.PublicKey, *pump_amm.Pool, error) {
+	result, err := c.rpcClient.GetProgramAccountsWithOpts(ctx, pump_amm.ProgramID, &rpc.GetProgramAccountsOpts{
+		Commitment: c.config.Commitment,
+		Encoding:   solana.EncodingBase64,
+		Filters: []rpc.RPCFilter{
+			{Memcmp: &rpc.RPCFilterMemcmp{Offset: poolBaseMintOffset, Bytes: mint[:]}},
+			{Memcmp: &rpc.RPCFilterMemcmp{Offset: poolQuoteMintOffset, Bytes: solana.WrappedSol[:]}},
+		},
+	})
+	if err != nil {
+		return solana.PublicKey{}, nil, fmt.Errorf("find pools for mint %s: %w", mint, err)
+	}
+
+	var address solana.PublicKey
+	var best *pump_amm.Pool
+	for _, item := range result {
+		pool, err := pump_amm.ParseAccount_Pool(item.Account.Data.GetBinary())
+		if err != nil {
+			continue
+		}
+		if best == nil || pool.LpSupply > best.LpSupply {
+			address, best = item.Pubkey, pool
+		}
+	}
+	if best == nil {
+		return solana.PublicKey{}, nil, fmt.Errorf("%w: mint %s", ErrPoolNotFound, mint)
+	}
+	return address, best, nil
+}
+
+// GetGlobalConfig 读取全局配置：手续费比例、协议手续费接收地址和禁用开关
+func (c *PumpSwapClient) GetGlobalConfig(ctx context.Context) (*pump_amm.GlobalConfig, error) {
+	address := globalConfigAddress()
+	accounts, err := c.getMultipleAccounts(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("get global config: %w", err)
+	}
+	if accounts[0] == nil {
+		return nil, fmt.Errorf("global config %s not found", address)
+	}
+	globalConfig, err := pump_amm.ParseAccount_GlobalConfig(accounts[0].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("parse global config: %w", err)
+	}
+	return globalConfig, nil
+}
+
+// GetFeeConfig 读取手续费配置，账户不存在时返回 nil，此时使用 GlobalConfig 中的手续费比例
+func (c *PumpSwapClient) GetFeeConfig(ctx context.Context) (*pump_amm.FeeConfig, error) {
+	address := feeConfigAddress()
+	accounts, err := c.getMultipleAccounts(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("get fee config: %w", err)
+	}
+	if accounts[0] == nil {
+		return nil, nil
+	}
+	feeConfig, err := pump_amm.ParseAccount_FeeConfig(accounts[0].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("parse fee config: %w", err)
+	}
+	return feeConfig, nil
+}
+
+// pickProtocolFeeRecipient 随机选择一个协议手续费接收地址，分散写锁竞争
+func pickProtocolFeeRecipient(globalConfig *pump_amm.GlobalConfig) (solana.PublicKey, error) {
+	recipients := make([]solana.PublicKey, 0, len(globalConfig.ProtocolFeeRecipients))
+	for _, recipient := range globalConfig.ProtocolFeeRecipients {
+		if !recipient.IsZero() {
+			recipients = append(recipients, recipient)
+		}
+	}
+	if len(recipients) == 0 {
+		return solana.PublicKey{}, errors.New("global config has no protocol fee recipient")
+	}
+	return recipients[rand.Intn(len(recipients))], nil
+}
+
+// getMultipleAccounts 批量读取账户，结果与 accounts 一一对应，不存在的账户为 nil
+func (c *PumpSwapClient) getMultipleAccounts(ctx context.Context, accounts ...solana.PublicKey) ([]*rpc.Account, error) {
+	resp, err := c.rpcClient.GetMultipleAccountsWithOpts(ctx, accounts, &rpc.GetMultipleAccountsOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: c.config.Commitment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || len(resp.Value) != len(accounts) {
+		return nil, fmt.Errorf("unexpected accounts size, want: %d", len(accounts))
+	}
+	return resp.Value, nil
+}
+
+// tokenAccountAmount 解析 token 账户余额，布局：mint(32) + owner(32) + amount(8) ...，Token-2022 账户前缀相同
+func tokenAccountAmount(account *rpc.Account) (uint64, error) {
+	data := account.Data.GetBinary()
+	if len(data) < 72 {
+		return 0, fmt.Errorf("invalid token account data size: %d", len(data))
+	}
+	return binary.LittleEndian.Uint64(data[64:72]), nil
+}
+
+// isTemporaryQuoteAccount WSOL 账户不存在或余额为 0 时为临时账户；
+// 用户用 wrap 包装过的 WSOL 不能随交易关闭，否则会被一并解包
+func isTemporaryQuoteAccount(account *rpc.Account) (bool, error) {
+	if account == nil {
+		return true, nil
+	}
+	amount, err := tokenAccountAmount(account)
+	if err != nil {
+		return false, err
+	}
+	return amount == 0, nil
+}
+
+// mintSupply 解析 mint 账户的总供应量，布局：mint_authority(4+32) + supply(8) ...
+func mintSupply(account *rpc.Account) (uint64, error) {
+	data := account.Data.GetBinary()
+	if len(data) < 44 {
+		return 0, fmt.Errorf("invalid mint account data size: %d", len(data))
+	}
+	return binary.LittleEndian.Uint64(data[36:44]), nil
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/programs/token"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// TokenBalance 一个代币账户的余额，数量为未除精度的原始数量
+type TokenBalance struct {
+	Mint         solana.PublicKey `json:"mint"`
+	Account      solana.PublicKey `json:"account"`
+	TokenProgram solana.PublicKey `json:"token_program"`
+	Amount       uint64           `json:"amount"`
+}
+
+// Balances 钱包的 SOL、WSOL 和代币余额
+type Balances struct {
+	Owner           solana.PublicKey `json:"owner"`
+	Lamports        uint64           `json:"lamports"`         // 钱包本身的 SOL
+	WrappedLamports uint64           `json:"wrapped_lamports"` // WSOL ATA 中包装的 SOL
+	Tokens          []TokenBalance   `json:"tokens"`           // 余额不为 0 的代币账户，不含 WSOL，按 mint 排序
+}
+
+// Balances 查询 owner 的余额，owner 为零值时查询当前钱包
+func (c *PumpSwapClient) Balances(ctx context.Context, owner solana.PublicKey) (*Balances, error) {
+	if owner.IsZero() {
+		if c.wallet == nil {
+			return nil, ErrNoWallet
+		}
+		owner = c.wallet.PublicKey()
+	}
+	balance, err := c.rpcClient.GetBalance(ctx, owner, c.config.Commitment)
+	if err != nil {
+		return nil, fmt.Errorf("get balance: %w", err)
+	}
+	balances := &Balances{Owner: owner, Lamports: balance.Value, Tokens: []TokenBalance{}}
+
+	for _, program := range []solana.PublicKey{solana.TokenProgramID, solana.Token2022ProgramID} {
+		program := program
+		resp, err := c.rpcClient.GetTokenAccountsByOwner(ctx, owner,
+			&rpc.GetTokenAccountsConfig{ProgramId: &program},
+			&rpc.GetTokenAccountsOpts{Commitment: c.config.Commitment, Encoding: solana.EncodingBase64},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("get token accounts of %s: %w", programName(program), err)
+		}
+		for _, account := range resp.Value {
+			data := account.Account.Data.GetBinary()
+			amount, err := tokenAccountAmount(&account.Account)
+			if err != nil {
+				return nil, fmt.Errorf("token account %s: %w", account.Pubkey, err)
+			}
+			mint := solana.PublicKeyFromBytes(data[:32])
+			if mint.Equals(solana.WrappedSol) {
+				balances.WrappedLamports += amount
+				continue
+			}
+			if amount == 0 {
+				continue
+			}
+			balances.Tokens = append(balances.Tokens, TokenBalance{
+				Mint: mint, Account: account.Pubkey, TokenProgram: program, Amount: amount,
+			})
+		}
+	}
+	sort.Slice(balances.Tokens, func(i, j int) bool {
+		return balances.Tokens[i].Mint.String() < balances.Tokens[j].Mint.String()
+	})
+	return balances, nil
+}
+
+// TokenBalance 当前钱包 mint 代币 ATA 中的数量，卖出使用的就是这个账户，ATA 不存在时为 0
+func (c *PumpSwapClient) TokenBalance(ctx context.Context, mint solana.PublicKey) (uint64, error) {
+	if c.wallet == nil {
+		return 0, ErrNoWallet
+	}
+	user := c.wallet.PublicKey()
+	// mint 属于哪个 token program 就只有对应的 ATA 会存在
+	accounts, err := c.getMultipleAccounts(ctx,
+		findAssociatedTokenAddress(user, mint, solana.TokenProgramID),
+		findAssociatedTokenAddress(user, mint, solana.Token2022ProgramID),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("get token accounts: %w", err)
+	}
+	var amount uint64
+	for _, account := range accounts {
+		if account == nil {
+			continue
+		}
+		value, err := tokenAccountAmount(account)
+		if err != nil {
+			return 0, err
+		}
+		amount += value
+	}
+	return amount, nil
+}
+
+// Wrap 把 lamports 包装成 WSOL，WSOL ATA 不存在时自动创建
+func (c *PumpSwapClient) Wrap(ctx context.Context, lamports uint64) (*TxResult, error) {
+	if c.wallet == nil {
+		return nil, ErrNoWallet
+	}
+	if lamports == 0 {
+		return nil, errors.New("wrap amount must be greater than 0")
+	}
+	user := c.wallet.PublicKey()
+	wsolAccount := findAssociatedTokenAddress(user, solana.WrappedSol, solana.TokenProgramID)
+	return c.sendAndConfirm(ctx, []solana.Instruction{
+		newCreateAssociatedTokenAccountIdempotentInstruction(user, wsolAccount, user, solana.WrappedSol, solana.TokenProgramID),
+		system.NewTransferInstruction(lamports, user, wsolAccount).Build(),
+		token.NewSyncNativeInstruction(wsolAccount).Build(),
+	})
+}
+
+// Unwrap 关闭 WSOL ATA，包装的 SOL 和租金全部退回钱包
+func (c *PumpSwapClient) Unwrap(ctx context.Context) (*TxResult, error) {
+	if c.wallet == nil {
+		return nil, ErrNoWallet
+	}
+	user := c.wallet.PublicKey()
+	wsolAccount := findAssociatedTokenAddress(user, solana.WrappedSol, solana.TokenProgramID)
+	existing, err := c.getMultipleAccounts(ctx, wsolAccount)
+	if err != nil {
+		return nil, fmt.Errorf("get wsol account: %w", err)
+	}
+	if existing[0] == nil {
+		return nil, fmt.Errorf("wsol account %s does not exist", wsolAccount)
+	}
+	return c.sendAndConfirm(ctx, []solana.Instruction{
+		token.NewCloseAccountInstruction(wsolAccount, user, user, nil).Build(),
+	})
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"swap/jito"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+// 交易的提交方式
+const (
+	SendViaRPC    = "rpc"
+	SendViaBundle = "bundle"
+)
+
+// tipInstruction 转账 JitoTipLamports 给随机选择的 tip 账户；放在交易最后，swap 失败时整笔交易回滚，不会白付 tip
+func (c *PumpSwapClient) tipInstruction(ctx context.Context) solana.Instruction {
+	c.tips.once.Do(func() {
+		accounts, err := c.bundler.GetTipAccounts(ctx)
+		if err != nil || len(accounts) == 0 {
+			c.logger.Warnf("get jito tip accounts err: %v, use default tip accounts", err)
+			return
+		}
+		c.tips.accounts = accounts
+	})
+	tipAccount := jito.RandomTipAccount(c.tips.accounts)
+	return system.NewTransferInstruction(c.config.JitoTipLamports, c.wallet.PublicKey(), tipAccount).Build()
+}
+
+// sendBundle 以单笔交易的 bundle 提交并等待上链，BundleTimeout 内没有上链或 bundle 失败时返回错误，由调用方改走 RPC
+func (c *PumpSwapClient) sendBundle(ctx context.Context, tx *solana.Transaction) (string, error) {
+	bundleCtx, cancel := context.WithTimeout(ctx, c.config.BundleTimeout)
+	defer cancel()
+
+	bundleID, err := c.bundler.SendBundle(bundleCtx, []*solana.Transaction{tx})
+	if err != nil {
+		return "", fmt.Errorf("send bundle: %w", err)
+	}
+	c.logger.Debugf("bundle sent: %s, transaction: %s", bundleID, tx.Signatures[0])
+
+	status, err := c.bundler.WaitForBundle(bundleCtx, bundleID, confirmPollInterval)
+	if err != nil {
+		return bundleID, err
+	}
+	if status.LandedSlot != nil {
+		c.logger.Debugf("bundle landed: %s, slot: %d", bundleID, *status.LandedSlot)
+	}
+	return bundleID, nil
+}
+
+// signatureSeen 交易是否已经被节点看到；bundle 超时后可能又上链了，改走 RPC 前先确认
+func (c *PumpSwapClient) signatureSeen(ctx context.Context, signature solana.Signature) bool {
+	statuses, err := c.rpcClient.GetSignatureStatuses(ctx, false, signature)
+	return err == nil && len(statuses.Value) > 0 && statuses.Value[0] != nil
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"swap/config"
+	"swap/wallet"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+// fakeChain 本地模拟的 RPC 节点和 Block Engine：交易通过 sendTransaction 或上链的 bundle 提交后即视为已确认
+type fakeChain struct {
+	mu           sync.Mutex
+	bundleLands  bool
+	landed       map[string]bool // 已上链的交易签名
+	rpcSends     int
+	bundleTxs    []*solana.Transaction
+	tipAccount   solana.PublicKey
+	unitsPerTx   uint64
+	recentFee    uint64
+	blockhash    solana.Hash
+	lastValidBlk uint64
+}
+
+func (f *fakeChain) rpcServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		rpcContext := map[string]any{"slot": 100}
+		var result any
+		switch req.Method {
+		case "getLatestBlockhash":
+			result = map[string]any{"context": rpcContext, "value": map[string]any{
+				"blockhash": f.blockhash.String(), "lastValidBlockHeight": f.lastValidBlk,
+			}}
+		case "getRecentPrioritizationFees":
+			result = []map[string]any{{"slot": 99, "prioritizationFee": f.recentFee}}
+		case "simulateTransaction":
+			result = map[string]any{"context": rpcContext, "value": map[string]any{
+				"err": nil, "logs": []string{}, "unitsConsumed": f.unitsPerTx,
+			}}
+		case "sendTransaction":
+			tx := decodeTransaction(t, req.Params[0])
+			f.rpcSends++
+			f.landed[tx.Signatures[0].String()] = true
+			result = tx.Signatures[0].String()
+		case "getSignatureStatuses":
+			var signatures []string
+			_ = json.Unmarshal(req.Params[0], &signatures)
+			value := make([]any, 0, len(signatures))
+			for _, signature := range signatures {
+				if !f.landed[signature] {
+					value = append(value, nil)
+					continue
+				}
+				value = append(value, map[string]any{
+					"slot": 101, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed",
+				})
+			}
+			result = map[string]any{"context": rpcContext, "value": value}
+		case "getBlockHeight":
+			result = 10
+		default:
+			t.Errorf("unexpected rpc method %s", req.Method)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (f *fakeChain) blockEngine(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		var result any
+		switch req.Method {
+		case "getTipAccounts":
+			result = []string{f.tipAccount.String()}
+		case "sendBundle":
+			var encoded []string
+			_ = json.Unmarshal(req.Params[0], &encoded)
+			for _, item := range encoded {
+				raw, _ := json.Marshal(item)
+				tx := decodeTransaction(t, raw)
+				f.bundleTxs = append(f.bundleTxs, tx)
+				if f.bundleLands {
+					f.landed[tx.Signatures[0].String()] = true
+				}
+			}
+			result = "test-bundle"
+		case "getInflightBundleStatuses":
+			status := map[string]any{"bundle_id": "test-bundle", "status": "Pending", "landed_slot": nil}
+			if f.bundleLands {
+				status["status"] = "Landed"
+				status["landed_slot"] = 101
+			}
+			result = map[string]any{"context": map[string]any{"slot": 101}, "value": []any{status}}
+		default:
+			t.Errorf("unexpected block engine method %s", req.Method)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func decodeTransaction(t *testing.T, param json.RawMessage) *solana.Transaction {
+	var encoded string
+	if err := json.Unmarshal(param, &encoded); err != nil {
+		t.Fatalf("decode transaction param: %v", err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("decode base64 transaction: %v", err)
+	}
+	tx, err := solana.TransactionFromBytes(raw)
+	if err != nil {
+		t.Fatalf("decode transaction: %v", err)
+	}
+	return tx
+}
+
+func newBundleTestClient(t *testing.T, chain *fakeChain) *PumpSwapClient {
+	cfg := config.DefaultConfig()
+	cfg.RPCEndpoint = chain.rpcServer(t).URL
+	cfg.MEVNodeUrl = chain.blockEngine(t).URL
+	cfg.Commitment = "confirmed"
+	cfg.BundleTimeout = 1200 * time.Millisecond
+	cfg.Maxretries = 0
+
+	c, err := NewPumpSwapClient(cfg, wallet.NewMemoryWallet(solana.NewWallet().PrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func newFakeChain(bundleLands bool) *fakeChain {
+	return &fakeChain{
+		bundleLands:  bundleLands,
+		landed:       make(map[string]bool),
+		tipAccount:   solana.NewWallet().PublicKey(),
+		unitsPerTx:   50_000,
+		recentFee:    20_000,
+		blockhash:    solana.Hash{7},
+		lastValidBlk: 1_000,
+	}
+}
+
+func memoInstructions() []solana.Instruction {
+	return []solana.Instruction{solana.NewInstruction(solana.MemoProgramID, nil, []byte("swap"))}
+}
+
+// assertTipTransfer 交易最后一条指令是给 tip 账户的转账
+func assertTipTransfer(t *testing.T, tx *solana.Transaction, tipAccount solana.PublicKey, lamports uint64) {
+	t.Helper()
+	compiled := tx.Message.Instructions[len(tx.Message.Instructions)-1]
+	program, err := tx.Message.ResolveProgramIDIndex(compiled.ProgramIDIndex)
+	if err != nil || !program.Equals(solana.SystemProgramID) {
+		t.Fatalf("last instruction is not a system instruction: %v", err)
+	}
+	accounts, err := compiled.ResolveInstructionAccounts(&tx.Message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := system.DecodeInstruction(accounts, compiled.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer, ok := decoded.Impl.(*system.Transfer)
+	if !ok {
+		t.Fatalf("last instruction is %T, want transfer", decoded.Impl)
+	}
+	if *transfer.Lamports != lamports || !transfer.GetRecipientAccount().PublicKey.Equals(tipAccount) {
+		t.Fatalf("tip transfer %d to %s, want %d to %s",
+			*transfer.Lamports, transfer.GetRecipientAccount().PublicKey, lamports, tipAccount)
+	}
+}
+
+func TestSendAndConfirmViaBundle(t *testing.T) {
+	chain := newFakeChain(true)
+	c := newBundleTestClient(t, chain)
+
+	result, err := c.sendAndConfirm(context.Background(), memoInstructions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SentVia != SendViaBundle || result.BundleID != "test-bundle" || result.Tip != c.config.JitoTipLamports {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if chain.rpcSends != 0 {
+		t.Fatalf("landed bundle should not be sent via rpc, got %d sends", chain.rpcSends)
+	}
+	if len(chain.bundleTxs) != 1 || chain.bundleTxs[0].Signatures[0] != result.Signature {
+		t.Fatalf("bundle transaction does not match the confirmed signature")
+	}
+	assertTipTransfer(t, chain.bundleTxs[0], chain.tipAccount, c.config.JitoTipLamports)
+}
+
+func TestSendAndConfirmFallsBackToRPC(t *testing.T) {
+	chain := newFakeChain(false)
+	c := newBundleTestClient(t, chain)
+
+	result, err := c.sendAndConfirm(context.Background(), memoInstructions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SentVia != SendViaRPC || result.BundleID != "test-bundle" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if chain.rpcSends != 1 {
+		t.Fatalf("expected exactly one rpc send, got %d", chain.rpcSends)
+	}
+	// 回退时发送的是同一笔已签名交易，bundle 晚到也不会重复成交
+	if len(chain.bundleTxs) != 1 || chain.bundleTxs[0].Signatures[0] != result.Signature {
+		t.Fatalf("rpc fallback should resend the bundled transaction")
+	}
+}
@@ -1,27 +1,80 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"swap/config"
+	"swap/jito"
 	"swap/wallet"
+	"sync"
 
+	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/sirupsen/logrus"
 )
 
 // PumpSwapClient 客户端对象
 type PumpSwapClient struct {
-	config    *config.SwapConfig
-	wallet    wallet.SecureWallet
-	rpcClient *rpc.Client
-	logger    *logrus.Logger
+	config     *config.SwapConfig
+	wallet     wallet.SecureWallet // 当前用于签名的钱包
+	walletName string
+	wallets    *wallet.Set // 可以切换的命名钱包
+	rpcClient  *rpc.Client
+	logger     *logrus.Logger
 
 	// 内部组件
+	bundler *jito.Client // 配置了 MEVNodeUrl 时以 Jito bundle 提交交易
+	tips    *tipAccounts
+}
 
+// tipAccounts 从 Block Engine 查询一次后缓存的 tip 账户，切换钱包得到的客户端共享
+type tipAccounts struct {
+	once     sync.Once
+	accounts []solana.PublicKey
 }
 
+// 默认钱包名，NewPumpSwapClient 传入的钱包以这个名称注册
+const DefaultWalletName = "default"
+
+var ErrNoWallet = errors.New("client has no wallet, it is read only")
+
 // 用swapConfig和wallet创建一个客户端
-func NewPumpSwapClient(swapConfig *config.SwapConfig, wallet *wallet.SecureWallet) (*PumpSwapClient, error) {
+func NewPumpSwapClient(swapConfig *config.SwapConfig, w wallet.SecureWallet) (*PumpSwapClient, error) {
+	if w == nil {
+		return nil, fmt.Errorf("wallet is required")
+	}
+	wallets := wallet.NewSet()
+	if err := wallets.Add(DefaultWalletName, w); err != nil {
+		return nil, err
+	}
+	return newPumpSwapClient(swapConfig, wallets, DefaultWalletName)
+}
+
+// NewPumpSwapClientWithWallets 用多个命名钱包创建客户端，默认使用 SwapConfig.WalletName 对应的钱包签名，
+// 之后可以用 UseWallet 按名称切换
+func NewPumpSwapClientWithWallets(swapConfig *config.SwapConfig, wallets *wallet.Set) (*PumpSwapClient, error) {
+	if wallets == nil {
+		return nil, fmt.Errorf("wallets are required")
+	}
+	if swapConfig == nil {
+		swapConfig = config.DefaultConfig()
+	}
+	return newPumpSwapClient(swapConfig, wallets, swapConfig.WalletName)
+}
+
+// NewReadOnlyClient 不带钱包的客户端，只能报价和查询，买卖返回 ErrNoWallet
+func NewReadOnlyClient(swapConfig *config.SwapConfig) (*PumpSwapClient, error) {
+	return newPumpSwapClient(swapConfig, wallet.NewSet(), "")
+}
+
+func newPumpSwapClient(swapConfig *config.SwapConfig, wallets *wallet.Set, walletName string) (*PumpSwapClient, error) {
+	var w wallet.SecureWallet
+	if walletName != "" {
+		var err error
+		if w, err = wallets.Get(walletName); err != nil {
+			return nil, err
+		}
+	}
 	if swapConfig == nil {
 		swapConfig = config.DefaultConfig()
 	}
@@ -38,9 +91,63 @@ func NewPumpSwapClient(swapConfig *config.SwapConfig, wallet *wallet.SecureWalle
 	}
 
 	client := &PumpSwapClient{
-		config:    swapConfig,
-		wallet:    wallet,
-		rpcClient: rpcClient,
-		logger:    logger,
+		config:     swapConfig,
+		wallet:     w,
+		walletName: walletName,
+		wallets:    wallets,
+		rpcClient:  rpcClient,
+		logger:     logger,
+		tips:       &tipAccounts{},
+	}
+	if swapConfig.MEVNodeUrl != "" {
+		client.bundler = jito.NewClient(swapConfig.MEVNodeUrl)
+	}
+	return client, nil
+}
+
+// PublicKey 返回客户端使用的钱包地址，只读客户端返回零值
+func (c *PumpSwapClient) PublicKey() solana.PublicKey {
+	if c.wallet == nil {
+		return solana.PublicKey{}
+	}
+	return c.wallet.PublicKey()
+}
+
+// Config 返回客户端使用的配置，不要修改
+func (c *PumpSwapClient) Config() *config.SwapConfig {
+	return c.config
+}
+
+// WalletName 返回客户端使用的钱包名
+func (c *PumpSwapClient) WalletName() string {
+	return c.walletName
+}
+
+// WalletNames 返回可以切换的钱包名
+func (c *PumpSwapClient) WalletNames() []string {
+	return c.wallets.Names()
+}
+
+// UseWallet 返回用名为 name 的钱包签名的客户端，和原客户端共享配置、RPC 连接和 Block Engine 连接
+func (c *PumpSwapClient) UseWallet(name string) (*PumpSwapClient, error) {
+	w, err := c.wallets.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	clone := *c
+	clone.wallet = w
+	clone.walletName = name
+	return &clone, nil
+}
+
+// WithSlippage 返回按 slippageBP 滑点下单的客户端，和原客户端共享钱包、RPC 连接和 Block Engine 连接
+func (c *PumpSwapClient) WithSlippage(slippageBP uint32) (*PumpSwapClient, error) {
+	cfg := *c.config
+	cfg.SlippageBP = slippageBP
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid slippage: %w", err)
 	}
+	clone := *c
+	clone.config = &cfg
+	return &clone, nil
 }
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"swap/config"
+	"swap/wallet"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+func TestUseWallet(t *testing.T) {
+	wallets := wallet.NewSet()
+	mainWallet := wallet.NewMemoryWallet(solana.NewWallet().PrivateKey)
+	sniperWallet := wallet.NewMemoryWallet(solana.NewWallet().PrivateKey)
+	if err := wallets.Add("main", mainWallet); err != nil {
+		t.Fatal(err)
+	}
+	if err := wallets.Add("sniper", sniperWallet); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.WalletName = "main"
+	c, err := NewPumpSwapClientWithWallets(cfg, wallets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.WalletName() != "main" || !c.PublicKey().Equals(mainWallet.PublicKey()) {
+		t.Fatalf("default wallet = %s %s", c.WalletName(), c.PublicKey())
+	}
+
+	sniper, err := c.UseWallet("sniper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sniper.WalletName() != "sniper" || !sniper.PublicKey().Equals(sniperWallet.PublicKey()) {
+		t.Fatalf("switched wallet = %s %s", sniper.WalletName(), sniper.PublicKey())
+	}
+	if !c.PublicKey().Equals(mainWallet.PublicKey()) {
+		t.Fatal("UseWallet should not change the original client")
+	}
+	if sniper.rpcClient != c.rpcClient || sniper.tips != c.tips {
+		t.Fatal("switched client should share connections")
+	}
+
+	if _, err = c.UseWallet("missing"); !errors.Is(err, wallet.ErrWalletNotFound) {
+		t.Fatalf("missing wallet err = %v", err)
+	}
+	cfg.WalletName = "missing"
+	if _, err = NewPumpSwapClientWithWallets(cfg, wallets); !errors.Is(err, wallet.ErrWalletNotFound) {
+		t.Fatalf("missing default wallet err = %v", err)
+	}
+}
+
+func TestWithSlippage(t *testing.T) {
+	c, err := NewReadOnlyClient(config.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	loose, err := c.WithSlippage(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loose.Config().SlippageBP != 1000 || c.Config().SlippageBP != config.DefaultConfig().SlippageBP {
+		t.Fatalf("slippage = %d, original = %d", loose.Config().SlippageBP, c.Config().SlippageBP)
+	}
+	if loose.rpcClient != c.rpcClient {
+		t.Fatal("slippage client should share connections")
+	}
+	if _, err = c.WithSlippage(c.Config().MaxSlippageBP + 1); err == nil {
+		t.Fatal("slippage above max should be rejected")
+	}
+}
+
+func TestIsTemporaryQuoteAccount(t *testing.T) {
+	tokenAccount := func(amount uint64) *rpc.Account {
+		data := make([]byte, 165)
+		binary.LittleEndian.PutUint64(data[64:72], amount)
+		return &rpc.Account{Data: rpc.DataBytesOrJSONFromBytes(data)}
+	}
+	cases := []struct {
+		name    string
+		account *rpc.Account
+		want    bool
+	}{
+		{name: "not created", want: true},
+		{name: "empty", account: tokenAccount(0), want: true},
+		// 用户 wrap 过的 WSOL 不能随交易关闭
+		{name: "wrapped balance", account: tokenAccount(1_000_000)},
+	}
+	for _, c := range cases {
+		got, err := isTemporaryQuoteAccount(c.account)
+		if err != nil || got != c.want {
+			t.Fatalf("%s: got %v, %v, want %v", c.name, got, err, c.want)
+		}
+	}
+	if _, err := isTemporaryQuoteAccount(&rpc.Account{Data: rpc.DataBytesOrJSONFromBytes(nil)}); err == nil {
+		t.Fatal("invalid account data should fail")
+	}
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	pump_amm "github.com/gagliardetto/anchor-go/generated"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+// 交易失败的分类，可以用 errors.Is 判断
+var (
+	ErrSlippageExceeded  = errors.New("slippage exceeded")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrPoolDisabled      = errors.New("pool disabled")
+	ErrNodeBehind        = errors.New("rpc node is behind")
+)
+
+// Solana RPC 的错误码：节点落后或未达到 minContextSlot 时可以换节点或稍后重试
+const (
+	rpcCodeNodeUnhealthy            = -32005
+	rpcCodeMinContextSlotNotReached = -32016
+	rpcCodeBlockNotAvailable        = -32004
+)
+
+// TxError 交易模拟或执行失败的原因
+type TxError struct {
+	InstructionIndex int              // 出错的指令序号，交易级错误为 -1
+	Program          solana.PublicKey // 出错指令所属的程序
+	Code             int              // 程序自定义错误码，非自定义错误为 -1
+	Name             string           // 错误名称，例如 ExceededSlippage、InsufficientFundsForFee
+	Kind             error            // 错误分类，未归类时为 nil
+	Retryable        bool             // 换新的 blockhash 重试是否可能成功
+	Logs             []string         // 模拟时的程序日志
+}
+
+func (e *TxError) Error() string {
+	var b strings.Builder
+	if e.InstructionIndex >= 0 {
+		fmt.Fprintf(&b, "instruction #%d", e.InstructionIndex)
+		if !e.Program.IsZero() {
+			fmt.Fprintf(&b, " (%s)", programName(e.Program))
+		}
+		b.WriteString(" failed: ")
+	} else {
+		b.WriteString("transaction failed: ")
+	}
+	b.WriteString(e.Name)
+	if e.Code >= 0 {
+		fmt.Fprintf(&b, "(%d)", e.Code)
+	}
+	if e.Kind != nil {
+		fmt.Fprintf(&b, ", %v", e.Kind)
+	}
+	return b.String()
+}
+
+func (e *TxError) Unwrap() error {
+	return e.Kind
+}
+
+// 交易级错误中可以换 blockhash 重试的情况
+var retryableTxErrors = map[string]bool{
+	"BlockhashNotFound":              true,
+	"AccountInUse":                   true,
+	"WouldExceedMaxBlockCostLimit":   true,
+	"WouldExceedMaxAccountCostLimit": true,
+}
+
+// Token 程序和 System 程序的自定义错误码
+const (
+	tokenErrInsufficientFunds           = 1
+	systemErrResultWithNegativeLamports = 1
+)
+
+// decodeTxError 解析模拟结果、交易状态或 RPC preflight 错误中的 err 字段：
+// 交易级错误为字符串，指令错误为 {"InstructionError": [index, detail]}，detail 为字符串或 {"Custom": code}
+func decodeTxError(raw any, instructions []solana.Instruction, logs []string) *TxError {
+	txErr := &TxError{InstructionIndex: -1, Code: -1, Logs: logs}
+	switch v := raw.(type) {
+	case string:
+		txErr.Name = v
+		txErr.Retryable = retryableTxErrors[v]
+		if strings.HasPrefix(v, "InsufficientFunds") {
+			txErr.Kind = ErrInsufficientFunds
+		}
+		return txErr
+	case map[string]any:
+		items, ok := v["InstructionError"].([]any)
+		if !ok || len(items) != 2 {
+			txErr.Name = fmt.Sprint(v)
+			return txErr
+		}
+		if index, ok := toInt(items[0]); ok {
+			txErr.InstructionIndex = index
+			if index >= 0 && index < len(instructions) {
+				txErr.Program = instructions[index].ProgramID()
+			}
+		}
+		switch detail := items[1].(type) {
+		case string:
+			txErr.Name = detail
+		case map[string]any:
+			if code, ok := toInt(detail["Custom"]); ok {
+				txErr.Code = code
+				txErr.Name, txErr.Kind = customErrorName(txErr.Program, code)
+			} else {
+				txErr.Name = fmt.Sprint(detail)
+			}
+		default:
+			txErr.Name = fmt.Sprint(detail)
+		}
+		return txErr
+	default:
+		txErr.Name = fmt.Sprint(raw)
+		return txErr
+	}
+}
+
+// customErrorName 按程序解析自定义错误码；PumpSwap 的错误码来自生成的 pump_amm.Errors
+func customErrorName(program solana.PublicKey, code int) (string, error) {
+	switch {
+	case program.Equals(pump_amm.ProgramID):
+		customErr, ok := pump_amm.Errors[code]
+		if !ok {
+			return fmt.Sprintf("PumpSwapError%d", code), nil
+		}
+		switch customErr {
+		case pump_amm.ErrExceededSlippage, pump_amm.ErrBuySlippageBelowMinBaseAmountOut:
+			return customErr.Name(), ErrSlippageExceeded
+		case pump_amm.ErrDisabledBuy, pump_amm.ErrDisabledSell:
+			return customErr.Name(), ErrPoolDisabled
+		}
+		return customErr.Name(), nil
+	case program.Equals(solana.TokenProgramID), program.Equals(solana.Token2022ProgramID):
+		if code == tokenErrInsufficientFunds {
+			return "InsufficientFunds", ErrInsufficientFunds
+		}
+		return fmt.Sprintf("TokenError%d", code), nil
+	case program.Equals(solana.SystemProgramID):
+		if code == systemErrResultWithNegativeLamports {
+			return "ResultWithNegativeLamports", ErrInsufficientFunds
+		}
+		return fmt.Sprintf("SystemError%d", code), nil
+	}
+	return fmt.Sprintf("Custom%d", code), nil
+}
+
+// decodeRPCError 解析 RPC 调用错误：preflight 失败时 data 中带有 err 和 logs，节点落后时可以重试
+func decodeRPCError(err error, instructions []solana.Instruction) error {
+	var rpcErr *jsonrpc.RPCError
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+	switch rpcErr.Code {
+	case rpcCodeNodeUnhealthy, rpcCodeMinContextSlotNotReached, rpcCodeBlockNotAvailable:
+		return &TxError{InstructionIndex: -1, Code: -1, Name: rpcErr.Message, Kind: ErrNodeBehind, Retryable: true}
+	}
+	data, ok := rpcErr.Data.(map[string]any)
+	if !ok || data["err"] == nil {
+		return err
+	}
+	var logs []string
+	if items, ok := data["logs"].([]any); ok {
+		for _, item := range items {
+			logs = append(logs, fmt.Sprint(item))
+		}
+	}
+	return decodeTxError(data["err"], instructions, logs)
+}
+
+// isRetryable 是否可以用新的 blockhash 重新构建交易重试
+func isRetryable(err error) bool {
+	if errors.Is(err, ErrBlockhashExpired) {
+		return true
+	}
+	var txErr *TxError
+	return errors.As(err, &txErr) && txErr.Retryable
+}
+
+func toInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err == nil
+	case float64:
+		return int(n), true
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+func programName(program solana.PublicKey) string {
+	switch {
+	case program.Equals(pump_amm.ProgramID):
+		return "pump_amm"
+	case program.Equals(solana.TokenProgramID):
+		return "token"
+	case program.Equals(solana.Token2022ProgramID):
+		return "token-2022"
+	case program.Equals(solana.SystemProgramID):
+		return "system"
+	case program.Equals(solana.SPLAssociatedTokenAccountProgramID):
+		return "associated-token"
+	case program.Equals(solana.ComputeBudget):
+		return "compute-budget"
+	}
+	return program.String()
+}
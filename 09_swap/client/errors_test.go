@@ -0,0 +1,97 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	pump_amm "github.com/gagliardetto/anchor-go/generated"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+func TestDecodeTxError(t *testing.T) {
+	instructions := []solana.Instruction{
+		solana.NewInstruction(solana.SystemProgramID, nil, nil),
+		solana.NewInstruction(solana.TokenProgramID, nil, nil),
+		solana.NewInstruction(pump_amm.ProgramID, nil, nil),
+	}
+	instructionError := func(index int, detail any) any {
+		return map[string]any{"InstructionError": []any{json.Number(strconv.Itoa(index)), detail}}
+	}
+	custom := func(code int) any {
+		return map[string]any{"Custom": json.Number(strconv.Itoa(code))}
+	}
+
+	tests := []struct {
+		name      string
+		raw       any
+		index     int
+		code      int
+		errName   string
+		kind      error
+		retryable bool
+	}{
+		{"slippage", instructionError(2, custom(6004)), 2, 6004, "ExceededSlippage", ErrSlippageExceeded, false},
+		{"min base out", instructionError(2, custom(6040)), 2, 6040, "BuySlippageBelowMinBaseAmountOut", ErrSlippageExceeded, false},
+		{"buy disabled", instructionError(2, custom(6020)), 2, 6020, "DisabledBuy", ErrPoolDisabled, false},
+		{"sell disabled", instructionError(2, custom(6021)), 2, 6021, "DisabledSell", ErrPoolDisabled, false},
+		{"token insufficient funds", instructionError(1, custom(1)), 1, 1, "InsufficientFunds", ErrInsufficientFunds, false},
+		{"system negative lamports", instructionError(0, custom(1)), 0, 1, "ResultWithNegativeLamports", ErrInsufficientFunds, false},
+		{"builtin instruction error", instructionError(2, "InvalidAccountData"), 2, -1, "InvalidAccountData", nil, false},
+		{"blockhash not found", "BlockhashNotFound", -1, -1, "BlockhashNotFound", nil, true},
+		{"fee payer", "InsufficientFundsForFee", -1, -1, "InsufficientFundsForFee", ErrInsufficientFunds, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txErr := decodeTxError(tt.raw, instructions, nil)
+			if txErr.InstructionIndex != tt.index || txErr.Code != tt.code || txErr.Name != tt.errName {
+				t.Fatalf("got index=%d code=%d name=%s", txErr.InstructionIndex, txErr.Code, txErr.Name)
+			}
+			if tt.kind != nil && !errors.Is(txErr, tt.kind) {
+				t.Fatalf("%v is not %v", txErr, tt.kind)
+			}
+			if tt.kind == nil && txErr.Kind != nil {
+				t.Fatalf("unexpected kind %v", txErr.Kind)
+			}
+			if isRetryable(txErr) != tt.retryable {
+				t.Fatalf("retryable = %v, want %v", isRetryable(txErr), tt.retryable)
+			}
+		})
+	}
+}
+
+func TestDecodeRPCError(t *testing.T) {
+	instructions := []solana.Instruction{solana.NewInstruction(pump_amm.ProgramID, nil, nil)}
+
+	behind := decodeRPCError(&jsonrpc.RPCError{Code: rpcCodeNodeUnhealthy, Message: "Node is behind"}, instructions)
+	if !errors.Is(behind, ErrNodeBehind) || !isRetryable(behind) {
+		t.Fatalf("node behind should be retryable: %v", behind)
+	}
+
+	preflight := decodeRPCError(&jsonrpc.RPCError{
+		Code:    -32002,
+		Message: "Transaction simulation failed",
+		Data: map[string]any{
+			"err":  map[string]any{"InstructionError": []any{json.Number("0"), map[string]any{"Custom": json.Number("6004")}}},
+			"logs": []any{"Program log: AnchorError occurred. Error Code: ExceededSlippage."},
+		},
+	}, instructions)
+	var txErr *TxError
+	if !errors.As(preflight, &txErr) || !errors.Is(preflight, ErrSlippageExceeded) || len(txErr.Logs) != 1 {
+		t.Fatalf("unexpected preflight error: %v", preflight)
+	}
+	if isRetryable(preflight) {
+		t.Fatal("slippage should not be retryable")
+	}
+
+	plain := errors.New("connection refused")
+	if decodeRPCError(plain, instructions) != plain || isRetryable(plain) {
+		t.Fatal("non rpc error should be returned as is")
+	}
+
+	if !isRetryable(ErrBlockhashExpired) {
+		t.Fatal("expired blockhash should be retryable")
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	pump_amm "github.com/gagliardetto/anchor-go/generated"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// eventLogPrefix Anchor 事件在程序日志中的前缀，后面是 base64 编码的事件数据
+const eventLogPrefix = "Program data: "
+
+// MaxHistoryLimit 一次最多查询的签名数，与 getSignaturesForAddress 的上限一致
+const MaxHistoryLimit = 1000
+
+// HistoryTrade 钱包在 PumpSwap 上的一笔成交，数量为未除精度的原始数量
+type HistoryTrade struct {
+	Signature   solana.Signature `json:"signature"`
+	Slot        uint64           `json:"slot"`
+	Timestamp   int64            `json:"timestamp"` // 事件中记录的链上时间戳
+	Side        SwapSide         `json:"side"`
+	Pool        solana.PublicKey `json:"pool"`
+	BaseAmount  uint64           `json:"base_amount"`  // 买入得到或卖出的代币数量
+	QuoteAmount uint64           `json:"quote_amount"` // 买入时实际花费的 lamports（含全部手续费），卖出时实际到账的 lamports
+	Fee         uint64           `json:"fee"`          // 交易费，lamports
+}
+
+// History 查询 owner 最近 limit 笔签名中的 PumpSwap 成交，按时间从新到旧排列；owner 为零值时查询当前钱包
+// 失败的交易和不含买卖事件的交易会被跳过，只统计 owner 作为 user 的成交（经路由程序调用的成交同样包含在内）
+func (c *PumpSwapClient) History(ctx context.Context, owner solana.PublicKey, limit int) ([]HistoryTrade, error) {
+	if owner.IsZero() {
+		if c.wallet == nil {
+			return nil, ErrNoWallet
+		}
+		owner = c.wallet.PublicKey()
+	}
+	limit = min(max(limit, 1), MaxHistoryLimit)
+	signatures, err := c.rpcClient.GetSignaturesForAddressWithOpts(ctx, owner, &rpc.GetSignaturesForAddressOpts{
+		Limit:      &limit,
+		Commitment: rpc.CommitmentConfirmed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get signatures: %w", err)
+	}
+
+	trades := []HistoryTrade{}
+	maxVersion := uint64(0)
+	for _, signature := range signatures {
+		if signature.Err != nil {
+			continue
+		}
+		tx, err := c.rpcClient.GetTransaction(ctx, signature.Signature, &rpc.GetTransactionOpts{
+			Encoding:                       solana.EncodingBase64,
+			Commitment:                     rpc.CommitmentConfirmed,
+			MaxSupportedTransactionVersion: &maxVersion,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get transaction %s: %w", signature.Signature, err)
+		}
+		if tx == nil || tx.Meta == nil {
+			continue
+		}
+		for _, trade := range parseSwapEvents(tx.Meta.LogMessages, owner) {
+			trade.Signature = signature.Signature
+			trade.Slot = tx.Slot
+			trade.Fee = tx.Meta.Fee
+			trades = append(trades, trade)
+		}
+	}
+	return trades, nil
+}
+
+// parseSwapEvents 从程序日志中解析 user 的 BuyEvent/SellEvent，按日志顺序返回
+func parseSwapEvents(logs []string, user solana.PublicKey) []HistoryTrade {
+	var trades []HistoryTrade
+	for _, log := range logs {
+		if !strings.HasPrefix(log, eventLogPrefix) {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(log, eventLogPrefix))
+		if err != nil || len(data) < 8 {
+			continue
+		}
+		switch [8]byte(data[:8]) {
+		case pump_amm.Event_BuyEvent:
+			event, err := pump_amm.ParseEvent_BuyEvent(data)
+			if err != nil || !event.User.Equals(user) {
+				continue
+			}
+			trades = append(trades, HistoryTrade{
+				Timestamp: event.Timestamp, Side: SwapSideBuy, Pool: event.Pool,
+				BaseAmount: event.BaseAmountOut, QuoteAmount: event.UserQuoteAmountIn,
+			})
+		case pump_amm.Event_SellEvent:
+			event, err := pump_amm.ParseEvent_SellEvent(data)
+			if err != nil || !event.User.Equals(user) {
+				continue
+			}
+			trades = append(trades, HistoryTrade{
+				Timestamp: event.Timestamp, Side: SwapSideSell, Pool: event.Pool,
+				BaseAmount: event.BaseAmountIn, QuoteAmount: event.UserQuoteAmountOut,
+			})
+		}
+	}
+	return trades
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"encoding/base64"
+	"testing"
+
+	pump_amm "github.com/gagliardetto/anchor-go/generated"
+	"github.com/gagliardetto/solana-go"
+)
+
+func eventLog(t *testing.T, discriminator [8]byte, event interface{ Marshal() ([]byte, error) }) string {
+	t.Helper()
+	data, err := event.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return eventLogPrefix + base64.StdEncoding.EncodeToString(append(discriminator[:], data...))
+}
+
+func TestParseSwapEvents(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+	other := solana.NewWallet().PublicKey()
+	pool := solana.NewWallet().PublicKey()
+	logs := []string{
+		"Program pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FMfXEA invoke [2]",
+		eventLog(t, pump_amm.Event_BuyEvent, pump_amm.BuyEvent{
+			Timestamp: 1_760_000_000, BaseAmountOut: 5_000_000, QuoteAmountIn: 1_000_000, UserQuoteAmountIn: 1_012_500, Pool: pool, User: user,
+		}),
+		// 同一笔交易中其他钱包的成交（例如路由程序替多个钱包下单）不计入
+		eventLog(t, pump_amm.Event_SellEvent, pump_amm.SellEvent{BaseAmountIn: 7, UserQuoteAmountOut: 3, Pool: pool, User: other}),
+		eventLog(t, pump_amm.Event_SellEvent, pump_amm.SellEvent{
+			Timestamp: 1_760_000_001, BaseAmountIn: 2_000_000, QuoteAmountOut: 400_000, UserQuoteAmountOut: 395_000, Pool: pool, User: user,
+		}),
+		eventLog(t, pump_amm.Event_DepositEvent, pump_amm.DepositEvent{User: user}),
+		eventLogPrefix + "not base64",
+		"Program log: Instruction: Buy",
+	}
+
+	trades := parseSwapEvents(logs, user)
+	if len(trades) != 2 {
+		t.Fatalf("got %d trades, want 2: %+v", len(trades), trades)
+	}
+	buy, sell := trades[0], trades[1]
+	if buy.Side != SwapSideBuy || buy.BaseAmount != 5_000_000 || buy.QuoteAmount != 1_012_500 || buy.Timestamp != 1_760_000_000 || !buy.Pool.Equals(pool) {
+		t.Errorf("buy = %+v", buy)
+	}
+	if sell.Side != SwapSideSell || sell.BaseAmount != 2_000_000 || sell.QuoteAmount != 395_000 || sell.Timestamp != 1_760_000_001 {
+		t.Errorf("sell = %+v", sell)
+	}
+}
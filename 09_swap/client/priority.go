@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"swap/config"
+
+	"github.com/gagliardetto/solana-go"
+	computebudget "github.com/gagliardetto/solana-go/programs/compute-budget"
+)
+
+const (
+	// lamportsPerSignature 每个签名的基础费用
+	lamportsPerSignature = 5000
+	// microLamportsPerLamport 计算单元价格的单位是 micro-lamports
+	microLamportsPerLamport = 1_000_000
+	// maxPrioritizationFeeAccounts getRecentPrioritizationFees 最多接受的账户数
+	maxPrioritizationFeeAccounts = 128
+)
+
+// computeBudget 交易申请的计算预算
+type computeBudget struct {
+	UnitLimit uint32 // 计算单元上限
+	UnitPrice uint64 // 计算单元价格，micro-lamports
+}
+
+// instructions 计算预算指令，必须放在交易最前面
+func (b computeBudget) instructions() []solana.Instruction {
+	return []solana.Instruction{
+		computebudget.NewSetComputeUnitLimitInstruction(b.UnitLimit).Build(),
+		computebudget.NewSetComputeUnitPriceInstruction(b.UnitPrice).Build(),
+	}
+}
+
+// priorityFee 优先费（lamports），按申请的计算单元上限而不是实际消耗收取
+func (b computeBudget) priorityFee() uint64 {
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(b.UnitPrice), new(big.Int).SetUint64(uint64(b.UnitLimit)))
+	fee.Add(fee, big.NewInt(microLamportsPerLamport-1))
+	fee.Quo(fee, big.NewInt(microLamportsPerLamport))
+	if !fee.IsUint64() {
+		return ^uint64(0)
+	}
+	return fee.Uint64()
+}
+
+// fee 交易实际支付的费用（lamports）：签名费 + 优先费
+func (b computeBudget) fee(signatures int) uint64 {
+	return uint64(signatures)*lamportsPerSignature + b.priorityFee()
+}
+
+// estimateComputeUnitPrice 按交易写入的账户查询近期优先费，估算计算单元价格；查询失败时使用最低价格
+func (c *PumpSwapClient) estimateComputeUnitPrice(ctx context.Context, instructions []solana.Instruction) uint64 {
+	accounts := writableAccounts(instructions)
+	results, err := c.rpcClient.GetRecentPrioritizationFees(ctx, accounts)
+	if err != nil {
+		c.logger.Warnf("get recent prioritization fees err: %v, use compute unit price %d", err, c.config.ComputeUnitprice)
+		return c.config.ComputeUnitprice
+	}
+
+	fees := make([]uint64, 0, len(results))
+	for _, result := range results {
+		fees = append(fees, result.PrioritizationFee)
+	}
+	price := computeUnitPrice(c.config, fees)
+	c.logger.Debugf("recent prioritization fees: %d slots, compute unit price: %d", len(fees), price)
+	return price
+}
+
+// computeUnitPrice 取近期优先费的百分位，按紧急程度放大，并限制在配置的最低价格和上限之间
+func computeUnitPrice(cfg *config.SwapConfig, fees []uint64) uint64 {
+	price := percentile(fees, cfg.PriorityFeePercentile)
+
+	multiplier, ok := config.UrgencyMultiplierBP[cfg.PriorityUrgency]
+	if !ok {
+		multiplier = config.UrgencyMultiplierBP[config.UrgencyMedium]
+	}
+	scaled := new(big.Int).Mul(new(big.Int).SetUint64(price), new(big.Int).SetUint64(multiplier))
+	scaled.Quo(scaled, big.NewInt(10_000))
+	if scaled.IsUint64() {
+		price = scaled.Uint64()
+	} else {
+		price = ^uint64(0)
+	}
+
+	if price < cfg.ComputeUnitprice {
+		price = cfg.ComputeUnitprice
+	}
+	if cfg.MaxComputeUnitPrice > 0 && price > cfg.MaxComputeUnitPrice {
+		price = cfg.MaxComputeUnitPrice
+	}
+	return price
+}
+
+// computeUnitLimit 在模拟消耗的计算单元上加余量，不超过配置的上限
+func computeUnitLimit(consumed uint64, marginBP uint32, maxLimit uint32) uint32 {
+	limit := consumed + (consumed*uint64(marginBP)+9_999)/10_000
+	if limit == 0 || limit > uint64(maxLimit) {
+		return maxLimit
+	}
+	return uint32(limit)
+}
+
+// percentile 最近秩法取百分位，没有数据时返回 0
+func percentile(values []uint64, p uint32) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]uint64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	if p > 100 {
+		p = 100
+	}
+	rank := (int(p)*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// writableAccounts 交易写入的账户，优先费只和这些账户的写锁竞争有关
+func writableAccounts(instructions []solana.Instruction) solana.PublicKeySlice {
+	seen := make(map[solana.PublicKey]bool)
+	var accounts solana.PublicKeySlice
+	for _, instruction := range instructions {
+		for _, meta := range instruction.Accounts() {
+			if !meta.IsWritable || seen[meta.PublicKey] {
+				continue
+			}
+			seen[meta.PublicKey] = true
+			accounts = append(accounts, meta.PublicKey)
+			if len(accounts) == maxPrioritizationFeeAccounts {
+				return accounts
+			}
+		}
+	}
+	return accounts
+}
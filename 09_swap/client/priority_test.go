@@ -0,0 +1,75 @@
+package client
+
+import (
+	"testing"
+
+	"swap/config"
+)
+
+func TestComputeUnitPrice(t *testing.T) {
+	fees := []uint64{0, 0, 100, 200, 300, 400, 500, 600, 700, 10_000}
+	tests := []struct {
+		name       string
+		percentile uint32
+		urgency    string
+		min, max   uint64
+		fees       []uint64
+		want       uint64
+	}{
+		{"median", 50, config.UrgencyMedium, 0, 0, fees, 300},
+		{"p75", 75, config.UrgencyMedium, 0, 0, fees, 600},
+		{"p100", 100, config.UrgencyMedium, 0, 0, fees, 10_000},
+		{"p0 takes the lowest", 0, config.UrgencyMedium, 0, 0, fees, 0},
+		{"high urgency", 75, config.UrgencyHigh, 0, 0, fees, 900},
+		{"low urgency", 75, config.UrgencyLow, 0, 0, fees, 450},
+		{"urgent hits cap", 100, config.UrgencyUrgent, 0, 5_000, fees, 5_000},
+		{"floor", 50, config.UrgencyMedium, 1_000, 0, fees, 1_000},
+		{"no data uses floor", 75, config.UrgencyUrgent, 1_000, 5_000, nil, 1_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.PriorityFeePercentile = tt.percentile
+			cfg.PriorityUrgency = tt.urgency
+			cfg.ComputeUnitprice = tt.min
+			cfg.MaxComputeUnitPrice = tt.max
+			if got := computeUnitPrice(cfg, tt.fees); got != tt.want {
+				t.Fatalf("computeUnitPrice = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeUnitLimit(t *testing.T) {
+	tests := []struct {
+		consumed uint64
+		marginBP uint32
+		max      uint32
+		want     uint32
+	}{
+		{100_000, 1000, 400_000, 110_000},
+		{100_001, 1000, 400_000, 110_002},
+		{380_000, 1000, 400_000, 400_000},
+		{0, 1000, 400_000, 400_000},
+		{100_000, 0, 400_000, 100_000},
+	}
+	for _, tt := range tests {
+		if got := computeUnitLimit(tt.consumed, tt.marginBP, tt.max); got != tt.want {
+			t.Fatalf("computeUnitLimit(%d, %d, %d) = %d, want %d", tt.consumed, tt.marginBP, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestComputeBudgetFee(t *testing.T) {
+	budget := computeBudget{UnitLimit: 110_000, UnitPrice: 12_345}
+	// 12345 * 110000 / 1e6 = 1357.95，向上取整
+	if got := budget.priorityFee(); got != 1358 {
+		t.Fatalf("priorityFee = %d, want 1358", got)
+	}
+	if got := budget.fee(1); got != 5000+1358 {
+		t.Fatalf("fee = %d, want %d", got, 5000+1358)
+	}
+	if got := (computeBudget{UnitLimit: 200_000}).fee(2); got != 10_000 {
+		t.Fatalf("fee without priority = %d, want 10000", got)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// TxStatus 交易的上链状态
+type TxStatus struct {
+	Signature          solana.Signature `json:"signature"`
+	Found              bool             `json:"found"` // 节点是否查到这笔交易
+	Slot               uint64           `json:"slot,omitempty"`
+	ConfirmationStatus string           `json:"confirmation_status,omitempty"` // processed、confirmed、finalized
+	Succeeded          bool             `json:"succeeded"`                     // 交易是否执行成功
+	Error              string           `json:"error,omitempty"`               // 解析后的失败原因
+	Fee                uint64           `json:"fee,omitempty"`                 // 交易费，lamports，需要 confirmed 之后才能查到
+	ComputeUnits       uint64           `json:"compute_units,omitempty"`       // 实际消耗的计算单元
+	Logs               []string         `json:"logs,omitempty"`                // 程序日志
+
+	TxError *TxError `json:"-"`
+}
+
+// TxStatus 查询交易状态；交易已确认时再读取交易详情，补充手续费、计算单元，并按出错指令的程序解析错误码
+func (c *PumpSwapClient) TxStatus(ctx context.Context, signature solana.Signature) (*TxStatus, error) {
+	statuses, err := c.rpcClient.GetSignatureStatuses(ctx, true, signature)
+	if err != nil {
+		return nil, fmt.Errorf("get signature status: %w", err)
+	}
+	status := &TxStatus{Signature: signature}
+	if len(statuses.Value) == 0 || statuses.Value[0] == nil {
+		return status, nil
+	}
+	result := statuses.Value[0]
+	status.Found = true
+	status.Slot = result.Slot
+	status.ConfirmationStatus = string(result.ConfirmationStatus)
+	status.Succeeded = result.Err == nil
+
+	// processed 状态的交易还查不到详情，只返回签名状态
+	var instructions []solana.Instruction
+	if result.ConfirmationStatus != rpc.ConfirmationStatusProcessed {
+		maxVersion := uint64(0)
+		tx, err := c.rpcClient.GetTransaction(ctx, signature, &rpc.GetTransactionOpts{
+			Encoding:                       solana.EncodingBase64,
+			Commitment:                     rpc.CommitmentConfirmed,
+			MaxSupportedTransactionVersion: &maxVersion,
+		})
+		if err != nil {
+			c.logger.Debugf("get transaction %s err: %v", signature, err)
+		} else if tx != nil {
+			if tx.Meta != nil {
+				status.Fee = tx.Meta.Fee
+				status.Logs = tx.Meta.LogMessages
+				if tx.Meta.ComputeUnitsConsumed != nil {
+					status.ComputeUnits = *tx.Meta.ComputeUnitsConsumed
+				}
+			}
+			if tx.Transaction != nil {
+				if parsed, err := tx.Transaction.GetTransaction(); err == nil {
+					instructions = programInstructions(parsed)
+				}
+			}
+		}
+	}
+
+	if result.Err != nil {
+		status.TxError = decodeTxError(result.Err, instructions, status.Logs)
+		status.Error = status.TxError.Error()
+	}
+	return status, nil
+}
+
+// programInstructions 只保留每条指令的程序 ID，用于按程序解析错误码
+func programInstructions(tx *solana.Transaction) []solana.Instruction {
+	instructions := make([]solana.Instruction, 0, len(tx.Message.Instructions))
+	for _, compiled := range tx.Message.Instructions {
+		program, err := tx.Message.ResolveProgramIDIndex(compiled.ProgramIDIndex)
+		if err != nil {
+			program = solana.PublicKey{}
+		}
+		instructions = append(instructions, solana.NewInstruction(program, nil, nil))
+	}
+	return instructions
+}
@@ -0,0 +1,360 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"swap/quote"
+
+	pump_amm "github.com/gagliardetto/anchor-go/generated"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/programs/token"
+)
+
+// SwapSide 交易方向
+type SwapSide string
+
+const (
+	SwapSideBuy  SwapSide = "buy"
+	SwapSideSell SwapSide = "sell"
+)
+
+// SwapResult 一笔已确认（或 dry-run 模拟）交易的结果，数量均为未除精度的原始数量
+type SwapResult struct {
+	TxResult
+	Side        SwapSide         `json:"side"`
+	Pool        solana.PublicKey `json:"pool"`
+	Mint        solana.PublicKey `json:"mint"`
+	BaseAmount  uint64           `json:"base_amount"`  // 买入时为得到的代币数量，卖出时为卖出的代币数量
+	QuoteAmount uint64           `json:"quote_amount"` // 买入时为最多花费的 lamports，卖出时为预期得到的 lamports
+	PriceImpact float64          `json:"price_impact"` // 报价时的价格影响，百分比
+}
+
+// Buy 用最多 quoteIn lamports 买入代币：quoteIn 是硬上限，按 SwapConfig.SlippageBP 缩小报价得到的代币数量，
+// 价格在滑点范围内上涨时仍能成交；缩小后的数量低于 minOut 时不发送交易。
+// 自动创建缺失的代币 ATA，并通过 WSOL 账户包装 SOL；WSOL 账户不存在或余额为 0 时交易结束后关闭解包，
+// 否则保留用户原有的 WSOL 余额，未花完的 lamports 留在 WSOL 账户中
+func (c *PumpSwapClient) Buy(ctx context.Context, mint solana.PublicKey, quoteIn, minOut uint64) (*SwapResult, error) {
+	if c.wallet == nil {
+		return nil, ErrNoWallet
+	}
+	if quoteIn == 0 {
+		return nil, errors.New("quote amount in must be greater than 0")
+	}
+	if err := quote.CheckSlippage(c.config, c.config.SlippageBP); err != nil {
+		return nil, err
+	}
+	if err := quote.CheckAmount(c.config, quoteIn); err != nil {
+		return nil, err
+	}
+	pool, globalConfig, fees, err := c.prepareSwap(ctx, mint, disableFlagBuy)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := quote.BuyQuoteIn(pool.State(), fees, quoteIn)
+	if err != nil {
+		return nil, fmt.Errorf("quote buy: %w", err)
+	}
+	baseOut := quote.WithSlippageDown(q.BaseAmountOut, c.config.SlippageBP)
+	if baseOut == 0 {
+		return nil, fmt.Errorf("quote amount in %d is too small to buy any token", quoteIn)
+	}
+	if baseOut < minOut {
+		return nil, fmt.Errorf("base amount out %d after slippage is less than min out %d", baseOut, minOut)
+	}
+	c.logger.Debugf("buy %s: quote in %d, quoted base out %d, base out %d, price impact %.4f%%, reserves %d/%d",
+		mint, quoteIn, q.BaseAmountOut, baseOut, q.PriceImpact, pool.BaseReserves, pool.QuoteReserves)
+
+	user := c.wallet.PublicKey()
+	accounts, err := c.swapAccounts(pool, globalConfig)
+	if err != nil {
+		return nil, err
+	}
+	instructions, closeQuote, err := c.ensureTokenAccounts(ctx, pool, accounts)
+	if err != nil {
+		return nil, err
+	}
+	// 包装 SOL：转入最多花费的 lamports 后同步 WSOL 余额
+	instructions = append(instructions,
+		system.NewTransferInstruction(quoteIn, user, accounts.userQuoteTokenAccount).Build(),
+		token.NewSyncNativeInstruction(accounts.userQuoteTokenAccount).Build(),
+	)
+
+	buy, err := pump_amm.NewBuyInstruction(
+		baseOut,
+		quoteIn,
+		pump_amm.OptionBool{V0: false},
+		pool.Address,
+		user,
+		accounts.globalConfig,
+		pool.Pool.BaseMint,
+		pool.Pool.QuoteMint,
+		accounts.userBaseTokenAccount,
+		accounts.userQuoteTokenAccount,
+		pool.Pool.PoolBaseTokenAccount,
+		pool.Pool.PoolQuoteTokenAccount,
+		accounts.protocolFeeRecipient,
+		accounts.protocolFeeRecipientTokenAccount,
+		pool.BaseTokenProgram,
+		pool.QuoteTokenProgram,
+		solana.SystemProgramID,
+		solana.SPLAssociatedTokenAccountProgramID,
+		accounts.eventAuthority,
+		pump_amm.ProgramID,
+		accounts.coinCreatorVaultAta,
+		accounts.coinCreatorVaultAuthority,
+		globalVolumeAccumulatorAddress(),
+		userVolumeAccumulatorAddress(user),
+		accounts.feeConfig,
+		PumpFeeProgramID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build buy instruction: %w", err)
+	}
+	// 解包 SOL：WSOL 账户是临时的才关闭，未花完的 lamports 和租金退回钱包；
+	// 用户原有的 WSOL 账户保持打开，未花完的部分留在账户中
+	instructions = append(instructions, buy)
+	if closeQuote {
+		instructions = append(instructions, c.closeQuoteAccountInstruction(accounts))
+	}
+
+	tx, err := c.sendAndConfirm(ctx, instructions)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapResult{
+		TxResult:    *tx,
+		Side:        SwapSideBuy,
+		Pool:        pool.Address,
+		Mint:        mint,
+		BaseAmount:  baseOut,
+		QuoteAmount: quoteIn,
+		PriceImpact: q.PriceImpact,
+	}, nil
+}
+
+// Sell 卖出 baseIn 个代币，报价得到的 lamports 低于 minQuoteOut 时不发送交易；
+// 链上最少得到的数量取 minQuoteOut 和按 SwapConfig.SlippageBP 缩小后的报价中较大者，卖得的 WSOL 在同一笔交易中解包回钱包；
+// 用户已有非零余额的 WSOL 账户时不关闭，卖得的 WSOL 留在该账户中
+func (c *PumpSwapClient) Sell(ctx context.Context, mint solana.PublicKey, baseIn, minQuoteOut uint64) (*SwapResult, error) {
+	if c.wallet == nil {
+		return nil, ErrNoWallet
+	}
+	if baseIn == 0 {
+		return nil, errors.New("base amount in must be greater than 0")
+	}
+	if err := quote.CheckSlippage(c.config, c.config.SlippageBP); err != nil {
+		return nil, err
+	}
+	pool, globalConfig, fees, err := c.prepareSwap(ctx, mint, disableFlagSell)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := quote.SellBaseIn(pool.State(), fees, baseIn)
+	if err != nil {
+		return nil, fmt.Errorf("quote sell: %w", err)
+	}
+	if q.UserQuoteAmountOut == 0 {
+		return nil, fmt.Errorf("base amount in %d is too small to receive any SOL", baseIn)
+	}
+	if err = quote.CheckAmount(c.config, q.UserQuoteAmountOut); err != nil {
+		return nil, err
+	}
+	if q.UserQuoteAmountOut < minQuoteOut {
+		return nil, fmt.Errorf("expected quote amount out %d is less than min quote out %d", q.UserQuoteAmountOut, minQuoteOut)
+	}
+	minQuoteOut = max(minQuoteOut, quote.WithSlippageDown(q.UserQuoteAmountOut, c.config.SlippageBP))
+	c.logger.Debugf("sell %s: base in %d, quoted quote out %d, min quote out %d, price impact %.4f%%, reserves %d/%d",
+		mint, baseIn, q.UserQuoteAmountOut, minQuoteOut, q.PriceImpact, pool.BaseReserves, pool.QuoteReserves)
+
+	user := c.wallet.PublicKey()
+	accounts, err := c.swapAccounts(pool, globalConfig)
+	if err != nil {
+		return nil, err
+	}
+	instructions, closeQuote, err := c.ensureTokenAccounts(ctx, pool, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	sell, err := pump_amm.NewSellInstruction(
+		baseIn,
+		minQuoteOut,
+		pool.Address,
+		user,
+		accounts.globalConfig,
+		pool.Pool.BaseMint,
+		pool.Pool.QuoteMint,
+		accounts.userBaseTokenAccount,
+		accounts.userQuoteTokenAccount,
+		pool.Pool.PoolBaseTokenAccount,
+		pool.Pool.PoolQuoteTokenAccount,
+		accounts.protocolFeeRecipient,
+		accounts.protocolFeeRecipientTokenAccount,
+		pool.BaseTokenProgram,
+		pool.QuoteTokenProgram,
+		solana.SystemProgramID,
+		solana.SPLAssociatedTokenAccountProgramID,
+		accounts.eventAuthority,
+		pump_amm.ProgramID,
+		accounts.coinCreatorVaultAta,
+		accounts.coinCreatorVaultAuthority,
+		accounts.feeConfig,
+		PumpFeeProgramID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build sell instruction: %w", err)
+	}
+	instructions = append(instructions, sell)
+	if closeQuote {
+		instructions = append(instructions, c.closeQuoteAccountInstruction(accounts))
+	}
+
+	tx, err := c.sendAndConfirm(ctx, instructions)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapResult{
+		TxResult:    *tx,
+		Side:        SwapSideSell,
+		Pool:        pool.Address,
+		Mint:        mint,
+		BaseAmount:  baseIn,
+		QuoteAmount: q.UserQuoteAmountOut,
+		PriceImpact: q.PriceImpact,
+	}, nil
+}
+
+// prepareSwap 读取池子、全局配置和手续费配置，检查对应方向是否被禁用，并计算适用的手续费比例
+func (c *PumpSwapClient) prepareSwap(ctx context.Context, mint solana.PublicKey, disableFlag uint8) (*PoolInfo, *pump_amm.GlobalConfig, quote.Fees, error) {
+	pool, err := c.ResolvePool(ctx, mint)
+	if err != nil {
+		return nil, nil, quote.Fees{}, err
+	}
+	globalConfig, err := c.GetGlobalConfig(ctx)
+	if err != nil {
+		return nil, nil, quote.Fees{}, err
+	}
+	if globalConfig.DisableFlags&disableFlag != 0 {
+		return nil, nil, quote.Fees{}, fmt.Errorf("%w: disable flags %08b", ErrPoolDisabled, globalConfig.DisableFlags)
+	}
+	if pool.BaseReserves == 0 || pool.QuoteReserves == 0 {
+		return nil, nil, quote.Fees{}, fmt.Errorf("pool %s has no liquidity", pool.Address)
+	}
+	feeConfig, err := c.GetFeeConfig(ctx)
+	if err != nil {
+		return nil, nil, quote.Fees{}, err
+	}
+	return pool, globalConfig, quote.ComputeFees(globalConfig, feeConfig, pool.State()), nil
+}
+
+// QuoteBuy 按链上当前状态报价：花费 quoteIn lamports（含手续费）最多能买到的代币数量
+func (c *PumpSwapClient) QuoteBuy(ctx context.Context, mint solana.PublicKey, quoteIn uint64) (*PoolInfo, *quote.BuyQuote, error) {
+	pool, _, fees, err := c.prepareSwap(ctx, mint, disableFlagBuy)
+	if err != nil {
+		return nil, nil, err
+	}
+	q, err := quote.BuyQuoteIn(pool.State(), fees, quoteIn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pool, q, nil
+}
+
+// QuoteSell 按链上当前状态报价：卖出 baseIn 个代币扣除手续费后得到的 lamports
+func (c *PumpSwapClient) QuoteSell(ctx context.Context, mint solana.PublicKey, baseIn uint64) (*PoolInfo, *quote.SellQuote, error) {
+	pool, _, fees, err := c.prepareSwap(ctx, mint, disableFlagSell)
+	if err != nil {
+		return nil, nil, err
+	}
+	q, err := quote.SellBaseIn(pool.State(), fees, baseIn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pool, q, nil
+}
+
+// swapAccounts 买卖指令共用的派生账户
+type swapAccounts struct {
+	globalConfig                     solana.PublicKey
+	eventAuthority                   solana.PublicKey
+	feeConfig                        solana.PublicKey
+	userBaseTokenAccount             solana.PublicKey
+	userQuoteTokenAccount            solana.PublicKey
+	protocolFeeRecipient             solana.PublicKey
+	protocolFeeRecipientTokenAccount solana.PublicKey
+	coinCreatorVaultAuthority        solana.PublicKey
+	coinCreatorVaultAta              solana.PublicKey
+}
+
+func (c *PumpSwapClient) swapAccounts(pool *PoolInfo, globalConfig *pump_amm.GlobalConfig) (*swapAccounts, error) {
+	user := c.wallet.PublicKey()
+	protocolFeeRecipient, err := pickProtocolFeeRecipient(globalConfig)
+	if err != nil {
+		return nil, err
+	}
+	coinCreatorVaultAuthority := coinCreatorVaultAuthorityAddress(pool.Pool.CoinCreator)
+	return &swapAccounts{
+		globalConfig:                     globalConfigAddress(),
+		eventAuthority:                   eventAuthorityAddress(),
+		feeConfig:                        feeConfigAddress(),
+		userBaseTokenAccount:             findAssociatedTokenAddress(user, pool.Pool.BaseMint, pool.BaseTokenProgram),
+		userQuoteTokenAccount:            findAssociatedTokenAddress(user, pool.Pool.QuoteMint, pool.QuoteTokenProgram),
+		protocolFeeRecipient:             protocolFeeRecipient,
+		protocolFeeRecipientTokenAccount: findAssociatedTokenAddress(protocolFeeRecipient, pool.Pool.QuoteMint, pool.QuoteTokenProgram),
+		coinCreatorVaultAuthority:        coinCreatorVaultAuthority,
+		coinCreatorVaultAta:              findAssociatedTokenAddress(coinCreatorVaultAuthority, pool.Pool.QuoteMint, pool.QuoteTokenProgram),
+	}, nil
+}
+
+// ensureTokenAccounts 为不存在的用户代币账户和 WSOL 账户生成创建指令；
+// closeQuote 表示 WSOL 账户是本次交易临时使用的（不存在或余额为 0），交易结束时可以关闭
+func (c *PumpSwapClient) ensureTokenAccounts(ctx context.Context, pool *PoolInfo, accounts *swapAccounts) (instructions []solana.Instruction, closeQuote bool, err error) {
+	user := c.wallet.PublicKey()
+	existing, err := c.getMultipleAccounts(ctx, accounts.userBaseTokenAccount, accounts.userQuoteTokenAccount)
+	if err != nil {
+		return nil, false, fmt.Errorf("get user token accounts: %w", err)
+	}
+	closeQuote, err = isTemporaryQuoteAccount(existing[1])
+	if err != nil {
+		return nil, false, fmt.Errorf("user quote token account %s: %w", accounts.userQuoteTokenAccount, err)
+	}
+
+	if existing[0] == nil {
+		c.logger.Debugf("create base token account %s", accounts.userBaseTokenAccount)
+		instructions = append(instructions, newCreateAssociatedTokenAccountIdempotentInstruction(
+			user, accounts.userBaseTokenAccount, user, pool.Pool.BaseMint, pool.BaseTokenProgram))
+	}
+	if existing[1] == nil {
+		c.logger.Debugf("create quote token account %s", accounts.userQuoteTokenAccount)
+		instructions = append(instructions, newCreateAssociatedTokenAccountIdempotentInstruction(
+			user, accounts.userQuoteTokenAccount, user, pool.Pool.QuoteMint, pool.QuoteTokenProgram))
+	}
+	return instructions, closeQuote, nil
+}
+
+// closeQuoteAccountInstruction 关闭 WSOL 账户，余额和租金退回钱包
+func (c *PumpSwapClient) closeQuoteAccountInstruction(accounts *swapAccounts) solana.Instruction {
+	user := c.wallet.PublicKey()
+	return token.NewCloseAccountInstruction(accounts.userQuoteTokenAccount, user, user, nil).Build()
+}
+
+// newCreateAssociatedTokenAccountIdempotentInstruction ATA 程序的 CreateIdempotent 指令（指令序号 1），账户已存在时不报错；
+// solana-go 自带的 Create 指令固定使用 Token 程序，无法创建 Token-2022 账户
+func newCreateAssociatedTokenAccountIdempotentInstruction(payer, ata, owner, mint, tokenProgram solana.PublicKey) solana.Instruction {
+	return solana.NewInstruction(
+		solana.SPLAssociatedTokenAccountProgramID,
+		solana.AccountMetaSlice{
+			solana.Meta(payer).WRITE().SIGNER(),
+			solana.Meta(ata).WRITE(),
+			solana.Meta(owner),
+			solana.Meta(mint),
+			solana.Meta(solana.SystemProgramID),
+			solana.Meta(tokenProgram),
+		},
+		[]byte{1},
+	)
+}
@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// confirmPollInterval 查询交易状态的间隔
+const confirmPollInterval = 500 * time.Millisecond
+
+var ErrBlockhashExpired = errors.New("transaction blockhash expired before confirmation")
+
+// TxResult 一笔已确认交易的结果；SwapConfig.DryRun 时只模拟不发送，Simulated 为 true，费用为预计值
+type TxResult struct {
+	Signature        solana.Signature `json:"signature"`
+	Slot             uint64           `json:"slot"`
+	Simulated        bool             `json:"simulated,omitempty"` // dry-run：只模拟，没有发送
+	ComputeUnits     uint64           `json:"compute_units"`       // 模拟时消耗的计算单元
+	ComputeUnitLimit uint32           `json:"compute_unit_limit"`  // 申请的计算单元上限
+	ComputeUnitPrice uint64           `json:"compute_unit_price"`  // 计算单元价格，micro-lamports
+	PriorityFee      uint64           `json:"priority_fee"`        // 优先费，lamports
+	Fee              uint64           `json:"fee"`                 // 实际支付的交易费（签名费 + 优先费），lamports
+	SentVia          string           `json:"sent_via,omitempty"`  // 提交方式：rpc、bundle
+	BundleID         string           `json:"bundle_id,omitempty"` // 以 bundle 提交时的 bundle id
+	Tip              uint64           `json:"tip"`                 // 支付的 Jito tip，lamports
+	Logs             []string         `json:"logs,omitempty"`      // dry-run 时的模拟日志
+}
+
+// sendAndConfirm 自动加上计算预算指令，模拟、发送并确认交易；只有可重试的错误（blockhash 过期、节点落后等）才会在 RetryDelay 后
+// 用新的 blockhash 重新构建交易，最多重试 SwapConfig.Maxretries 次
+func (c *PumpSwapClient) sendAndConfirm(ctx context.Context, instructions []solana.Instruction) (*TxResult, error) {
+	if c.wallet == nil {
+		return nil, ErrNoWallet
+	}
+	attempts := c.config.Maxretries + 1
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("retry canceled: %w, last err: %v", ctx.Err(), lastErr)
+			case <-time.After(c.config.RetryDelay):
+			}
+		}
+
+		result, err := c.trySendAndConfirm(ctx, instructions)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			return nil, err
+		}
+		c.logger.Warnf("attempt %d/%d failed, retry with a fresh blockhash: %v", attempt, attempts, err)
+	}
+	return nil, fmt.Errorf("transaction failed after %d attempts: %w", attempts, lastErr)
+}
+
+// trySendAndConfirm 用最新 blockhash 构建交易：先按 ComputeUnitLimit 模拟，再用实际消耗加余量作为计算单元上限，
+// 计算单元价格按近期优先费估算，签名后发送并等待达到 SwapConfig.Commitment 确认级别。
+// 配置了 MEVNodeUrl 时交易末尾加上 tip 转账，先以 bundle 提交，BundleTimeout 内没有上链再把同一笔交易走 RPC 发送
+func (c *PumpSwapClient) trySendAndConfirm(ctx context.Context, instructions []solana.Instruction) (*TxResult, error) {
+	recent, err := c.rpcClient.GetLatestBlockhash(ctx, c.config.Commitment)
+	if err != nil {
+		return nil, fmt.Errorf("get latest blockhash: %w", decodeRPCError(err, instructions))
+	}
+	budget := computeBudget{
+		UnitLimit: c.config.ComputeUnitLimit,
+		UnitPrice: c.estimateComputeUnitPrice(ctx, instructions),
+	}
+	var tip uint64
+	if c.bundler != nil {
+		instructions = append(instructions[:len(instructions):len(instructions)], c.tipInstruction(ctx))
+		tip = c.config.JitoTipLamports
+	}
+
+	simulateInstructions := append(budget.instructions(), instructions...)
+	tx, err := c.buildTransaction(simulateInstructions, recent.Value.Blockhash)
+	if err != nil {
+		return nil, err
+	}
+	units, logs, err := c.simulate(ctx, tx, simulateInstructions)
+	if err != nil {
+		return nil, err
+	}
+
+	budget.UnitLimit = computeUnitLimit(units, c.config.ComputeUnitMarginBP, c.config.ComputeUnitLimit)
+	instructions = append(budget.instructions(), instructions...)
+	if tx, err = c.buildTransaction(instructions, recent.Value.Blockhash); err != nil {
+		return nil, err
+	}
+	c.logger.Debugf("compute budget: limit %d, price %d, priority fee %d", budget.UnitLimit, budget.UnitPrice, budget.priorityFee())
+	if c.config.DryRun {
+		return &TxResult{
+			Signature:        tx.Signatures[0],
+			Simulated:        true,
+			ComputeUnits:     units,
+			ComputeUnitLimit: budget.UnitLimit,
+			ComputeUnitPrice: budget.UnitPrice,
+			PriorityFee:      budget.priorityFee(),
+			Fee:              budget.fee(len(tx.Signatures)),
+			Tip:              tip,
+			Logs:             logs,
+		}, nil
+	}
+
+	signature := tx.Signatures[0]
+	sentVia := SendViaRPC
+	var bundleID string
+	if c.bundler != nil {
+		bundleID, err = c.sendBundle(ctx, tx)
+		switch {
+		case err == nil:
+			sentVia = SendViaBundle
+		case ctx.Err() != nil:
+			return nil, fmt.Errorf("send bundle: %w", ctx.Err())
+		case c.signatureSeen(ctx, signature):
+			sentVia = SendViaBundle
+		default:
+			c.logger.Warnf("bundle %s not landed, fall back to rpc: %v", bundleID, err)
+		}
+	}
+
+	if sentVia == SendViaRPC {
+		// 刚模拟过，跳过节点的 preflight 检查
+		if _, err = c.rpcClient.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{
+			SkipPreflight:       true,
+			PreflightCommitment: c.config.Commitment,
+		}); err != nil {
+			return nil, fmt.Errorf("send transaction: %w", decodeRPCError(err, instructions))
+		}
+	}
+	c.logger.Debugf("transaction sent via %s: %s", sentVia, signature)
+
+	slot, err := c.waitForConfirmation(ctx, signature, recent.Value.LastValidBlockHeight, instructions)
+	if err != nil {
+		return nil, err
+	}
+	fee := budget.fee(len(tx.Signatures))
+	c.logger.Debugf("transaction confirmed: %s, slot: %d, compute units: %d, fee: %d", signature, slot, units, fee)
+	return &TxResult{
+		Signature:        signature,
+		Slot:             slot,
+		ComputeUnits:     units,
+		ComputeUnitLimit: budget.UnitLimit,
+		ComputeUnitPrice: budget.UnitPrice,
+		PriorityFee:      budget.priorityFee(),
+		Fee:              fee,
+		SentVia:          sentVia,
+		BundleID:         bundleID,
+		Tip:              tip,
+	}, nil
+}
+
+// buildTransaction 用指定 blockhash 构建交易并由钱包签名
+func (c *PumpSwapClient) buildTransaction(instructions []solana.Instruction, blockhash solana.Hash) (*solana.Transaction, error) {
+	tx, err := solana.NewTransaction(instructions, blockhash, solana.TransactionPayer(c.wallet.PublicKey()))
+	if err != nil {
+		return nil, fmt.Errorf("build transaction: %w", err)
+	}
+	if err = c.wallet.SignTransaction(tx); err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// simulate 模拟交易，失败时解析出错的指令和程序错误码，成功时返回消耗的计算单元和程序日志
+func (c *PumpSwapClient) simulate(ctx context.Context, tx *solana.Transaction, instructions []solana.Instruction) (uint64, []string, error) {
+	resp, err := c.rpcClient.SimulateTransactionWithOpts(ctx, tx, &rpc.SimulateTransactionOpts{
+		Commitment: c.config.Commitment,
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("simulate transaction: %w", decodeRPCError(err, instructions))
+	}
+	if resp == nil || resp.Value == nil {
+		return 0, nil, errors.New("simulate transaction: empty response")
+	}
+
+	var units uint64
+	if resp.Value.UnitsConsumed != nil {
+		units = *resp.Value.UnitsConsumed
+	}
+	if resp.Value.Err != nil {
+		txErr := decodeTxError(resp.Value.Err, instructions, resp.Value.Logs)
+		for _, line := range resp.Value.Logs {
+			c.logger.Debugf("simulate log: %s", line)
+		}
+		return units, resp.Value.Logs, fmt.Errorf("simulate transaction: %w, compute units: %d", txErr, units)
+	}
+	c.logger.Debugf("simulate succeeded, compute units: %d", units)
+	return units, resp.Value.Logs, nil
+}
+
+// waitForConfirmation 轮询交易状态直到达到确认级别；交易执行失败或 blockhash 过期时返回错误
+func (c *PumpSwapClient) waitForConfirmation(ctx context.Context, signature solana.Signature, lastValidBlockHeight uint64, instructions []solana.Instruction) (uint64, error) {
+	ticker := time.NewTicker(confirmPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("wait for transaction %s: %w", signature, ctx.Err())
+		case <-ticker.C:
+		}
+
+		statuses, err := c.rpcClient.GetSignatureStatuses(ctx, false, signature)
+		if err != nil {
+			c.logger.Debugf("get signature status %s err: %v", signature, err)
+			continue
+		}
+		if len(statuses.Value) > 0 && statuses.Value[0] != nil {
+			status := statuses.Value[0]
+			if status.Err != nil {
+				return 0, fmt.Errorf("transaction %s: %w", signature, decodeTxError(status.Err, instructions, nil))
+			}
+			if confirmationReached(status.ConfirmationStatus, c.config.Commitment) {
+				return status.Slot, nil
+			}
+			continue
+		}
+
+		blockHeight, err := c.rpcClient.GetBlockHeight(ctx, c.config.Commitment)
+		if err != nil {
+			continue
+		}
+		if blockHeight > lastValidBlockHeight {
+			return 0, fmt.Errorf("%w: %s", ErrBlockhashExpired, signature)
+		}
+	}
+}
+
+// confirmationReached 交易状态是否达到要求的确认级别：processed < confirmed < finalized
+func confirmationReached(status rpc.ConfirmationStatusType, commitment rpc.CommitmentType) bool {
+	levels := map[rpc.ConfirmationStatusType]int{
+		rpc.ConfirmationStatusProcessed: 1,
+		rpc.ConfirmationStatusConfirmed: 2,
+		rpc.ConfirmationStatusFinalized: 3,
+	}
+	required := map[rpc.CommitmentType]int{
+		rpc.CommitmentProcessed: 1,
+		rpc.CommitmentConfirmed: 2,
+		rpc.CommitmentFinalized: 3,
+	}[commitment]
+	if required == 0 {
+		required = levels[rpc.ConfirmationStatusConfirmed]
+	}
+	return levels[status] >= required
+}
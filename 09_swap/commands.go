@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"swap/client"
+	"swap/quote"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// command 一个子命令：flags 注册子命令自己的参数，run 执行
+type command struct {
+	name       string
+	usage      string
+	needWallet func(args []string, flags any) bool // 是否需要解锁钱包签名，nil 表示不需要
+	flags      func(fs *flag.FlagSet) any
+	run        func(ctx context.Context, c *client.PumpSwapClient, p printer, args []string, flags any) error
+}
+
+// swapFlags 报价和买卖共用的参数
+type swapFlags struct {
+	mint   string
+	side   string
+	amount string
+	minOut string
+}
+
+func registerSwapFlags(fs *flag.FlagSet, withSide bool) any {
+	f := &swapFlags{}
+	fs.StringVar(&f.mint, "mint", "", "代币地址")
+	fs.StringVar(&f.amount, "amount", "", "买入时为花费的 lamports，卖出时为卖出的代币数量（原始数量）")
+	if withSide {
+		fs.StringVar(&f.side, "side", string(client.SwapSideBuy), "交易方向：buy、sell")
+	} else {
+		fs.StringVar(&f.minOut, "min-out", "0", "最少得到的数量，买入时为代币数量，卖出时为 lamports")
+	}
+	return f
+}
+
+func always([]string, any) bool { return true }
+
+var commands = []command{
+	{
+		name:  "quote",
+		usage: "quote --mint <代币> --amount <数量> [--side buy|sell]    按链上当前状态报价，不需要钱包",
+		flags: func(fs *flag.FlagSet) any { return registerSwapFlags(fs, true) },
+		run:   runQuote,
+	},
+	{
+		name:       "buy",
+		usage:      "buy --mint <代币> --amount <lamports> [--min-out <代币数量>]    买入代币",
+		needWallet: always,
+		flags:      func(fs *flag.FlagSet) any { return registerSwapFlags(fs, false) },
+		run:        runSwap(client.SwapSideBuy),
+	},
+	{
+		name:       "sell",
+		usage:      "sell --mint <代币> --amount <代币数量> [--min-out <lamports>]    卖出代币",
+		needWallet: always,
+		flags:      func(fs *flag.FlagSet) any { return registerSwapFlags(fs, false) },
+		run:        runSwap(client.SwapSideSell),
+	},
+	{
+		name:  "balances",
+		usage: "balances [--address <地址>]    查询 SOL、WSOL 和代币余额，默认查询当前钱包",
+		flags: func(fs *flag.FlagSet) any {
+			address := new(string)
+			fs.StringVar(address, "address", "", "要查询的地址，设置后不需要解锁钱包")
+			return address
+		},
+		needWallet: func(_ []string, flags any) bool { return *flags.(*string) == "" },
+		run:        runBalances,
+	},
+	{
+		name:       "history",
+		usage:      "history [--address <地址>] [--mint <代币>] [--limit <签名数>]    查询最近的 PumpSwap 买卖记录，默认查询当前钱包",
+		flags:      registerHistoryFlags,
+		needWallet: func(_ []string, flags any) bool { return flags.(*historyFlags).address == "" },
+		run:        runHistory,
+	},
+	{
+		name:       "wrap",
+		usage:      "wrap --amount <lamports>    把 SOL 包装成 WSOL",
+		needWallet: always,
+		flags: func(fs *flag.FlagSet) any {
+			amount := new(string)
+			fs.StringVar(amount, "amount", "", "要包装的 lamports")
+			return amount
+		},
+		run: runWrap,
+	},
+	{
+		name:       "unwrap",
+		usage:      "unwrap    关闭 WSOL 账户，SOL 全部退回钱包",
+		needWallet: always,
+		run:        runUnwrap,
+	},
+	{
+		name:       "order",
+		usage:      "order add|list|cancel <id>|log [id]|run    价格触发的限价、止盈、止损和跟踪止损订单",
+		needWallet: func(args []string, _ any) bool { return len(args) > 0 && args[0] == "run" },
+		flags:      registerOrderFlags,
+		run:        runOrder,
+	},
+	{
+		name:       "copy",
+		usage:      "copy run|log    跟随指定钱包的买卖，按仓位规则、风控限额和代币检测结果跟单",
+		needWallet: func(args []string, _ any) bool { return len(args) > 0 && args[0] == "run" },
+		flags:      registerCopyFlags,
+		run:        runCopy,
+	},
+	{
+		name:       "dca",
+		usage:      "dca add|list|show <id>|cancel <id>|run    DCA/TWAP 分批买入或卖出，价格影响过大时跳过该批",
+		needWallet: func(args []string, _ any) bool { return len(args) > 0 && args[0] == "run" },
+		flags:      registerDCAFlags,
+		run:        runDCA,
+	},
+	{
+		name:  "tx",
+		usage: "tx status <签名>    查询交易状态，失败时解析错误原因",
+		run:   runTx,
+	},
+}
+
+func runQuote(ctx context.Context, c *client.PumpSwapClient, p printer, _ []string, flags any) error {
+	f := flags.(*swapFlags)
+	mint, err := parsePublicKey("mint", f.mint)
+	if err != nil {
+		return err
+	}
+	amount, err := parseAmount("amount", f.amount)
+	if err != nil {
+		return err
+	}
+	slippage := c.Config().SlippageBP
+
+	switch client.SwapSide(f.side) {
+	case client.SwapSideBuy:
+		pool, q, err := c.QuoteBuy(ctx, mint, amount)
+		if err != nil {
+			return err
+		}
+		minOut := quote.WithSlippageDown(q.BaseAmountOut, slippage)
+		p.result(struct {
+			Side       client.SwapSide  `json:"side"`
+			Pool       solana.PublicKey `json:"pool"`
+			Mint       solana.PublicKey `json:"mint"`
+			SlippageBP uint32           `json:"slippage_bp"`
+			MinOut     uint64           `json:"min_out"` // 按滑点缩小后的最少得到数量
+			*quote.BuyQuote
+		}{client.SwapSideBuy, pool.Address, mint, slippage, minOut, q}, func() {
+			fmt.Printf("💱 池子：%s.\n", pool.Address)
+			fmt.Printf("🟢 花费 %d lamports 预计得到 %d 个代币，手续费 %d lamports，价格影响 %.4f%%.\n",
+				q.UserQuoteAmountIn, q.BaseAmountOut, q.LpFee+q.ProtocolFee+q.CoinCreatorFee, q.PriceImpact)
+			fmt.Printf("🛡️ 滑点 %d bp，最少得到 %d 个代币.\n", slippage, minOut)
+		})
+	case client.SwapSideSell:
+		pool, q, err := c.QuoteSell(ctx, mint, amount)
+		if err != nil {
+			return err
+		}
+		minOut := quote.WithSlippageDown(q.UserQuoteAmountOut, slippage)
+		p.result(struct {
+			Side       client.SwapSide  `json:"side"`
+			Pool       solana.PublicKey `json:"pool"`
+			Mint       solana.PublicKey `json:"mint"`
+			SlippageBP uint32           `json:"slippage_bp"`
+			MinOut     uint64           `json:"min_out"`
+			*quote.SellQuote
+		}{client.SwapSideSell, pool.Address, mint, slippage, minOut, q}, func() {
+			fmt.Printf("💱 池子：%s.\n", pool.Address)
+			fmt.Printf("🔴 卖出 %d 个代币预计得到 %d lamports，手续费 %d lamports，价格影响 %.4f%%.\n",
+				q.BaseAmountIn, q.UserQuoteAmountOut, q.LpFee+q.ProtocolFee+q.CoinCreatorFee, q.PriceImpact)
+			fmt.Printf("🛡️ 滑点 %d bp，最少得到 %d lamports.\n", slippage, minOut)
+		})
+	default:
+		return usageError("unsupported --side %s", f.side)
+	}
+	return nil
+}
+
+func runSwap(side client.SwapSide) func(context.Context, *client.PumpSwapClient, printer, []string, any) error {
+	return func(ctx context.Context, c *client.PumpSwapClient, p printer, _ []string, flags any) error {
+		f := flags.(*swapFlags)
+		mint, err := parsePublicKey("mint", f.mint)
+		if err != nil {
+			return err
+		}
+		amount, err := parseAmount("amount", f.amount)
+		if err != nil {
+			return err
+		}
+		minOut, err := parseUint("min-out", f.minOut)
+		if err != nil {
+			return err
+		}
+
+		p.info("👛 钱包：%s，地址：%s.\n", c.WalletName(), c.PublicKey())
+		var result *client.SwapResult
+		if side == client.SwapSideBuy {
+			result, err = c.Buy(ctx, mint, amount, minOut)
+		} else {
+			result, err = c.Sell(ctx, mint, amount, minOut)
+		}
+		if err != nil {
+			return err
+		}
+		p.result(result, func() {
+			printTxResult(&result.TxResult)
+			fmt.Printf("💱 池子：%s，代币数量：%d，SOL 数量：%d，价格影响 %.4f%%.\n",
+				result.Pool, result.BaseAmount, result.QuoteAmount, result.PriceImpact)
+		})
+		return nil
+	}
+}
+
+func runBalances(ctx context.Context, c *client.PumpSwapClient, p printer, _ []string, flags any) error {
+	var owner solana.PublicKey
+	if address := *flags.(*string); address != "" {
+		var err error
+		if owner, err = parsePublicKey("address", address); err != nil {
+			return err
+		}
+	}
+	balances, err := c.Balances(ctx, owner)
+	if err != nil {
+		return err
+	}
+	p.result(balances, func() {
+		fmt.Printf("👛 地址：%s.\n", balances.Owner)
+		fmt.Printf("💰 SOL：%d lamports，WSOL：%d lamports.\n", balances.Lamports, balances.WrappedLamports)
+		for _, token := range balances.Tokens {
+			fmt.Printf("🪙 %s：%d（账户 %s）.\n", token.Mint, token.Amount, token.Account)
+		}
+	})
+	return nil
+}
+
+// historyFlags history 命令的参数
+type historyFlags struct {
+	address string
+	mint    string
+	limit   int
+}
+
+func registerHistoryFlags(fs *flag.FlagSet) any {
+	f := &historyFlags{}
+	fs.StringVar(&f.address, "address", "", "要查询的地址，设置后不需要解锁钱包")
+	fs.StringVar(&f.mint, "mint", "", "只显示该代币池子的成交")
+	fs.IntVar(&f.limit, "limit", 20, fmt.Sprintf("查询最近多少笔签名，最多 %d", client.MaxHistoryLimit))
+	return f
+}
+
+func runHistory(ctx context.Context, c *client.PumpSwapClient, p printer, _ []string, flags any) error {
+	f := flags.(*historyFlags)
+	if f.limit <= 0 || f.limit > client.MaxHistoryLimit {
+		return usageError("invalid --limit %d, must be between 1 and %d", f.limit, client.MaxHistoryLimit)
+	}
+	var owner, pool solana.PublicKey
+	var err error
+	if f.address != "" {
+		if owner, err = parsePublicKey("address", f.address); err != nil {
+			return err
+		}
+	}
+	if f.mint != "" {
+		mint, err := parsePublicKey("mint", f.mint)
+		if err != nil {
+			return err
+		}
+		info, err := c.ResolvePool(ctx, mint)
+		if err != nil {
+			return err
+		}
+		pool = info.Address
+	}
+
+	trades, err := c.History(ctx, owner, f.limit)
+	if err != nil {
+		return err
+	}
+	if !pool.IsZero() {
+		trades = slices.DeleteFunc(trades, func(trade client.HistoryTrade) bool { return !trade.Pool.Equals(pool) })
+	}
+	p.result(trades, func() {
+		if len(trades) == 0 {
+			fmt.Println("🔍 最近没有 PumpSwap 成交.")
+			return
+		}
+		for _, trade := range trades {
+			action := "🟢 买入"
+			if trade.Side == client.SwapSideSell {
+				action = "🔴 卖出"
+			}
+			fmt.Printf("%s %s，代币 %d，SOL %d lamports，池子 %s，交易 %s.\n",
+				action, time.Unix(trade.Timestamp, 0).Format(time.DateTime), trade.BaseAmount, trade.QuoteAmount, trade.Pool, trade.Signature)
+		}
+	})
+	return nil
+}
+
+func runWrap(ctx context.Context, c *client.PumpSwapClient, p printer, _ []string, flags any) error {
+	amount, err := parseAmount("amount", *flags.(*string))
+	if err != nil {
+		return err
+	}
+	result, err := c.Wrap(ctx, amount)
+	if err != nil {
+		return err
+	}
+	p.result(result, func() { printTxResult(result) })
+	return nil
+}
+
+func runUnwrap(ctx context.Context, c *client.PumpSwapClient, p printer, _ []string, _ any) error {
+	result, err := c.Unwrap(ctx)
+	if err != nil {
+		return err
+	}
+	p.result(result, func() { printTxResult(result) })
+	return nil
+}
+
+func runTx(ctx context.Context, c *client.PumpSwapClient, p printer, args []string, _ any) error {
+	if len(args) != 2 || args[0] != "status" {
+		return usageError("usage: tx status <signature>")
+	}
+	signature, err := solana.SignatureFromBase58(args[1])
+	if err != nil {
+		return usageError("invalid signature %s: %v", args[1], err)
+	}
+	status, err := c.TxStatus(ctx, signature)
+	if err != nil {
+		return err
+	}
+	p.result(status, func() {
+		switch {
+		case !status.Found:
+			fmt.Printf("🔍 没有查到交易 %s.\n", status.Signature)
+		case status.Succeeded:
+			fmt.Printf("✅ 交易成功：%s，slot：%d，状态：%s.\n", status.Signature, status.Slot, status.ConfirmationStatus)
+		default:
+			fmt.Printf("❌ 交易失败：%s，slot：%d，原因：%s.\n", status.Signature, status.Slot, status.Error)
+		}
+		if status.Fee > 0 {
+			fmt.Printf("⛽ 计算单元：%d，交易费：%d lamports.\n", status.ComputeUnits, status.Fee)
+		}
+	})
+	return nil
+}
+
+func printTxResult(result *client.TxResult) {
+	if result.Simulated {
+		fmt.Printf("🧪 模拟成功（未发送）：%s.\n", result.Signature)
+		for _, line := range result.Logs {
+			fmt.Fprintln(os.Stderr, "   "+line)
+		}
+	} else {
+		fmt.Printf("✅ 交易已确认：%s，slot：%d.\n", result.Signature, result.Slot)
+	}
+	fmt.Printf("⛽ 计算单元：%d/%d，单价：%d micro-lamports，交易费：%d lamports（优先费 %d）.\n",
+		result.ComputeUnits, result.ComputeUnitLimit, result.ComputeUnitPrice, result.Fee, result.PriorityFee)
+	if result.SentVia == client.SendViaBundle {
+		fmt.Printf("🛡️ 通过 Jito bundle 上链：%s，tip：%d lamports.\n", result.BundleID, result.Tip)
+	}
+}
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"net"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gagliardetto/solana-go/rpc"
@@ -14,11 +18,32 @@ type SwapConfig struct {
 	RPCEndpoint string             `json:"rpc_endpoint"`
 	Commitment  rpc.CommitmentType `json:"commitment"` // 交易确认级别：finalized、confirmed、processed
 
+	// 钱包配置
+	WalletName string `json:"wallet_name"` // 多钱包时默认用于签名的钱包名
+
 	// 交易配置
-	ComputeUnitprice uint64        `json:"compute_unit_price"` // todo ： what?
-	ComputeUnitLimit uint32        `json:"compute_unit_limit"` // todo ： what?
-	Maxretries       int           `json:"max_retries"`
-	RetryDelay       time.Duration `json:"retry_delay"`
+	Maxretries int           `json:"max_retries"`
+	RetryDelay time.Duration `json:"retry_delay"`
+
+	// 计算预算配置
+	/*
+		交易费 = 5000 lamports * 签名数 + 计算单元价格(micro-lamports) * 计算单元上限 / 1e6
+			-计算单元上限：先按 ComputeUnitLimit 模拟，再在实际消耗上加 ComputeUnitMarginBP 的余量，不超过 ComputeUnitLimit
+			-计算单元价格：取交易写入账户近期优先费的 PriorityFeePercentile 百分位，按 PriorityUrgency 放大，
+			 再限制在 [ComputeUnitprice, MaxComputeUnitPrice] 之间
+	*/
+	ComputeUnitprice      uint64 `json:"compute_unit_price"`      // 最低计算单元价格（micro-lamports），没有近期优先费数据时也用它
+	MaxComputeUnitPrice   uint64 `json:"max_compute_unit_price"`  // 计算单元价格上限（micro-lamports），0 表示不限制
+	PriorityFeePercentile uint32 `json:"priority_fee_percentile"` // 取近期优先费的百分位：0-100
+	PriorityUrgency       string `json:"priority_urgency"`        // 紧急程度：low、medium、high、urgent
+	ComputeUnitLimit      uint32 `json:"compute_unit_limit"`      // 计算单元上限，模拟时使用，不能超过 MaxComputeUnitLimit
+	ComputeUnitMarginBP   uint32 `json:"compute_unit_margin_bp"`  // 在模拟消耗上增加的余量：1000 = 10%
+
+	// MEV 保护配置：设置 MEVNodeUrl 后交易以 Jito bundle 提交，交易末尾转账 JitoTipLamports 给随机的 tip 账户，
+	// BundleTimeout 内没有上链则把同一笔已签名交易改走普通 RPC 发送（签名相同，最多只会成交一次）
+	MEVNodeUrl      string        `json:"mev_node_url"`      // Jito Block Engine 地址，为空时不使用 bundle
+	JitoTipLamports uint64        `json:"jito_tip_lamports"` // 每个 bundle 的 tip
+	BundleTimeout   time.Duration `json:"bundle_timeout"`    // 等待 bundle 上链的时间
 
 	// 安全配置
 	/*
@@ -30,30 +55,102 @@ type SwapConfig struct {
 			    --零值 big.Int{} 表示 0，但无法表示“未设置”
 			    --指针 *big.Int 的零值是 nil，可以表示“未设置”
 	*/
+	SlippageBP         uint32   `json:"slippage_bp"`          // 下单使用的滑点，不能超过 MaxSlippageBP
 	MaxSlippageBP      uint32   `json:"max_slippage_bp"`      // 最大允许滑点：10000 = 100%，例如1000 = 10%
 	MinAmountThreshold *big.Int `json:"min_amount_threshold"` // 最小交易金额
 	MaxAmountThreshold *big.Int `json:"max_amount_threshold"` // 最大交易金额
 
+	// dry-run：只模拟交易，不发送
+	DryRun bool `json:"dry_run"`
+
 	// 调试配置
 	EnableDebugLog bool   `json:"enable_debug_log"` // 是否启用调试日志
 	LogLevel       string `json:"log_level"`        // 日志级别：debug、info、warn、error
 
 }
 
+// Jito bundle 的最低 tip
+const MinJitoTipLamports = 1000
+
+// 交易最多能申请的计算单元
+const MaxComputeUnitLimit = 1_400_000
+
+// 紧急程度
+const (
+	UrgencyLow    = "low"
+	UrgencyMedium = "medium"
+	UrgencyHigh   = "high"
+	UrgencyUrgent = "urgent"
+)
+
+// UrgencyMultiplierBP 各紧急程度对百分位价格的放大倍数：10000 = 1 倍
+var UrgencyMultiplierBP = map[string]uint64{
+	UrgencyLow:    7_500,
+	UrgencyMedium: 10_000,
+	UrgencyHigh:   15_000,
+	UrgencyUrgent: 20_000,
+}
+
 func DefaultConfig() *SwapConfig {
 	return &SwapConfig{
-		RPCEndpoint:        "https://api.devnet.solana.com",
-		Commitment:         rpc.CommitmentProcessed,
-		ComputeUnitprice:   1000,
-		ComputeUnitLimit:   200_000,
-		Maxretries:         3,
-		RetryDelay:         time.Second * 2,
-		MaxSlippageBP:      5000,
-		MinAmountThreshold: big.NewInt(1000),
-		MaxAmountThreshold: big.NewInt(100_000_000_000),
-		EnableDebugLog:     false,
-		LogLevel:           "info",
+		RPCEndpoint:           "https://api.devnet.solana.com",
+		Commitment:            rpc.CommitmentProcessed,
+		WalletName:            "default",
+		Maxretries:            3,
+		RetryDelay:            time.Second * 2,
+		ComputeUnitprice:      1000,
+		MaxComputeUnitPrice:   1_000_000,
+		PriorityFeePercentile: 75,
+		PriorityUrgency:       UrgencyMedium,
+		ComputeUnitLimit:      400_000,
+		ComputeUnitMarginBP:   1000,
+		JitoTipLamports:       10_000,
+		BundleTimeout:         time.Second * 15,
+		SlippageBP:            100,
+		MaxSlippageBP:         5000,
+		MinAmountThreshold:    big.NewInt(1000),
+		MaxAmountThreshold:    big.NewInt(100_000_000_000),
+		EnableDebugLog:        false,
+		LogLevel:              "info",
+	}
+}
+
+// 各公开网络的创世哈希
+const (
+	MainnetGenesisHash = "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc8k3PNExpn"
+	TestnetGenesisHash = "4uhcVJyU9pJkvQyS88uRDiswHXSCkY3zQawwpjk2NsNY"
+	DevnetGenesisHash  = "EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG"
+)
+
+// IsDevnet 按 RPC 节点返回的创世哈希判断是否为 devnet 或本地测试节点，只有这些网络允许使用 DEVNET_PRIVATE_KEY；
+// 端点 URL 里带 devnet 字样的主网节点不会被误判
+func (c *SwapConfig) IsDevnet(ctx context.Context) (bool, error) {
+	hash, err := rpc.New(c.RPCEndpoint).GetGenesisHash(ctx)
+	if err != nil {
+		return false, fmt.Errorf("get genesis hash: %w", err)
 	}
+	return isDevnetGenesis(hash.String(), c.RPCEndpoint), nil
+}
+
+// isDevnetGenesis solana-test-validator 每次启动生成新的创世哈希，没有固定值：
+// 端点为回环地址且创世哈希不是主网、测试网的视为本地测试节点
+func isDevnetGenesis(genesisHash, endpoint string) bool {
+	switch genesisHash {
+	case DevnetGenesisHash:
+		return true
+	case MainnetGenesisHash, TestnetGenesisHash:
+		return false
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
 
 func (c *SwapConfig) Validate() error {
@@ -65,10 +162,39 @@ func (c *SwapConfig) Validate() error {
 		return fmt.Errorf("compute unit limit must be greater than 0")
 	}
 
+	if c.ComputeUnitLimit > MaxComputeUnitLimit {
+		return fmt.Errorf("compute unit limit must be less than %d", MaxComputeUnitLimit)
+	}
+
+	if c.MaxComputeUnitPrice != 0 && c.MaxComputeUnitPrice < c.ComputeUnitprice {
+		return fmt.Errorf("max compute unit price must be greater than compute unit price")
+	}
+
+	if c.PriorityFeePercentile > 100 {
+		return fmt.Errorf("priority fee percentile must be less than 100")
+	}
+
+	if _, ok := UrgencyMultiplierBP[c.PriorityUrgency]; !ok {
+		return fmt.Errorf("unknown priority urgency: %s", c.PriorityUrgency)
+	}
+
+	if c.MEVNodeUrl != "" {
+		if c.JitoTipLamports < MinJitoTipLamports {
+			return fmt.Errorf("jito tip must be at least %d lamports", MinJitoTipLamports)
+		}
+		if c.BundleTimeout <= 0 {
+			return fmt.Errorf("bundle timeout must be greater than 0")
+		}
+	}
+
 	if c.MaxSlippageBP > 10000 {
 		return fmt.Errorf("max slippage must be less than 10000")
 	}
 
+	if c.SlippageBP > c.MaxSlippageBP {
+		return fmt.Errorf("slippage must be less than max slippage")
+	}
+
 	if c.Maxretries < 0 {
 		return fmt.Errorf("max retries must be greater than 0")
 	}
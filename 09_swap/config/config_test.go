@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsDevnetGenesis(t *testing.T) {
+	const localGenesis = "3Xq8cLsVf5b1sLVD8zvP3WtaCR9ctfrVqCLvMbD9hVKS"
+	cases := []struct {
+		hash, endpoint string
+		want           bool
+	}{
+		{DevnetGenesisHash, "https://api.devnet.solana.com", true},
+		{DevnetGenesisHash, "https://rpc.example.com", true},
+		{MainnetGenesisHash, "https://devnet-looking.example.com", false},
+		{MainnetGenesisHash, "http://127.0.0.1:8899", false},
+		{TestnetGenesisHash, "https://api.testnet.solana.com", false},
+		{localGenesis, "http://127.0.0.1:8899", true},
+		{localGenesis, "http://localhost:8899", true},
+		{localGenesis, "http://[::1]:8899", true},
+		{localGenesis, "https://127.0.0.1.example.com", false},
+		{localGenesis, "https://rpc.example.com/?devnet", false},
+	}
+	for _, c := range cases {
+		if got := isDevnetGenesis(c.hash, c.endpoint); got != c.want {
+			t.Errorf("isDevnetGenesis(%s, %s) = %v, want %v", c.hash, c.endpoint, got, c.want)
+		}
+	}
+}
+
+func TestMergeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swap.json")
+	if err := os.WriteFile(path, []byte(`{"wallet_name": "trader", "slippage_bp": 300}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// 文件中没有的字段保留合并前的值（例如来自环境变量的 RPC 节点），文件中的字段覆盖原值
+	cfg := DefaultConfig()
+	cfg.RPCEndpoint = "https://rpc.example.com"
+	cfg.WalletName = "from-env"
+	if err := MergeFile(cfg, path); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.RPCEndpoint != "https://rpc.example.com" {
+		t.Errorf("RPCEndpoint = %s, want the value set before merging", cfg.RPCEndpoint)
+	}
+	if cfg.WalletName != "trader" || cfg.SlippageBP != 300 {
+		t.Errorf("WalletName = %s, SlippageBP = %d, want trader, 300 from the file", cfg.WalletName, cfg.SlippageBP)
+	}
+}
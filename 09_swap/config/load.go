@@ -0,0 +1,71 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// LoadFile 从 JSON 文件加载配置，文件中没有的字段保留 DefaultConfig 的默认值
+func LoadFile(path string) (*SwapConfig, error) {
+	cfg := DefaultConfig()
+	if err := MergeFile(cfg, path); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// MergeFile 用 JSON 文件中出现的字段覆盖 cfg，文件中没有的字段保留 cfg 原来的值
+func MergeFile(cfg *SwapConfig, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("decode config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// UnmarshalJSON 时长字段既可以写纳秒数，也可以写 "2s"、"500ms" 这样的字符串
+func (c *SwapConfig) UnmarshalJSON(data []byte) error {
+	type plain SwapConfig
+	aux := struct {
+		*plain
+		RetryDelay    *duration `json:"retry_delay"`
+		BundleTimeout *duration `json:"bundle_timeout"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.RetryDelay != nil {
+		c.RetryDelay = time.Duration(*aux.RetryDelay)
+	}
+	if aux.BundleTimeout != nil {
+		c.BundleTimeout = time.Duration(*aux.BundleTimeout)
+	}
+	return nil
+}
+
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case float64:
+		*d = duration(v)
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = duration(parsed)
+	default:
+		return fmt.Errorf("invalid duration: %s", data)
+	}
+	return nil
+}
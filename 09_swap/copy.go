@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"swap/client"
+	"swap/copytrade"
+	"swap/feed"
+
+	"github.com/sirupsen/logrus"
+)
+
+// copyFlags copy 命令的参数
+type copyFlags struct {
+	config     string
+	dir        string
+	feedURL    string
+	gatewayURL string
+	leader     string
+	action     string
+}
+
+func registerCopyFlags(fs *flag.FlagSet) any {
+	f := &copyFlags{}
+	fs.StringVar(&f.config, "copy-config", os.Getenv("COPY_CONFIG"), "跟单配置 JSON 文件：被跟钱包、仓位、风控和代币检测要求（环境变量 COPY_CONFIG）")
+	fs.StringVar(&f.dir, "copy-dir", getEnvOrDefault("COPY_DIR", defaultCopyDir()), "决策记录目录（环境变量 COPY_DIR）")
+	fs.StringVar(&f.feedURL, "feed", os.Getenv("FEED_URL"), "consumer 的 WebSocket 推送地址，例如 ws://127.0.0.1:8081/ws（环境变量 FEED_URL）")
+	fs.StringVar(&f.gatewayURL, "gateway", os.Getenv("GATEWAY_URL"), "consumer 网关地址，用于查询代币检测结果，例如 http://127.0.0.1:8888（环境变量 GATEWAY_URL）")
+	fs.StringVar(&f.leader, "leader", "", "log 时只显示该被跟钱包的决策")
+	fs.StringVar(&f.action, "action", "", "log 时只显示该结果的决策：copied、simulated、skipped、failed")
+	return f
+}
+
+func defaultCopyDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".pumpswap/copy"
+	}
+	return filepath.Join(home, ".pumpswap", "copy")
+}
+
+func runCopy(ctx context.Context, c *client.PumpSwapClient, p printer, args []string, flags any) error {
+	f := flags.(*copyFlags)
+	if len(args) == 0 {
+		return usageError("usage: copy run|log")
+	}
+	log, err := copytrade.OpenDecisionLog(f.dir)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "run":
+		return runCopyEngine(ctx, c, p, log, f)
+	case "log":
+		decisions, err := log.Read(func(d *copytrade.Decision) bool {
+			return (f.leader == "" || d.Leader == f.leader) && (f.action == "" || d.Action == f.action)
+		})
+		if err != nil {
+			return err
+		}
+		if decisions == nil {
+			decisions = []*copytrade.Decision{}
+		}
+		p.result(decisions, func() {
+			if len(decisions) == 0 {
+				fmt.Println("📭 没有跟单记录.")
+			}
+			for _, d := range decisions {
+				printDecision(d)
+			}
+		})
+	default:
+		return usageError("unsupported copy command %s", args[0])
+	}
+	return nil
+}
+
+// runCopyEngine 运行跟单直到收到退出信号，正在执行的跟单会等待完成
+func runCopyEngine(ctx context.Context, c *client.PumpSwapClient, p printer, log *copytrade.DecisionLog, f *copyFlags) error {
+	if f.config == "" || f.feedURL == "" || f.gatewayURL == "" {
+		return usageError("--copy-config, --feed and --gateway are required")
+	}
+	cfg, err := copytrade.LoadConfig(f.config)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	// 跟单常驻运行，不受 --timeout 限制
+	ctx, stop := signal.NotifyContext(context.WithoutCancel(ctx), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := logrus.New()
+	if c.Config().EnableDebugLog {
+		logger.SetLevel(logrus.DebugLevel)
+	}
+	engine, err := copytrade.NewEngine(cfg, c.Config().WalletName, c.Config().SlippageBP,
+		copytrade.NewClientExecutor(c), feed.NewGateway(f.gatewayURL), log, logger)
+	if err != nil {
+		return err
+	}
+	p.info("👀 跟单已启动，跟随 %d 个钱包，钱包：%v，行情：%s，记录目录：%s.\n", len(cfg.Leaders), c.WalletNames(), f.feedURL, f.dir)
+	if c.Config().DryRun {
+		p.info("🧪 dry-run 模式：跟单只模拟不发送.\n")
+	}
+	err = engine.Run(ctx, feed.NewClient(f.feedURL, logger))
+	if errors.Is(err, context.Canceled) {
+		p.info("👋 跟单已退出.\n")
+		return nil
+	}
+	return err
+}
+
+func printDecision(d *copytrade.Decision) {
+	leader := d.Leader
+	if d.Label != "" {
+		leader += "(" + d.Label + ")"
+	}
+	fmt.Printf("%s %-9s %s %s %s 被跟 %d lamports / %g 个", d.Time.Format("2006-01-02 15:04:05"), d.Action, leader,
+		d.Side, d.Mint, d.LeaderLamports, d.LeaderTokens)
+	if d.Amount > 0 {
+		fmt.Printf(" 跟单 %d", d.Amount)
+	}
+	if d.Signature != "" {
+		fmt.Printf(" 交易 %s", d.Signature)
+	}
+	if d.Reason != "" {
+		fmt.Printf(" 原因 %s", d.Reason)
+	}
+	fmt.Println(".")
+}
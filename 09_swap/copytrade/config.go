@@ -0,0 +1,190 @@
+package copytrade
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"swap/feed"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// 跟单仓位的计算方式
+const (
+	SizingFixed   = "fixed"   // 每次买入固定的 lamports
+	SizingPercent = "percent" // 按跟单钱包 SOL 余额的比例买入
+	SizingRatio   = "ratio"   // 按被跟钱包花费的 SOL 乘以比例买入
+)
+
+// Sizing 跟单买入的仓位大小
+type Sizing struct {
+	Mode      string  `json:"mode"`
+	Lamports  uint64  `json:"lamports,omitempty"`   // fixed：每次买入花费的 lamports
+	PercentBP uint32  `json:"percent_bp,omitempty"` // percent：钱包 SOL 余额的比例，10000 = 100%
+	Ratio     float64 `json:"ratio,omitempty"`      // ratio：被跟钱包花费的 SOL × Ratio
+}
+
+// Leader 被跟的钱包
+type Leader struct {
+	Address string  `json:"address"`
+	Label   string  `json:"label,omitempty"`  // 备注，例如 wallet_label 表中的 kol、top_pnl，写入决策记录便于复盘
+	Wallet  string  `json:"wallet,omitempty"` // 跟单使用的钱包名，空表示 SwapConfig.WalletName
+	Sizing  *Sizing `json:"sizing,omitempty"` // 空表示使用全局 Sizing
+}
+
+// Limits 风控限额，lamports 为 0 的限额表示不限制；每日限额按 UTC 自然日统计，重启后从决策记录恢复
+type Limits struct {
+	MinLeaderLamports    uint64 `json:"min_leader_lamports"`    // 被跟钱包单笔花费低于此值时不跟，过滤试探性小单
+	MinCopyLamports      uint64 `json:"min_copy_lamports"`      // 计算或截断后的买入金额低于此值时不跟
+	MaxTradeLamports     uint64 `json:"max_trade_lamports"`     // 单笔买入上限
+	MaxTokenLamports     uint64 `json:"max_token_lamports"`     // 每个代币的持仓成本上限，卖出后按比例释放，不按天重置
+	MaxDailyLamports     uint64 `json:"max_daily_lamports"`     // 每天的买入上限
+	MaxDailyTrades       int    `json:"max_daily_trades"`       // 每天的跟单笔数上限，0 表示不限制
+	MaxPriceImpactBP     uint32 `json:"max_price_impact_bp"`    // 执行前报价的价格影响上限，0 表示不限制
+	MaxDelaySeconds      int64  `json:"max_delay_seconds"`      // 被跟成交的区块时间距现在超过此值时不跟，0 表示不限制
+	SecurityCacheSeconds int64  `json:"security_cache_seconds"` // 代币检测结果的缓存时间
+}
+
+// SecurityPolicy 代币检测要求，对应 solmodel.Token 的检测字段；只在跟买时检查，跟卖总是允许退出
+type SecurityPolicy struct {
+	RequireChecked        bool    `json:"require_checked"`          // 要求已完成合约检测（is_check_ca）
+	AllowMintAuthority    bool    `json:"allow_mint_authority"`     // 允许保留增发权限（is_can_add_token）
+	AllowFreezeAuthority  bool    `json:"allow_freeze_authority"`   // 允许冻结权限或默认冻结（is_have_black_list）
+	AllowTransferHook     bool    `json:"allow_transfer_hook"`      // 允许 Token-2022 转账钩子（is_can_external_call）
+	AllowBundled          bool    `json:"allow_bundled"`            // 允许开盘被捆绑买入（is_bundled）
+	MaxTax                float64 `json:"max_tax"`                  // 买卖税上限，0-1
+	MaxSniperHoldPercent  float64 `json:"max_sniper_hold_percent"`  // 狙击钱包持仓上限，0-100，0 表示不限制
+	MaxBundlerHoldPercent float64 `json:"max_bundler_hold_percent"` // 捆绑钱包持仓上限，0-100，0 表示不限制
+}
+
+// Config 跟单配置
+type Config struct {
+	Leaders    []Leader       `json:"leaders"`
+	Sizing     Sizing         `json:"sizing"`
+	CopySells  bool           `json:"copy_sells"`  // 被跟钱包卖出时按卖出比例卖出自己的仓位
+	SlippageBP uint32         `json:"slippage_bp"` // 跟单下单的滑点，0 表示使用 SwapConfig.SlippageBP
+	Limits     Limits         `json:"limits"`
+	Security   SecurityPolicy `json:"security"`
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Sizing:    Sizing{Mode: SizingFixed, Lamports: 100_000_000},
+		CopySells: true,
+		Limits: Limits{
+			MinLeaderLamports:    50_000_000,
+			MinCopyLamports:      10_000_000,
+			MaxTradeLamports:     500_000_000,
+			MaxTokenLamports:     1_000_000_000,
+			MaxDailyLamports:     5_000_000_000,
+			MaxDailyTrades:       50,
+			MaxPriceImpactBP:     500,
+			MaxDelaySeconds:      30,
+			SecurityCacheSeconds: 300,
+		},
+		Security: SecurityPolicy{
+			RequireChecked:        true,
+			MaxTax:                0.05,
+			MaxSniperHoldPercent:  30,
+			MaxBundlerHoldPercent: 30,
+		},
+	}
+}
+
+// LoadConfig 从 JSON 文件加载配置，文件中没有的字段保留 DefaultConfig 的默认值
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read copy trade config: %w", err)
+	}
+	cfg := DefaultConfig()
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("decode copy trade config %s: %w", path, err)
+	}
+	return cfg, cfg.Validate()
+}
+
+func (c *Config) Validate() error {
+	if len(c.Leaders) == 0 {
+		return errors.New("no leader to follow")
+	}
+	seen := make(map[string]bool, len(c.Leaders))
+	for _, leader := range c.Leaders {
+		if _, err := solana.PublicKeyFromBase58(leader.Address); err != nil {
+			return fmt.Errorf("invalid leader address %s: %w", leader.Address, err)
+		}
+		if seen[leader.Address] {
+			return fmt.Errorf("duplicate leader %s", leader.Address)
+		}
+		seen[leader.Address] = true
+		if leader.Sizing != nil {
+			if err := leader.Sizing.Validate(); err != nil {
+				return fmt.Errorf("leader %s: %w", leader.Address, err)
+			}
+		}
+	}
+	if err := c.Sizing.Validate(); err != nil {
+		return err
+	}
+	if c.SlippageBP > 10000 || c.Limits.MaxPriceImpactBP > 10000 {
+		return errors.New("slippage and price impact bp must not exceed 10000")
+	}
+	return nil
+}
+
+func (s *Sizing) Validate() error {
+	switch s.Mode {
+	case SizingFixed:
+		if s.Lamports == 0 {
+			return errors.New("fixed sizing requires lamports")
+		}
+	case SizingPercent:
+		if s.PercentBP == 0 || s.PercentBP > 10000 {
+			return errors.New("percent sizing requires percent_bp between 1 and 10000")
+		}
+	case SizingRatio:
+		if s.Ratio <= 0 {
+			return errors.New("ratio sizing requires a positive ratio")
+		}
+	default:
+		return fmt.Errorf("unsupported sizing mode %q", s.Mode)
+	}
+	return nil
+}
+
+// Check 返回代币不满足要求的原因，全部满足时返回空
+func (p *SecurityPolicy) Check(token *feed.Token) []string {
+	if !token.IsCheckCa {
+		if p.RequireChecked {
+			return []string{"security check not finished"}
+		}
+		return nil
+	}
+	var reasons []string
+	fail := func(failed bool, reason string) {
+		if failed {
+			reasons = append(reasons, reason)
+		}
+	}
+	fail(token.IsHoneyScam || !token.IsCanAllSell, "honeypot")
+	fail(token.IsCanPauseTrade, "trading can be paused")
+	fail(token.IsCanChangeTax, "transfer fee can be changed")
+	fail(token.IsCanChangeToken, "permanent delegate")
+	fail(!p.AllowMintAuthority && token.IsCanAddToken, "mint authority not revoked")
+	fail(!p.AllowFreezeAuthority && token.IsHaveBlackList, "freeze authority or default frozen")
+	fail(!p.AllowTransferHook && token.IsCanExternalCall, "transfer hook")
+	fail(!p.AllowBundled && token.IsBundled, "bundled launch")
+	fail(max(token.BuyTax, token.SellTax) > p.MaxTax, fmt.Sprintf("tax %.2f%% above %.2f%%", max(token.BuyTax, token.SellTax)*100, p.MaxTax*100))
+	fail(p.MaxSniperHoldPercent > 0 && token.SniperHoldPercent > p.MaxSniperHoldPercent,
+		fmt.Sprintf("snipers hold %.2f%%", token.SniperHoldPercent))
+	fail(p.MaxBundlerHoldPercent > 0 && token.BundlerHoldPercent > p.MaxBundlerHoldPercent,
+		fmt.Sprintf("bundlers hold %.2f%%", token.BundlerHoldPercent))
+	return reasons
+}
+
+func (l *Limits) securityCacheTTL() time.Duration {
+	return time.Duration(l.SecurityCacheSeconds) * time.Second
+}
@@ -0,0 +1,339 @@
+package copytrade
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"swap/feed"
+	"swap/order/ordertest"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	testLeader = "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"
+	testOther  = "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+	testMint   = "So11111111111111111111111111111111111111112"
+)
+
+var testNow = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+type fakeTokens struct {
+	token *feed.Token
+	calls int
+}
+
+func (f *fakeTokens) Token(context.Context, string) (*feed.Token, error) {
+	f.calls++
+	if f.token == nil {
+		return nil, feed.ErrTokenNotFound
+	}
+	return f.token, nil
+}
+
+func safeToken() *feed.Token {
+	return &feed.Token{Address: testMint, IsCheckCa: true, IsCanAllSell: true}
+}
+
+func testConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.Leaders = []Leader{{Address: testLeader, Label: "kol"}}
+	return cfg
+}
+
+func newTestEngine(t *testing.T, cfg *Config, executor Executor, tokens TokenSource, dir string) *Engine {
+	log, err := OpenDecisionLog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	engine, err := NewEngine(cfg, "main", 100, executor, tokens, log, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.now = func() time.Time { return testNow }
+	return engine
+}
+
+var tradeSeq int
+
+// trade 被跟钱包的一笔成交，sol 为花费或得到的 SOL
+func trade(maker, side string, sol, tokens float64) *feed.Event {
+	tradeSeq++
+	id := strings.Repeat("x", tradeSeq)
+	return &feed.Event{
+		Type:         feed.EventTrade,
+		TokenAddress: testMint,
+		Trade: &feed.Trade{
+			TxHash:          "tx" + id,
+			HashId:          "hash" + id,
+			Maker:           maker,
+			TradeType:       side,
+			BaseTokenAmount: sol,
+			TokenAmount:     tokens,
+			BlockTime:       testNow.Unix() - 2,
+		},
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := testConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	cases := []func(*Config){
+		func(c *Config) { c.Leaders = nil },
+		func(c *Config) { c.Leaders = append(c.Leaders, c.Leaders[0]) },
+		func(c *Config) { c.Leaders[0].Address = "bad" },
+		func(c *Config) { c.Sizing = Sizing{Mode: SizingPercent, PercentBP: 20000} },
+		func(c *Config) { c.Leaders[0].Sizing = &Sizing{Mode: SizingRatio} },
+		func(c *Config) { c.Sizing = Sizing{Mode: "all_in"} },
+	}
+	for i, mutate := range cases {
+		cfg := testConfig()
+		mutate(cfg)
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
+
+func TestSecurityCheck(t *testing.T) {
+	policy := DefaultConfig().Security
+	if reasons := policy.Check(safeToken()); len(reasons) != 0 {
+		t.Fatalf("safe token reasons = %v", reasons)
+	}
+	if reasons := policy.Check(&feed.Token{}); len(reasons) != 1 || reasons[0] != "security check not finished" {
+		t.Fatalf("unchecked token reasons = %v", reasons)
+	}
+
+	token := safeToken()
+	token.IsCanAddToken = true
+	token.IsHaveBlackList = true
+	token.SellTax = 0.1
+	token.SniperHoldPercent = 45
+	reasons := policy.Check(token)
+	if len(reasons) != 4 {
+		t.Fatalf("reasons = %v", reasons)
+	}
+	policy.AllowMintAuthority = true
+	policy.AllowFreezeAuthority = true
+	policy.MaxTax = 0.2
+	policy.MaxSniperHoldPercent = 0
+	if reasons = policy.Check(token); len(reasons) != 0 {
+		t.Fatalf("relaxed policy reasons = %v", reasons)
+	}
+
+	token = safeToken()
+	token.IsCanAllSell = false
+	if reasons = policy.Check(token); len(reasons) != 1 || reasons[0] != "honeypot" {
+		t.Fatalf("honeypot reasons = %v", reasons)
+	}
+}
+
+func TestSizing(t *testing.T) {
+	cases := []struct {
+		sizing Sizing
+		leader float64 // 被跟钱包花费的 SOL
+		want   uint64
+	}{
+		{Sizing{Mode: SizingFixed, Lamports: 200_000_000}, 1, 200_000_000},
+		// 余额 2 SOL 的 5%
+		{Sizing{Mode: SizingPercent, PercentBP: 500}, 1, 100_000_000},
+		{Sizing{Mode: SizingRatio, Ratio: 0.25}, 1.2, 300_000_000},
+		// 按单笔上限 0.5 SOL 截断
+		{Sizing{Mode: SizingRatio, Ratio: 1}, 3, 500_000_000},
+	}
+	for i, c := range cases {
+		executor := &ordertest.Executor{SOL: 2_000_000_000}
+		cfg := testConfig()
+		cfg.Sizing = c.sizing
+		engine := newTestEngine(t, cfg, executor, &fakeTokens{token: safeToken()}, t.TempDir())
+		d := engine.Handle(context.Background(), trade(testLeader, feed.TradeTypeBuy, c.leader, 1000))
+		if d.Action != ActionCopied || d.Amount != c.want {
+			t.Errorf("case %d: decision = %+v, want amount %d", i, d, c.want)
+		}
+	}
+}
+
+func TestHandleSkips(t *testing.T) {
+	executor := &ordertest.Executor{}
+	tokens := &fakeTokens{token: safeToken()}
+	cfg := testConfig()
+	cfg.Leaders = append(cfg.Leaders, Leader{Address: testOther, Wallet: "locked"})
+	engine := newTestEngine(t, cfg, executor, tokens, t.TempDir())
+	ctx := context.Background()
+
+	if d := engine.Handle(ctx, trade("someone", feed.TradeTypeBuy, 1, 1000)); d != nil {
+		t.Fatalf("unfollowed maker decision = %+v", d)
+	}
+
+	event := trade(testLeader, feed.TradeTypeBuy, 1, 1000)
+	event.Trade.MevTag = "sandwich_front"
+	stale := trade(testLeader, feed.TradeTypeBuy, 1, 1000)
+	stale.Trade.BlockTime = testNow.Unix() - 120
+	cases := []struct {
+		event  *feed.Event
+		reason string
+	}{
+		{event, "tagged sandwich_front"},
+		{stale, "120s old"},
+		{trade(testLeader, feed.TradeTypeBuy, 0.01, 10), "below min"},
+		{trade(testOther, feed.TradeTypeBuy, 1, 1000), "not unlocked"},
+	}
+	for _, c := range cases {
+		d := engine.Handle(ctx, c.event)
+		if d.Action != ActionSkipped || !strings.Contains(d.Reason, c.reason) {
+			t.Errorf("decision = %+v, want skip %q", d, c.reason)
+		}
+	}
+
+	// 检测不通过的代币不跟买，检测结果缓存
+	tokens.token = &feed.Token{IsCheckCa: true, IsHoneyScam: true}
+	for range 2 {
+		d := engine.Handle(ctx, trade(testLeader, feed.TradeTypeBuy, 1, 1000))
+		if d.Action != ActionSkipped || d.Reason != "security: honeypot" {
+			t.Fatalf("decision = %+v", d)
+		}
+	}
+	if tokens.calls != 1 {
+		t.Fatalf("token lookups = %d, want 1", tokens.calls)
+	}
+
+	// 同一笔成交只处理一次
+	tokens.token = safeToken()
+	engine.security = make(map[string]securityResult)
+	event = trade(testLeader, feed.TradeTypeBuy, 1, 1000)
+	if d := engine.Handle(ctx, event); d.Action != ActionCopied {
+		t.Fatalf("decision = %+v", d)
+	}
+	if d := engine.Handle(ctx, event); d != nil {
+		t.Fatalf("duplicate decision = %+v", d)
+	}
+
+	executor.Impact = 8
+	if d := engine.Handle(ctx, trade(testLeader, feed.TradeTypeBuy, 1, 1000)); d.Action != ActionSkipped || !strings.Contains(d.Reason, "price impact") {
+		t.Fatalf("decision = %+v", d)
+	}
+	executor.Impact = 0
+	executor.Err = errors.New("rpc down")
+	if d := engine.Handle(ctx, trade(testLeader, feed.TradeTypeBuy, 1, 1000)); d.Action != ActionFailed || d.Reason != "rpc down" {
+		t.Fatalf("decision = %+v", d)
+	}
+	if len(executor.Swaps) != 2 || executor.Swaps[0].SlippageBP != 100 || executor.Swaps[0].Wallet != "main" {
+		t.Fatalf("swaps = %+v", executor.Swaps)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	executor := &ordertest.Executor{}
+	cfg := testConfig()
+	cfg.Sizing = Sizing{Mode: SizingFixed, Lamports: 400_000_000}
+	cfg.Limits.MaxTokenLamports = 1_000_000_000
+	cfg.Limits.MaxDailyLamports = 0
+	cfg.Limits.MaxDailyTrades = 3
+	dir := t.TempDir()
+	engine := newTestEngine(t, cfg, executor, &fakeTokens{token: safeToken()}, dir)
+	ctx := context.Background()
+
+	// 单代币上限 1 SOL：0.4 + 0.4 + 截断为 0.2
+	var amounts []uint64
+	for range 3 {
+		d := engine.Handle(ctx, trade(testLeader, feed.TradeTypeBuy, 1, 1000))
+		amounts = append(amounts, d.Amount)
+	}
+	if amounts[0] != 400_000_000 || amounts[1] != 400_000_000 || amounts[2] != 200_000_000 {
+		t.Fatalf("amounts = %v", amounts)
+	}
+	d := engine.Handle(ctx, trade(testLeader, feed.TradeTypeBuy, 1, 1000))
+	if d.Action != ActionSkipped || !strings.Contains(d.Reason, "daily trade limit") {
+		t.Fatalf("decision = %+v", d)
+	}
+
+	// 重启后从决策记录恢复当天的统计
+	engine = newTestEngine(t, cfg, executor, &fakeTokens{token: safeToken()}, dir)
+	if engine.dayTrades != 3 || engine.exposure[testMint] != 1_000_000_000 {
+		t.Fatalf("restored trades %d exposure %.0f", engine.dayTrades, engine.exposure[testMint])
+	}
+	cfg.Limits.MaxDailyTrades = 0
+	d = engine.Handle(ctx, trade(testLeader, feed.TradeTypeBuy, 1, 1000))
+	if d.Action != ActionSkipped || !strings.Contains(d.Reason, "capped to 0") {
+		t.Fatalf("decision = %+v", d)
+	}
+
+	// 第二天重新计算每日限额，单代币的持仓成本不清零
+	next := testNow.Add(24 * time.Hour)
+	engine.now = func() time.Time { return next }
+	at := func(e *feed.Event) *feed.Event {
+		e.Trade.BlockTime = next.Unix()
+		return e
+	}
+	if d = engine.Handle(ctx, at(trade(testLeader, feed.TradeTypeBuy, 1, 1000))); d.Action != ActionSkipped || !strings.Contains(d.Reason, "capped to 0") {
+		t.Fatalf("next day decision = %+v", d)
+	}
+	if engine.dayTrades != 0 || engine.exposure[testMint] != 1_000_000_000 {
+		t.Fatalf("next day trades %d exposure %.0f", engine.dayTrades, engine.exposure[testMint])
+	}
+
+	// 卖出持仓的 40% 后按比例释放额度：1 SOL * 60% = 0.6 SOL，还能买入 0.4 SOL
+	executor.Balance = 10_000
+	if d = engine.Handle(ctx, at(trade(testLeader, feed.TradeTypeSell, 1, 2400))); d.Action != ActionCopied || d.Amount != 4_000 || d.Holding != 10_000 {
+		t.Fatalf("sell decision = %+v", d)
+	}
+	if d = engine.Handle(ctx, at(trade(testLeader, feed.TradeTypeBuy, 1, 1000))); d.Action != ActionCopied || d.Amount != 400_000_000 {
+		t.Fatalf("buy after sell decision = %+v", d)
+	}
+	engine = newTestEngine(t, cfg, executor, &fakeTokens{token: safeToken()}, dir)
+	if engine.exposure[testMint] != 1_000_000_000 {
+		t.Fatalf("restored exposure %.0f", engine.exposure[testMint])
+	}
+}
+
+func TestCopySells(t *testing.T) {
+	executor := &ordertest.Executor{Balance: 10_000}
+	dir := t.TempDir()
+	engine := newTestEngine(t, testConfig(), executor, &fakeTokens{token: safeToken()}, dir)
+	ctx := context.Background()
+
+	// 开始跟单前的持仓不知道，全部卖出
+	d := engine.Handle(ctx, trade(testLeader, feed.TradeTypeSell, 1, 500))
+	if d.Action != ActionCopied || d.Amount != 10_000 {
+		t.Fatalf("decision = %+v", d)
+	}
+
+	// 被跟钱包买入 1000 后卖出 250，跟着卖出 25%
+	engine.Handle(ctx, trade(testLeader, feed.TradeTypeBuy, 1, 1000))
+	d = engine.Handle(ctx, trade(testLeader, feed.TradeTypeSell, 0.3, 250))
+	if d.Action != ActionCopied || d.Amount != 2_500 || d.LeaderPosition != 750 {
+		t.Fatalf("decision = %+v", d)
+	}
+
+	// 重启后恢复被跟钱包的持仓：再卖出 375 为剩余的一半
+	engine = newTestEngine(t, testConfig(), executor, &fakeTokens{token: safeToken()}, dir)
+	d = engine.Handle(ctx, trade(testLeader, feed.TradeTypeSell, 0.3, 375))
+	if d.Action != ActionCopied || d.Amount != 5_000 || d.LeaderPosition != 375 {
+		t.Fatalf("decision = %+v", d)
+	}
+
+	executor.Balance = 0
+	if d = engine.Handle(ctx, trade(testLeader, feed.TradeTypeSell, 0.3, 375)); d.Action != ActionSkipped {
+		t.Fatalf("decision = %+v", d)
+	}
+
+	cfg := testConfig()
+	cfg.CopySells = false
+	engine = newTestEngine(t, cfg, executor, &fakeTokens{token: safeToken()}, t.TempDir())
+	if d = engine.Handle(ctx, trade(testLeader, feed.TradeTypeSell, 1, 100)); d.Action != ActionSkipped {
+		t.Fatalf("decision = %+v", d)
+	}
+
+	log, _ := OpenDecisionLog(dir)
+	decisions, err := log.Read(func(d *Decision) bool { return d.Action == ActionCopied })
+	if err != nil || len(decisions) != 4 || decisions[0].Label != "kol" {
+		t.Fatalf("decisions = %d, %v", len(decisions), err)
+	}
+}
@@ -0,0 +1,112 @@
+package copytrade
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"swap/client"
+)
+
+// 决策结果
+const (
+	ActionCopied    = "copied"    // 已跟单
+	ActionSimulated = "simulated" // dry-run 模式下只模拟了交易
+	ActionSkipped   = "skipped"   // 不满足条件，没有下单
+	ActionFailed    = "failed"    // 下单失败
+)
+
+// Decision 对被跟钱包一笔成交的处理结果，跳过的成交也会记录，便于复盘
+type Decision struct {
+	Time           time.Time       `json:"time"`
+	Leader         string          `json:"leader"`
+	Label          string          `json:"label,omitempty"`
+	LeaderTx       string          `json:"leader_tx"`
+	TradeID        string          `json:"trade_id"` // 成交的唯一标识，用于去重
+	Mint           string          `json:"mint"`
+	Side           client.SwapSide `json:"side"`
+	LeaderLamports uint64          `json:"leader_lamports"` // 被跟钱包花费或得到的 SOL
+	LeaderTokens   float64         `json:"leader_tokens"`   // 被跟钱包买入或卖出的代币数量（已除精度）
+	LeaderPosition float64         `json:"leader_position"` // 这笔成交之后跟踪到的被跟钱包持仓
+	BlockTime      int64           `json:"block_time"`
+	Action         string          `json:"action"`
+	Reason         string          `json:"reason,omitempty"`
+	Wallet         string          `json:"wallet,omitempty"`  // 跟单使用的钱包名
+	Amount         uint64          `json:"amount,omitempty"`  // 买入为花费的 lamports，卖出为代币数量
+	Holding        uint64          `json:"holding,omitempty"` // 卖出前跟单钱包持有的代币数量，用于按比例减少持仓成本
+	Signature      string          `json:"signature,omitempty"`
+	BaseAmount     uint64          `json:"base_amount,omitempty"`
+	QuoteAmount    uint64          `json:"quote_amount,omitempty"`
+}
+
+// Spent 这笔决策计入买入限额的 lamports
+func (d *Decision) Spent() uint64 {
+	if d.Side != client.SwapSideBuy || (d.Action != ActionCopied && d.Action != ActionSimulated) {
+		return 0
+	}
+	return d.Amount
+}
+
+// DecisionLog 决策记录，每行一条 JSON，只追加
+type DecisionLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// OpenDecisionLog 打开 dir 下的决策记录，目录不存在时创建
+func OpenDecisionLog(dir string) (*DecisionLog, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create copy trade dir: %w", err)
+	}
+	return &DecisionLog{path: filepath.Join(dir, "decisions.jsonl")}, nil
+}
+
+func (l *DecisionLog) Append(d *Decision) error {
+	line, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open decision log: %w", err)
+	}
+	defer f.Close()
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write decision log: %w", err)
+	}
+	return f.Sync()
+}
+
+// Read 按时间顺序读取决策记录，filter 为空时返回全部
+func (l *DecisionLog) Read(filter func(*Decision) bool) ([]*Decision, error) {
+	f, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var decisions []*Decision
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		d := &Decision{}
+		if err = json.Unmarshal(scanner.Bytes(), d); err != nil {
+			return nil, fmt.Errorf("decode decision log: %w", err)
+		}
+		if filter == nil || filter(d) {
+			decisions = append(decisions, d)
+		}
+	}
+	return decisions, scanner.Err()
+}
@@ -0,0 +1,462 @@
+package copytrade
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"swap/client"
+	"swap/feed"
+	"swap/order"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/sirupsen/logrus"
+)
+
+const lamportsPerSOL = 1_000_000_000
+
+// queueSize 等待处理的成交数，跟单按顺序执行，队列写满时后到的成交记为跳过
+const queueSize = 256
+
+// Executor 跟单下单，在订单执行器的基础上需要查询钱包的 SOL 余额
+type Executor interface {
+	order.Executor
+	// Lamports 钱包的 SOL 余额
+	Lamports(ctx context.Context, wallet string) (uint64, error)
+}
+
+// TokenSource 代币检测结果的来源，feed.Gateway 实现
+type TokenSource interface {
+	Token(ctx context.Context, address string) (*feed.Token, error)
+}
+
+type securityResult struct {
+	reasons []string
+	expires time.Time
+}
+
+// Engine 跟单执行器：订阅被跟钱包的成交，按顺序逐笔决定是否跟单并记录决策
+type Engine struct {
+	config        *Config
+	defaultWallet string
+	slippageBP    uint32
+	executor      Executor
+	tokens        TokenSource
+	log           *DecisionLog
+	logger        *logrus.Logger
+	leaders       map[string]Leader
+	queue         chan *feed.Event
+	now           func() time.Time
+
+	// 以下状态只在处理成交的协程中读写
+	positions map[string]float64 // 被跟钱包在每个代币上跟踪到的持仓，key 为 leader/mint
+	seen      map[string]bool    // 已处理的成交
+	day       string             // 当前统计的 UTC 日期
+	daySpent  uint64
+	dayTrades int
+	exposure  map[string]float64 // 每个代币当前持仓的买入成本（lamports），卖出时按卖出比例减少，不按天清零
+	security  map[string]securityResult
+}
+
+// NewEngine 创建跟单执行器，并从决策记录恢复被跟钱包的持仓、每个代币的持仓成本和当天已用的限额
+func NewEngine(cfg *Config, defaultWallet string, slippageBP uint32, executor Executor, tokens TokenSource,
+	log *DecisionLog, logger *logrus.Logger) (*Engine, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
+	if cfg.SlippageBP > 0 {
+		slippageBP = cfg.SlippageBP
+	}
+	e := &Engine{
+		config:        cfg,
+		defaultWallet: defaultWallet,
+		slippageBP:    slippageBP,
+		executor:      executor,
+		tokens:        tokens,
+		log:           log,
+		logger:        logger,
+		leaders:       make(map[string]Leader, len(cfg.Leaders)),
+		queue:         make(chan *feed.Event, queueSize),
+		now:           time.Now,
+		positions:     make(map[string]float64),
+		seen:          make(map[string]bool),
+		exposure:      make(map[string]float64),
+		security:      make(map[string]securityResult),
+	}
+	for _, leader := range cfg.Leaders {
+		e.leaders[leader.Address] = leader
+	}
+
+	decisions, err := log.Read(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range decisions {
+		e.seen[d.TradeID] = true
+		e.positions[positionKey(d.Leader, d.Mint)] = d.LeaderPosition
+		e.expose(d)
+		e.rollDay(d.Time)
+		if d.Time.UTC().Format(time.DateOnly) == e.day {
+			e.count(d)
+		}
+	}
+	e.rollDay(e.now())
+	return e, nil
+}
+
+// Run 订阅被跟钱包的成交并逐笔处理，直到 ctx 结束；返回前处理完正在执行的一笔
+func (e *Engine) Run(ctx context.Context, source order.Feed) error {
+	wallets := make([]string, 0, len(e.leaders))
+	for address := range e.leaders {
+		wallets = append(wallets, address)
+	}
+	if err := source.Subscribe(feed.Subscription{Wallets: wallets}); err != nil {
+		return fmt.Errorf("subscribe leaders: %w", err)
+	}
+
+	feedDone := make(chan error, 1)
+	go func() {
+		feedDone <- source.Run(ctx, func(event *feed.Event) {
+			if event.Type != feed.EventTrade || event.Trade == nil {
+				return
+			}
+			select {
+			case e.queue <- event:
+			default:
+				// 处理不过来时不阻塞读取，记录后丢弃；这里不在处理协程中，只写决策记录
+				leader, ok := e.leaders[event.Trade.Maker]
+				if !ok {
+					return
+				}
+				d := e.newDecision(event, leader)
+				d.Time, d.Action, d.Reason = e.now(), ActionSkipped, "copy queue is full"
+				e.logger.Warnf("copy queue is full, drop trade %s of %s", d.LeaderTx, d.Leader)
+				if err := e.log.Append(d); err != nil {
+					e.logger.Errorf("record copy trade decision err: %v", err)
+				}
+			}
+		})
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			<-feedDone
+			return ctx.Err()
+		case event := <-e.queue:
+			// 已经开始的跟单不因退出而中断
+			e.Handle(context.WithoutCancel(ctx), event)
+		}
+	}
+}
+
+// Handle 处理被跟钱包的一笔成交，返回记录的决策；不是被跟钱包的成交返回 nil
+func (e *Engine) Handle(ctx context.Context, event *feed.Event) *Decision {
+	trade := event.Trade
+	if trade == nil {
+		return nil
+	}
+	leader, ok := e.leaders[trade.Maker]
+	if !ok {
+		return nil
+	}
+	d := e.newDecision(event, leader)
+	if d.Side == "" {
+		return nil
+	}
+	if e.seen[d.TradeID] {
+		return nil
+	}
+	e.rollDay(e.now())
+
+	// 先记下成交前被跟钱包的持仓，卖出比例按成交前的持仓计算
+	key := positionKey(d.Leader, d.Mint)
+	before := e.positions[key]
+	if d.Side == client.SwapSideBuy {
+		d.LeaderPosition = before + d.LeaderTokens
+	} else {
+		d.LeaderPosition = max(before-d.LeaderTokens, 0)
+	}
+	e.positions[key] = d.LeaderPosition
+
+	if reason := e.precheck(d, trade); reason != "" {
+		return e.record(d, ActionSkipped, reason)
+	}
+	if d.Side == client.SwapSideBuy {
+		return e.copyBuy(ctx, d, leader)
+	}
+	return e.copySell(ctx, d, before)
+}
+
+func (e *Engine) newDecision(event *feed.Event, leader Leader) *Decision {
+	trade := event.Trade
+	d := &Decision{
+		Leader:         trade.Maker,
+		Label:          leader.Label,
+		LeaderTx:       trade.TxHash,
+		TradeID:        trade.HashId,
+		Mint:           event.TokenAddress,
+		LeaderLamports: uint64(math.Round(trade.BaseTokenAmount * lamportsPerSOL)),
+		LeaderTokens:   trade.TokenAmount,
+		BlockTime:      trade.BlockTime,
+		Wallet:         leader.Wallet,
+	}
+	if d.Wallet == "" {
+		d.Wallet = e.defaultWallet
+	}
+	switch trade.TradeType {
+	case feed.TradeTypeBuy:
+		d.Side = client.SwapSideBuy
+	case feed.TradeTypeSell:
+		d.Side = client.SwapSideSell
+	}
+	if d.TradeID == "" {
+		d.TradeID = fmt.Sprintf("%s/%s/%s", trade.TxHash, d.Mint, d.Side)
+	}
+	return d
+}
+
+// precheck 买卖共同的检查
+func (e *Engine) precheck(d *Decision, trade *feed.Trade) string {
+	if trade.MevTag != "" {
+		return "leader trade tagged " + trade.MevTag
+	}
+	if maxDelay := e.config.Limits.MaxDelaySeconds; maxDelay > 0 && trade.BlockTime > 0 {
+		if delay := e.now().Unix() - trade.BlockTime; delay > maxDelay {
+			return fmt.Sprintf("leader trade is %ds old", delay)
+		}
+	}
+	if d.Side == client.SwapSideSell && !e.config.CopySells {
+		return "copying sells is disabled"
+	}
+	if !e.executor.HasWallet(d.Wallet) {
+		return fmt.Sprintf("wallet %s is not unlocked", d.Wallet)
+	}
+	return ""
+}
+
+func (e *Engine) copyBuy(ctx context.Context, d *Decision, leader Leader) *Decision {
+	limits := &e.config.Limits
+	if d.LeaderLamports < limits.MinLeaderLamports {
+		return e.record(d, ActionSkipped, fmt.Sprintf("leader spent %d lamports, below min %d", d.LeaderLamports, limits.MinLeaderLamports))
+	}
+	if limits.MaxDailyTrades > 0 && e.dayTrades >= limits.MaxDailyTrades {
+		return e.record(d, ActionSkipped, fmt.Sprintf("daily trade limit %d reached", limits.MaxDailyTrades))
+	}
+	if reasons := e.checkSecurity(ctx, d.Mint); len(reasons) > 0 {
+		return e.record(d, ActionSkipped, "security: "+strings.Join(reasons, ", "))
+	}
+
+	sizing := e.config.Sizing
+	if leader.Sizing != nil {
+		sizing = *leader.Sizing
+	}
+	amount, err := e.size(ctx, d, sizing)
+	if err != nil {
+		return e.record(d, ActionFailed, err.Error())
+	}
+	// 按单笔、单代币持仓、单日限额截断
+	capped := amount
+	if limits.MaxTradeLamports > 0 {
+		capped = min(capped, limits.MaxTradeLamports)
+	}
+	if limits.MaxTokenLamports > 0 {
+		capped = min(capped, remaining(limits.MaxTokenLamports, uint64(math.Ceil(e.exposure[d.Mint]))))
+	}
+	if limits.MaxDailyLamports > 0 {
+		capped = min(capped, remaining(limits.MaxDailyLamports, e.daySpent))
+	}
+	if capped == 0 || capped < limits.MinCopyLamports {
+		reason := fmt.Sprintf("size %d lamports below min %d", capped, limits.MinCopyLamports)
+		if capped < amount {
+			reason = fmt.Sprintf("size %d lamports capped to %d by risk limits", amount, capped)
+		}
+		return e.record(d, ActionSkipped, reason)
+	}
+	d.Amount = capped
+	return e.execute(ctx, d)
+}
+
+func (e *Engine) copySell(ctx context.Context, d *Decision, leaderBefore float64) *Decision {
+	mint, err := solana.PublicKeyFromBase58(d.Mint)
+	if err != nil {
+		return e.record(d, ActionSkipped, err.Error())
+	}
+	balance, err := e.executor.TokenBalance(ctx, d.Wallet, mint)
+	if err != nil {
+		return e.record(d, ActionFailed, fmt.Sprintf("get token balance: %v", err))
+	}
+	if balance == 0 {
+		return e.record(d, ActionSkipped, "no position to sell")
+	}
+	// 按被跟钱包卖出的比例卖出；没有跟踪到它的持仓（开始跟单前买入）时全部卖出
+	fraction := 1.0
+	if leaderBefore > 0 {
+		fraction = min(d.LeaderTokens/leaderBefore, 1)
+	}
+	d.Holding = balance
+	d.Amount = balance
+	if fraction < 0.999 {
+		d.Amount = uint64(float64(balance) * fraction)
+	}
+	if d.Amount == 0 {
+		return e.record(d, ActionSkipped, fmt.Sprintf("sell fraction %.4f of %d is zero", fraction, balance))
+	}
+	return e.execute(ctx, d)
+}
+
+// size 按仓位规则计算买入的 lamports
+func (e *Engine) size(ctx context.Context, d *Decision, sizing Sizing) (uint64, error) {
+	switch sizing.Mode {
+	case SizingFixed:
+		return sizing.Lamports, nil
+	case SizingPercent:
+		balance, err := e.executor.Lamports(ctx, d.Wallet)
+		if err != nil {
+			return 0, fmt.Errorf("get sol balance: %w", err)
+		}
+		return balance * uint64(sizing.PercentBP) / 10000, nil
+	case SizingRatio:
+		return uint64(float64(d.LeaderLamports) * sizing.Ratio), nil
+	}
+	return 0, fmt.Errorf("unsupported sizing mode %q", sizing.Mode)
+}
+
+// checkSecurity 按策略检查代币，结果缓存一段时间；查询失败时不跟买
+func (e *Engine) checkSecurity(ctx context.Context, mint string) []string {
+	if cached, ok := e.security[mint]; ok && e.now().Before(cached.expires) {
+		return cached.reasons
+	}
+	token, err := e.tokens.Token(ctx, mint)
+	if err != nil {
+		return []string{fmt.Sprintf("get token: %v", err)}
+	}
+	reasons := e.config.Security.Check(token)
+	e.security[mint] = securityResult{reasons: reasons, expires: e.now().Add(e.config.Limits.securityCacheTTL())}
+	return reasons
+}
+
+func (e *Engine) execute(ctx context.Context, d *Decision) *Decision {
+	mint, err := solana.PublicKeyFromBase58(d.Mint)
+	if err != nil {
+		return e.record(d, ActionSkipped, err.Error())
+	}
+	if maxImpact := e.config.Limits.MaxPriceImpactBP; maxImpact > 0 {
+		impact, err := e.executor.PriceImpact(ctx, mint, d.Side, d.Amount)
+		if err != nil {
+			return e.record(d, ActionFailed, fmt.Sprintf("quote: %v", err))
+		}
+		if impact*100 > float64(maxImpact) {
+			return e.record(d, ActionSkipped, fmt.Sprintf("price impact %.4f%% exceeds max %d bp", impact, maxImpact))
+		}
+	}
+	result, err := e.executor.Swap(ctx, d.Wallet, mint, d.Side, d.Amount, e.slippageBP)
+	if err != nil {
+		return e.record(d, ActionFailed, err.Error())
+	}
+	d.Signature = result.Signature.String()
+	d.BaseAmount = result.BaseAmount
+	d.QuoteAmount = result.QuoteAmount
+	if result.Simulated {
+		return e.record(d, ActionSimulated, "")
+	}
+	return e.record(d, ActionCopied, "")
+}
+
+// record 写入决策记录并更新持仓成本和当天的限额统计
+func (e *Engine) record(d *Decision, action, reason string) *Decision {
+	d.Time = e.now()
+	d.Action = action
+	d.Reason = reason
+	e.seen[d.TradeID] = true
+	e.expose(d)
+	e.count(d)
+	if err := e.log.Append(d); err != nil {
+		e.logger.Errorf("record copy trade decision err: %v", err)
+	}
+
+	fields := logrus.Fields{"leader": d.Leader, "mint": d.Mint, "side": d.Side, "amount": d.Amount}
+	switch action {
+	case ActionFailed:
+		e.logger.WithFields(fields).Warnf("copy trade failed: %s", reason)
+	case ActionSkipped:
+		e.logger.WithFields(fields).Debugf("copy trade skipped: %s", reason)
+	default:
+		e.logger.WithFields(fields).Infof("copy trade %s: %s", action, d.Signature)
+	}
+	return d
+}
+
+func (e *Engine) count(d *Decision) {
+	spent := d.Spent()
+	if spent == 0 {
+		return
+	}
+	e.daySpent += spent
+	e.dayTrades++
+}
+
+// expose 按已执行的跟单更新代币的持仓成本：买入增加花费的 lamports，卖出按卖出占持仓的比例减少
+func (e *Engine) expose(d *Decision) {
+	if d.Action != ActionCopied && d.Action != ActionSimulated {
+		return
+	}
+	if d.Side == client.SwapSideBuy {
+		e.exposure[d.Mint] += float64(d.Amount)
+		return
+	}
+	if d.Holding == 0 || d.Amount >= d.Holding {
+		delete(e.exposure, d.Mint)
+		return
+	}
+	e.exposure[d.Mint] *= 1 - float64(d.Amount)/float64(d.Holding)
+}
+
+// rollDay 跨过 UTC 日期时清零当天的统计
+func (e *Engine) rollDay(t time.Time) {
+	day := t.UTC().Format(time.DateOnly)
+	if day == e.day {
+		return
+	}
+	e.day = day
+	e.daySpent = 0
+	e.dayTrades = 0
+}
+
+func positionKey(leader, mint string) string {
+	return leader + "/" + mint
+}
+
+func remaining(limit, used uint64) uint64 {
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
+
+// ClientExecutor 通过 swap 客户端跟单
+type ClientExecutor struct {
+	*order.ClientExecutor
+	client *client.PumpSwapClient
+}
+
+func NewClientExecutor(c *client.PumpSwapClient) *ClientExecutor {
+	return &ClientExecutor{ClientExecutor: order.NewClientExecutor(c), client: c}
+}
+
+func (e *ClientExecutor) Lamports(ctx context.Context, wallet string) (uint64, error) {
+	c, err := e.client.UseWallet(wallet)
+	if err != nil {
+		return 0, err
+	}
+	balances, err := c.Balances(ctx, solana.PublicKey{})
+	if err != nil {
+		return 0, err
+	}
+	return balances.Lamports, nil
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"swap/client"
+	"swap/dca"
+	"swap/order"
+
+	"github.com/sirupsen/logrus"
+)
+
+// dcaFlags dca 命令的参数
+type dcaFlags struct {
+	dir         string
+	mint        string
+	side        string
+	amount      string
+	slices      int
+	interval    time.Duration
+	duration    time.Duration
+	jitterBP    uint
+	maxImpactBP uint
+	maxSkips    int
+	all         bool
+}
+
+func registerDCAFlags(fs *flag.FlagSet) any {
+	f := &dcaFlags{}
+	fs.StringVar(&f.dir, "dca-dir", getEnvOrDefault("DCA_DIR", defaultDCADir()), "分批计划目录（环境变量 DCA_DIR）")
+	fs.StringVar(&f.mint, "mint", "", "代币地址")
+	fs.StringVar(&f.side, "side", string(client.SwapSideBuy), "交易方向：buy、sell")
+	fs.StringVar(&f.amount, "amount", "", "总量：买入为花费的 lamports，卖出为代币数量（原始数量）")
+	fs.IntVar(&f.slices, "slices", 10, "分成多少批")
+	fs.DurationVar(&f.interval, "interval", 0, "DCA：批次之间的平均间隔，例如 1h")
+	fs.DurationVar(&f.duration, "duration", 0, "TWAP：在这段时间内执行完所有批次，例如 30m，与 --interval 二选一")
+	fs.UintVar(&f.jitterBP, "jitter-bp", 2000, "每批数量和间隔的随机浮动比例，10000 = 100%")
+	fs.UintVar(&f.maxImpactBP, "max-impact-bp", 300, "报价的价格影响超过此值时跳过这一批，0 表示不限制")
+	fs.IntVar(&f.maxSkips, "max-skips", -1, "跳过和失败的批次超过此数时计划过期，-1 表示等于 --slices")
+	fs.BoolVar(&f.all, "all", false, "list 时包含已结束的计划")
+	return f
+}
+
+func defaultDCADir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".pumpswap/dca"
+	}
+	return filepath.Join(home, ".pumpswap", "dca")
+}
+
+func runDCA(ctx context.Context, c *client.PumpSwapClient, p printer, args []string, flags any) error {
+	f := flags.(*dcaFlags)
+	if len(args) == 0 {
+		return usageError("usage: dca add|list|show <id>|cancel <id>|run")
+	}
+	store, err := dca.OpenStore(f.dir)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "add":
+		return addPlan(c, p, store, f)
+	case "list":
+		plans, err := store.List()
+		if err != nil {
+			return err
+		}
+		shown := make([]*dca.Plan, 0, len(plans))
+		for _, plan := range plans {
+			if f.all || !plan.State.Closed() {
+				shown = append(shown, plan)
+			}
+		}
+		p.result(shown, func() {
+			if len(shown) == 0 {
+				fmt.Println("📭 没有分批计划.")
+			}
+			for _, plan := range shown {
+				printPlan(plan)
+			}
+		})
+	case "show":
+		if len(args) != 2 {
+			return usageError("usage: dca show <id>")
+		}
+		plan, err := store.Load(args[1])
+		if err != nil {
+			return err
+		}
+		p.result(plan, func() {
+			printPlan(plan)
+			for _, s := range plan.History {
+				fmt.Printf("  #%-3d %s %-9s 数量 %d 价格影响 %.4f%%", s.Index, s.Time.Format("2006-01-02 15:04:05"), s.Status, s.Amount, s.PriceImpact)
+				if s.Signature != "" {
+					fmt.Printf(" 交易 %s", s.Signature)
+				}
+				if s.Reason != "" {
+					fmt.Printf(" 原因 %s", s.Reason)
+				}
+				fmt.Println(".")
+			}
+		})
+	case "cancel":
+		if len(args) != 2 {
+			return usageError("usage: dca cancel <id>")
+		}
+		plan, err := store.RequestCancel(args[1])
+		if err != nil {
+			return err
+		}
+		p.result(plan, func() {
+			fmt.Printf("🛑 已提交撤销请求：%s，执行器下次扫描时撤销，已执行的批次不受影响.\n", plan.ID)
+		})
+	case "run":
+		return runDCARunner(ctx, c, p, store, f)
+	default:
+		return usageError("unsupported dca command %s", args[0])
+	}
+	return nil
+}
+
+func addPlan(c *client.PumpSwapClient, p printer, store *dca.Store, f *dcaFlags) error {
+	if _, err := parsePublicKey("mint", f.mint); err != nil {
+		return err
+	}
+	total, err := parseAmount("amount", f.amount)
+	if err != nil {
+		return err
+	}
+	if f.slices < 1 {
+		return usageError("--slices must be at least 1")
+	}
+	interval := f.interval
+	switch {
+	case f.interval > 0 && f.duration > 0:
+		return usageError("--interval and --duration are mutually exclusive")
+	case f.duration > 0:
+		// 第一批立即执行，剩下的批次平均分布在 duration 内
+		interval = f.duration / time.Duration(max(f.slices-1, 1))
+	}
+	plan := dca.New(c.Config().WalletName, f.mint, client.SwapSide(f.side), total, f.slices, interval, c.Config().SlippageBP)
+	plan.JitterBP = uint32(f.jitterBP)
+	plan.MaxPriceImpactBP = uint32(f.maxImpactBP)
+	if f.maxSkips >= 0 {
+		plan.MaxSkips = f.maxSkips
+	}
+	if err = plan.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if err = store.Create(plan); err != nil {
+		return err
+	}
+	p.result(plan, func() {
+		fmt.Print("✅ 已创建分批计划：")
+		printPlan(plan)
+	})
+	return nil
+}
+
+// runDCARunner 运行计划执行器直到收到退出信号，正在执行的一批会等待完成
+func runDCARunner(ctx context.Context, c *client.PumpSwapClient, p printer, store *dca.Store, f *dcaFlags) error {
+	// 执行器常驻运行，不受 --timeout 限制
+	ctx, stop := signal.NotifyContext(context.WithoutCancel(ctx), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := logrus.New()
+	if c.Config().EnableDebugLog {
+		logger.SetLevel(logrus.DebugLevel)
+	}
+	runner := dca.NewRunner(store, order.NewClientExecutor(c), dca.DefaultRunnerConfig(), logger)
+	p.info("⏱️ 分批执行器已启动，钱包：%v，计划目录：%s.\n", c.WalletNames(), f.dir)
+	if c.Config().DryRun {
+		p.info("🧪 dry-run 模式：每批只模拟不发送.\n")
+	}
+	err := runner.Run(ctx)
+	if errors.Is(err, context.Canceled) {
+		p.info("👋 分批执行器已退出.\n")
+		return nil
+	}
+	return err
+}
+
+func printPlan(plan *dca.Plan) {
+	fmt.Printf("📊 %s [%s] %s %s 钱包 %s 进度 %d/%d (%.2f%%) 批次 %d/%d", plan.ID, plan.State, plan.Side, plan.Mint, plan.Wallet,
+		plan.Executed, plan.Total, plan.Progress(), plan.Done, plan.Slices)
+	if skipped := plan.Skipped(); skipped > 0 {
+		fmt.Printf(" 跳过 %d", skipped)
+	}
+	if plan.Received > 0 {
+		fmt.Printf(" 得到 %d", plan.Received)
+	}
+	if plan.State == dca.StateRunning {
+		fmt.Printf(" 下一批 %s", plan.NextAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if plan.Error != "" {
+		fmt.Printf(" 错误 %s", plan.Error)
+	}
+	fmt.Println(".")
+}
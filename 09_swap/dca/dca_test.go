@@ -0,0 +1,291 @@
+package dca
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"swap/client"
+	"swap/order/ordertest"
+
+	"github.com/sirupsen/logrus"
+)
+
+const testMint = "So11111111111111111111111111111111111111112"
+
+// testClock 测试用时钟，每次执行后手动推进
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func newTestRunner(t *testing.T, executor *ordertest.Executor, dir string) (*Runner, *Store, *testClock) {
+	store, err := OpenStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	runner := NewRunner(store, executor, DefaultRunnerConfig(), logger)
+	clock := &testClock{now: time.Now()}
+	runner.now = clock.Now
+	runner.random = func() float64 { return 0.5 }
+	return runner, store, clock
+}
+
+// create 保存计划，第一批在测试时钟的当前时间到期
+func create(t *testing.T, store *Store, clock *testClock, p *Plan) {
+	t.Helper()
+	p.NextAt = clock.now
+	if err := store.Create(p); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Plan { return New("main", testMint, client.SwapSideBuy, 1000, 10, time.Minute, 100) }
+	if err := valid().Validate(); err != nil {
+		t.Fatal(err)
+	}
+	cases := []func(*Plan){
+		func(p *Plan) { p.Wallet = "" },
+		func(p *Plan) { p.Mint = "bad" },
+		func(p *Plan) { p.Side = "hold" },
+		func(p *Plan) { p.Total = 0 },
+		func(p *Plan) { p.Slices = 0 },
+		func(p *Plan) { p.Slices = 2000 },
+		func(p *Plan) { p.IntervalSeconds = 0 },
+		func(p *Plan) { p.JitterBP = 10000 },
+		func(p *Plan) { p.MaxPriceImpactBP = 20000 },
+	}
+	for i, mutate := range cases {
+		p := valid()
+		mutate(p)
+		if err := p.Validate(); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
+
+func TestNextAmount(t *testing.T) {
+	p := New("main", testMint, client.SwapSideBuy, 1000, 4, time.Minute, 100)
+	p.JitterBP = 2000
+	// 250 ± 20%
+	if got := p.NextAmount(0); got != 200 {
+		t.Fatalf("low jitter amount = %d", got)
+	}
+	if got := p.NextAmount(0.5); got != 250 {
+		t.Fatalf("mid jitter amount = %d", got)
+	}
+	if got := p.NextAmount(0.999999); got != 299 {
+		t.Fatalf("high jitter amount = %d", got)
+	}
+	if got := p.NextDelay(0); got != 48*time.Second {
+		t.Fatalf("low jitter delay = %s", got)
+	}
+
+	// 前面的批次少执行（卖出受持仓限制）时，剩余数量不集中到后面的批次，每批不超过 MaxSlice
+	if got := p.MaxSlice(); got != 300 {
+		t.Fatalf("max slice = %d", got)
+	}
+	p.Done, p.Executed = 2, 250
+	if got := p.NextAmount(0.5); got != 300 {
+		t.Fatalf("capped amount = %d", got)
+	}
+	p.Done, p.Executed = 3, 800
+	if got := p.NextAmount(0); got != 200 {
+		t.Fatalf("last amount = %d", got)
+	}
+	p.Done, p.Executed = 4, 900
+	if got := p.NextAmount(0); got != 100 {
+		t.Fatalf("extra amount = %d", got)
+	}
+}
+
+func TestStore(t *testing.T) {
+	store, err := OpenStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := New("main", testMint, client.SwapSideSell, 1000, 5, time.Minute, 100)
+	if err = store.Create(p); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Create(p); err == nil {
+		t.Fatal("expected duplicate create to fail")
+	}
+	if _, err = store.Load("missing"); !errors.Is(err, ErrPlanNotFound) {
+		t.Fatalf("load missing err = %v", err)
+	}
+	if _, err = store.RequestCancel(p.ID); err != nil || !store.CancelRequested(p.ID) {
+		t.Fatalf("cancel err = %v", err)
+	}
+	p.State = StateCompleted
+	if err = store.Save(p); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.RequestCancel(p.ID); !errors.Is(err, ErrPlanClosed) {
+		t.Fatalf("cancel closed err = %v", err)
+	}
+	plans, err := store.List()
+	if err != nil || len(plans) != 1 || plans[0].State != StateCompleted {
+		t.Fatalf("plans = %+v, %v", plans, err)
+	}
+}
+
+func TestRunnerSlices(t *testing.T) {
+	executor := &ordertest.Executor{Impacts: []float64{1, 5, 1, 1, 1}}
+	runner, store, clock := newTestRunner(t, executor, t.TempDir())
+	ctx := context.Background()
+
+	p := New("main", testMint, client.SwapSideBuy, 1000, 4, time.Minute, 100)
+	p.MaxPriceImpactBP = 300
+	create(t, store, clock, p)
+
+	// 第一批立即执行，未到下一批的时间不执行
+	for range 2 {
+		if err := runner.Tick(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(executor.Swaps) != 1 || executor.Swaps[0].Amount != 250 || executor.Swaps[0].SlippageBP != 100 {
+		t.Fatalf("swaps = %+v", executor.Swaps)
+	}
+
+	// 第二次价格影响 5% 超过 3%，跳过，不计入批次，计划顺延一个间隔
+	for range 4 {
+		clock.now = clock.now.Add(time.Minute)
+		if err := runner.Tick(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p, err := store.Load(p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.State != StateCompleted || p.Done != 4 || len(p.History) != 5 || p.Executed != 1000 || p.Received != 10000 || p.Skipped() != 1 {
+		t.Fatalf("plan = %+v", p)
+	}
+	if h := p.History[1]; h.Status != SliceSkipped || !strings.Contains(h.Reason, "price impact") {
+		t.Fatalf("skipped slice = %+v", h)
+	}
+	var amounts []uint64
+	for _, s := range executor.Swaps {
+		amounts = append(amounts, s.Amount)
+	}
+	if len(amounts) != 4 || amounts[1] != 250 || amounts[2] != 250 || amounts[3] != 250 {
+		t.Fatalf("amounts = %v", amounts)
+	}
+}
+
+func TestRunnerExpires(t *testing.T) {
+	executor := &ordertest.Executor{Impacts: []float64{1, 5, 5, 5, 5}}
+	runner, store, clock := newTestRunner(t, executor, t.TempDir())
+	p := New("main", testMint, client.SwapSideBuy, 1000, 4, time.Minute, 100)
+	p.MaxPriceImpactBP = 300
+	p.MaxSkips = 2
+	create(t, store, clock, p)
+
+	for range 5 {
+		if err := runner.Tick(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		clock.now = clock.now.Add(time.Minute)
+	}
+	p, _ = store.Load(p.ID)
+	// 跳过 3 次超过 MaxSkips，剩余的 750 不再执行，不会集中到一批
+	if p.State != StateExpired || p.Done != 1 || p.Remaining() != 750 || p.Skipped() != 3 || !strings.Contains(p.Error, "750 of 1000") {
+		t.Fatalf("plan = %+v", p)
+	}
+	if len(executor.Swaps) != 1 {
+		t.Fatalf("swaps = %+v", executor.Swaps)
+	}
+}
+
+func TestRunnerSellCapsBalance(t *testing.T) {
+	executor := &ordertest.Executor{Balance: 100}
+	runner, store, clock := newTestRunner(t, executor, t.TempDir())
+	p := New("main", testMint, client.SwapSideSell, 1000, 2, time.Minute, 100)
+	create(t, store, clock, p)
+	if err := runner.Tick(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	p, _ = store.Load(p.ID)
+	if len(executor.Swaps) != 1 || executor.Swaps[0].Amount != 100 || p.Executed != 100 || p.Received != 10 {
+		t.Fatalf("swaps = %+v, plan = %+v", executor.Swaps, p)
+	}
+}
+
+func TestRunnerFailuresAndCancel(t *testing.T) {
+	executor := &ordertest.Executor{Err: errors.New("rpc down")}
+	runner, store, clock := newTestRunner(t, executor, t.TempDir())
+	ctx := context.Background()
+
+	failing := New("main", testMint, client.SwapSideBuy, 1000, 10, time.Minute, 100)
+	cancelled := New("main", testMint, client.SwapSideBuy, 1000, 10, time.Minute, 100)
+	locked := New("locked", testMint, client.SwapSideBuy, 1000, 10, time.Minute, 100)
+	for _, p := range []*Plan{failing, cancelled, locked} {
+		create(t, store, clock, p)
+	}
+	if _, err := store.RequestCancel(cancelled.ID); err != nil {
+		t.Fatal(err)
+	}
+	for range maxConsecutiveFailures {
+		if err := runner.Tick(ctx); err != nil {
+			t.Fatal(err)
+		}
+		clock.now = clock.now.Add(2 * time.Minute)
+	}
+
+	failing, _ = store.Load(failing.ID)
+	if failing.State != StateFailed || failing.Executed != 0 || !strings.Contains(failing.Error, "rpc down") {
+		t.Fatalf("failing plan = %+v", failing)
+	}
+	cancelled, _ = store.Load(cancelled.ID)
+	if cancelled.State != StateCancelled || store.CancelRequested(cancelled.ID) {
+		t.Fatalf("cancelled plan = %+v", cancelled)
+	}
+	locked, _ = store.Load(locked.ID)
+	if locked.State != StateRunning || locked.Done != 0 {
+		t.Fatalf("locked plan = %+v", locked)
+	}
+	if len(executor.Swaps) != maxConsecutiveFailures {
+		t.Fatalf("swaps = %d", len(executor.Swaps))
+	}
+}
+
+func TestRunnerRecover(t *testing.T) {
+	dir := t.TempDir()
+	executor := &ordertest.Executor{}
+	_, store, clock := newTestRunner(t, executor, dir)
+
+	// 模拟执行第二批时退出
+	p := New("main", testMint, client.SwapSideBuy, 1000, 4, time.Minute, 100)
+	p.Done, p.Executed = 1, 250
+	p.History = append(p.History, Slice{Index: 1, Status: SliceFilled, Amount: 250})
+	p.Inflight = &Slice{Index: 2, Time: clock.now, Amount: 250}
+	create(t, store, clock, p)
+
+	runner, _, _ := newTestRunner(t, executor, dir)
+	if err := runner.Recover(); err != nil {
+		t.Fatal(err)
+	}
+	p, _ = store.Load(p.ID)
+	if p.Inflight != nil || p.Done != 2 || p.Executed != 500 || p.History[1].Status != SliceUnknown {
+		t.Fatalf("recovered plan = %+v", p)
+	}
+	if len(executor.Swaps) != 0 {
+		t.Fatalf("swaps = %+v", executor.Swaps)
+	}
+
+	// 继续执行剩余的批次
+	runner.now = func() time.Time { return p.NextAt }
+	if err := runner.Tick(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	p, _ = store.Load(p.ID)
+	if p.Done != 3 || p.Executed != 750 || len(executor.Swaps) != 1 {
+		t.Fatalf("resumed plan = %+v", p)
+	}
+}
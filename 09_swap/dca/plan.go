@@ -0,0 +1,238 @@
+package dca
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"swap/client"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// State 计划状态：running → completed/expired/cancelled/failed，running 的计划可以撤销
+type State string
+
+const (
+	StateRunning   State = "running"   // 按计划分批执行中
+	StateCompleted State = "completed" // 总量已全部执行
+	StateExpired   State = "expired"   // 跳过的批次超过 MaxSkips，剩余数量不再执行
+	StateCancelled State = "cancelled" // 已撤销，已执行的批次不受影响
+	StateFailed    State = "failed"    // 连续失败次数过多，停止执行
+)
+
+// Closed 是否为终态
+func (s State) Closed() bool {
+	return s == StateCompleted || s == StateExpired || s == StateCancelled || s == StateFailed
+}
+
+// 每一批的执行结果
+const (
+	SliceFilled    = "filled"    // 已成交
+	SliceSimulated = "simulated" // dry-run 模式下只模拟了交易
+	SliceSkipped   = "skipped"   // 价格影响超过上限等原因没有下单，不计入批次，顺延一个间隔后重试
+	SliceFailed    = "failed"    // 下单失败，不计入批次，顺延一个间隔后重试
+	SliceUnknown   = "unknown"   // 执行中途退出，无法确定是否上链，按已执行计算，避免超出总量
+)
+
+// maxConsecutiveFailures 连续失败达到此次数时计划标记为失败
+const maxConsecutiveFailures = 3
+
+var (
+	ErrPlanNotFound = errors.New("plan not found")
+	ErrPlanClosed   = errors.New("plan already closed")
+)
+
+// Slice 一批的执行记录，数量均为未除精度的原始数量
+type Slice struct {
+	Index       int       `json:"index"` // 第几次执行，包括跳过和失败的，从 1 开始
+	Time        time.Time `json:"time"`
+	Amount      uint64    `json:"amount"` // 买入为花费的 lamports，卖出为代币数量
+	Status      string    `json:"status"`
+	PriceImpact float64   `json:"price_impact,omitempty"` // 执行前报价的价格影响，百分比
+	Signature   string    `json:"signature,omitempty"`
+	BaseAmount  uint64    `json:"base_amount,omitempty"`
+	QuoteAmount uint64    `json:"quote_amount,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+}
+
+// Plan 分批买入或卖出的计划：把 Total 分成 Slices 批，每隔 Interval 执行一批，
+// 每批的数量和间隔按 JitterBP 随机浮动；按总时长拆分即为 TWAP，按固定间隔定投即为 DCA。
+// 跳过和失败的批次不计入 Slices，计划顺延，每批数量不超过 MaxSlice，不会把剩余数量集中到最后一批
+type Plan struct {
+	ID               string          `json:"id"`
+	Wallet           string          `json:"wallet"` // 下单的钱包名
+	Mint             string          `json:"mint"`
+	Side             client.SwapSide `json:"side"`
+	Total            uint64          `json:"total"`                         // 买入为花费的 lamports，卖出为代币数量
+	Slices           int             `json:"slices"`                        // 分成多少批
+	IntervalSeconds  int64           `json:"interval_seconds"`              // 批次之间的平均间隔
+	JitterBP         uint32          `json:"jitter_bp"`                     // 每批数量和间隔的随机浮动比例，10000 = 100%
+	SlippageBP       uint32          `json:"slippage_bp"`                   // 下单使用的滑点
+	MaxPriceImpactBP uint32          `json:"max_price_impact_bp,omitempty"` // 报价的价格影响超过此值时跳过这一批，0 表示不限制
+	MaxSkips         int             `json:"max_skips"`                     // 跳过和失败的批次超过此数时计划过期
+	State            State           `json:"state"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+
+	// 执行进度，重启后从这里继续
+	NextAt   time.Time `json:"next_at"`            // 下一批的执行时间
+	Done     int       `json:"done"`               // 已执行的批次，不含跳过和失败的
+	Executed uint64    `json:"executed"`           // 已执行的数量，包括 unknown 的批次
+	Received uint64    `json:"received"`           // 买入得到的代币数量，卖出得到的 lamports（预期值）
+	Failures int       `json:"failures,omitempty"` // 连续失败次数
+	Inflight *Slice    `json:"inflight,omitempty"` // 正在执行的批次，下单前保存，用于退出后判断
+	History  []Slice   `json:"history"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// New 创建立即开始执行的计划
+func New(wallet, mint string, side client.SwapSide, total uint64, slices int, interval time.Duration, slippageBP uint32) *Plan {
+	now := time.Now()
+	return &Plan{
+		ID:              newID(),
+		Wallet:          wallet,
+		Mint:            mint,
+		Side:            side,
+		Total:           total,
+		Slices:          slices,
+		IntervalSeconds: int64(interval / time.Second),
+		SlippageBP:      slippageBP,
+		MaxSkips:        slices,
+		State:           StateRunning,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		NextAt:          now,
+		History:         []Slice{},
+	}
+}
+
+func (p *Plan) RecordID() string           { return p.ID }
+func (p *Plan) RecordCreatedAt() time.Time { return p.CreatedAt }
+func (p *Plan) SetUpdatedAt(t time.Time)   { p.UpdatedAt = t }
+
+func newID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func (p *Plan) Validate() error {
+	if p.Wallet == "" {
+		return errors.New("plan wallet is required")
+	}
+	if _, err := solana.PublicKeyFromBase58(p.Mint); err != nil {
+		return fmt.Errorf("invalid plan mint %s: %w", p.Mint, err)
+	}
+	if p.Side != client.SwapSideBuy && p.Side != client.SwapSideSell {
+		return fmt.Errorf("unsupported plan side %q", p.Side)
+	}
+	if p.Total == 0 {
+		return errors.New("plan total must be greater than 0")
+	}
+	if p.Slices < 1 || uint64(p.Slices) > p.Total {
+		return fmt.Errorf("plan slices must be between 1 and total %d", p.Total)
+	}
+	if p.Slices > 1 && p.IntervalSeconds <= 0 {
+		return errors.New("plan interval must be at least 1s")
+	}
+	if p.MaxSkips < 0 {
+		return errors.New("plan max skips must not be negative")
+	}
+	if p.JitterBP >= 10000 {
+		return errors.New("plan jitter bp must be less than 10000")
+	}
+	if p.SlippageBP > 10000 || p.MaxPriceImpactBP > 10000 {
+		return errors.New("slippage and price impact bp must not exceed 10000")
+	}
+	return nil
+}
+
+// Remaining 还未执行的数量
+func (p *Plan) Remaining() uint64 {
+	if p.Executed >= p.Total {
+		return 0
+	}
+	return p.Total - p.Executed
+}
+
+// Progress 已执行数量占总量的百分比
+func (p *Plan) Progress() float64 {
+	return float64(p.Total-p.Remaining()) * 100 / float64(p.Total)
+}
+
+// Skipped 跳过和失败的批次数
+func (p *Plan) Skipped() int {
+	n := 0
+	for _, s := range p.History {
+		if s.Status == SliceSkipped || s.Status == SliceFailed {
+			n++
+		}
+	}
+	return n
+}
+
+// MaxSlice 每批数量的上限：平均每批数量按 JitterBP 向上浮动的最大值
+func (p *Plan) MaxSlice() uint64 {
+	average := (p.Total + uint64(p.Slices) - 1) / uint64(p.Slices)
+	return uint64(float64(average) * (1 + float64(p.JitterBP)/10000))
+}
+
+// NextAmount 下一批的数量：剩余数量平均分到剩余批次后按 JitterBP 随机浮动，最后一批为全部剩余，
+// 均不超过 MaxSlice；卖出受持仓限制少卖的部分在之后追加的批次中执行。random 为 [0, 1) 的随机数
+func (p *Plan) NextAmount(random float64) uint64 {
+	remaining := p.Remaining()
+	amount := remaining
+	if left := p.Slices - p.Done; left > 1 {
+		amount = max(uint64(float64(remaining/uint64(left))*jitter(p.JitterBP, random)), 1)
+	}
+	return min(amount, remaining, p.MaxSlice())
+}
+
+// NextDelay 这一批之后到下一批的间隔，按 JitterBP 随机浮动
+func (p *Plan) NextDelay(random float64) time.Duration {
+	return time.Duration(float64(p.IntervalSeconds) * jitter(p.JitterBP, random) * float64(time.Second))
+}
+
+// jitter 把 [0, 1) 的随机数映射到 [1 - bp, 1 + bp)
+func jitter(bp uint32, random float64) float64 {
+	return 1 + (2*random-1)*float64(bp)/10000
+}
+
+// record 记录一批的结果并推进进度，返回计划是否结束
+func (p *Plan) record(s Slice, now time.Time, delay time.Duration) bool {
+	p.Inflight = nil
+	p.History = append(p.History, s)
+	switch s.Status {
+	case SliceFilled, SliceSimulated:
+		p.Done++
+		p.Executed += s.Amount
+		p.Failures = 0
+		if p.Side == client.SwapSideBuy {
+			p.Received += s.BaseAmount
+		} else {
+			p.Received += s.QuoteAmount
+		}
+	case SliceUnknown:
+		p.Done++
+		p.Executed += s.Amount
+	case SliceFailed:
+		p.Failures++
+	}
+
+	switch {
+	case p.Failures >= maxConsecutiveFailures:
+		p.State = StateFailed
+		p.Error = fmt.Sprintf("%d consecutive slices failed, last: %s", p.Failures, s.Reason)
+	case p.Remaining() == 0:
+		p.State = StateCompleted
+	case p.Skipped() > p.MaxSkips:
+		p.State = StateExpired
+		p.Error = fmt.Sprintf("%d slices skipped or failed, %d of %d not executed, last: %s", p.Skipped(), p.Remaining(), p.Total, s.Reason)
+	default:
+		p.NextAt = now.Add(delay)
+	}
+	return p.State.Closed()
+}
@@ -0,0 +1,232 @@
+package dca
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"swap/client"
+	"swap/order"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/sirupsen/logrus"
+)
+
+// RunnerConfig 执行器配置
+type RunnerConfig struct {
+	ScanInterval time.Duration // 检查到期批次、新计划和撤销请求的间隔
+	SwapTimeout  time.Duration // 单批执行的超时时间
+}
+
+func DefaultRunnerConfig() RunnerConfig {
+	return RunnerConfig{
+		ScanInterval: 2 * time.Second,
+		SwapTimeout:  2 * time.Minute,
+	}
+}
+
+// Runner 计划执行器：按时间执行到期的批次，每批执行后保存进度，重启后从保存的进度继续。
+// 批次之间间隔较长，所有计划的到期批次在同一个协程中依次执行
+type Runner struct {
+	store    *Store
+	executor order.Executor
+	config   RunnerConfig
+	logger   *logrus.Logger
+	random   func() float64
+	now      func() time.Time
+	locked   map[string]bool // 已提示过钱包未解锁的计划
+}
+
+func NewRunner(store *Store, executor order.Executor, config RunnerConfig, logger *logrus.Logger) *Runner {
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
+	return &Runner{
+		store:    store,
+		executor: executor,
+		config:   config,
+		logger:   logger,
+		random:   rand.Float64,
+		now:      time.Now,
+		locked:   make(map[string]bool),
+	}
+}
+
+// Run 恢复上次中断的批次后按时间执行计划，直到 ctx 结束；正在执行的一批会等待完成
+func (r *Runner) Run(ctx context.Context) error {
+	if err := r.Recover(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(r.config.ScanInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.Tick(ctx); err != nil {
+			r.logger.Errorf("scan plans err: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Recover 处理上次退出时正在执行的批次：无法确定交易是否已经上链，记为 unknown 并按已执行计算，不再重复下单
+func (r *Runner) Recover() error {
+	plans, err := r.store.List()
+	if err != nil {
+		return err
+	}
+	for _, p := range plans {
+		if p.State != StateRunning || p.Inflight == nil {
+			continue
+		}
+		s := *p.Inflight
+		s.Status = SliceUnknown
+		s.Reason = "runner stopped while the slice was executing, check the wallet for the transaction"
+		r.record(p, s)
+	}
+	return nil
+}
+
+// Tick 处理撤销请求，并执行所有到期的批次
+func (r *Runner) Tick(ctx context.Context) error {
+	plans, err := r.store.List()
+	if err != nil {
+		return err
+	}
+	for _, p := range plans {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if p.State != StateRunning {
+			continue
+		}
+		if r.store.CancelRequested(p.ID) {
+			p.State = StateCancelled
+			r.save(p)
+			r.store.clearCancel(p.ID)
+			r.logger.Infof("plan %s cancelled at %.2f%%, executed %d of %d", p.ID, p.Progress(), p.Executed, p.Total)
+			continue
+		}
+		if !r.executor.HasWallet(p.Wallet) {
+			if !r.locked[p.ID] {
+				r.logger.Warnf("plan %s skipped, wallet %s is not unlocked", p.ID, p.Wallet)
+				r.locked[p.ID] = true
+			}
+			continue
+		}
+		if r.now().Before(p.NextAt) {
+			continue
+		}
+		r.execute(ctx, p)
+	}
+	return nil
+}
+
+// execute 执行一批：确定数量、检查价格影响后下单。
+// 停机时间较长时到期的批次只执行一批，下一批从现在起重新计时，不会集中补单
+func (r *Runner) execute(ctx context.Context, p *Plan) {
+	// 停止执行器时不打断正在发送的交易
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.config.SwapTimeout)
+	defer cancel()
+
+	s := Slice{Index: len(p.History) + 1, Time: r.now(), Amount: p.NextAmount(r.random())}
+	mint, err := solana.PublicKeyFromBase58(p.Mint)
+	if err != nil {
+		s.Status, s.Reason = SliceFailed, err.Error()
+		r.record(p, s)
+		return
+	}
+
+	if p.Side == client.SwapSideSell {
+		// 不超过当前持仓，持仓不足的部分留给后面的批次
+		balance, err := r.executor.TokenBalance(ctx, p.Wallet, mint)
+		if err != nil {
+			s.Status, s.Reason = SliceFailed, fmt.Sprintf("get token balance: %v", err)
+			r.record(p, s)
+			return
+		}
+		if balance == 0 {
+			s.Status, s.Reason = SliceSkipped, "wallet holds no token to sell"
+			r.record(p, s)
+			return
+		}
+		s.Amount = min(s.Amount, balance)
+	}
+
+	if p.MaxPriceImpactBP > 0 {
+		impact, err := r.executor.PriceImpact(ctx, mint, p.Side, s.Amount)
+		if err != nil {
+			s.Status, s.Reason = SliceFailed, fmt.Sprintf("quote: %v", err)
+			r.record(p, s)
+			return
+		}
+		s.PriceImpact = impact
+		if impact*100 > float64(p.MaxPriceImpactBP) {
+			s.Status, s.Reason = SliceSkipped, fmt.Sprintf("price impact %.4f%% exceeds max %d bp", impact, p.MaxPriceImpactBP)
+			r.record(p, s)
+			return
+		}
+	}
+
+	// 下单前保存正在执行的批次，中途退出时由 Recover 处理
+	p.Inflight = &s
+	if err = r.store.Save(p); err != nil {
+		p.Inflight = nil
+		r.logger.Errorf("save plan %s err: %v", p.ID, err)
+		return
+	}
+	result, err := r.executor.Swap(ctx, p.Wallet, mint, p.Side, s.Amount, p.SlippageBP)
+	if err != nil {
+		s.Status, s.Reason = SliceFailed, err.Error()
+		r.record(p, s)
+		return
+	}
+	s.Status = SliceFilled
+	if result.Simulated {
+		s.Status = SliceSimulated
+	}
+	s.Signature = result.Signature.String()
+	s.BaseAmount = result.BaseAmount
+	s.QuoteAmount = result.QuoteAmount
+	if s.PriceImpact == 0 {
+		s.PriceImpact = result.PriceImpact
+	}
+	r.record(p, s)
+}
+
+// record 保存一批的结果并输出进度
+func (r *Runner) record(p *Plan, s Slice) {
+	closed := p.record(s, r.now(), p.NextDelay(r.random()))
+	r.save(p)
+
+	fields := logrus.Fields{"plan": p.ID, "mint": p.Mint, "side": p.Side, "slice": s.Index, "amount": s.Amount}
+	progress := fmt.Sprintf("executed %d of %d (%.2f%%) in %d/%d slices", p.Executed, p.Total, p.Progress(), p.Done, p.Slices)
+	switch s.Status {
+	case SliceFilled, SliceSimulated:
+		r.logger.WithFields(fields).Infof("slice %s %s, %s", s.Status, s.Signature, progress)
+	default:
+		r.logger.WithFields(fields).Warnf("slice %s: %s, %s", s.Status, s.Reason, progress)
+	}
+	if closed {
+		level := logrus.InfoLevel
+		if p.State != StateCompleted {
+			level = logrus.WarnLevel
+		}
+		r.logger.Logf(level, "plan %s %s: %s, %d slices skipped or failed", p.ID, p.State, progress, p.Skipped())
+	} else {
+		r.logger.Debugf("plan %s next slice at %s", p.ID, p.NextAt.Format(time.RFC3339))
+	}
+}
+
+func (r *Runner) save(p *Plan) {
+	if p.State.Closed() && p.State != StateCancelled {
+		// 撤销请求晚于最后一批，计划已经结束
+		r.store.clearCancel(p.ID)
+	}
+	if err := r.store.Save(p); err != nil {
+		r.logger.Errorf("save plan %s err: %v", p.ID, err)
+	}
+}
@@ -0,0 +1,73 @@
+package dca
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"swap/filestore"
+)
+
+// Store 计划的文件存储，创建、撤销计划的命令行和执行器可以是不同进程
+/*
+	<dir>/plans/<id>.json    每个计划一个文件，包含执行进度和每批的结果，见 filestore.Store
+	<dir>/plans/<id>.cancel  撤销请求，由执行器处理
+*/
+type Store struct {
+	plans *filestore.Store[Plan, *Plan]
+}
+
+const plansDir = "plans"
+
+// OpenStore 打开 dir 下的计划存储，目录不存在时创建
+func OpenStore(dir string) (*Store, error) {
+	plans, err := filestore.Open[Plan](filepath.Join(dir, plansDir), "plan", ErrPlanNotFound)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{plans: plans}, nil
+}
+
+// Create 保存新计划，ID 已存在时返回错误
+func (s *Store) Create(p *Plan) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	return s.plans.Create(p)
+}
+
+// Save 覆盖保存计划
+func (s *Store) Save(p *Plan) error {
+	return s.plans.Save(p)
+}
+
+// Load 读取计划
+func (s *Store) Load(id string) (*Plan, error) {
+	return s.plans.Load(id)
+}
+
+// List 按创建时间列出全部计划
+func (s *Store) List() ([]*Plan, error) {
+	return s.plans.List()
+}
+
+// RequestCancel 提交撤销请求，由执行器在下次扫描时撤销；正在执行的一批不受影响
+func (s *Store) RequestCancel(id string) (*Plan, error) {
+	p, err := s.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if p.State.Closed() {
+		return p, fmt.Errorf("%w: %s is %s", ErrPlanClosed, id, p.State)
+	}
+	return p, s.plans.RequestCancel(id)
+}
+
+// CancelRequested 是否有撤销请求
+func (s *Store) CancelRequested(id string) bool {
+	return s.plans.CancelRequested(id)
+}
+
+// clearCancel 删除已处理的撤销请求
+func (s *Store) clearCancel(id string) {
+	s.plans.ClearCancel(id)
+}
@@ -0,0 +1,246 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+// 事件类型，与 consumer 推送的 Event.type 一致
+const (
+	EventTrade     = "trade"
+	EventPair      = "pair"
+	EventNewPair   = "new_pair"
+	EventHeartbeat = "heartbeat"
+)
+
+// 成交方向
+const (
+	TradeTypeBuy  = "buy"
+	TradeTypeSell = "sell"
+)
+
+// Event consumer 推送的事件，JSON 由 protojson 编码：字段为 lowerCamelCase，int64 编码为字符串
+type Event struct {
+	Type         string `json:"type"`
+	Slot         int64  `json:"slot,string"`
+	Time         int64  `json:"time,string"` // 事件生成时间（秒）
+	TokenAddress string `json:"tokenAddress"`
+	Trade        *Trade `json:"trade"`
+	Pair         *Pair  `json:"pair"`
+}
+
+// Trade 落库后的成交，数量已除精度
+type Trade struct {
+	TxHash            string  `json:"txHash"`
+	HashId            string  `json:"hashId"` // 成交的唯一标识
+	PairAddr          string  `json:"pairAddr"`
+	Maker             string  `json:"maker"`
+	TradeType         string  `json:"tradeType"`
+	BaseTokenAmount   float64 `json:"baseTokenAmount"`
+	TokenAmount       float64 `json:"tokenAmount"`
+	BaseTokenPriceUsd float64 `json:"baseTokenPriceUsd"`
+	TotalUsd          float64 `json:"totalUsd"`
+	TokenPriceUsd     float64 `json:"tokenPriceUsd"`
+	BlockNum          int64   `json:"blockNum,string"`
+	BlockTime         int64   `json:"blockTime,string"` // 秒
+	SwapName          string  `json:"swapName"`
+	MevTag            string  `json:"mevTag"` // 夹子、自成交、刷量标记，空为正常成交
+	Platform          string  `json:"platform"`
+}
+
+// Pair 交易对的价格和流动性
+type Pair struct {
+	Address          string  `json:"address"`
+	Name             string  `json:"name"`
+	TokenAddress     string  `json:"tokenAddress"`
+	BaseTokenAddress string  `json:"baseTokenAddress"`
+	TokenSymbol      string  `json:"tokenSymbol"`
+	TokenDecimal     int64   `json:"tokenDecimal,string"`
+	Liquidity        float64 `json:"liquidity"`
+	TokenPrice       float64 `json:"tokenPrice"`     // 代币价格（USD）
+	BaseTokenPrice   float64 `json:"baseTokenPrice"` // 基础币价格（USD）
+	IsRug            bool    `json:"isRug"`
+}
+
+// Price 代币的最新价格
+type Price struct {
+	Token     string    `json:"token"`
+	Pair      string    `json:"pair"`
+	PriceUSD  float64   `json:"price_usd"`
+	Slot      int64     `json:"slot"`
+	Time      time.Time `json:"time"`
+	Source    string    `json:"source"` // trade 或 pair
+	Rugged    bool      `json:"rugged,omitempty"`
+	Reference string    `json:"reference,omitempty"` // 来自成交时为交易哈希
+}
+
+// Price 从事件中取代币价格：正常成交取成交价，交易对更新取 Pair.TokenPrice；
+// 带夹子、刷量标记的成交价格只是块内的瞬时价格，忽略，块末的交易对更新会给出真实价格
+func (e *Event) Price() (Price, bool) {
+	switch e.Type {
+	case EventTrade:
+		if e.Trade == nil || e.Trade.MevTag != "" || e.Trade.TokenPriceUsd <= 0 {
+			return Price{}, false
+		}
+		return Price{
+			Token:     e.TokenAddress,
+			Pair:      e.Trade.PairAddr,
+			PriceUSD:  e.Trade.TokenPriceUsd,
+			Slot:      e.Slot,
+			Time:      time.Unix(e.Trade.BlockTime, 0),
+			Source:    EventTrade,
+			Reference: e.Trade.TxHash,
+		}, true
+	case EventPair, EventNewPair:
+		if e.Pair == nil || e.Pair.TokenPrice <= 0 {
+			return Price{}, false
+		}
+		return Price{
+			Token:    e.Pair.TokenAddress,
+			Pair:     e.Pair.Address,
+			PriceUSD: e.Pair.TokenPrice,
+			Slot:     e.Slot,
+			Time:     time.Unix(e.Time, 0),
+			Source:   EventPair,
+			Rugged:   e.Pair.IsRug,
+		}, true
+	}
+	return Price{}, false
+}
+
+// Subscription 订阅条件，与 consumer 的 SubscribeRequest 一致，各条件之间为“或”的关系
+type Subscription struct {
+	PairAddresses  []string `json:"pairAddresses"`
+	TokenAddresses []string `json:"tokenAddresses"`
+	Wallets        []string `json:"wallets"` // 按成交发起地址过滤
+	NewPairs       bool     `json:"newPairs"`
+}
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+	writeTimeout      = 10 * time.Second
+)
+
+var ErrNotConnected = errors.New("feed not connected")
+
+// Client consumer WebSocket 推送的客户端，断线后按指数退避重连并重新发送订阅
+type Client struct {
+	url    string
+	logger *logrus.Logger
+	dialer *websocket.Dialer
+
+	mu           sync.Mutex
+	subscription Subscription
+	conn         *websocket.Conn
+}
+
+// NewClient url 为 consumer 的 WebSocket 推送地址，例如 ws://127.0.0.1:8081/ws
+func NewClient(url string, logger *logrus.Logger) *Client {
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
+	return &Client{
+		url:    url,
+		logger: logger,
+		dialer: &websocket.Dialer{HandshakeTimeout: 10 * time.Second},
+	}
+}
+
+// Subscribe 替换订阅条件；已连接时立即发送，否则在连接后发送
+func (c *Client) Subscribe(subscription Subscription) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscription = subscription
+	if c.conn == nil {
+		return nil
+	}
+	return c.writeSubscription()
+}
+
+// Run 连接并读取事件直到 ctx 结束，每个事件同步交给 handle 处理；断线后自动重连，返回 ctx 的错误
+func (c *Client) Run(ctx context.Context, handle func(*Event)) error {
+	delay := minReconnectDelay
+	for {
+		connected, err := c.runOnce(ctx, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if connected {
+			delay = minReconnectDelay
+		}
+		c.logger.Warnf("feed %s disconnected: %v, reconnect in %s", c.url, err, delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// runOnce 建立一次连接并读到断开，connected 表示是否连接成功过
+func (c *Client) runOnce(ctx context.Context, handle func(*Event)) (connected bool, err error) {
+	conn, _, err := c.dialer.DialContext(ctx, c.url, nil)
+	if err != nil {
+		return false, fmt.Errorf("dial: %w", err)
+	}
+	defer conn.Close()
+
+	c.mu.Lock()
+	c.conn = conn
+	err = c.writeSubscription()
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.conn = nil
+		c.mu.Unlock()
+	}()
+	if err != nil {
+		return true, err
+	}
+	c.logger.Debugf("feed %s connected", c.url)
+
+	// ctx 结束时关闭连接，让阻塞的 ReadMessage 返回
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return true, err
+		}
+		event := &Event{}
+		if err = json.Unmarshal(message, event); err != nil {
+			c.logger.Warnf("decode feed event err: %v", err)
+			continue
+		}
+		if event.Type == EventHeartbeat {
+			continue
+		}
+		handle(event)
+	}
+}
+
+// writeSubscription 发送当前订阅条件，调用方持有 mu
+func (c *Client) writeSubscription() error {
+	if c.conn == nil {
+		return ErrNotConnected
+	}
+	message, err := json.Marshal(c.subscription)
+	if err != nil {
+		return err
+	}
+	_ = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err = c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+		return fmt.Errorf("send subscription: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// 与 consumer 推送的格式一致：protojson 编码，int64 为字符串
+const (
+	tradeEvent    = `{"type":"trade","slot":"310000001","time":"1760000000","tokenAddress":"Mint111","trade":{"txHash":"tx1","pairAddr":"Pair111","maker":"Maker111","tradeType":"buy","baseTokenAmount":1.5,"tokenAmount":30000,"baseTokenPriceUsd":160,"totalUsd":240,"tokenPriceUsd":0.008,"blockNum":"310000001","blockTime":"1760000000","mevTag":""},"pair":null}`
+	sandwichEvent = `{"type":"trade","slot":"310000001","time":"1760000000","tokenAddress":"Mint111","trade":{"txHash":"tx0","pairAddr":"Pair111","tokenPriceUsd":0.02,"blockTime":"1760000000","mevTag":"sandwich_front"}}`
+	pairEvent     = `{"type":"pair","slot":"310000002","time":"1760000001","tokenAddress":"Mint111","trade":null,"pair":{"address":"Pair111","tokenAddress":"Mint111","tokenDecimal":"6","tokenPrice":0.0075,"isRug":true}}`
+	heartbeat     = `{"type":"heartbeat","slot":"0","time":"1760000003","tokenAddress":""}`
+)
+
+func TestEventPrice(t *testing.T) {
+	var event Event
+	if err := json.Unmarshal([]byte(tradeEvent), &event); err != nil {
+		t.Fatal(err)
+	}
+	price, ok := event.Price()
+	if !ok || price.Token != "Mint111" || price.PriceUSD != 0.008 || price.Slot != 310000001 || price.Reference != "tx1" {
+		t.Fatalf("trade price = %+v, %v", price, ok)
+	}
+	if event.Trade.Maker != "Maker111" || event.Trade.TokenAmount != 30000 {
+		t.Fatalf("trade = %+v", event.Trade)
+	}
+
+	event = Event{}
+	if err := json.Unmarshal([]byte(sandwichEvent), &event); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = event.Price(); ok {
+		t.Fatal("sandwich trade price should be ignored")
+	}
+
+	event = Event{}
+	if err := json.Unmarshal([]byte(pairEvent), &event); err != nil {
+		t.Fatal(err)
+	}
+	price, ok = event.Price()
+	if !ok || price.PriceUSD != 0.0075 || price.Source != EventPair || !price.Rugged || event.Pair.TokenDecimal != 6 {
+		t.Fatalf("pair price = %+v, %v", price, ok)
+	}
+}
+
+func TestClientResubscribesAfterReconnect(t *testing.T) {
+	subscriptions := make(chan Subscription, 10)
+	var connections atomic.Int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		connection := connections.Add(1)
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var subscription Subscription
+		if err = json.Unmarshal(message, &subscription); err != nil {
+			t.Errorf("decode subscription: %v", err)
+			return
+		}
+		subscriptions <- subscription
+		for _, event := range []string{heartbeat, tradeEvent} {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(event))
+		}
+		// 第一个连接发完事件后断开，客户端应重连并重新订阅
+		if connection == 1 {
+			return
+		}
+		for {
+			if _, _, err = conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err := c.Subscribe(Subscription{TokenAddresses: []string{"Mint111"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	events := make(chan *Event, 10)
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx, func(event *Event) { events <- event }) }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case subscription := <-subscriptions:
+			if len(subscription.TokenAddresses) != 1 || subscription.TokenAddresses[0] != "Mint111" {
+				t.Fatalf("subscription = %+v", subscription)
+			}
+		case <-ctx.Done():
+			t.Fatalf("subscription %d not received", i)
+		}
+		select {
+		case event := <-events:
+			if event.Type != EventTrade {
+				t.Fatalf("heartbeat should be skipped, got %s", event.Type)
+			}
+		case <-ctx.Done():
+			t.Fatalf("event %d not received", i)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("run err = %v", err)
+	}
+}
+
+func TestGatewayToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/tokens/Mint111":
+			_, _ = w.Write([]byte(`{"token":{"address":"Mint111","decimals":"6","isCheckCa":true,"isCanAllSell":true,"isCanAddToken":true,"sellTax":0.03,"sniperHoldPercent":12.5}}`))
+		case "/v1/tokens/Broken":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":13,"msg":"db unavailable"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gateway := NewGateway(server.URL + "/")
+	token, err := gateway.Token(context.Background(), "Mint111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Decimals != 6 || !token.IsCheckCa || !token.IsCanAddToken || token.SellTax != 0.03 || token.SniperHoldPercent != 12.5 {
+		t.Fatalf("token = %+v", token)
+	}
+	if _, err = gateway.Token(context.Background(), "Missing"); !errors.Is(err, ErrTokenNotFound) {
+		t.Fatalf("missing token err = %v", err)
+	}
+	if _, err = gateway.Token(context.Background(), "Broken"); err == nil || !strings.Contains(err.Error(), "db unavailable") {
+		t.Fatalf("broken token err = %v", err)
+	}
+}
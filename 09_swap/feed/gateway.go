@@ -0,0 +1,100 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrTokenNotFound = errors.New("token not found")
+
+// Token 代币信息和合约检测结果，与 consumer 的 Token（solmodel.Token）一致
+type Token struct {
+	Address            string  `json:"address"`
+	Program            string  `json:"program"`
+	Name               string  `json:"name"`
+	Symbol             string  `json:"symbol"`
+	Decimals           int64   `json:"decimals,string"`
+	IsCheckCa          bool    `json:"isCheckCa"` // 是否完成合约检测，未完成时以下风险字段无意义
+	IsCaDropOwner      bool    `json:"isCaDropOwner"`
+	IsHoneyScam        bool    `json:"isHoneyScam"`
+	IsLiquidLock       bool    `json:"isLiquidLock"`
+	IsBurnPool         bool    `json:"isBurnPool"`
+	IsCanPauseTrade    bool    `json:"isCanPauseTrade"`
+	IsCanChangeTax     bool    `json:"isCanChangeTax"`
+	IsHaveBlackList    bool    `json:"isHaveBlackList"`
+	IsCanAllSell       bool    `json:"isCanAllSell"`
+	IsCanExternalCall  bool    `json:"isCanExternalCall"`
+	IsCanAddToken      bool    `json:"isCanAddToken"`
+	IsCanChangeToken   bool    `json:"isCanChangeToken"`
+	SellTax            float64 `json:"sellTax"` // 0-1
+	BuyTax             float64 `json:"buyTax"`
+	SniperHoldPercent  float64 `json:"sniperHoldPercent"` // 0-100
+	BundlerHoldPercent float64 `json:"bundlerHoldPercent"`
+	IsBundled          bool    `json:"isBundled"`
+}
+
+// Gateway consumer 数据查询接口（gateway REST）的客户端
+type Gateway struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewGateway baseURL 为网关地址，例如 http://127.0.0.1:8888
+func NewGateway(baseURL string) *Gateway {
+	return &Gateway{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Token 查询代币信息，对应 GET /v1/tokens/:address
+func (g *Gateway) Token(ctx context.Context, address string) (*Token, error) {
+	var resp struct {
+		Token *Token `json:"token"`
+	}
+	if err := g.get(ctx, "/v1/tokens/"+url.PathEscape(address), &resp); err != nil {
+		return nil, err
+	}
+	if resp.Token == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, address)
+	}
+	return resp.Token, nil
+}
+
+func (g *Gateway) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrTokenNotFound, path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// 网关的错误响应为 {"code": gRPC 状态码, "msg": "..."}
+		var gatewayErr struct {
+			Code int    `json:"code"`
+			Msg  string `json:"msg"`
+		}
+		if json.Unmarshal(body, &gatewayErr) == nil && gatewayErr.Msg != "" {
+			return fmt.Errorf("gateway %s: %s (code %d)", path, gatewayErr.Msg, gatewayErr.Code)
+		}
+		return fmt.Errorf("gateway %s: http status %d", path, resp.StatusCode)
+	}
+	return json.Unmarshal(body, out)
+}
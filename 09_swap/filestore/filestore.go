@@ -0,0 +1,164 @@
+package filestore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Record 存储的记录，订单和分批计划实现
+type Record interface {
+	RecordID() string
+	RecordCreatedAt() time.Time
+	SetUpdatedAt(t time.Time)
+}
+
+// Store 记录的文件存储，写入记录的命令行和执行器可以是不同进程
+/*
+	<dir>/<id>.json    每条记录一个文件，先写临时文件再改名，读到的总是完整的记录
+	<dir>/<id>.cancel  撤销请求，由执行器处理；记录创建后只有执行器改写，避免两个进程互相覆盖
+*/
+type Store[T any, P interface {
+	*T
+	Record
+}] struct {
+	dir      string
+	kind     string // 记录名称，用于错误信息
+	notFound error
+}
+
+const (
+	recordSuffix = ".json"
+	cancelSuffix = ".cancel"
+)
+
+// Open 打开 dir 下的存储，目录不存在时创建；kind 为记录名称，notFound 为记录不存在时返回的错误
+func Open[T any, P interface {
+	*T
+	Record
+}](dir, kind string, notFound error) (*Store[T, P], error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create %s store: %w", kind, err)
+	}
+	return &Store[T, P]{dir: dir, kind: kind, notFound: notFound}, nil
+}
+
+func (s *Store[T, P]) path(id, suffix string) string {
+	return filepath.Join(s.dir, id+suffix)
+}
+
+// Create 保存新记录，ID 已存在时返回错误
+func (s *Store[T, P]) Create(r P) error {
+	tmp, err := s.writeTemp(r)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	// 硬链接不会覆盖已有文件
+	if err = os.Link(tmp, s.path(r.RecordID(), recordSuffix)); err != nil {
+		return fmt.Errorf("create %s %s: %w", s.kind, r.RecordID(), err)
+	}
+	return nil
+}
+
+// Save 覆盖保存记录
+func (s *Store[T, P]) Save(r P) error {
+	r.SetUpdatedAt(time.Now())
+	tmp, err := s.writeTemp(r)
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, s.path(r.RecordID(), recordSuffix)); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("save %s %s: %w", s.kind, r.RecordID(), err)
+	}
+	return nil
+}
+
+func (s *Store[T, P]) writeTemp(r P) (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(s.dir, "."+r.RecordID()+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("write %s %s: %w", s.kind, r.RecordID(), err)
+	}
+	return f.Name(), nil
+}
+
+// Load 读取记录
+func (s *Store[T, P]) Load(id string) (P, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return nil, fmt.Errorf("%w: %s", s.notFound, id)
+	}
+	data, err := os.ReadFile(s.path(id, recordSuffix))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", s.notFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r := P(new(T))
+	if err = json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("decode %s %s: %w", s.kind, id, err)
+	}
+	return r, nil
+}
+
+// List 按创建时间列出全部记录
+func (s *Store[T, P]) List() ([]P, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]P, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, recordSuffix) {
+			continue
+		}
+		r, err := s.Load(strings.TrimSuffix(name, recordSuffix))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].RecordCreatedAt().Before(records[j].RecordCreatedAt()) })
+	return records, nil
+}
+
+// RequestCancel 写入撤销请求，是否允许撤销由调用方判断
+func (s *Store[T, P]) RequestCancel(id string) error {
+	if err := os.WriteFile(s.path(id, cancelSuffix), nil, 0o600); err != nil {
+		return fmt.Errorf("request cancel %s: %w", id, err)
+	}
+	return nil
+}
+
+// CancelRequested 是否有撤销请求
+func (s *Store[T, P]) CancelRequested(id string) bool {
+	_, err := os.Stat(s.path(id, cancelSuffix))
+	return err == nil
+}
+
+// ClearCancel 删除已处理的撤销请求
+func (s *Store[T, P]) ClearCancel(id string) {
+	_ = os.Remove(s.path(id, cancelSuffix))
+}
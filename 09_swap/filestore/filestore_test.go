@@ -0,0 +1,70 @@
+package filestore
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+var errNotFound = errors.New("record not found")
+
+type record struct {
+	ID        string    `json:"id"`
+	Value     int       `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (r *record) RecordID() string           { return r.ID }
+func (r *record) RecordCreatedAt() time.Time { return r.CreatedAt }
+func (r *record) SetUpdatedAt(t time.Time)   { r.UpdatedAt = t }
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open[record](dir, "record", errNotFound)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	second := &record{ID: "b", CreatedAt: now}
+	first := &record{ID: "a", CreatedAt: now.Add(-time.Minute)}
+	for _, r := range []*record{second, first} {
+		if err = store.Create(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = store.Create(first); err == nil {
+		t.Fatal("expected duplicate create to fail")
+	}
+
+	second.Value = 7
+	if err = store.Save(second); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := store.Load("b")
+	if err != nil || loaded.Value != 7 || loaded.UpdatedAt.IsZero() {
+		t.Fatalf("loaded = %+v, %v", loaded, err)
+	}
+	for _, id := range []string{"missing", "", "../b"} {
+		if _, err = store.Load(id); !errors.Is(err, errNotFound) {
+			t.Fatalf("load %q err = %v", id, err)
+		}
+	}
+
+	// 临时文件和撤销请求不出现在列表中
+	if err = os.WriteFile(dir+"/.c.123.tmp", []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.RequestCancel("a"); err != nil || !store.CancelRequested("a") {
+		t.Fatalf("cancel err = %v", err)
+	}
+	records, err := store.List()
+	if err != nil || len(records) != 2 || records[0].ID != "a" || records[1].ID != "b" {
+		t.Fatalf("records = %+v, %v", records, err)
+	}
+	store.ClearCancel("a")
+	if store.CancelRequested("a") {
+		t.Fatal("cancel request not cleared")
+	}
+}
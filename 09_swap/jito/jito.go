@@ -0,0 +1,225 @@
+package jito
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// bundlesPath Block Engine 的 bundle JSON-RPC 路径，sendBundle、getTipAccounts、getInflightBundleStatuses 都走这里
+const bundlesPath = "/api/v1/bundles"
+
+// MaxBundleTransactions 一个 bundle 最多包含的交易数
+const MaxBundleTransactions = 5
+
+// DefaultTipAccounts 主网的 tip 账户，Block Engine 的 getTipAccounts 不可用时使用
+var DefaultTipAccounts = []solana.PublicKey{
+	solana.MustPublicKeyFromBase58("96gYZGLnJYVFmbjzopPSU6QiEV5fGqZNyN9nmNhvrZU5"),
+	solana.MustPublicKeyFromBase58("HFqU5x63VTqvQss8hp11i4wVV8bD44PvwucfZ2bU7gRe"),
+	solana.MustPublicKeyFromBase58("Cw8CFyM9FkoMi7K7Crf6HNQqf4uEMzpKw6QNghXLvLkY"),
+	solana.MustPublicKeyFromBase58("ADaUMid9yfUytqMBgopwjb2DTLSokTSzL1zt6iGPaS49"),
+	solana.MustPublicKeyFromBase58("DfXygSm4jCyNCybVYYK6DwvWqjKee8pbDmJGcLWNDXjh"),
+	solana.MustPublicKeyFromBase58("ADuUkR4vqLUMWXxW9gh6D6L8pMSawimctcNZ5pGwDcEt"),
+	solana.MustPublicKeyFromBase58("DttWaMuVvTiduZRnguLF7jNxTgiMBZ1hyAumKUiL2KRL"),
+	solana.MustPublicKeyFromBase58("3AVi9Tg9Uo68tJfuvoKvqKNWKkC5wPdSSdeBnizKZ6jT"),
+}
+
+var (
+	ErrBundleTimeout = errors.New("bundle not landed before timeout")
+	ErrBundleFailed  = errors.New("bundle failed")
+)
+
+// BundleStatus getInflightBundleStatuses 返回的 bundle 状态
+type BundleStatus string
+
+const (
+	BundleStatusInvalid BundleStatus = "Invalid" // 最近 5 分钟内没有这个 bundle
+	BundleStatusPending BundleStatus = "Pending" // 还没有上链也没有失败
+	BundleStatusFailed  BundleStatus = "Failed"  // 所有区域都标记为失败，没有转发
+	BundleStatusLanded  BundleStatus = "Landed"  // 已经上链
+)
+
+// InflightBundleStatus 最近 5 分钟内提交的 bundle 状态
+type InflightBundleStatus struct {
+	BundleID   string       `json:"bundle_id"`
+	Status     BundleStatus `json:"status"`
+	LandedSlot *uint64      `json:"landed_slot"`
+}
+
+// RPCError Block Engine 返回的 JSON-RPC 错误
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("jito rpc error %d: %s", e.Code, e.Message)
+}
+
+// Client Jito Block Engine 的 bundle 客户端
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+	requestID  atomic.Uint64
+}
+
+// NewClient endpoint 为 Block Engine 地址，例如 https://mainnet.block-engine.jito.wtf
+func NewClient(endpoint string) *Client {
+	return &Client{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SendBundle 提交按顺序原子执行的交易，返回 bundle id；tip 转账要放在其中某笔交易里
+func (c *Client) SendBundle(ctx context.Context, transactions []*solana.Transaction) (string, error) {
+	if len(transactions) == 0 || len(transactions) > MaxBundleTransactions {
+		return "", fmt.Errorf("bundle must contain 1 to %d transactions, got %d", MaxBundleTransactions, len(transactions))
+	}
+	encoded := make([]string, 0, len(transactions))
+	for _, tx := range transactions {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return "", fmt.Errorf("marshal transaction: %w", err)
+		}
+		encoded = append(encoded, base64.StdEncoding.EncodeToString(raw))
+	}
+
+	var bundleID string
+	params := []any{encoded, map[string]string{"encoding": "base64"}}
+	if err := c.call(ctx, "sendBundle", params, &bundleID); err != nil {
+		return "", err
+	}
+	return bundleID, nil
+}
+
+// GetTipAccounts 查询 tip 账户
+func (c *Client) GetTipAccounts(ctx context.Context) ([]solana.PublicKey, error) {
+	var addresses []string
+	if err := c.call(ctx, "getTipAccounts", []any{}, &addresses); err != nil {
+		return nil, err
+	}
+	accounts := make([]solana.PublicKey, 0, len(addresses))
+	for _, address := range addresses {
+		account, err := solana.PublicKeyFromBase58(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tip account %s: %w", address, err)
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// GetInflightBundleStatus 查询最近 5 分钟内提交的 bundle 状态
+func (c *Client) GetInflightBundleStatus(ctx context.Context, bundleID string) (*InflightBundleStatus, error) {
+	var result struct {
+		Value []*InflightBundleStatus `json:"value"`
+	}
+	if err := c.call(ctx, "getInflightBundleStatuses", []any{[]string{bundleID}}, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Value) == 0 || result.Value[0] == nil {
+		return &InflightBundleStatus{BundleID: bundleID, Status: BundleStatusInvalid}, nil
+	}
+	return result.Value[0], nil
+}
+
+// WaitForBundle 轮询 bundle 状态直到上链；失败时返回 ErrBundleFailed，ctx 到期时返回 ErrBundleTimeout。
+// 刚提交时可能还查不到 bundle，Invalid 状态会继续等待
+func (c *Client) WaitForBundle(ctx context.Context, bundleID string, interval time.Duration) (*InflightBundleStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %s", ErrBundleTimeout, bundleID)
+		case <-ticker.C:
+		}
+
+		status, err := c.GetInflightBundleStatus(ctx, bundleID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("%w: %s", ErrBundleTimeout, bundleID)
+			}
+			continue
+		}
+		switch status.Status {
+		case BundleStatusLanded:
+			return status, nil
+		case BundleStatusFailed:
+			return status, fmt.Errorf("%w: %s", ErrBundleFailed, bundleID)
+		}
+	}
+}
+
+// RandomTipAccount 随机选择一个 tip 账户，分散对同一账户的写锁竞争
+func RandomTipAccount(accounts []solana.PublicKey) solana.PublicKey {
+	if len(accounts) == 0 {
+		accounts = DefaultTipAccounts
+	}
+	return accounts[rand.Intn(len(accounts))]
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+func (c *Client) call(ctx context.Context, method string, params any, result any) error {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: c.requestID.Add(1), Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+bundlesPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: read response: %w", method, err)
+	}
+	var out rpcResponse
+	if err = json.Unmarshal(data, &out); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s: http status %d: %s", method, resp.StatusCode, strings.TrimSpace(string(data)))
+		}
+		return fmt.Errorf("%s: decode response: %w", method, err)
+	}
+	if out.Error != nil {
+		return fmt.Errorf("%s: %w", method, out.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: http status %d", method, resp.StatusCode)
+	}
+	if err = json.Unmarshal(out.Result, result); err != nil {
+		return fmt.Errorf("%s: decode result: %w", method, err)
+	}
+	return nil
+}
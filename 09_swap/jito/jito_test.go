@@ -0,0 +1,206 @@
+package jito
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// standIn 本地模拟的 Block Engine，只实现 bundle 相关的 JSON-RPC 方法
+type standIn struct {
+	mu          sync.Mutex
+	tipAccounts []string
+	bundles     map[string][]string // bundle id -> base64 交易
+	polls       map[string]int      // bundle id -> 已查询状态的次数
+	landAfter   int                 // 查询多少次后返回 Landed，0 表示一直 Pending
+	fail        bool                // 返回 Failed
+}
+
+func newStandIn(t *testing.T, s *standIn) *httptest.Server {
+	s.bundles = make(map[string][]string)
+	s.polls = make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != bundlesPath {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var result any
+		switch req.Method {
+		case "getTipAccounts":
+			result = s.tipAccounts
+		case "sendBundle":
+			var txs []string
+			if err := json.Unmarshal(req.Params[0], &txs); err != nil || len(txs) == 0 {
+				writeError(w, req.ID, -32602, "invalid bundle")
+				return
+			}
+			id := "bundle-" + string(rune('a'+len(s.bundles)))
+			s.bundles[id] = txs
+			result = id
+		case "getInflightBundleStatuses":
+			var ids []string
+			_ = json.Unmarshal(req.Params[0], &ids)
+			value := make([]map[string]any, 0, len(ids))
+			for _, id := range ids {
+				if _, ok := s.bundles[id]; !ok {
+					value = append(value, map[string]any{"bundle_id": id, "status": "Invalid", "landed_slot": nil})
+					continue
+				}
+				s.polls[id]++
+				status := map[string]any{"bundle_id": id, "status": "Pending", "landed_slot": nil}
+				switch {
+				case s.fail:
+					status["status"] = "Failed"
+				case s.landAfter > 0 && s.polls[id] >= s.landAfter:
+					status["status"] = "Landed"
+					status["landed_slot"] = 42
+				}
+				value = append(value, status)
+			}
+			result = map[string]any{"context": map[string]any{"slot": 42}, "value": value}
+		default:
+			writeError(w, req.ID, -32601, "method not found")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeError(w http.ResponseWriter, id uint64, code int, message string) {
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"jsonrpc": "2.0", "id": id, "error": map[string]any{"code": code, "message": message},
+	})
+}
+
+func testTransaction(t *testing.T) *solana.Transaction {
+	payer := solana.NewWallet()
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{solana.NewInstruction(solana.MemoProgramID, nil, []byte("jito"))},
+		solana.Hash{1},
+		solana.TransactionPayer(payer.PublicKey()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tx.Sign(func(solana.PublicKey) *solana.PrivateKey { return &payer.PrivateKey }); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestSendBundleAndWait(t *testing.T) {
+	tip := solana.NewWallet().PublicKey()
+	engine := &standIn{tipAccounts: []string{tip.String()}, landAfter: 2}
+	server := newStandIn(t, engine)
+	client := NewClient(server.URL + "/")
+	ctx := context.Background()
+
+	accounts, err := client.GetTipAccounts(ctx)
+	if err != nil || len(accounts) != 1 || !accounts[0].Equals(tip) {
+		t.Fatalf("GetTipAccounts = %v, %v", accounts, err)
+	}
+
+	tx := testTransaction(t)
+	bundleID, err := client.SendBundle(ctx, []*solana.Transaction{tx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(engine.bundles[bundleID][0])
+	sent, err := solana.TransactionFromBytes(raw)
+	if err != nil || sent.Signatures[0] != tx.Signatures[0] {
+		t.Fatalf("bundle transaction mismatch: %v", err)
+	}
+
+	status, err := client.WaitForBundle(ctx, bundleID, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != BundleStatusLanded || status.LandedSlot == nil || *status.LandedSlot != 42 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestWaitForBundleTimeout(t *testing.T) {
+	server := newStandIn(t, &standIn{})
+	client := NewClient(server.URL)
+
+	bundleID, err := client.SendBundle(context.Background(), []*solana.Transaction{testTransaction(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err = client.WaitForBundle(ctx, bundleID, 10*time.Millisecond); !errors.Is(err, ErrBundleTimeout) {
+		t.Fatalf("err = %v, want ErrBundleTimeout", err)
+	}
+}
+
+func TestWaitForBundleFailed(t *testing.T) {
+	server := newStandIn(t, &standIn{fail: true})
+	client := NewClient(server.URL)
+
+	bundleID, err := client.SendBundle(context.Background(), []*solana.Transaction{testTransaction(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.WaitForBundle(context.Background(), bundleID, 10*time.Millisecond); !errors.Is(err, ErrBundleFailed) {
+		t.Fatalf("err = %v, want ErrBundleFailed", err)
+	}
+}
+
+func TestSendBundleErrors(t *testing.T) {
+	server := newStandIn(t, &standIn{})
+	client := NewClient(server.URL)
+
+	if _, err := client.SendBundle(context.Background(), nil); err == nil {
+		t.Fatal("empty bundle should be rejected")
+	}
+
+	var rpcErr *RPCError
+	err := client.call(context.Background(), "unknownMethod", []any{}, new(any))
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Fatalf("err = %v, want rpc error -32601", err)
+	}
+}
+
+func TestRandomTipAccount(t *testing.T) {
+	accounts := []solana.PublicKey{solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()}
+	seen := make(map[solana.PublicKey]bool)
+	for i := 0; i < 200; i++ {
+		seen[RandomTipAccount(accounts)] = true
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected both tip accounts to be chosen, got %d", len(seen))
+	}
+
+	fallback := RandomTipAccount(nil)
+	found := false
+	for _, account := range DefaultTipAccounts {
+		found = found || account.Equals(fallback)
+	}
+	if !found {
+		t.Fatalf("%s is not a default tip account", fallback)
+	}
+}
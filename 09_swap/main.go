@@ -1,56 +1,112 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
 	"os"
 	"strings"
-	"swap/config"
-	"swap/wallet"
-
-	"github.com/mr-tron/base58"
 )
 
-func main() {
-	fmt.Println("🚀 Pump.fun AMM Swap Start!")
-	fmt.Println(strings.Repeat("=", 50))
-
-	// 1. 准备钱包信息
-	rpcEndpoint := getEnvOrDefault("RPC_ENDPOINT", "http://api.devnet.solana.com")
-	privateKeyArr := []byte{172, 254, 142, 221, 147, 137, 233, 182, 189, 100, 45, 12, 9, 141, 74, 187, 22, 151, 243, 72, 227, 34, 224, 218, 92, 211, 206, 167, 125, 152, 100, 129, 235, 135, 85, 90, 106, 132, 39, 123, 201, 171, 57, 209, 164, 200, 109, 9, 76, 241, 19, 19, 135, 28, 127, 247, 211, 221, 190, 87, 162, 8, 203, 50}
-	privateKeyStr := getEnvOrDefault("PRIVATE_KEY", base58.Encode(privateKeyArr))
+// 命令行入口：swap <命令> [参数]
+/*
+	swap quote --mint <代币> --amount 100000000 --side buy
+	swap buy --mint <代币> --amount 100000000 --dry-run
+	swap sell --mint <代币> --amount 5000000 --json
+	swap balances --address <地址>
+	swap history --mint <代币> --limit 50
+	swap wrap --amount 100000000
+	swap unwrap
+	swap tx status <签名>
+	swap order add --type stop_loss --mint <代币> --price 0.0005
+	swap order run --feed ws://127.0.0.1:8081/ws --wallets main,sniper
+	swap copy run --copy-config copy.json --feed ws://127.0.0.1:8081/ws --gateway http://127.0.0.1:8888
+	swap copy log --action copied
+	swap dca add --mint <代币> --side buy --amount 5000000000 --slices 20 --duration 2h --max-impact-bp 200
+	swap dca run --wallets main
 
-	if privateKeyStr == "" {
-		log.Fatal("❌ 需要设置 PRIVATE_KEY 环境变量")
+	公共参数（--rpc、--config、--wallet 等）可以放在命令后的任意位置，显式传入的参数覆盖 --config 配置文件，
+	配置文件覆盖 RPC_ENDPOINT、WALLET_NAME 环境变量；
+	--json 时结果以 JSON 写到 stdout，失败时输出 {"error": ..., "kind": ...} 并以状态码 1 退出
+*/
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		usage()
+		return
 	}
 
-	// 2. 客户端配置，用于初始化交换客户端
-	cfg := config.DefaultConfig()
-	cfg.RPCEndpoint = rpcEndpoint
-	cfg.EnableDebugLog = true
+	cmd, ok := findCommand(os.Args[1])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "❌ 不支持的命令：%s.\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
 
-	fmt.Printf("📢 RPC 节点：%s.\n", cfg.RPCEndpoint)
+	opts := &options{}
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	opts.register(fs)
+	var cmdFlags any
+	if cmd.flags != nil {
+		cmdFlags = cmd.flags(fs)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "用法：swap %s\n\n参数：\n", cmd.usage)
+		fs.PrintDefaults()
+	}
+	args, err := opts.parse(fs, os.Args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
 
-	// 3. 根据私钥恢复/加载钱包对象（用于签名交易）
-	// 基于1中的私钥字符串得到的钱包对象，w.publicKey()是钱包的地址，todo 打印出来看下是否与Solana address得到的地址一致GrQVv3uEobKDrfXbVaP6qvEA6ioX5yZUASV3FCz7xoFw
-	w, err := wallet.NewMemoryWalletFromBase58(privateKeyStr)
+	p := printer{json: opts.json}
+	c, release, err := opts.newClient(cmd.needWallet != nil && cmd.needWallet(args, cmdFlags))
 	if err != nil {
-		log.Fatalf("❌ 获取钱包对象失败：%v.\n", err) //%v 是 Go 的通用格式化动词，适用于大多数类型
+		p.fail(fmt.Errorf("create client: %w", err))
 	}
 
-	fmt.Printf("👛 钱包地址：%s.\n", w.PublicKey().String())
-	// 4. 创建客户端连接对象
-	// 如果函数名首字母大写（公开），可以直接通过包名调用
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	p.info("📢 RPC 节点：%s.\n", c.Config().RPCEndpoint)
+	err = cmd.run(ctx, c, p, args, cmdFlags)
+	cancel()
+	release()
+	if err != nil {
+		p.fail(err)
+	}
+}
 
-	// 5. 构建交易请求
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
 
+func usage() {
+	fmt.Fprintln(os.Stderr, "🚀 Pump.fun AMM Swap")
+	fmt.Fprintln(os.Stderr, strings.Repeat("=", 50))
+	fmt.Fprintln(os.Stderr, "用法：swap <命令> [参数]")
+	fmt.Fprintln(os.Stderr)
+	for _, cmd := range commands {
+		fmt.Fprintln(os.Stderr, "  "+cmd.usage)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "执行 swap <命令> --help 查看参数；--dry-run 只模拟不发送，--json 输出 JSON")
 }
 
 // os.Getenv(key) 直接从操作系统的环境变量中读取配置
 /*
 方法一：在终端中临时设置（当前会话有效）
 export RPC_ENDPOINT="https://api.mainnet-beta.solana.com"
-export PRIVATE_KEY="your-private-key"
+export KEYSTORE_DIR="$HOME/.pumpswap/keystore"
+export WALLET_NAME="main"
+export FEED_URL="ws://127.0.0.1:8081/ws"
+export GATEWAY_URL="http://127.0.0.1:8888"
 */
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
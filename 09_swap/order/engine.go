@@ -0,0 +1,319 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"swap/client"
+	"swap/feed"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/sirupsen/logrus"
+)
+
+// Feed 价格来源，feed.Client 实现
+type Feed interface {
+	Subscribe(subscription feed.Subscription) error
+	Run(ctx context.Context, handle func(*feed.Event)) error
+}
+
+// EngineConfig 执行器配置
+type EngineConfig struct {
+	ScanInterval  time.Duration // 重新扫描订单存储的间隔，用于发现新订单和撤单请求
+	MaxConcurrent int           // 同时执行的订单数
+	SwapTimeout   time.Duration // 单个订单执行的超时时间
+}
+
+func DefaultEngineConfig() EngineConfig {
+	return EngineConfig{
+		ScanInterval:  2 * time.Second,
+		MaxConcurrent: 4,
+		SwapTimeout:   2 * time.Minute,
+	}
+}
+
+// Engine 订单执行器：订阅待触发订单代币的价格，价格满足条件时通过 Executor 下单，
+// 每次状态变化都保存订单并写入审计日志
+type Engine struct {
+	store    *Store
+	executor Executor
+	config   EngineConfig
+	logger   *logrus.Logger
+
+	mu      sync.Mutex
+	pending map[string]*Order // 待触发的订单，只在 mu 下读写
+	tokens  string            // 当前订阅的代币，用于判断是否需要重新订阅
+	slots   chan struct{}
+	wg      sync.WaitGroup
+}
+
+func NewEngine(store *Store, executor Executor, config EngineConfig, logger *logrus.Logger) *Engine {
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = 1
+	}
+	return &Engine{
+		store:    store,
+		executor: executor,
+		config:   config,
+		logger:   logger,
+		pending:  make(map[string]*Order),
+		slots:    make(chan struct{}, config.MaxConcurrent),
+	}
+}
+
+// Run 恢复上次中断的订单后开始监听价格，直到 ctx 结束；返回前等待正在执行的订单完成
+func (e *Engine) Run(ctx context.Context, source Feed) error {
+	if err := e.Recover(); err != nil {
+		return err
+	}
+	if err := e.Scan(source); err != nil {
+		return err
+	}
+
+	feedDone := make(chan error, 1)
+	go func() {
+		feedDone <- source.Run(ctx, func(event *feed.Event) {
+			if price, ok := event.Price(); ok {
+				e.OnPrice(ctx, price)
+			}
+		})
+	}()
+
+	ticker := time.NewTicker(e.config.ScanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			<-feedDone
+			e.wg.Wait()
+			return ctx.Err()
+		case <-ticker.C:
+			if err := e.Scan(source); err != nil {
+				e.logger.Errorf("scan orders err: %v", err)
+			}
+		}
+	}
+}
+
+// Recover 处理上次退出时停在 triggered 的订单：无法确定交易是否已经上链，标记为失败，不再重复下单
+func (e *Engine) Recover() error {
+	orders, err := e.store.List()
+	if err != nil {
+		return err
+	}
+	for _, o := range orders {
+		if o.State == StateTriggered {
+			e.fail(o, 0, "executor stopped while the order was executing, check the wallet before placing it again")
+		}
+	}
+	return nil
+}
+
+// Scan 重新读取订单存储：处理撤单请求，加入新订单，并按待触发订单的代币更新订阅
+func (e *Engine) Scan(source Feed) error {
+	orders, err := e.store.List()
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	seen := make(map[string]bool, len(orders))
+	for _, stored := range orders {
+		if stored.State != StatePending {
+			continue
+		}
+		seen[stored.ID] = true
+		o, ok := e.pending[stored.ID]
+		if !ok {
+			o = stored
+		}
+		if e.store.CancelRequested(o.ID) {
+			delete(e.pending, o.ID)
+			e.transition(o, StateCancelled, AuditEntry{Event: "cancelled"})
+			e.store.clearCancel(o.ID)
+			continue
+		}
+		if !ok {
+			if !e.executor.HasWallet(o.Wallet) {
+				e.logger.Warnf("order %s skipped, wallet %s is not unlocked", o.ID, o.Wallet)
+				continue
+			}
+			e.pending[o.ID] = o
+			e.logger.Infof("watching order %s: %s %s trigger %g", o.ID, o.Kind, o.Mint, o.TriggerPrice)
+		}
+	}
+	// 订单文件被删除时不再跟踪
+	for id := range e.pending {
+		if !seen[id] {
+			delete(e.pending, id)
+		}
+	}
+
+	tokens := e.watchedTokens()
+	if key := fmt.Sprint(tokens); key != e.tokens {
+		if err = source.Subscribe(feed.Subscription{TokenAddresses: tokens}); err != nil {
+			return fmt.Errorf("subscribe prices: %w", err)
+		}
+		e.tokens = key
+	}
+	return nil
+}
+
+func (e *Engine) watchedTokens() []string {
+	set := make(map[string]struct{})
+	for _, o := range e.pending {
+		set[o.Mint] = struct{}{}
+	}
+	tokens := make([]string, 0, len(set))
+	for token := range set {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// OnPrice 用最新价格检查该代币的待触发订单，触发的订单异步执行
+func (e *Engine) OnPrice(ctx context.Context, price feed.Price) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for id, o := range e.pending {
+		if o.Mint != price.Token {
+			continue
+		}
+		wasActivated := o.Activated
+		triggered, changed := o.Observe(price.PriceUSD)
+		if changed {
+			if !wasActivated && o.Activated {
+				_ = e.store.Audit(AuditEntry{OrderID: o.ID, Wallet: o.Wallet, Event: "activated", Price: price.PriceUSD})
+			}
+			if err := e.store.Save(o); err != nil {
+				e.logger.Errorf("save order %s err: %v", o.ID, err)
+			}
+		}
+		if !triggered {
+			continue
+		}
+		delete(e.pending, id)
+		o.TriggeredAt = time.Now()
+		o.TriggeredPrice = price.PriceUSD
+		message := fmt.Sprintf("%s price %g from %s", price.Source, price.PriceUSD, price.Pair)
+		if o.Kind == KindTrailingStop {
+			message += fmt.Sprintf(", peak %g, stop %g", o.PeakPrice, o.StopPrice())
+		}
+		if !e.transition(o, StateTriggered, AuditEntry{Event: "triggered", Price: price.PriceUSD, Message: message}) {
+			continue
+		}
+		e.wg.Add(1)
+		go func(o *Order, rugged bool) {
+			defer e.wg.Done()
+			e.execute(ctx, o, rugged)
+		}(o, price.Rugged)
+	}
+}
+
+// execute 执行已触发的订单：确定数量、检查价格影响后下单
+func (e *Engine) execute(ctx context.Context, o *Order, rugged bool) {
+	select {
+	case e.slots <- struct{}{}:
+		defer func() { <-e.slots }()
+	case <-ctx.Done():
+		e.fail(o, o.TriggeredPrice, "executor stopped before the order was executed")
+		return
+	}
+	// 停止执行器时不打断正在发送的交易
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), e.config.SwapTimeout)
+	defer cancel()
+
+	if o.IsBuy() && rugged {
+		e.fail(o, o.TriggeredPrice, "pair is marked as rugged, buy skipped")
+		return
+	}
+	mint, err := solana.PublicKeyFromBase58(o.Mint)
+	if err != nil {
+		e.fail(o, o.TriggeredPrice, err.Error())
+		return
+	}
+	side := client.SwapSideSell
+	if o.IsBuy() {
+		side = client.SwapSideBuy
+	}
+
+	amount := o.Amount
+	if amount == 0 {
+		// 卖出全部持仓
+		if amount, err = e.executor.TokenBalance(ctx, o.Wallet, mint); err != nil {
+			e.fail(o, o.TriggeredPrice, fmt.Sprintf("get token balance: %v", err))
+			return
+		}
+		if amount == 0 {
+			e.fail(o, o.TriggeredPrice, "wallet holds no token to sell")
+			return
+		}
+	}
+
+	if o.MaxPriceImpactBP > 0 {
+		impact, err := e.executor.PriceImpact(ctx, mint, side, amount)
+		if err != nil {
+			e.fail(o, o.TriggeredPrice, fmt.Sprintf("quote: %v", err))
+			return
+		}
+		if impact*100 > float64(o.MaxPriceImpactBP) {
+			e.fail(o, o.TriggeredPrice, fmt.Sprintf("price impact %.4f%% exceeds max %d bp", impact, o.MaxPriceImpactBP))
+			return
+		}
+	}
+
+	result, err := e.executor.Swap(ctx, o.Wallet, mint, side, amount, o.SlippageBP)
+	if err != nil {
+		e.fail(o, o.TriggeredPrice, err.Error())
+		return
+	}
+	o.Signature = result.Signature.String()
+	o.Simulated = result.Simulated
+	o.BaseAmount = result.BaseAmount
+	o.QuoteAmount = result.QuoteAmount
+	message := fmt.Sprintf("base amount %d, quote amount %d, price impact %.4f%%", result.BaseAmount, result.QuoteAmount, result.PriceImpact)
+	if result.Simulated {
+		message = "simulated only, " + message
+	}
+	e.transition(o, StateFilled, AuditEntry{Event: "filled", Price: o.TriggeredPrice, Signature: o.Signature, Message: message})
+}
+
+func (e *Engine) fail(o *Order, price float64, reason string) {
+	o.Error = reason
+	e.transition(o, StateFailed, AuditEntry{Event: "failed", Price: price, Message: reason})
+}
+
+// transition 修改订单状态并保存，写入审计日志；保存失败时返回 false
+func (e *Engine) transition(o *Order, to State, entry AuditEntry) bool {
+	entry.OrderID = o.ID
+	entry.Wallet = o.Wallet
+	entry.From = o.State
+	entry.To = to
+	o.State = to
+	if to.Closed() && to != StateCancelled && e.store.CancelRequested(o.ID) {
+		// 撤单请求晚于触发，订单已经在执行
+		entry.Message += " (cancel request ignored, order was already triggered)"
+		e.store.clearCancel(o.ID)
+	}
+	if err := e.store.Save(o); err != nil {
+		e.logger.Errorf("save order %s err: %v", o.ID, err)
+		return false
+	}
+	if err := e.store.Audit(entry); err != nil {
+		e.logger.Errorf("audit order %s err: %v", o.ID, err)
+	}
+	level := logrus.InfoLevel
+	if to == StateFailed {
+		level = logrus.WarnLevel
+	}
+	e.logger.Logf(level, "order %s %s -> %s: %s", o.ID, entry.From, to, entry.Message)
+	return true
+}
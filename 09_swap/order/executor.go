@@ -0,0 +1,76 @@
+package order
+
+import (
+	"context"
+
+	"swap/client"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Executor 按钱包名报价和下单，ClientExecutor 用 swap 客户端实现
+type Executor interface {
+	// HasWallet 钱包是否已解锁，没有解锁的钱包的订单不会触发
+	HasWallet(wallet string) bool
+	// PriceImpact 按链上当前状态报价，返回价格影响（百分比）
+	PriceImpact(ctx context.Context, mint solana.PublicKey, side client.SwapSide, amount uint64) (float64, error)
+	// TokenBalance 钱包持有的代币数量
+	TokenBalance(ctx context.Context, wallet string, mint solana.PublicKey) (uint64, error)
+	// Swap 用 wallet 按 slippageBP 滑点买入（amount 为 lamports）或卖出（amount 为代币数量）
+	Swap(ctx context.Context, wallet string, mint solana.PublicKey, side client.SwapSide, amount uint64, slippageBP uint32) (*client.SwapResult, error)
+}
+
+// ClientExecutor 通过 swap 客户端执行订单，按订单的钱包名切换签名钱包
+type ClientExecutor struct {
+	client *client.PumpSwapClient
+}
+
+func NewClientExecutor(c *client.PumpSwapClient) *ClientExecutor {
+	return &ClientExecutor{client: c}
+}
+
+func (e *ClientExecutor) HasWallet(wallet string) bool {
+	for _, name := range e.client.WalletNames() {
+		if name == wallet {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *ClientExecutor) PriceImpact(ctx context.Context, mint solana.PublicKey, side client.SwapSide, amount uint64) (float64, error) {
+	if side == client.SwapSideBuy {
+		_, q, err := e.client.QuoteBuy(ctx, mint, amount)
+		if err != nil {
+			return 0, err
+		}
+		return q.PriceImpact, nil
+	}
+	_, q, err := e.client.QuoteSell(ctx, mint, amount)
+	if err != nil {
+		return 0, err
+	}
+	return q.PriceImpact, nil
+}
+
+func (e *ClientExecutor) TokenBalance(ctx context.Context, wallet string, mint solana.PublicKey) (uint64, error) {
+	c, err := e.client.UseWallet(wallet)
+	if err != nil {
+		return 0, err
+	}
+	return c.TokenBalance(ctx, mint)
+}
+
+func (e *ClientExecutor) Swap(ctx context.Context, wallet string, mint solana.PublicKey, side client.SwapSide, amount uint64, slippageBP uint32) (*client.SwapResult, error) {
+	c, err := e.client.UseWallet(wallet)
+	if err != nil {
+		return nil, err
+	}
+	if c, err = c.WithSlippage(slippageBP); err != nil {
+		return nil, err
+	}
+	if side == client.SwapSideBuy {
+		return c.Buy(ctx, mint, amount, 0)
+	}
+	return c.Sell(ctx, mint, amount, 0)
+}
@@ -0,0 +1,173 @@
+package order
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Kind 订单类型，触发价均为代币的 USD 价格，与 consumer 推送的价格一致
+type Kind string
+
+const (
+	KindLimitBuy     Kind = "limit_buy"     // 价格不高于触发价时买入
+	KindLimitSell    Kind = "limit_sell"    // 价格不低于触发价时卖出
+	KindTakeProfit   Kind = "take_profit"   // 止盈：价格不低于触发价时卖出
+	KindStopLoss     Kind = "stop_loss"     // 止损：价格不高于触发价时卖出
+	KindTrailingStop Kind = "trailing_stop" // 跟踪止损：价格从最高点回落 TrailBP 时卖出；设置了触发价时，价格涨到触发价后才开始跟踪
+)
+
+// State 订单状态：pending → triggered → filled/failed，pending 的订单可以撤销
+type State string
+
+const (
+	StatePending   State = "pending"   // 等待触发
+	StateTriggered State = "triggered" // 已触发，正在执行
+	StateFilled    State = "filled"    // 已成交
+	StateFailed    State = "failed"    // 执行失败，不会重试
+	StateCancelled State = "cancelled" // 已撤销
+)
+
+// Closed 是否为终态
+func (s State) Closed() bool {
+	return s == StateFilled || s == StateFailed || s == StateCancelled
+}
+
+var (
+	ErrOrderNotFound = errors.New("order not found")
+	ErrOrderClosed   = errors.New("order already closed")
+)
+
+// Order 价格触发的订单，数量均为未除精度的原始数量
+type Order struct {
+	ID               string    `json:"id"`
+	Wallet           string    `json:"wallet"` // 下单的钱包名
+	Mint             string    `json:"mint"`
+	Kind             Kind      `json:"kind"`
+	TriggerPrice     float64   `json:"trigger_price"`                 // 触发价（USD），跟踪止损为激活价，0 表示立即开始跟踪
+	TrailBP          uint32    `json:"trail_bp,omitempty"`            // 跟踪止损的回撤比例，10000 = 100%
+	Amount           uint64    `json:"amount"`                        // 买入为花费的 lamports，卖出为代币数量，卖出时 0 表示全部持仓
+	SlippageBP       uint32    `json:"slippage_bp"`                   // 下单使用的滑点
+	MaxPriceImpactBP uint32    `json:"max_price_impact_bp,omitempty"` // 执行前报价的价格影响上限，0 表示不限制
+	State            State     `json:"state"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// 跟踪止损的状态，重启后继续跟踪
+	Activated bool    `json:"activated,omitempty"`
+	PeakPrice float64 `json:"peak_price,omitempty"`
+
+	// 触发和执行结果
+	TriggeredAt    time.Time `json:"triggered_at,omitzero"`
+	TriggeredPrice float64   `json:"triggered_price,omitempty"`
+	Signature      string    `json:"signature,omitempty"`
+	Simulated      bool      `json:"simulated,omitempty"` // dry-run 模式下只模拟了交易
+	BaseAmount     uint64    `json:"base_amount,omitempty"`
+	QuoteAmount    uint64    `json:"quote_amount,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// New 创建待触发的订单
+func New(wallet string, kind Kind, mint string, triggerPrice float64, amount uint64, slippageBP uint32) *Order {
+	now := time.Now()
+	return &Order{
+		ID:           newID(),
+		Wallet:       wallet,
+		Mint:         mint,
+		Kind:         kind,
+		TriggerPrice: triggerPrice,
+		Amount:       amount,
+		SlippageBP:   slippageBP,
+		State:        StatePending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+func (o *Order) RecordID() string           { return o.ID }
+func (o *Order) RecordCreatedAt() time.Time { return o.CreatedAt }
+func (o *Order) SetUpdatedAt(t time.Time)   { o.UpdatedAt = t }
+
+func newID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func (o *Order) Validate() error {
+	if o.Wallet == "" {
+		return errors.New("order wallet is required")
+	}
+	if _, err := solana.PublicKeyFromBase58(o.Mint); err != nil {
+		return fmt.Errorf("invalid order mint %s: %w", o.Mint, err)
+	}
+	if o.TriggerPrice < 0 {
+		return errors.New("trigger price must not be negative")
+	}
+	switch o.Kind {
+	case KindLimitBuy:
+		if o.Amount == 0 {
+			return errors.New("limit buy amount must be greater than 0")
+		}
+		fallthrough
+	case KindLimitSell, KindTakeProfit, KindStopLoss:
+		if o.TriggerPrice == 0 {
+			return fmt.Errorf("%s requires a trigger price", o.Kind)
+		}
+	case KindTrailingStop:
+		if o.TrailBP == 0 || o.TrailBP >= 10000 {
+			return errors.New("trailing stop trail bp must be between 1 and 9999")
+		}
+	default:
+		return fmt.Errorf("unsupported order kind %s", o.Kind)
+	}
+	if o.SlippageBP > 10000 || o.MaxPriceImpactBP > 10000 {
+		return errors.New("slippage and price impact bp must not exceed 10000")
+	}
+	return nil
+}
+
+// IsBuy 是否为买单
+func (o *Order) IsBuy() bool {
+	return o.Kind == KindLimitBuy
+}
+
+// Observe 用最新价格判断是否触发；跟踪止损同时更新激活状态和最高价，changed 表示这些状态有变化需要保存
+func (o *Order) Observe(price float64) (triggered, changed bool) {
+	if o.State != StatePending || price <= 0 {
+		return false, false
+	}
+	switch o.Kind {
+	case KindLimitBuy, KindStopLoss:
+		return price <= o.TriggerPrice, false
+	case KindLimitSell, KindTakeProfit:
+		return price >= o.TriggerPrice, false
+	case KindTrailingStop:
+		if !o.Activated {
+			if price < o.TriggerPrice {
+				return false, false
+			}
+			o.Activated = true
+			o.PeakPrice = price
+			return false, true
+		}
+		if price > o.PeakPrice {
+			o.PeakPrice = price
+			return false, true
+		}
+		return price <= o.StopPrice(), false
+	}
+	return false, false
+}
+
+// StopPrice 跟踪止损当前的止损价，未激活时为 0
+func (o *Order) StopPrice() float64 {
+	if o.Kind != KindTrailingStop || !o.Activated {
+		return 0
+	}
+	return o.PeakPrice * float64(10000-o.TrailBP) / 10000
+}
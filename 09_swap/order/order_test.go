@@ -0,0 +1,285 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"swap/client"
+	"swap/feed"
+	"swap/order/ordertest"
+
+	"github.com/sirupsen/logrus"
+)
+
+const testMint = "So11111111111111111111111111111111111111112"
+
+func TestObserve(t *testing.T) {
+	cases := []struct {
+		kind      Kind
+		trigger   float64
+		prices    []float64
+		triggered int // 第几个价格触发，-1 表示不触发
+	}{
+		{KindLimitBuy, 1.0, []float64{1.2, 1.05, 0.99}, 2},
+		{KindLimitSell, 2.0, []float64{1.5, 2.0}, 1},
+		{KindTakeProfit, 2.0, []float64{1.5, 1.9}, -1},
+		{KindStopLoss, 0.5, []float64{0.8, 0.6, 0.5}, 2},
+		// 激活价 1.0，回撤 10%：涨到 1.5 后止损价为 1.35
+		{KindTrailingStop, 1.0, []float64{0.9, 0.5, 1.2, 1.5, 1.4, 1.35}, 5},
+		// 未激活时下跌不触发
+		{KindTrailingStop, 1.0, []float64{0.9, 0.1}, -1},
+	}
+	for _, c := range cases {
+		o := New("main", c.kind, testMint, c.trigger, 1, 100)
+		o.TrailBP = 1000
+		got := -1
+		for i, price := range c.prices {
+			if triggered, _ := o.Observe(price); triggered {
+				got = i
+				break
+			}
+		}
+		if got != c.triggered {
+			t.Errorf("%s %v: triggered at %d, want %d", c.kind, c.prices, got, c.triggered)
+		}
+	}
+}
+
+func TestTrailingStopState(t *testing.T) {
+	o := New("main", KindTrailingStop, testMint, 0, 0, 100)
+	o.TrailBP = 2000
+	if _, changed := o.Observe(1.0); !changed || !o.Activated || o.PeakPrice != 1.0 {
+		t.Fatalf("without activation price should activate immediately: %+v", o)
+	}
+	if _, changed := o.Observe(0.9); changed {
+		t.Fatal("lower price should not change the peak")
+	}
+	if _, changed := o.Observe(2.0); !changed || o.StopPrice() != 1.6 {
+		t.Fatalf("stop price = %g, want 1.6", o.StopPrice())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := New("main", KindStopLoss, testMint, 0.5, 0, 100)
+	if err := valid.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	invalid := []*Order{
+		New("", KindStopLoss, testMint, 0.5, 0, 100),
+		New("main", KindStopLoss, "bad", 0.5, 0, 100),
+		New("main", KindStopLoss, testMint, 0, 0, 100),
+		New("main", KindLimitBuy, testMint, 0.5, 0, 100),
+		New("main", KindTrailingStop, testMint, 0, 0, 100),
+		New("main", "market", testMint, 0.5, 1, 100),
+	}
+	for _, o := range invalid {
+		if err := o.Validate(); err == nil {
+			t.Errorf("%+v should be invalid", o)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	store, err := OpenStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := New("main", KindTakeProfit, testMint, 2, 100, 100)
+	if err = store.Create(o); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Create(o); err == nil {
+		t.Fatal("creating the same order twice should fail")
+	}
+	loaded, err := store.Load(o.ID)
+	if err != nil || loaded.Kind != KindTakeProfit || loaded.State != StatePending {
+		t.Fatalf("load = %+v, %v", loaded, err)
+	}
+	if _, err = store.Load("../audit"); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("load outside store err = %v", err)
+	}
+
+	if _, err = store.RequestCancel(o.ID); err != nil || !store.CancelRequested(o.ID) {
+		t.Fatalf("request cancel err = %v", err)
+	}
+	loaded.State = StateFilled
+	if err = store.Save(loaded); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.RequestCancel(o.ID); !errors.Is(err, ErrOrderClosed) {
+		t.Fatalf("cancel filled order err = %v", err)
+	}
+
+	orders, err := store.List()
+	if err != nil || len(orders) != 1 || orders[0].State != StateFilled {
+		t.Fatalf("list = %v, %v", orders, err)
+	}
+	entries, err := store.AuditLog(o.ID)
+	if err != nil || len(entries) != 2 || entries[0].Event != "created" || entries[1].Event != "cancel_requested" {
+		t.Fatalf("audit = %+v, %v", entries, err)
+	}
+}
+
+type fakeFeed struct {
+	mu            sync.Mutex
+	subscriptions []feed.Subscription
+}
+
+func (f *fakeFeed) Subscribe(subscription feed.Subscription) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscriptions = append(f.subscriptions, subscription)
+	return nil
+}
+
+func (f *fakeFeed) Run(ctx context.Context, _ func(*feed.Event)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func newTestEngine(t *testing.T, executor Executor) (*Engine, *Store, *fakeFeed) {
+	store, err := OpenStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	engine := NewEngine(store, executor, DefaultEngineConfig(), logger)
+	return engine, store, &fakeFeed{}
+}
+
+func price(value float64) feed.Price {
+	return feed.Price{Token: testMint, Pair: "pair", PriceUSD: value, Source: feed.EventTrade}
+}
+
+func TestEngineExecute(t *testing.T) {
+	executor := &ordertest.Executor{Balance: 5000}
+	engine, store, source := newTestEngine(t, executor)
+
+	stopLoss := New("main", KindStopLoss, testMint, 0.5, 0, 300)
+	takeProfit := New("main", KindTakeProfit, testMint, 2, 1000, 100)
+	other := New("sniper", KindStopLoss, testMint, 0.5, 0, 100) // 钱包没有解锁
+	cancelled := New("main", KindLimitBuy, testMint, 0.4, 1000, 100)
+	for _, o := range []*Order{stopLoss, takeProfit, other, cancelled} {
+		if err := store.Create(o); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.RequestCancel(cancelled.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Scan(source); err != nil {
+		t.Fatal(err)
+	}
+	if len(source.subscriptions) != 1 || len(source.subscriptions[0].TokenAddresses) != 1 {
+		t.Fatalf("subscriptions = %+v", source.subscriptions)
+	}
+
+	ctx := context.Background()
+	engine.OnPrice(ctx, price(1))
+	engine.OnPrice(ctx, price(0.45))
+	engine.wg.Wait()
+
+	if len(executor.Swaps) != 1 {
+		t.Fatalf("swaps = %+v", executor.Swaps)
+	}
+	// 数量为 0 的止损卖出全部持仓，使用订单的滑点
+	if call := executor.Swaps[0]; call.Side != client.SwapSideSell || call.Amount != 5000 || call.SlippageBP != 300 {
+		t.Fatalf("swap = %+v", call)
+	}
+	states := map[string]State{stopLoss.ID: StateFilled, takeProfit.ID: StatePending, other.ID: StatePending, cancelled.ID: StateCancelled}
+	for id, want := range states {
+		o, err := store.Load(id)
+		if err != nil || o.State != want {
+			t.Errorf("order %s state = %s, want %s", id, o.State, want)
+		}
+	}
+	filled, _ := store.Load(stopLoss.ID)
+	if filled.Signature == "" || filled.TriggeredPrice != 0.45 {
+		t.Fatalf("filled order = %+v", filled)
+	}
+
+	entries, err := store.AuditLog(stopLoss.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []string
+	for _, entry := range entries {
+		events = append(events, entry.Event)
+	}
+	if len(events) != 3 || events[0] != "created" || events[1] != "triggered" || events[2] != "filled" {
+		t.Fatalf("audit events = %v", events)
+	}
+}
+
+func TestEngineGuards(t *testing.T) {
+	executor := &ordertest.Executor{Impact: 12.5}
+	engine, store, source := newTestEngine(t, executor)
+
+	guarded := New("main", KindLimitBuy, testMint, 1, 1000, 100)
+	guarded.MaxPriceImpactBP = 1000
+	if err := store.Create(guarded); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Scan(source); err != nil {
+		t.Fatal(err)
+	}
+	engine.OnPrice(context.Background(), price(0.9))
+	engine.wg.Wait()
+
+	o, _ := store.Load(guarded.ID)
+	if o.State != StateFailed || len(executor.Swaps) != 0 {
+		t.Fatalf("price impact guard: state %s, swaps %d", o.State, len(executor.Swaps))
+	}
+
+	// 执行中退出的订单重启后标记为失败，不会重复下单
+	interrupted := New("main", KindStopLoss, testMint, 1, 10, 100)
+	interrupted.State = StateTriggered
+	if err := store.Create(interrupted); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Recover(); err != nil {
+		t.Fatal(err)
+	}
+	if o, _ = store.Load(interrupted.ID); o.State != StateFailed {
+		t.Fatalf("interrupted order state = %s", o.State)
+	}
+}
+
+func TestEngineTrailingStopPersistsPeak(t *testing.T) {
+	executor := &ordertest.Executor{}
+	engine, store, source := newTestEngine(t, executor)
+
+	trailing := New("main", KindTrailingStop, testMint, 0, 100, 100)
+	trailing.TrailBP = 1000
+	if err := store.Create(trailing); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Scan(source); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	engine.OnPrice(ctx, price(1))
+	engine.OnPrice(ctx, price(2))
+	o, _ := store.Load(trailing.ID)
+	if !o.Activated || o.PeakPrice != 2 {
+		t.Fatalf("stored trailing state = %+v", o)
+	}
+
+	// 重启后从保存的最高价继续跟踪
+	restarted := NewEngine(store, executor, DefaultEngineConfig(), engine.logger)
+	if err := restarted.Scan(source); err != nil {
+		t.Fatal(err)
+	}
+	restarted.OnPrice(ctx, price(1.85))
+	if o, _ = store.Load(trailing.ID); o.State != StatePending {
+		t.Fatalf("price above stop should not trigger: %s", o.State)
+	}
+	restarted.OnPrice(ctx, price(1.75))
+	restarted.wg.Wait()
+	if o, _ = store.Load(trailing.ID); o.State != StateFilled {
+		t.Fatalf("trailing stop state = %s", o.State)
+	}
+}
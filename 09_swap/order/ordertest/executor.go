@@ -0,0 +1,67 @@
+// Package ordertest 提供订单、跟单、分批计划测试共用的 Executor
+package ordertest
+
+import (
+	"context"
+	"sync"
+
+	"swap/client"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// SwapCall 一次 Swap 调用的参数
+type SwapCall struct {
+	Wallet     string
+	Side       client.SwapSide
+	Amount     uint64
+	SlippageBP uint32
+}
+
+// Executor 不访问链的 Executor：只有名为 main 的钱包已解锁，按 1 lamport 兑换 10 个代币成交
+type Executor struct {
+	mu      sync.Mutex
+	Balance uint64    // TokenBalance 返回的代币余额
+	SOL     uint64    // Lamports 返回的 SOL 余额，跟单按比例计算仓位时使用
+	Impact  float64   // 报价的价格影响
+	Impacts []float64 // 依次返回的价格影响，用完后返回 Impact
+	Err     error     // 不为空时 Swap 返回此错误
+	Swaps   []SwapCall
+}
+
+func (f *Executor) HasWallet(wallet string) bool { return wallet == "main" }
+
+func (f *Executor) PriceImpact(context.Context, solana.PublicKey, client.SwapSide, uint64) (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.Impacts) == 0 {
+		return f.Impact, nil
+	}
+	impact := f.Impacts[0]
+	f.Impacts = f.Impacts[1:]
+	return impact, nil
+}
+
+func (f *Executor) TokenBalance(context.Context, string, solana.PublicKey) (uint64, error) {
+	return f.Balance, nil
+}
+
+func (f *Executor) Lamports(context.Context, string) (uint64, error) {
+	return f.SOL, nil
+}
+
+func (f *Executor) Swap(_ context.Context, wallet string, mint solana.PublicKey, side client.SwapSide, amount uint64, slippageBP uint32) (*client.SwapResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Swaps = append(f.Swaps, SwapCall{wallet, side, amount, slippageBP})
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &client.SwapResult{
+		TxResult:    client.TxResult{Signature: solana.Signature{1}},
+		Side:        side,
+		Mint:        mint,
+		BaseAmount:  amount * 10,
+		QuoteAmount: amount / 10,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package order
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"swap/filestore"
+)
+
+// AuditEntry 审计日志中的一条记录：订单状态变化、跟踪止损激活、撤单请求等
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	OrderID   string    `json:"order_id"`
+	Wallet    string    `json:"wallet"`
+	Event     string    `json:"event"` // created、activated、triggered、filled、failed、cancel_requested、cancelled
+	From      State     `json:"from,omitempty"`
+	To        State     `json:"to,omitempty"`
+	Price     float64   `json:"price,omitempty"` // 事件发生时的代币价格（USD）
+	Signature string    `json:"signature,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// Store 订单和审计日志的文件存储，下单、撤单的命令行和执行器可以是不同进程
+/*
+	<dir>/orders/<id>.json    每个订单一个文件，见 filestore.Store
+	<dir>/orders/<id>.cancel  撤单请求，由执行器处理
+	<dir>/audit.jsonl         审计日志，每行一条记录，只追加
+*/
+type Store struct {
+	dir    string
+	orders *filestore.Store[Order, *Order]
+	mu     sync.Mutex // 保护审计日志的追加
+}
+
+const (
+	ordersDir = "orders"
+	auditFile = "audit.jsonl"
+)
+
+// OpenStore 打开 dir 下的订单存储，目录不存在时创建
+func OpenStore(dir string) (*Store, error) {
+	orders, err := filestore.Open[Order](filepath.Join(dir, ordersDir), "order", ErrOrderNotFound)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{dir: dir, orders: orders}, nil
+}
+
+// Create 保存新订单并记录审计日志，ID 已存在时返回错误
+func (s *Store) Create(o *Order) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	if err := s.orders.Create(o); err != nil {
+		return err
+	}
+	return s.Audit(AuditEntry{OrderID: o.ID, Wallet: o.Wallet, Event: "created", To: o.State, Price: o.TriggerPrice,
+		Message: fmt.Sprintf("%s %s amount %d", o.Kind, o.Mint, o.Amount)})
+}
+
+// Save 覆盖保存订单
+func (s *Store) Save(o *Order) error {
+	return s.orders.Save(o)
+}
+
+// Load 读取订单
+func (s *Store) Load(id string) (*Order, error) {
+	return s.orders.Load(id)
+}
+
+// List 按创建时间列出全部订单
+func (s *Store) List() ([]*Order, error) {
+	return s.orders.List()
+}
+
+// RequestCancel 提交撤单请求，由执行器在下次扫描时撤销；已触发或已结束的订单不能撤销
+func (s *Store) RequestCancel(id string) (*Order, error) {
+	o, err := s.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if o.State != StatePending {
+		return o, fmt.Errorf("%w: %s is %s", ErrOrderClosed, id, o.State)
+	}
+	if err = s.orders.RequestCancel(id); err != nil {
+		return nil, err
+	}
+	return o, s.Audit(AuditEntry{OrderID: id, Wallet: o.Wallet, Event: "cancel_requested"})
+}
+
+// CancelRequested 是否有撤单请求
+func (s *Store) CancelRequested(id string) bool {
+	return s.orders.CancelRequested(id)
+}
+
+// clearCancel 删除已处理的撤单请求
+func (s *Store) clearCancel(id string) {
+	s.orders.ClearCancel(id)
+}
+
+// Audit 追加一条审计日志
+func (s *Store) Audit(entry AuditEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(filepath.Join(s.dir, auditFile), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+	// 一次写入整行，多个进程同时追加时不会交错
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return f.Sync()
+}
+
+// AuditLog 读取审计日志，orderID 为空时返回全部
+func (s *Store) AuditLog(orderID string) ([]AuditEntry, error) {
+	f, err := os.Open(filepath.Join(s.dir, auditFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("decode audit log: %w", err)
+		}
+		if orderID == "" || entry.OrderID == orderID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
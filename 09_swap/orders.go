@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"swap/client"
+	"swap/feed"
+	"swap/order"
+
+	"github.com/sirupsen/logrus"
+)
+
+// orderFlags order 命令的参数
+type orderFlags struct {
+	dir         string
+	feedURL     string
+	kind        string
+	mint        string
+	price       string
+	amount      string
+	trailBP     uint
+	maxImpactBP uint
+	all         bool
+}
+
+func registerOrderFlags(fs *flag.FlagSet) any {
+	f := &orderFlags{}
+	fs.StringVar(&f.dir, "orders", getEnvOrDefault("ORDERS_DIR", defaultOrdersDir()), "订单和审计日志目录（环境变量 ORDERS_DIR）")
+	fs.StringVar(&f.feedURL, "feed", os.Getenv("FEED_URL"), "consumer 的 WebSocket 推送地址，例如 ws://127.0.0.1:8081/ws（环境变量 FEED_URL）")
+	fs.StringVar(&f.kind, "type", "", "订单类型：limit_buy、limit_sell、take_profit、stop_loss、trailing_stop")
+	fs.StringVar(&f.mint, "mint", "", "代币地址")
+	fs.StringVar(&f.price, "price", "", "触发价（USD），跟踪止损为激活价，可以不填")
+	fs.StringVar(&f.amount, "amount", "0", "买入为花费的 lamports，卖出为代币数量（原始数量），卖出时 0 表示全部持仓")
+	fs.UintVar(&f.trailBP, "trail-bp", 0, "跟踪止损的回撤比例，10000 = 100%")
+	fs.UintVar(&f.maxImpactBP, "max-impact-bp", 0, "执行前报价的价格影响上限，0 表示不限制")
+	fs.BoolVar(&f.all, "all", false, "list 时包含已结束的订单")
+	return f
+}
+
+func defaultOrdersDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".pumpswap/orders"
+	}
+	return filepath.Join(home, ".pumpswap", "orders")
+}
+
+func runOrder(ctx context.Context, c *client.PumpSwapClient, p printer, args []string, flags any) error {
+	f := flags.(*orderFlags)
+	if len(args) == 0 {
+		return usageError("usage: order add|list|cancel <id>|log [id]|run")
+	}
+	store, err := order.OpenStore(f.dir)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "add":
+		return addOrder(c, p, store, f)
+	case "list":
+		orders, err := store.List()
+		if err != nil {
+			return err
+		}
+		shown := make([]*order.Order, 0, len(orders))
+		for _, o := range orders {
+			if f.all || !o.State.Closed() {
+				shown = append(shown, o)
+			}
+		}
+		p.result(shown, func() {
+			if len(shown) == 0 {
+				fmt.Println("📭 没有订单.")
+			}
+			for _, o := range shown {
+				printOrder(o)
+			}
+		})
+	case "cancel":
+		if len(args) != 2 {
+			return usageError("usage: order cancel <id>")
+		}
+		o, err := store.RequestCancel(args[1])
+		if err != nil {
+			return err
+		}
+		p.result(o, func() {
+			fmt.Printf("🛑 已提交撤单请求：%s，执行器下次扫描时撤销.\n", o.ID)
+		})
+	case "log":
+		id := ""
+		if len(args) > 1 {
+			id = args[1]
+		}
+		entries, err := store.AuditLog(id)
+		if err != nil {
+			return err
+		}
+		p.result(entries, func() {
+			for _, entry := range entries {
+				fmt.Printf("%s %s %-16s %s→%s price %g %s %s\n", entry.Time.Format("2006-01-02 15:04:05"), entry.OrderID,
+					entry.Event, entry.From, entry.To, entry.Price, entry.Signature, entry.Message)
+			}
+		})
+	case "run":
+		return runOrderEngine(ctx, c, p, store, f)
+	default:
+		return usageError("unsupported order command %s", args[0])
+	}
+	return nil
+}
+
+func addOrder(c *client.PumpSwapClient, p printer, store *order.Store, f *orderFlags) error {
+	var price float64
+	if f.price != "" {
+		var err error
+		if price, err = strconv.ParseFloat(f.price, 64); err != nil {
+			return usageError("invalid --price %s", f.price)
+		}
+	}
+	amount, err := parseUint("amount", f.amount)
+	if err != nil {
+		return err
+	}
+	o := order.New(c.Config().WalletName, order.Kind(f.kind), f.mint, price, amount, c.Config().SlippageBP)
+	o.TrailBP = uint32(f.trailBP)
+	o.MaxPriceImpactBP = uint32(f.maxImpactBP)
+	if err = o.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if err = store.Create(o); err != nil {
+		return err
+	}
+	p.result(o, func() {
+		fmt.Print("✅ 已下单：")
+		printOrder(o)
+	})
+	return nil
+}
+
+// runOrderEngine 运行订单执行器直到收到退出信号，正在执行的订单会等待完成
+func runOrderEngine(ctx context.Context, c *client.PumpSwapClient, p printer, store *order.Store, f *orderFlags) error {
+	if f.feedURL == "" {
+		return usageError("--feed is required")
+	}
+	// 执行器常驻运行，不受 --timeout 限制
+	ctx, stop := signal.NotifyContext(context.WithoutCancel(ctx), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := logrus.New()
+	if c.Config().EnableDebugLog {
+		logger.SetLevel(logrus.DebugLevel)
+	}
+	engine := order.NewEngine(store, order.NewClientExecutor(c), order.DefaultEngineConfig(), logger)
+	p.info("👀 订单执行器已启动，钱包：%v，行情：%s，订单目录：%s.\n", c.WalletNames(), f.feedURL, f.dir)
+	if c.Config().DryRun {
+		p.info("🧪 dry-run 模式：触发的订单只模拟不发送.\n")
+	}
+	err := engine.Run(ctx, feed.NewClient(f.feedURL, logger))
+	if errors.Is(err, context.Canceled) {
+		p.info("👋 订单执行器已退出.\n")
+		return nil
+	}
+	return err
+}
+
+func printOrder(o *order.Order) {
+	fmt.Printf("📝 %s [%s] %s %s 钱包 %s 触发价 %g 数量 %d", o.ID, o.State, o.Kind, o.Mint, o.Wallet, o.TriggerPrice, o.Amount)
+	if o.Kind == order.KindTrailingStop {
+		fmt.Printf(" 回撤 %d bp 最高价 %g", o.TrailBP, o.PeakPrice)
+	}
+	if o.Signature != "" {
+		fmt.Printf(" 交易 %s", o.Signature)
+	}
+	if o.Error != "" {
+		fmt.Printf(" 错误 %s", o.Error)
+	}
+	fmt.Println(".")
+}
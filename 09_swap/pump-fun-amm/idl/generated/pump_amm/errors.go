@@ -2,3 +2,166 @@
 // This file contains errors.
 
 package pump_amm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+type CustomError interface {
+	Code() int
+	Name() string
+	Error() string
+}
+
+type customErrorDef struct {
+	code int
+	name string
+	msg  string
+}
+
+func (e *customErrorDef) Code() int {
+	return e.code
+}
+
+func (e *customErrorDef) Name() string {
+	return e.name
+}
+
+func (e *customErrorDef) Error() string {
+	if e.msg == "" {
+		return fmt.Sprintf("%s(%d)", e.name, e.code)
+	}
+	return fmt.Sprintf("%s(%d): %s", e.name, e.code, e.msg)
+}
+
+var (
+	ErrFeeBasisPointsExceedsMaximum             = &customErrorDef{code: 6000, name: "FeeBasisPointsExceedsMaximum", msg: ""}
+	ErrZeroBaseAmount                           = &customErrorDef{code: 6001, name: "ZeroBaseAmount", msg: ""}
+	ErrZeroQuoteAmount                          = &customErrorDef{code: 6002, name: "ZeroQuoteAmount", msg: ""}
+	ErrTooLittlePoolTokenLiquidity              = &customErrorDef{code: 6003, name: "TooLittlePoolTokenLiquidity", msg: ""}
+	ErrExceededSlippage                         = &customErrorDef{code: 6004, name: "ExceededSlippage", msg: ""}
+	ErrInvalidAdmin                             = &customErrorDef{code: 6005, name: "InvalidAdmin", msg: ""}
+	ErrUnsupportedBaseMint                      = &customErrorDef{code: 6006, name: "UnsupportedBaseMint", msg: ""}
+	ErrUnsupportedQuoteMint                     = &customErrorDef{code: 6007, name: "UnsupportedQuoteMint", msg: ""}
+	ErrInvalidBaseMint                          = &customErrorDef{code: 6008, name: "InvalidBaseMint", msg: ""}
+	ErrInvalidQuoteMint                         = &customErrorDef{code: 6009, name: "InvalidQuoteMint", msg: ""}
+	ErrInvalidLpMint                            = &customErrorDef{code: 6010, name: "InvalidLpMint", msg: ""}
+	ErrAllProtocolFeeRecipientsShouldBeNonZero  = &customErrorDef{code: 6011, name: "AllProtocolFeeRecipientsShouldBeNonZero", msg: ""}
+	ErrUnsortedNotUniqueProtocolFeeRecipients   = &customErrorDef{code: 6012, name: "UnsortedNotUniqueProtocolFeeRecipients", msg: ""}
+	ErrInvalidProtocolFeeRecipient              = &customErrorDef{code: 6013, name: "InvalidProtocolFeeRecipient", msg: ""}
+	ErrInvalidPoolBaseTokenAccount              = &customErrorDef{code: 6014, name: "InvalidPoolBaseTokenAccount", msg: ""}
+	ErrInvalidPoolQuoteTokenAccount             = &customErrorDef{code: 6015, name: "InvalidPoolQuoteTokenAccount", msg: ""}
+	ErrBuyMoreBaseAmountThanPoolReserves        = &customErrorDef{code: 6016, name: "BuyMoreBaseAmountThanPoolReserves", msg: ""}
+	ErrDisabledCreatePool                       = &customErrorDef{code: 6017, name: "DisabledCreatePool", msg: ""}
+	ErrDisabledDeposit                          = &customErrorDef{code: 6018, name: "DisabledDeposit", msg: ""}
+	ErrDisabledWithdraw                         = &customErrorDef{code: 6019, name: "DisabledWithdraw", msg: ""}
+	ErrDisabledBuy                              = &customErrorDef{code: 6020, name: "DisabledBuy", msg: ""}
+	ErrDisabledSell                             = &customErrorDef{code: 6021, name: "DisabledSell", msg: ""}
+	ErrSameMint                                 = &customErrorDef{code: 6022, name: "SameMint", msg: ""}
+	ErrOverflow                                 = &customErrorDef{code: 6023, name: "Overflow", msg: ""}
+	ErrTruncation                               = &customErrorDef{code: 6024, name: "Truncation", msg: ""}
+	ErrDivisionByZero                           = &customErrorDef{code: 6025, name: "DivisionByZero", msg: ""}
+	ErrNewSizeLessThanCurrentSize               = &customErrorDef{code: 6026, name: "NewSizeLessThanCurrentSize", msg: ""}
+	ErrAccountTypeNotSupported                  = &customErrorDef{code: 6027, name: "AccountTypeNotSupported", msg: ""}
+	ErrOnlyCanonicalPumpPoolsCanHaveCoinCreator = &customErrorDef{code: 6028, name: "OnlyCanonicalPumpPoolsCanHaveCoinCreator", msg: ""}
+	ErrInvalidAdminSetCoinCreatorAuthority      = &customErrorDef{code: 6029, name: "InvalidAdminSetCoinCreatorAuthority", msg: ""}
+	ErrStartTimeInThePast                       = &customErrorDef{code: 6030, name: "StartTimeInThePast", msg: ""}
+	ErrEndTimeInThePast                         = &customErrorDef{code: 6031, name: "EndTimeInThePast", msg: ""}
+	ErrEndTimeBeforeStartTime                   = &customErrorDef{code: 6032, name: "EndTimeBeforeStartTime", msg: ""}
+	ErrTimeRangeTooLarge                        = &customErrorDef{code: 6033, name: "TimeRangeTooLarge", msg: ""}
+	ErrEndTimeBeforeCurrentDay                  = &customErrorDef{code: 6034, name: "EndTimeBeforeCurrentDay", msg: ""}
+	ErrSupplyUpdateForFinishedRange             = &customErrorDef{code: 6035, name: "SupplyUpdateForFinishedRange", msg: ""}
+	ErrDayIndexAfterEndIndex                    = &customErrorDef{code: 6036, name: "DayIndexAfterEndIndex", msg: ""}
+	ErrDayInActiveRange                         = &customErrorDef{code: 6037, name: "DayInActiveRange", msg: ""}
+	ErrInvalidIncentiveMint                     = &customErrorDef{code: 6038, name: "InvalidIncentiveMint", msg: ""}
+	ErrBuyNotEnoughQuoteTokensToCoverFees       = &customErrorDef{code: 6039, name: "BuyNotEnoughQuoteTokensToCoverFees", msg: "buy: Not enough quote tokens to cover for fees."}
+	ErrBuySlippageBelowMinBaseAmountOut         = &customErrorDef{code: 6040, name: "BuySlippageBelowMinBaseAmountOut", msg: "buy: slippage - would buy less tokens than expected min_base_amount_out"}
+)
+
+var Errors = map[int]CustomError{
+	6000: ErrFeeBasisPointsExceedsMaximum,
+	6001: ErrZeroBaseAmount,
+	6002: ErrZeroQuoteAmount,
+	6003: ErrTooLittlePoolTokenLiquidity,
+	6004: ErrExceededSlippage,
+	6005: ErrInvalidAdmin,
+	6006: ErrUnsupportedBaseMint,
+	6007: ErrUnsupportedQuoteMint,
+	6008: ErrInvalidBaseMint,
+	6009: ErrInvalidQuoteMint,
+	6010: ErrInvalidLpMint,
+	6011: ErrAllProtocolFeeRecipientsShouldBeNonZero,
+	6012: ErrUnsortedNotUniqueProtocolFeeRecipients,
+	6013: ErrInvalidProtocolFeeRecipient,
+	6014: ErrInvalidPoolBaseTokenAccount,
+	6015: ErrInvalidPoolQuoteTokenAccount,
+	6016: ErrBuyMoreBaseAmountThanPoolReserves,
+	6017: ErrDisabledCreatePool,
+	6018: ErrDisabledDeposit,
+	6019: ErrDisabledWithdraw,
+	6020: ErrDisabledBuy,
+	6021: ErrDisabledSell,
+	6022: ErrSameMint,
+	6023: ErrOverflow,
+	6024: ErrTruncation,
+	6025: ErrDivisionByZero,
+	6026: ErrNewSizeLessThanCurrentSize,
+	6027: ErrAccountTypeNotSupported,
+	6028: ErrOnlyCanonicalPumpPoolsCanHaveCoinCreator,
+	6029: ErrInvalidAdminSetCoinCreatorAuthority,
+	6030: ErrStartTimeInThePast,
+	6031: ErrEndTimeInThePast,
+	6032: ErrEndTimeBeforeStartTime,
+	6033: ErrTimeRangeTooLarge,
+	6034: ErrEndTimeBeforeCurrentDay,
+	6035: ErrSupplyUpdateForFinishedRange,
+	6036: ErrDayIndexAfterEndIndex,
+	6037: ErrDayInActiveRange,
+	6038: ErrInvalidIncentiveMint,
+	6039: ErrBuyNotEnoughQuoteTokensToCoverFees,
+	6040: ErrBuySlippageBelowMinBaseAmountOut,
+}
+
+// DecodeCustomError returns the program error carried by an RPC error, if any.
+func DecodeCustomError(rpcErr error) (err error, ok bool) {
+	if errCode, o := decodeErrorCode(rpcErr); o {
+		if customErr, o := Errors[errCode]; o {
+			err = customErr
+			ok = true
+			return
+		}
+	}
+	return
+}
+
+func decodeErrorCode(rpcErr error) (errorCode int, ok bool) {
+	var jErr *jsonrpc.RPCError
+	if errors.As(rpcErr, &jErr) && jErr.Data != nil {
+		if root, o := jErr.Data.(map[string]any); o {
+			if rootErr, o := root["err"].(map[string]any); o {
+				if rootErrInstructionError, o := rootErr["InstructionError"]; o {
+					if rootErrInstructionErrorItems, o := rootErrInstructionError.([]any); o {
+						if len(rootErrInstructionErrorItems) == 2 {
+							if v, o := rootErrInstructionErrorItems[1].(map[string]any); o {
+								if v2, o := v["Custom"].(json.Number); o {
+									if code, err := v2.Int64(); err == nil {
+										ok = true
+										errorCode = int(code)
+									}
+								} else if v2, o := v["Custom"].(float64); o {
+									ok = true
+									errorCode = int(v2)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,51 @@
+package quote
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"swap/config"
+)
+
+var (
+	ErrSlippageTooLarge = errors.New("slippage exceeds max slippage")
+	ErrAmountTooSmall   = errors.New("amount is below min amount threshold")
+	ErrAmountTooLarge   = errors.New("amount is above max amount threshold")
+)
+
+// CheckSlippage 滑点不能超过 SwapConfig.MaxSlippageBP
+func CheckSlippage(cfg *config.SwapConfig, slippageBps uint32) error {
+	if slippageBps > cfg.MaxSlippageBP {
+		return fmt.Errorf("%w: %d > %d bps", ErrSlippageTooLarge, slippageBps, cfg.MaxSlippageBP)
+	}
+	return nil
+}
+
+// CheckAmount 交易的 SOL 数量（lamports）需要在 SwapConfig.MinAmountThreshold 和 MaxAmountThreshold 之间，阈值为 nil 表示不限制
+func CheckAmount(cfg *config.SwapConfig, quoteAmount uint64) error {
+	amount := new(big.Int).SetUint64(quoteAmount)
+	if cfg.MinAmountThreshold != nil && amount.Cmp(cfg.MinAmountThreshold) < 0 {
+		return fmt.Errorf("%w: %d < %s", ErrAmountTooSmall, quoteAmount, cfg.MinAmountThreshold)
+	}
+	if cfg.MaxAmountThreshold != nil && amount.Cmp(cfg.MaxAmountThreshold) > 0 {
+		return fmt.Errorf("%w: %d > %s", ErrAmountTooLarge, quoteAmount, cfg.MaxAmountThreshold)
+	}
+	return nil
+}
+
+// WithSlippageUp 按滑点放大数量，向上取整，用于最多支付金额
+func WithSlippageUp(amount uint64, slippageBps uint32) uint64 {
+	v := ceilDiv(mul(amount, FeeDenominator+uint64(slippageBps)), u(FeeDenominator))
+	if !v.IsUint64() {
+		return ^uint64(0)
+	}
+	return v.Uint64()
+}
+
+// WithSlippageDown 按滑点缩小数量，向下取整，用于最少得到数量
+func WithSlippageDown(amount uint64, slippageBps uint32) uint64 {
+	if uint64(slippageBps) >= FeeDenominator {
+		return 0
+	}
+	return new(big.Int).Div(mul(amount, FeeDenominator-uint64(slippageBps)), u(FeeDenominator)).Uint64()
+}
@@ -0,0 +1,275 @@
+package quote
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	pump_amm "github.com/gagliardetto/anchor-go/generated"
+	"github.com/gagliardetto/solana-go"
+)
+
+// FeeDenominator 手续费比例的分母：1 bp = 1/10000
+const FeeDenominator = 10_000
+
+var (
+	ErrZeroAmount          = errors.New("amount must be greater than 0")
+	ErrInsufficientReserve = errors.New("amount exceeds pool reserves")
+	ErrFeesExceedAmount    = errors.New("amount is not enough to cover fees")
+)
+
+// Fees 一笔交易适用的手续费比例（bp）
+type Fees struct {
+	LpFeeBps       uint64 `json:"lp_fee_bps"`
+	ProtocolFeeBps uint64 `json:"protocol_fee_bps"`
+	CreatorFeeBps  uint64 `json:"creator_fee_bps"`
+}
+
+// PoolState 报价所需的池子状态，数量均为未除精度的原始数量
+type PoolState struct {
+	BaseReserves   uint64           // base（代币）金库余额
+	QuoteReserves  uint64           // quote（SOL）金库余额
+	CoinCreator    solana.PublicKey // 零地址表示池子没有 coin creator，不收创建者手续费
+	BaseMintSupply uint64           // 代币总供应量，用于计算市值选择手续费档位
+	Canonical      bool             // 是否为内盘毕业迁移创建的池子，只有这类池子使用市值分档手续费
+}
+
+// MarketCap 以 quote 计价的市值（lamports）：quote 储备 × 代币总供应量 ÷ base 储备
+func (s *PoolState) MarketCap() *big.Int {
+	if s.BaseReserves == 0 {
+		return new(big.Int)
+	}
+	marketCap := new(big.Int).Mul(u(s.QuoteReserves), u(s.BaseMintSupply))
+	return marketCap.Div(marketCap, u(s.BaseReserves))
+}
+
+// ComputeFees 按链上规则选择手续费比例：
+// 没有 FeeConfig 时使用 GlobalConfig 中的比例；有 FeeConfig 时迁移池子按市值选择档位，其他池子使用固定比例
+func ComputeFees(globalConfig *pump_amm.GlobalConfig, feeConfig *pump_amm.FeeConfig, state *PoolState) Fees {
+	if feeConfig == nil {
+		return Fees{
+			LpFeeBps:       globalConfig.LpFeeBasisPoints,
+			ProtocolFeeBps: globalConfig.ProtocolFeeBasisPoints,
+			CreatorFeeBps:  globalConfig.CoinCreatorFeeBasisPoints,
+		}
+	}
+	if !state.Canonical {
+		return fromFeeConfig(feeConfig.FlatFees)
+	}
+	return fromFeeConfig(feeTier(feeConfig.FeeTiers, feeConfig.FlatFees, state.MarketCap()))
+}
+
+// feeTier 取市值不低于门槛的最高档位，低于第一档门槛时使用第一档
+func feeTier(tiers []pump_amm.FeeTier, flat pump_amm.Fees, marketCap *big.Int) pump_amm.Fees {
+	if len(tiers) == 0 {
+		return flat
+	}
+	for i := len(tiers) - 1; i >= 0; i-- {
+		if marketCap.Cmp(tiers[i].MarketCapLamportsThreshold.BigInt()) >= 0 {
+			return tiers[i].Fees
+		}
+	}
+	return tiers[0].Fees
+}
+
+func fromFeeConfig(fees pump_amm.Fees) Fees {
+	return Fees{
+		LpFeeBps:       fees.LpFeeBps,
+		ProtocolFeeBps: fees.ProtocolFeeBps,
+		CreatorFeeBps:  fees.CreatorFeeBps,
+	}
+}
+
+// BuyQuote 买入报价，与链上 BuyEvent 的字段一一对应
+type BuyQuote struct {
+	BaseAmountOut          uint64  `json:"base_amount_out"`
+	QuoteAmountIn          uint64  `json:"quote_amount_in"` // 不含手续费
+	LpFee                  uint64  `json:"lp_fee"`
+	ProtocolFee            uint64  `json:"protocol_fee"`
+	CoinCreatorFee         uint64  `json:"coin_creator_fee"`
+	QuoteAmountInWithLpFee uint64  `json:"quote_amount_in_with_lp_fee"`
+	UserQuoteAmountIn      uint64  `json:"user_quote_amount_in"` // 用户实际支付，含全部手续费
+	PriceImpact            float64 `json:"price_impact"`         // 成交均价相对现价的偏离，百分比
+}
+
+// SellQuote 卖出报价，与链上 SellEvent 的字段一一对应
+type SellQuote struct {
+	BaseAmountIn               uint64  `json:"base_amount_in"`
+	QuoteAmountOut             uint64  `json:"quote_amount_out"` // 扣除手续费前
+	LpFee                      uint64  `json:"lp_fee"`
+	ProtocolFee                uint64  `json:"protocol_fee"`
+	CoinCreatorFee             uint64  `json:"coin_creator_fee"`
+	QuoteAmountOutWithoutLpFee uint64  `json:"quote_amount_out_without_lp_fee"`
+	UserQuoteAmountOut         uint64  `json:"user_quote_amount_out"` // 用户实际得到，扣除全部手续费
+	PriceImpact                float64 `json:"price_impact"`          // 成交均价相对现价的偏离，百分比
+}
+
+// BuyBaseOut 买入 baseOut 个代币需要支付的 quote，与链上 buy 指令的计算一致：
+// quote = ceil(quote 储备 × baseOut ÷ (base 储备 − baseOut))，三项手续费分别按 quote 向上取整
+func BuyBaseOut(state *PoolState, fees Fees, baseOut uint64) (*BuyQuote, error) {
+	if baseOut == 0 {
+		return nil, ErrZeroAmount
+	}
+	if baseOut >= state.BaseReserves {
+		return nil, fmt.Errorf("%w: base out %d, base reserves %d", ErrInsufficientReserve, baseOut, state.BaseReserves)
+	}
+	quoteIn := ceilDiv(mul(state.QuoteReserves, baseOut), u(state.BaseReserves-baseOut))
+	if !quoteIn.IsUint64() {
+		return nil, fmt.Errorf("quote amount in overflows: %s", quoteIn)
+	}
+
+	q := &BuyQuote{
+		BaseAmountOut: baseOut,
+		QuoteAmountIn: quoteIn.Uint64(),
+	}
+	q.LpFee = fee(q.QuoteAmountIn, fees.LpFeeBps)
+	q.ProtocolFee = fee(q.QuoteAmountIn, fees.ProtocolFeeBps)
+	if !state.CoinCreator.IsZero() {
+		q.CoinCreatorFee = fee(q.QuoteAmountIn, fees.CreatorFeeBps)
+	}
+	q.QuoteAmountInWithLpFee = q.QuoteAmountIn + q.LpFee
+	q.UserQuoteAmountIn = q.QuoteAmountInWithLpFee + q.ProtocolFee + q.CoinCreatorFee
+	q.PriceImpact = priceImpact(state, baseOut, q.QuoteAmountIn)
+	return q, nil
+}
+
+// BuyQuoteIn 支付不超过 quoteIn（含手续费）时最多能买到的代币数量：
+// 不考虑取整时 baseOut ≤ base 储备 × quoteIn × 10000 ÷ (quote 储备 × (10000 + 总费率) + quoteIn × 10000)，
+// 以此为上界用 BuyBaseOut 二分，保证链上取整后的支付金额不超过 quoteIn
+func BuyQuoteIn(state *PoolState, fees Fees, quoteIn uint64) (*BuyQuote, error) {
+	if quoteIn == 0 {
+		return nil, ErrZeroAmount
+	}
+	if state.BaseReserves <= 1 || state.QuoteReserves == 0 {
+		return nil, ErrInsufficientReserve
+	}
+
+	totalFeeBps := fees.LpFeeBps + fees.ProtocolFeeBps
+	if !state.CoinCreator.IsZero() {
+		totalFeeBps += fees.CreatorFeeBps
+	}
+	numerator := new(big.Int).Mul(u(state.BaseReserves), mul(quoteIn, FeeDenominator))
+	denominator := new(big.Int).Add(mul(state.QuoteReserves, FeeDenominator+totalFeeBps), mul(quoteIn, FeeDenominator))
+	bound := numerator.Div(numerator, denominator)
+
+	fits := func(baseOut uint64) bool {
+		q, err := BuyBaseOut(state, fees, baseOut)
+		return err == nil && q.UserQuoteAmountIn <= quoteIn
+	}
+	// 二分：lo 满足约束，hi 不满足
+	hi := min(bound.Uint64(), state.BaseReserves-1)
+	if hi > 0 && fits(hi) {
+		return BuyBaseOut(state, fees, hi)
+	}
+	lo := uint64(0)
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		if fits(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	if lo == 0 {
+		return nil, fmt.Errorf("%w: quote in %d", ErrFeesExceedAmount, quoteIn)
+	}
+	return BuyBaseOut(state, fees, lo)
+}
+
+// SellBaseIn 卖出 baseIn 个代币得到的 quote，与链上 sell 指令的计算一致：
+// quote = floor(quote 储备 × baseIn ÷ (base 储备 + baseIn))，三项手续费分别按 quote 向上取整后扣除
+func SellBaseIn(state *PoolState, fees Fees, baseIn uint64) (*SellQuote, error) {
+	if baseIn == 0 {
+		return nil, ErrZeroAmount
+	}
+	if state.BaseReserves == 0 || state.QuoteReserves == 0 {
+		return nil, ErrInsufficientReserve
+	}
+	quoteOut := new(big.Int).Div(mul(state.QuoteReserves, baseIn), new(big.Int).Add(u(state.BaseReserves), u(baseIn)))
+
+	q := &SellQuote{
+		BaseAmountIn:   baseIn,
+		QuoteAmountOut: quoteOut.Uint64(),
+	}
+	q.LpFee = fee(q.QuoteAmountOut, fees.LpFeeBps)
+	q.ProtocolFee = fee(q.QuoteAmountOut, fees.ProtocolFeeBps)
+	if !state.CoinCreator.IsZero() {
+		q.CoinCreatorFee = fee(q.QuoteAmountOut, fees.CreatorFeeBps)
+	}
+	totalFee := q.LpFee + q.ProtocolFee + q.CoinCreatorFee
+	if totalFee > q.QuoteAmountOut {
+		return nil, fmt.Errorf("%w: base in %d", ErrFeesExceedAmount, baseIn)
+	}
+	q.QuoteAmountOutWithoutLpFee = q.QuoteAmountOut - q.LpFee
+	q.UserQuoteAmountOut = q.QuoteAmountOut - totalFee
+	q.PriceImpact = priceImpact(state, baseIn, q.QuoteAmountOut)
+	return q, nil
+}
+
+// SellQuoteOut 扣除手续费后至少得到 quoteOut 需要卖出的最少代币数量
+func SellQuoteOut(state *PoolState, fees Fees, quoteOut uint64) (*SellQuote, error) {
+	if quoteOut == 0 {
+		return nil, ErrZeroAmount
+	}
+	if quoteOut >= state.QuoteReserves {
+		return nil, fmt.Errorf("%w: quote out %d, quote reserves %d", ErrInsufficientReserve, quoteOut, state.QuoteReserves)
+	}
+
+	enough := func(baseIn uint64) bool {
+		q, err := SellBaseIn(state, fees, baseIn)
+		return err == nil && q.UserQuoteAmountOut >= quoteOut
+	}
+	// 倍增找到满足条件的上界，再二分找最小值
+	hi := uint64(1)
+	for !enough(hi) {
+		if hi > (1<<63)/2 {
+			return nil, fmt.Errorf("%w: quote out %d", ErrInsufficientReserve, quoteOut)
+		}
+		hi *= 2
+	}
+	lo := hi / 2
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		if enough(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return SellBaseIn(state, fees, hi)
+}
+
+// priceImpact 成交均价（不含手续费）相对现价的偏离百分比
+func priceImpact(state *PoolState, base, quote uint64) float64 {
+	if base == 0 || state.BaseReserves == 0 || state.QuoteReserves == 0 {
+		return 0
+	}
+	spot := float64(state.QuoteReserves) / float64(state.BaseReserves)
+	exec := float64(quote) / float64(base)
+	impact := (exec - spot) / spot * 100
+	if impact < 0 {
+		impact = -impact
+	}
+	return impact
+}
+
+// fee 按 bp 计算手续费，向上取整
+func fee(amount, bps uint64) uint64 {
+	return ceilDiv(mul(amount, bps), u(FeeDenominator)).Uint64()
+}
+
+func u(v uint64) *big.Int {
+	return new(big.Int).SetUint64(v)
+}
+
+func mul(a, b uint64) *big.Int {
+	return new(big.Int).Mul(u(a), u(b))
+}
+
+func ceilDiv(a, b *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	if r.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
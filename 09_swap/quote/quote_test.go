@@ -0,0 +1,273 @@
+package quote
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"swap/config"
+	"testing"
+
+	pump_amm "github.com/gagliardetto/anchor-go/generated"
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// eventCase testdata/events.json 中的一条用例：主网交易中记录的 BuyEvent/SellEvent，
+// event 与 ParseEvent_BuyEvent/ParseEvent_SellEvent 解析结果的 json 一致，signature 和 slot 用于追溯来源
+type eventCase struct {
+	Name      string          `json:"name"`
+	Type      string          `json:"type"`
+	Signature string          `json:"signature"`
+	Slot      uint64          `json:"slot,omitempty"`
+	Note      string          `json:"note,omitempty"`
+	Event     json.RawMessage `json:"event"`
+}
+
+func loadEventCases(t *testing.T) []eventCase {
+	data, err := os.ReadFile("testdata/events.json")
+	if err != nil {
+		t.Fatalf("read events: %v", err)
+	}
+	var cases []eventCase
+	if err = json.Unmarshal(data, &cases); err != nil {
+		t.Fatalf("unmarshal events: %v", err)
+	}
+	return cases
+}
+
+func TestQuoteMatchesEvents(t *testing.T) {
+	for _, c := range loadEventCases(t) {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Signature == "" {
+				t.Fatal("event case must record the transaction signature")
+			}
+			switch c.Type {
+			case "buy":
+				var e pump_amm.BuyEvent
+				if err := json.Unmarshal(c.Event, &e); err != nil {
+					t.Fatalf("unmarshal buy event: %v", err)
+				}
+				state := &PoolState{BaseReserves: e.PoolBaseTokenReserves, QuoteReserves: e.PoolQuoteTokenReserves, CoinCreator: e.CoinCreator}
+				fees := Fees{LpFeeBps: e.LpFeeBasisPoints, ProtocolFeeBps: e.ProtocolFeeBasisPoints, CreatorFeeBps: e.CoinCreatorFeeBasisPoints}
+				q, err := BuyBaseOut(state, fees, e.BaseAmountOut)
+				if err != nil {
+					t.Fatalf("BuyBaseOut: %v", err)
+				}
+				want := BuyQuote{
+					BaseAmountOut:          e.BaseAmountOut,
+					QuoteAmountIn:          e.QuoteAmountIn,
+					LpFee:                  e.LpFee,
+					ProtocolFee:            e.ProtocolFee,
+					CoinCreatorFee:         e.CoinCreatorFee,
+					QuoteAmountInWithLpFee: e.QuoteAmountInWithLpFee,
+					UserQuoteAmountIn:      e.UserQuoteAmountIn,
+					PriceImpact:            q.PriceImpact,
+				}
+				if *q != want {
+					t.Fatalf("buy quote mismatch\n got: %+v\nwant: %+v", *q, want)
+				}
+
+				// 花费事件中的实际支付金额，最多能买到的数量不少于事件中的数量
+				byQuote, err := BuyQuoteIn(state, fees, e.UserQuoteAmountIn)
+				if err != nil {
+					t.Fatalf("BuyQuoteIn: %v", err)
+				}
+				if byQuote.BaseAmountOut < e.BaseAmountOut || byQuote.UserQuoteAmountIn > e.UserQuoteAmountIn {
+					t.Fatalf("BuyQuoteIn(%d) = %+v, want base out >= %d", e.UserQuoteAmountIn, *byQuote, e.BaseAmountOut)
+				}
+			case "sell":
+				var e pump_amm.SellEvent
+				if err := json.Unmarshal(c.Event, &e); err != nil {
+					t.Fatalf("unmarshal sell event: %v", err)
+				}
+				state := &PoolState{BaseReserves: e.PoolBaseTokenReserves, QuoteReserves: e.PoolQuoteTokenReserves, CoinCreator: e.CoinCreator}
+				fees := Fees{LpFeeBps: e.LpFeeBasisPoints, ProtocolFeeBps: e.ProtocolFeeBasisPoints, CreatorFeeBps: e.CoinCreatorFeeBasisPoints}
+				q, err := SellBaseIn(state, fees, e.BaseAmountIn)
+				if err != nil {
+					t.Fatalf("SellBaseIn: %v", err)
+				}
+				want := SellQuote{
+					BaseAmountIn:               e.BaseAmountIn,
+					QuoteAmountOut:             e.QuoteAmountOut,
+					LpFee:                      e.LpFee,
+					ProtocolFee:                e.ProtocolFee,
+					CoinCreatorFee:             e.CoinCreatorFee,
+					QuoteAmountOutWithoutLpFee: e.QuoteAmountOutWithoutLpFee,
+					UserQuoteAmountOut:         e.UserQuoteAmountOut,
+					PriceImpact:                q.PriceImpact,
+				}
+				if *q != want {
+					t.Fatalf("sell quote mismatch\n got: %+v\nwant: %+v", *q, want)
+				}
+
+				// 想得到事件中的实际到账金额，需要卖出的最少数量不超过事件中的数量
+				if e.UserQuoteAmountOut == 0 {
+					return
+				}
+				byQuote, err := SellQuoteOut(state, fees, e.UserQuoteAmountOut)
+				if err != nil {
+					t.Fatalf("SellQuoteOut: %v", err)
+				}
+				if byQuote.BaseAmountIn > e.BaseAmountIn || byQuote.UserQuoteAmountOut < e.UserQuoteAmountOut {
+					t.Fatalf("SellQuoteOut(%d) = %+v, want base in <= %d", e.UserQuoteAmountOut, *byQuote, e.BaseAmountIn)
+				}
+			default:
+				t.Fatalf("unknown event type %q", c.Type)
+			}
+		})
+	}
+}
+
+func TestBuyQuoteInIsExact(t *testing.T) {
+	creator := solana.MustPublicKeyFromBase58("bPBZwKdrKyA8fKdRNzupsNyWTHrYNXFsFKcDaVPoQYF")
+	fees := Fees{LpFeeBps: 20, ProtocolFeeBps: 5, CreatorFeeBps: 5}
+	tests := []struct {
+		name    string
+		state   PoolState
+		quoteIn uint64
+	}{
+		{"cheap token", PoolState{BaseReserves: 987_654_321_000_000, QuoteReserves: 40_123_456_789, CoinCreator: creator}, 1_000_000_000},
+		{"expensive token", PoolState{BaseReserves: 1_000_000, QuoteReserves: 500_000_000_000}, 10_000_000_000},
+		{"tiny spend", PoolState{BaseReserves: 206_900_000_000_000, QuoteReserves: 84_990_000_000, CoinCreator: creator}, 7},
+		{"spend more than reserves", PoolState{BaseReserves: 206_900_000_000_000, QuoteReserves: 84_990_000_000, CoinCreator: creator}, 500_000_000_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := BuyQuoteIn(&tt.state, fees, tt.quoteIn)
+			if err != nil {
+				t.Fatalf("BuyQuoteIn: %v", err)
+			}
+			if q.UserQuoteAmountIn > tt.quoteIn {
+				t.Fatalf("user quote in %d exceeds %d", q.UserQuoteAmountIn, tt.quoteIn)
+			}
+			// 多买一个单位就会超出预算
+			if next, err := BuyBaseOut(&tt.state, fees, q.BaseAmountOut+1); err == nil && next.UserQuoteAmountIn <= tt.quoteIn {
+				t.Fatalf("base out %d is not the maximum, %d also fits", q.BaseAmountOut, q.BaseAmountOut+1)
+			}
+		})
+	}
+
+	if _, err := BuyQuoteIn(&PoolState{BaseReserves: 1_000_000, QuoteReserves: 500_000_000_000}, fees, 1); !errors.Is(err, ErrFeesExceedAmount) {
+		t.Fatalf("BuyQuoteIn with dust: got %v, want ErrFeesExceedAmount", err)
+	}
+}
+
+func TestSellQuoteOutIsMinimal(t *testing.T) {
+	fees := Fees{LpFeeBps: 20, ProtocolFeeBps: 5, CreatorFeeBps: 5}
+	state := &PoolState{BaseReserves: 206_900_000_000_000, QuoteReserves: 84_990_000_000, CoinCreator: solana.MustPublicKeyFromBase58("bPBZwKdrKyA8fKdRNzupsNyWTHrYNXFsFKcDaVPoQYF")}
+	for _, quoteOut := range []uint64{1, 999, 1_000_000_000, 42_000_000_000} {
+		q, err := SellQuoteOut(state, fees, quoteOut)
+		if err != nil {
+			t.Fatalf("SellQuoteOut(%d): %v", quoteOut, err)
+		}
+		if q.UserQuoteAmountOut < quoteOut {
+			t.Fatalf("SellQuoteOut(%d) user quote out %d", quoteOut, q.UserQuoteAmountOut)
+		}
+		if prev, err := SellBaseIn(state, fees, q.BaseAmountIn-1); err == nil && prev.UserQuoteAmountOut >= quoteOut {
+			t.Fatalf("SellQuoteOut(%d) base in %d is not the minimum", quoteOut, q.BaseAmountIn)
+		}
+	}
+	if _, err := SellQuoteOut(state, fees, state.QuoteReserves); !errors.Is(err, ErrInsufficientReserve) {
+		t.Fatalf("SellQuoteOut all reserves: got %v, want ErrInsufficientReserve", err)
+	}
+}
+
+func TestComputeFees(t *testing.T) {
+	tier := func(threshold uint64, lp, protocol, creator uint64) pump_amm.FeeTier {
+		return pump_amm.FeeTier{
+			MarketCapLamportsThreshold: bin.Uint128{Lo: threshold},
+			Fees:                       pump_amm.Fees{LpFeeBps: lp, ProtocolFeeBps: protocol, CreatorFeeBps: creator},
+		}
+	}
+	globalConfig := &pump_amm.GlobalConfig{LpFeeBasisPoints: 20, ProtocolFeeBasisPoints: 5, CoinCreatorFeeBasisPoints: 5}
+	feeConfig := &pump_amm.FeeConfig{
+		FlatFees: pump_amm.Fees{LpFeeBps: 25, ProtocolFeeBps: 5},
+		FeeTiers: []pump_amm.FeeTier{
+			tier(100_000_000_000, 2, 93, 30),
+			tier(500_000_000_000, 20, 5, 95),
+			tier(2_000_000_000_000, 20, 5, 5),
+		},
+	}
+	// 总供应量 10 亿（6 位精度），市值 = quote 储备 × 10^15 ÷ base 储备
+	supply := uint64(1_000_000_000_000_000)
+	pool := func(quoteReserves uint64, canonical bool) *PoolState {
+		return &PoolState{BaseReserves: supply / 5, QuoteReserves: quoteReserves, BaseMintSupply: supply, Canonical: canonical}
+	}
+
+	tests := []struct {
+		name      string
+		feeConfig *pump_amm.FeeConfig
+		state     *PoolState
+		want      Fees
+	}{
+		{"no fee config uses global config", nil, pool(10_000_000_000, true), Fees{20, 5, 5}},
+		{"non canonical pool uses flat fees", feeConfig, pool(10_000_000_000, false), Fees{25, 5, 0}},
+		{"below first tier uses first tier", feeConfig, pool(10_000_000_000, true), Fees{2, 93, 30}},
+		{"exactly at threshold", feeConfig, pool(100_000_000_000, true), Fees{20, 5, 95}},
+		{"highest tier", feeConfig, pool(1_000_000_000_000, true), Fees{20, 5, 5}},
+		{"empty tiers use flat fees", &pump_amm.FeeConfig{FlatFees: feeConfig.FlatFees}, pool(10_000_000_000, true), Fees{25, 5, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeFees(globalConfig, tt.feeConfig, tt.state); got != tt.want {
+				t.Fatalf("ComputeFees = %+v, want %+v, market cap %s", got, tt.want, tt.state.MarketCap())
+			}
+		})
+	}
+}
+
+func TestPriceImpact(t *testing.T) {
+	state := &PoolState{BaseReserves: 1_000_000_000, QuoteReserves: 1_000_000_000}
+	buy, err := BuyBaseOut(state, Fees{}, 100_000_000)
+	if err != nil {
+		t.Fatalf("BuyBaseOut: %v", err)
+	}
+	// 买走 10% 的 base，均价为现价的 1/0.9 倍
+	if buy.PriceImpact < 11.11 || buy.PriceImpact > 11.12 {
+		t.Fatalf("buy price impact = %v, want ~11.11", buy.PriceImpact)
+	}
+	sell, err := SellBaseIn(state, Fees{}, 100_000_000)
+	if err != nil {
+		t.Fatalf("SellBaseIn: %v", err)
+	}
+	if sell.PriceImpact < 9.09 || sell.PriceImpact > 9.10 {
+		t.Fatalf("sell price impact = %v, want ~9.09", sell.PriceImpact)
+	}
+}
+
+func TestGuards(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.MaxSlippageBP = 500
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"slippage within max", CheckSlippage(cfg, 500), nil},
+		{"slippage above max", CheckSlippage(cfg, 501), ErrSlippageTooLarge},
+		{"amount at min", CheckAmount(cfg, 1000), nil},
+		{"amount below min", CheckAmount(cfg, 999), ErrAmountTooSmall},
+		{"amount above max", CheckAmount(cfg, 100_000_000_001), ErrAmountTooLarge},
+		{"no thresholds", CheckAmount(&config.SwapConfig{}, 1), nil},
+		{"big max threshold", CheckAmount(&config.SwapConfig{MaxAmountThreshold: new(big.Int).Lsh(big.NewInt(1), 70)}, ^uint64(0)), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) || (tt.want == nil && tt.err != nil) {
+				t.Fatalf("got %v, want %v", tt.err, tt.want)
+			}
+		})
+	}
+
+	if got := WithSlippageUp(10_001, 100); got != 10_102 {
+		t.Fatalf("WithSlippageUp = %d, want 10102", got)
+	}
+	if got := WithSlippageDown(10_001, 100); got != 9_900 {
+		t.Fatalf("WithSlippageDown = %d, want 9900", got)
+	}
+	if got := WithSlippageUp(^uint64(0), 100); got != ^uint64(0) {
+		t.Fatalf("WithSlippageUp overflow = %d", got)
+	}
+}
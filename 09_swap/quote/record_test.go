@@ -0,0 +1,125 @@
+package quote
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	pump_amm "github.com/gagliardetto/anchor-go/generated"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// 从主网交易抓取 PumpSwap 事件追加到 testdata/events.json：
+//
+//	go test ./quote -run TestRecordEvents -record-rpc https://api.mainnet-beta.solana.com -record-signatures <签名>,<签名>
+var (
+	recordRPC        = flag.String("record-rpc", "", "抓取事件使用的 RPC 节点，为空时跳过 TestRecordEvents")
+	recordSignatures = flag.String("record-signatures", "", "要抓取的交易签名，逗号分隔")
+)
+
+const eventLogPrefix = "Program data: "
+
+func TestRecordEvents(t *testing.T) {
+	if *recordRPC == "" || *recordSignatures == "" {
+		t.Skip("set -record-rpc and -record-signatures to record events")
+	}
+	cases := loadEventCases(t)
+	recorded := make(map[string]bool, len(cases))
+	for _, c := range cases {
+		recorded[c.Signature] = true
+	}
+
+	client := rpc.New(*recordRPC)
+	for _, signature := range strings.Split(*recordSignatures, ",") {
+		signature = strings.TrimSpace(signature)
+		if signature == "" || recorded[signature] {
+			continue
+		}
+		recordedCases, err := recordTransaction(client, signature)
+		if err != nil {
+			t.Fatalf("record %s: %v", signature, err)
+		}
+		if len(recordedCases) == 0 {
+			t.Fatalf("record %s: no buy or sell event in logs", signature)
+		}
+		cases = append(cases, recordedCases...)
+		recorded[signature] = true
+	}
+
+	data, err := json.MarshalIndent(cases, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile("testdata/events.json", append(data, '\n'), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// recordTransaction 读取交易日志中的 BuyEvent/SellEvent，一笔交易中有多个事件时按出现顺序编号
+func recordTransaction(client *rpc.Client, signature string) ([]eventCase, error) {
+	sig, err := solana.SignatureFromBase58(signature)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	maxVersion := uint64(0)
+	tx, err := client.GetTransaction(ctx, sig, &rpc.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     rpc.CommitmentFinalized,
+		MaxSupportedTransactionVersion: &maxVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil || tx.Meta == nil {
+		return nil, fmt.Errorf("transaction not found")
+	}
+	if tx.Meta.Err != nil {
+		return nil, fmt.Errorf("transaction failed: %v", tx.Meta.Err)
+	}
+
+	var cases []eventCase
+	for _, log := range tx.Meta.LogMessages {
+		data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(log, eventLogPrefix))
+		if !strings.HasPrefix(log, eventLogPrefix) || err != nil || len(data) < 8 {
+			continue
+		}
+		var (
+			typ   string
+			event any
+		)
+		switch [8]byte(data[:8]) {
+		case pump_amm.Event_BuyEvent:
+			typ = "buy"
+			event, err = pump_amm.ParseEvent_BuyEvent(data)
+		case pump_amm.Event_SellEvent:
+			typ = "sell"
+			event, err = pump_amm.ParseEvent_SellEvent(data)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, eventCase{
+			Name:      fmt.Sprintf("%s %s #%d", typ, signature[:8], len(cases)),
+			Type:      typ,
+			Signature: signature,
+			Slot:      tx.Slot,
+			Event:     raw,
+		})
+	}
+	return cases, nil
+}
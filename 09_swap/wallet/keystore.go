@@ -0,0 +1,383 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+	"golang.org/x/crypto/scrypt"
+)
+
+/*
+Keystore 加密存储的多钱包
+
+	-每个钱包一个 <name>.json 文件，权限 0600
+	-私钥用 scrypt 从口令派生的 256 位密钥做 AES-GCM 加密，钱包名和地址作为附加数据参与认证，文件被篡改或改名都无法解锁
+	-解锁后私钥只保存在内存中的 KeystoreWallet 里，Lock 时清零
+*/
+type Keystore struct {
+	dir string
+
+	// scrypt 参数，测试时可以调小
+	scryptN int
+	scryptR int
+	scryptP int
+}
+
+const (
+	keystoreVersion = 1
+	keystoreCipher  = "aes-256-gcm"
+	keystoreKDF     = "scrypt"
+
+	// 默认 scrypt 参数：N=2^17、r=8、p=1，约 128MB 内存
+	defaultScryptN = 1 << 17
+	defaultScryptR = 8
+	defaultScryptP = 1
+	maxScryptN     = 1 << 20
+
+	scryptKeyLen = 32
+	saltLen      = 32
+)
+
+var (
+	ErrWalletNotFound    = errors.New("wallet not found")
+	ErrWalletExists      = errors.New("wallet already exists")
+	ErrWrongPassphrase   = errors.New("wrong passphrase or corrupted keystore")
+	ErrWalletLocked      = errors.New("wallet is locked")
+	ErrEmptyPassphrase   = errors.New("passphrase is required")
+	ErrInvalidWalletName = errors.New("invalid wallet name")
+)
+
+var walletNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// keyFile 钱包文件格式
+type keyFile struct {
+	Version int       `json:"version"`
+	Name    string    `json:"name"`
+	Address string    `json:"address"`
+	Crypto  keyCrypto `json:"crypto"`
+}
+
+type keyCrypto struct {
+	KDF        string       `json:"kdf"`
+	KDFParams  scryptParams `json:"kdfparams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      string       `json:"nonce"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+type scryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"`
+}
+
+// KeystoreEntry 钱包名和地址，不需要解锁就能列出
+type KeystoreEntry struct {
+	Name    string           `json:"name"`
+	Address solana.PublicKey `json:"address"`
+}
+
+// NewKeystore 打开 dir 下的钱包，目录不存在时创建
+func NewKeystore(dir string) (*Keystore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create keystore dir: %w", err)
+	}
+	return &Keystore{dir: dir, scryptN: defaultScryptN, scryptR: defaultScryptR, scryptP: defaultScryptP}, nil
+}
+
+// Create 生成新的私钥并加密保存
+func (ks *Keystore) Create(name, passphrase string) (solana.PublicKey, error) {
+	privateKey, err := solana.NewRandomPrivateKey()
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("generate private key: %w", err)
+	}
+	defer zero(privateKey)
+	return ks.Import(name, privateKey, passphrase)
+}
+
+// Import 把已有私钥加密保存为名为 name 的钱包
+func (ks *Keystore) Import(name string, privateKey solana.PrivateKey, passphrase string) (solana.PublicKey, error) {
+	if !walletNamePattern.MatchString(name) {
+		return solana.PublicKey{}, fmt.Errorf("%w: %q", ErrInvalidWalletName, name)
+	}
+	if passphrase == "" {
+		return solana.PublicKey{}, ErrEmptyPassphrase
+	}
+	if len(privateKey) != 64 {
+		return solana.PublicKey{}, fmt.Errorf("invalid private key length: %d", len(privateKey))
+	}
+	address := privateKey.PublicKey()
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return solana.PublicKey{}, err
+	}
+	params := scryptParams{N: ks.scryptN, R: ks.scryptR, P: ks.scryptP, Salt: hex.EncodeToString(salt)}
+	aead, err := newAEAD(passphrase, params)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return solana.PublicKey{}, err
+	}
+	ciphertext := aead.Seal(nil, nonce, privateKey, additionalData(name, address))
+
+	data, err := json.MarshalIndent(keyFile{
+		Version: keystoreVersion,
+		Name:    name,
+		Address: address.String(),
+		Crypto: keyCrypto{
+			KDF:        keystoreKDF,
+			KDFParams:  params,
+			Cipher:     keystoreCipher,
+			Nonce:      hex.EncodeToString(nonce),
+			Ciphertext: hex.EncodeToString(ciphertext),
+		},
+	}, "", "  ")
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	if err = ks.writeNew(name, data); err != nil {
+		return solana.PublicKey{}, err
+	}
+	return address, nil
+}
+
+// Unlock 用口令解密名为 name 的钱包
+func (ks *Keystore) Unlock(name, passphrase string) (*KeystoreWallet, error) {
+	file, err := ks.read(name)
+	if err != nil {
+		return nil, err
+	}
+	if file.Crypto.KDF != keystoreKDF || file.Crypto.Cipher != keystoreCipher {
+		return nil, fmt.Errorf("unsupported keystore %s/%s", file.Crypto.KDF, file.Crypto.Cipher)
+	}
+	address, err := solana.PublicKeyFromBase58(file.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore address: %w", err)
+	}
+	nonce, err := hex.DecodeString(file.Crypto.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(file.Crypto.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore ciphertext: %w", err)
+	}
+
+	// 文件中的参数不可信，过大的参数会耗尽内存
+	if params := file.Crypto.KDFParams; params.N > maxScryptN || params.R*params.P >= 1<<30 {
+		return nil, fmt.Errorf("keystore scrypt params too large: n=%d r=%d p=%d", params.N, params.R, params.P)
+	}
+	aead, err := newAEAD(passphrase, file.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData(file.Name, address))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	privateKey := solana.PrivateKey(plaintext)
+	if len(privateKey) != 64 || !privateKey.PublicKey().Equals(address) {
+		zero(privateKey)
+		return nil, ErrWrongPassphrase
+	}
+	return &KeystoreWallet{name: file.Name, address: address, privateKey: privateKey}, nil
+}
+
+// List 列出所有钱包，按名称排序
+func (ks *Keystore) List() ([]KeystoreEntry, error) {
+	paths, err := filepath.Glob(filepath.Join(ks.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]KeystoreEntry, 0, len(paths))
+	for _, path := range paths {
+		file, err := ks.read(strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		address, err := solana.PublicKeyFromBase58(file.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address in %s: %w", path, err)
+		}
+		entries = append(entries, KeystoreEntry{Name: file.Name, Address: address})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// Delete 删除钱包文件
+func (ks *Keystore) Delete(name string) error {
+	if !walletNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidWalletName, name)
+	}
+	if err := os.Remove(ks.path(name)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrWalletNotFound, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (ks *Keystore) path(name string) string {
+	return filepath.Join(ks.dir, name+".json")
+}
+
+func (ks *Keystore) read(name string) (*keyFile, error) {
+	if !walletNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidWalletName, name)
+	}
+	data, err := os.ReadFile(ks.path(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrWalletNotFound, name)
+		}
+		return nil, err
+	}
+	var file keyFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode keystore %s: %w", name, err)
+	}
+	if file.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version %d", file.Version)
+	}
+	if file.Name != name {
+		return nil, fmt.Errorf("keystore file %s contains wallet %q", name, file.Name)
+	}
+	return &file, nil
+}
+
+// writeNew 先写临时文件再硬链接到目标文件，目标已存在时失败，不会覆盖已有钱包
+func (ks *Keystore) writeNew(name string, data []byte) error {
+	tmp, err := os.CreateTemp(ks.dir, "."+name+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	if err = os.Link(tmp.Name(), ks.path(name)); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%w: %s", ErrWalletExists, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func newAEAD(passphrase string, params scryptParams) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, ErrEmptyPassphrase
+	}
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore salt: %w", err)
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	defer zero(key)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func additionalData(name string, address solana.PublicKey) []byte {
+	return append([]byte(name+":"), address[:]...)
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// KeystoreWallet 从 Keystore 解锁的钱包
+type KeystoreWallet struct {
+	mu         sync.RWMutex
+	name       string
+	address    solana.PublicKey
+	privateKey solana.PrivateKey
+}
+
+// Name 钱包名
+func (w *KeystoreWallet) Name() string {
+	return w.name
+}
+
+// PublicKey 返回钱包的公钥，锁定后仍然可用
+func (w *KeystoreWallet) PublicKey() solana.PublicKey {
+	return w.address
+}
+
+// Sign 签名消息
+func (w *KeystoreWallet) Sign(message []byte) ([]byte, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.privateKey == nil {
+		return nil, ErrWalletLocked
+	}
+	signature, err := w.privateKey.Sign(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+	return signature[:], nil
+}
+
+// SignTransaction 签名交易
+func (w *KeystoreWallet) SignTransaction(tx *solana.Transaction) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.privateKey == nil {
+		return ErrWalletLocked
+	}
+	_, err := tx.Sign(func(key solana.PublicKey) *solana.PrivateKey {
+		if key.Equals(w.address) {
+			return &w.privateKey
+		}
+		return nil
+	})
+	return err
+}
+
+// Lock 清零内存中的私钥，之后签名返回 ErrWalletLocked
+func (w *KeystoreWallet) Lock() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	zero(w.privateKey)
+	w.privateKey = nil
+}
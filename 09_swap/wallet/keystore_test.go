@@ -0,0 +1,183 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// newTestKeystore 调小 scrypt 参数，加快测试
+func newTestKeystore(t *testing.T) *Keystore {
+	ks, err := NewKeystore(filepath.Join(t.TempDir(), "keystore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks.scryptN = 1 << 10
+	return ks
+}
+
+func TestKeystoreImportAndUnlock(t *testing.T) {
+	ks := newTestKeystore(t)
+	privateKey := solana.NewWallet().PrivateKey
+
+	address, err := ks.Import("main", privateKey, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !address.Equals(privateKey.PublicKey()) {
+		t.Fatalf("address = %s, want %s", address, privateKey.PublicKey())
+	}
+
+	data, err := os.ReadFile(ks.path("main"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(privateKey.String())) || bytes.Contains(data, privateKey) {
+		t.Fatal("keystore file contains the plaintext private key")
+	}
+	info, err := os.Stat(ks.path("main"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("keystore file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	w, err := ks.Unlock("main", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Name() != "main" || !w.PublicKey().Equals(address) {
+		t.Fatalf("unlocked %s %s", w.Name(), w.PublicKey())
+	}
+	message := []byte("swap")
+	signature, err := w.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !address.Verify(message, solana.SignatureFromBytes(signature)) {
+		t.Fatal("signature does not verify")
+	}
+
+	w.Lock()
+	if _, err = w.Sign(message); !errors.Is(err, ErrWalletLocked) {
+		t.Fatalf("sign after lock err = %v, want ErrWalletLocked", err)
+	}
+	if !w.PublicKey().Equals(address) {
+		t.Fatal("public key should stay available after lock")
+	}
+}
+
+func TestKeystoreErrors(t *testing.T) {
+	ks := newTestKeystore(t)
+	if _, err := ks.Create("main", "pass"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ks.Unlock("main", "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("wrong passphrase err = %v", err)
+	}
+	if _, err := ks.Unlock("missing", "pass"); !errors.Is(err, ErrWalletNotFound) {
+		t.Fatalf("missing wallet err = %v", err)
+	}
+	if _, err := ks.Create("main", "pass"); !errors.Is(err, ErrWalletExists) {
+		t.Fatalf("duplicate wallet err = %v", err)
+	}
+	if _, err := ks.Create("../escape", "pass"); !errors.Is(err, ErrInvalidWalletName) {
+		t.Fatalf("invalid name err = %v", err)
+	}
+	if _, err := ks.Create("other", ""); !errors.Is(err, ErrEmptyPassphrase) {
+		t.Fatalf("empty passphrase err = %v", err)
+	}
+}
+
+func TestKeystoreDetectsTampering(t *testing.T) {
+	ks := newTestKeystore(t)
+	if _, err := ks.Create("main", "pass"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(ks.path("main"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 把地址换成别的钱包：地址参与认证，解锁失败
+	var file keyFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	file.Address = solana.NewWallet().PublicKey().String()
+	tampered, _ := json.Marshal(file)
+	if err = os.WriteFile(ks.path("main"), tampered, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ks.Unlock("main", "pass"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("tampered address err = %v", err)
+	}
+
+	// 把文件改名成另一个钱包：文件内的名称对不上
+	if err = os.WriteFile(ks.path("renamed"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ks.Unlock("renamed", "pass"); err == nil {
+		t.Fatal("renamed keystore should not unlock")
+	}
+}
+
+func TestKeystoreList(t *testing.T) {
+	ks := newTestKeystore(t)
+	second, err := ks.Create("sniper", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := ks.Create("main", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ks.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Name != "main" || !entries[0].Address.Equals(first) ||
+		entries[1].Name != "sniper" || !entries[1].Address.Equals(second) {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+
+	if err = ks.Delete("sniper"); err != nil {
+		t.Fatal(err)
+	}
+	if err = ks.Delete("sniper"); !errors.Is(err, ErrWalletNotFound) {
+		t.Fatalf("delete missing err = %v", err)
+	}
+}
+
+func TestSet(t *testing.T) {
+	set := NewSet()
+	mainWallet := NewMemoryWallet(solana.NewWallet().PrivateKey)
+	if err := set.Add("main", mainWallet); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Add("main", mainWallet); !errors.Is(err, ErrWalletExists) {
+		t.Fatalf("duplicate err = %v", err)
+	}
+	if err := set.Add("sniper", NewMemoryWallet(solana.NewWallet().PrivateKey)); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := set.Get("main")
+	if err != nil || !w.PublicKey().Equals(mainWallet.PublicKey()) {
+		t.Fatalf("get main = %v, %v", w, err)
+	}
+	if _, err = set.Get("missing"); !errors.Is(err, ErrWalletNotFound) {
+		t.Fatalf("get missing err = %v", err)
+	}
+	if names := set.Names(); len(names) != 2 || names[0] != "main" || names[1] != "sniper" {
+		t.Fatalf("names = %v", names)
+	}
+}
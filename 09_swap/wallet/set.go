@@ -0,0 +1,68 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Set 按名称管理多个已解锁的钱包，客户端按名称选择签名钱包
+type Set struct {
+	mu      sync.RWMutex
+	wallets map[string]SecureWallet
+}
+
+func NewSet() *Set {
+	return &Set{wallets: make(map[string]SecureWallet)}
+}
+
+// Add 注册钱包，名称重复时返回 ErrWalletExists
+func (s *Set) Add(name string, w SecureWallet) error {
+	if !walletNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidWalletName, name)
+	}
+	if w == nil {
+		return fmt.Errorf("wallet %s is nil", name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.wallets[name]; ok {
+		return fmt.Errorf("%w: %s", ErrWalletExists, name)
+	}
+	s.wallets[name] = w
+	return nil
+}
+
+// Get 按名称取钱包
+func (s *Set) Get(name string) (SecureWallet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	w, ok := s.wallets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrWalletNotFound, name)
+	}
+	return w, nil
+}
+
+// Names 所有钱包名，按名称排序
+func (s *Set) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.wallets))
+	for name := range s.wallets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lock 锁定所有支持锁定的钱包
+func (s *Set) Lock() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, w := range s.wallets {
+		if locker, ok := w.(interface{ Lock() }); ok {
+			locker.Lock()
+		}
+	}
+}
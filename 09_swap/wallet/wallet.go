@@ -27,7 +27,6 @@ func NewMemoryWalletFromBase58(privateKeyStr string) (*MemoryWallet, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid private key: %w", err)
 	}
-	fmt.Printf("👛 钱包地址：%s.\n", privateKey)
 	return NewMemoryWallet(privateKey), nil
 }
 
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"swap/config"
+	"swap/wallet"
+
+	"github.com/gagliardetto/solana-go"
+	"golang.org/x/term"
+)
+
+// devnetCheckTimeout 查询创世哈希判断网络的超时时间
+const devnetCheckTimeout = 10 * time.Second
+
+// loadWallets 加载命名钱包
+/*
+	-keystoreDir（--keystore / KEYSTORE_DIR）：加密钱包目录，names（--wallets / WALLETS）为要解锁的钱包名，逗号分隔，
+	 默认 WalletName；口令取 WALLET_PASSPHRASE 或终端输入
+	-keystoreDir 和 PRIVATE_KEY 同时设置时，先把 PRIVATE_KEY 加密导入为 WalletName，之后就可以删掉 PRIVATE_KEY
+	-只设置 PRIVATE_KEY：明文私钥钱包
+	-都没有设置：取 DEVNET_PRIVATE_KEY 作为明文私钥钱包，只允许在 devnet 或本地测试节点使用，其他网络报错
+*/
+func loadWallets(cfg *config.SwapConfig, keystoreDir, names string) (*wallet.Set, error) {
+	wallets := wallet.NewSet()
+	privateKeyStr := os.Getenv("PRIVATE_KEY")
+
+	if keystoreDir == "" {
+		if privateKeyStr == "" {
+			privateKeyStr = os.Getenv("DEVNET_PRIVATE_KEY")
+			if privateKeyStr == "" {
+				return nil, errors.New("没有可用的钱包，请设置 KEYSTORE_DIR、PRIVATE_KEY 或 DEVNET_PRIVATE_KEY")
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), devnetCheckTimeout)
+			devnet, err := cfg.IsDevnet(ctx)
+			cancel()
+			if err != nil {
+				return nil, fmt.Errorf("check network: %w", err)
+			}
+			if !devnet {
+				return nil, errors.New("DEVNET_PRIVATE_KEY 只能用于 devnet，请设置 KEYSTORE_DIR 或 PRIVATE_KEY")
+			}
+			fmt.Fprintln(os.Stderr, "⚠️ 使用 DEVNET_PRIVATE_KEY 中的 devnet 测试私钥.")
+		}
+		w, err := wallet.NewMemoryWalletFromBase58(privateKeyStr)
+		if err != nil {
+			return nil, err
+		}
+		return wallets, wallets.Add(cfg.WalletName, w)
+	}
+
+	ks, err := wallet.NewKeystore(keystoreDir)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := readPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	if privateKeyStr != "" {
+		privateKey, err := solana.PrivateKeyFromBase58(privateKeyStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %w", err)
+		}
+		address, err := ks.Import(cfg.WalletName, privateKey, passphrase)
+		switch {
+		case errors.Is(err, wallet.ErrWalletExists):
+			fmt.Fprintf(os.Stderr, "ℹ️ 钱包 %s 已存在，忽略 PRIVATE_KEY.\n", cfg.WalletName)
+		case err != nil:
+			return nil, err
+		default:
+			fmt.Fprintf(os.Stderr, "🔐 已加密导入钱包 %s：%s，可以删除 PRIVATE_KEY 环境变量了.\n", cfg.WalletName, address)
+		}
+	}
+
+	if names == "" {
+		names = cfg.WalletName
+	}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		w, err := ks.Unlock(name, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("unlock wallet %s: %w", name, err)
+		}
+		if err = wallets.Add(name, w); err != nil {
+			return nil, err
+		}
+	}
+	return wallets, nil
+}
+
+// readPassphrase 钱包口令优先取 WALLET_PASSPHRASE，否则在终端中输入（不回显）
+func readPassphrase() (string, error) {
+	if passphrase := os.Getenv("WALLET_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("需要设置 WALLET_PASSPHRASE 环境变量")
+	}
+	fmt.Fprint(os.Stderr, "🔑 请输入钱包口令：")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read passphrase: %w", err)
+	}
+	return string(passphrase), nil
+}
@@ -7,6 +7,11 @@ import (
 	"richcode.cc/dex/consumer/consumer"
 	"richcode.cc/dex/consumer/internal/config"
 	"richcode.cc/dex/consumer/internal/logic/block"
+	"richcode.cc/dex/consumer/internal/logic/label"
+	"richcode.cc/dex/consumer/internal/logic/launch"
+	"richcode.cc/dex/consumer/internal/logic/push"
+	"richcode.cc/dex/consumer/internal/logic/reconcile"
+	"richcode.cc/dex/consumer/internal/logic/security"
 	"richcode.cc/dex/consumer/internal/logic/slot"
 	"richcode.cc/dex/consumer/internal/server"
 	"richcode.cc/dex/consumer/internal/svc"
@@ -65,6 +70,31 @@ func main() {
 		// group.Add(slot.NewSlotWsService(slotService))
 	}
 
+	// 钱包标签：写入人工标签并周期性计算自动标签
+	group.Add(label.NewWalletLabelService(ctx))
+
+	// 开盘分析：识别狙击和捆绑买入，持仓占比写回 token 表
+	group.Add(launch.NewLaunchService(ctx))
+
+	// 合约检测：权限、Token-2022 扩展、LP 销毁/锁仓，写回 token 表风险字段
+	group.Add(security.NewSecurityService(ctx))
+
+	// 池子储备校对：对比活跃 PumpSwap 池子的链上金库余额，记录偏差并修正交易对储备
+	group.Add(reconcile.NewReconcileService(ctx))
+
+	// 实时推送：WebSocket 订阅成交、交易对更新和新交易对，gRPC 订阅见 Subscribe
+	group.Add(push.NewPushService(ctx))
+
+	// 消息队列：区块落库后按 slot 发布代币、交易对更新和成交
+	if ctx.Publisher != nil {
+		group.Add(ctx.Publisher)
+	}
+
+	// 告警：按规则检查新交易对、交易对更新和成交，发送到日志、webhook 或 Telegram
+	if ctx.Alert != nil {
+		group.Add(ctx.Alert)
+	}
+
 	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
 	group.Start()
 }
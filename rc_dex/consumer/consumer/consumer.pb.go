@@ -109,6 +109,2438 @@ func (x *Response) GetPong() string {
 	return ""
 }
 
+// 交易对
+type Pair struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	ChainId                int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Address                string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Name                   string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // DEX 名称
+	TokenAddress           string                 `protobuf:"bytes,4,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	BaseTokenAddress       string                 `protobuf:"bytes,5,opt,name=base_token_address,json=baseTokenAddress,proto3" json:"base_token_address,omitempty"`
+	TokenSymbol            string                 `protobuf:"bytes,6,opt,name=token_symbol,json=tokenSymbol,proto3" json:"token_symbol,omitempty"`
+	BaseTokenSymbol        string                 `protobuf:"bytes,7,opt,name=base_token_symbol,json=baseTokenSymbol,proto3" json:"base_token_symbol,omitempty"`
+	TokenDecimal           int64                  `protobuf:"varint,8,opt,name=token_decimal,json=tokenDecimal,proto3" json:"token_decimal,omitempty"`
+	BaseTokenDecimal       int64                  `protobuf:"varint,9,opt,name=base_token_decimal,json=baseTokenDecimal,proto3" json:"base_token_decimal,omitempty"`
+	CurrentTokenAmount     float64                `protobuf:"fixed64,10,opt,name=current_token_amount,json=currentTokenAmount,proto3" json:"current_token_amount,omitempty"`
+	CurrentBaseTokenAmount float64                `protobuf:"fixed64,11,opt,name=current_base_token_amount,json=currentBaseTokenAmount,proto3" json:"current_base_token_amount,omitempty"`
+	Liquidity              float64                `protobuf:"fixed64,12,opt,name=liquidity,proto3" json:"liquidity,omitempty"`
+	TokenPrice             float64                `protobuf:"fixed64,13,opt,name=token_price,json=tokenPrice,proto3" json:"token_price,omitempty"`
+	BaseTokenPrice         float64                `protobuf:"fixed64,14,opt,name=base_token_price,json=baseTokenPrice,proto3" json:"base_token_price,omitempty"`
+	Fdv                    float64                `protobuf:"fixed64,15,opt,name=fdv,proto3" json:"fdv,omitempty"`
+	MktCap                 float64                `protobuf:"fixed64,16,opt,name=mkt_cap,json=mktCap,proto3" json:"mkt_cap,omitempty"`
+	PumpPoint              float64                `protobuf:"fixed64,17,opt,name=pump_point,json=pumpPoint,proto3" json:"pump_point,omitempty"`
+	PumpStatus             int64                  `protobuf:"varint,18,opt,name=pump_status,json=pumpStatus,proto3" json:"pump_status,omitempty"`
+	PumpPairAddr           string                 `protobuf:"bytes,19,opt,name=pump_pair_addr,json=pumpPairAddr,proto3" json:"pump_pair_addr,omitempty"`
+	BlockNum               int64                  `protobuf:"varint,20,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`                        // 创建 slot
+	BlockTime              int64                  `protobuf:"varint,21,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`                     // 创建时间（秒）
+	LatestTradeTime        int64                  `protobuf:"varint,22,opt,name=latest_trade_time,json=latestTradeTime,proto3" json:"latest_trade_time,omitempty"` // 最新成交时间（秒）
+	Volume                 float64                `protobuf:"fixed64,23,opt,name=volume,proto3" json:"volume,omitempty"`                                           // 近 24 小时成交额（USD）
+	IsRug                  bool                   `protobuf:"varint,24,opt,name=is_rug,json=isRug,proto3" json:"is_rug,omitempty"`                                 // 是否已判定跑路
+	RugReason              string                 `protobuf:"bytes,25,opt,name=rug_reason,json=rugReason,proto3" json:"rug_reason,omitempty"`                      // lp_removed / dev_dump / liquidity_drained
+	RugAt                  int64                  `protobuf:"varint,26,opt,name=rug_at,json=rugAt,proto3" json:"rug_at,omitempty"`                                 // 跑路区块时间（秒）
+	RugTxHash              string                 `protobuf:"bytes,27,opt,name=rug_tx_hash,json=rugTxHash,proto3" json:"rug_tx_hash,omitempty"`
+	OrganicVolume          float64                `protobuf:"fixed64,28,opt,name=organic_volume,json=organicVolume,proto3" json:"organic_volume,omitempty"` // 近 24 小时自然成交额（USD），排除夹子前后腿和刷量成交
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Pair) Reset() {
+	*x = Pair{}
+	mi := &file_consumer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pair) ProtoMessage() {}
+
+func (x *Pair) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pair.ProtoReflect.Descriptor instead.
+func (*Pair) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Pair) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Pair) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Pair) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pair) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *Pair) GetBaseTokenAddress() string {
+	if x != nil {
+		return x.BaseTokenAddress
+	}
+	return ""
+}
+
+func (x *Pair) GetTokenSymbol() string {
+	if x != nil {
+		return x.TokenSymbol
+	}
+	return ""
+}
+
+func (x *Pair) GetBaseTokenSymbol() string {
+	if x != nil {
+		return x.BaseTokenSymbol
+	}
+	return ""
+}
+
+func (x *Pair) GetTokenDecimal() int64 {
+	if x != nil {
+		return x.TokenDecimal
+	}
+	return 0
+}
+
+func (x *Pair) GetBaseTokenDecimal() int64 {
+	if x != nil {
+		return x.BaseTokenDecimal
+	}
+	return 0
+}
+
+func (x *Pair) GetCurrentTokenAmount() float64 {
+	if x != nil {
+		return x.CurrentTokenAmount
+	}
+	return 0
+}
+
+func (x *Pair) GetCurrentBaseTokenAmount() float64 {
+	if x != nil {
+		return x.CurrentBaseTokenAmount
+	}
+	return 0
+}
+
+func (x *Pair) GetLiquidity() float64 {
+	if x != nil {
+		return x.Liquidity
+	}
+	return 0
+}
+
+func (x *Pair) GetTokenPrice() float64 {
+	if x != nil {
+		return x.TokenPrice
+	}
+	return 0
+}
+
+func (x *Pair) GetBaseTokenPrice() float64 {
+	if x != nil {
+		return x.BaseTokenPrice
+	}
+	return 0
+}
+
+func (x *Pair) GetFdv() float64 {
+	if x != nil {
+		return x.Fdv
+	}
+	return 0
+}
+
+func (x *Pair) GetMktCap() float64 {
+	if x != nil {
+		return x.MktCap
+	}
+	return 0
+}
+
+func (x *Pair) GetPumpPoint() float64 {
+	if x != nil {
+		return x.PumpPoint
+	}
+	return 0
+}
+
+func (x *Pair) GetPumpStatus() int64 {
+	if x != nil {
+		return x.PumpStatus
+	}
+	return 0
+}
+
+func (x *Pair) GetPumpPairAddr() string {
+	if x != nil {
+		return x.PumpPairAddr
+	}
+	return ""
+}
+
+func (x *Pair) GetBlockNum() int64 {
+	if x != nil {
+		return x.BlockNum
+	}
+	return 0
+}
+
+func (x *Pair) GetBlockTime() int64 {
+	if x != nil {
+		return x.BlockTime
+	}
+	return 0
+}
+
+func (x *Pair) GetLatestTradeTime() int64 {
+	if x != nil {
+		return x.LatestTradeTime
+	}
+	return 0
+}
+
+func (x *Pair) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *Pair) GetIsRug() bool {
+	if x != nil {
+		return x.IsRug
+	}
+	return false
+}
+
+func (x *Pair) GetRugReason() string {
+	if x != nil {
+		return x.RugReason
+	}
+	return ""
+}
+
+func (x *Pair) GetRugAt() int64 {
+	if x != nil {
+		return x.RugAt
+	}
+	return 0
+}
+
+func (x *Pair) GetRugTxHash() string {
+	if x != nil {
+		return x.RugTxHash
+	}
+	return ""
+}
+
+func (x *Pair) GetOrganicVolume() float64 {
+	if x != nil {
+		return x.OrganicVolume
+	}
+	return 0
+}
+
+type GetPairRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPairRequest) Reset() {
+	*x = GetPairRequest{}
+	mi := &file_consumer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPairRequest) ProtoMessage() {}
+
+func (x *GetPairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPairRequest.ProtoReflect.Descriptor instead.
+func (*GetPairRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPairRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type GetPairResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pair          *Pair                  `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPairResponse) Reset() {
+	*x = GetPairResponse{}
+	mi := &file_consumer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPairResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPairResponse) ProtoMessage() {}
+
+func (x *GetPairResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPairResponse.ProtoReflect.Descriptor instead.
+func (*GetPairResponse) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPairResponse) GetPair() *Pair {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+type ListPairsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                      // DEX 名称，空表示全部
+	PumpStatus    *int64                 `protobuf:"varint,2,opt,name=pump_status,json=pumpStatus,proto3,oneof" json:"pump_status,omitempty"` // 不传表示全部
+	MinLiquidity  float64                `protobuf:"fixed64,3,opt,name=min_liquidity,json=minLiquidity,proto3" json:"min_liquidity,omitempty"`
+	MinVolume     float64                `protobuf:"fixed64,4,opt,name=min_volume,json=minVolume,proto3" json:"min_volume,omitempty"` // 近 24 小时成交额下限（USD）
+	OrderBy       string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`         // liquidity（默认）、volume、organic_volume、created
+	Asc           bool                   `protobuf:"varint,6,opt,name=asc,proto3" json:"asc,omitempty"`                               // 默认倒序
+	Page          int64                  `protobuf:"varint,7,opt,name=page,proto3" json:"page,omitempty"`                             // 从 1 开始
+	PageSize      int64                  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	ExcludeRug    bool                   `protobuf:"varint,9,opt,name=exclude_rug,json=excludeRug,proto3" json:"exclude_rug,omitempty"` // 排除已判定跑路的交易对
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPairsRequest) Reset() {
+	*x = ListPairsRequest{}
+	mi := &file_consumer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPairsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPairsRequest) ProtoMessage() {}
+
+func (x *ListPairsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPairsRequest.ProtoReflect.Descriptor instead.
+func (*ListPairsRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListPairsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListPairsRequest) GetPumpStatus() int64 {
+	if x != nil && x.PumpStatus != nil {
+		return *x.PumpStatus
+	}
+	return 0
+}
+
+func (x *ListPairsRequest) GetMinLiquidity() float64 {
+	if x != nil {
+		return x.MinLiquidity
+	}
+	return 0
+}
+
+func (x *ListPairsRequest) GetMinVolume() float64 {
+	if x != nil {
+		return x.MinVolume
+	}
+	return 0
+}
+
+func (x *ListPairsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListPairsRequest) GetAsc() bool {
+	if x != nil {
+		return x.Asc
+	}
+	return false
+}
+
+func (x *ListPairsRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPairsRequest) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPairsRequest) GetExcludeRug() bool {
+	if x != nil {
+		return x.ExcludeRug
+	}
+	return false
+}
+
+type ListPairsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	List          []*Pair                `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPairsResponse) Reset() {
+	*x = ListPairsResponse{}
+	mi := &file_consumer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPairsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPairsResponse) ProtoMessage() {}
+
+func (x *ListPairsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPairsResponse.ProtoReflect.Descriptor instead.
+func (*ListPairsResponse) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListPairsResponse) GetList() []*Pair {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+// 代币
+type Token struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ChainId            int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Address            string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Program            string                 `protobuf:"bytes,3,opt,name=program,proto3" json:"program,omitempty"`
+	Name               string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol             string                 `protobuf:"bytes,5,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals           int64                  `protobuf:"varint,6,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	TotalSupply        float64                `protobuf:"fixed64,7,opt,name=total_supply,json=totalSupply,proto3" json:"total_supply,omitempty"`
+	Icon               string                 `protobuf:"bytes,8,opt,name=icon,proto3" json:"icon,omitempty"`
+	Description        string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	TwitterUsername    string                 `protobuf:"bytes,10,opt,name=twitter_username,json=twitterUsername,proto3" json:"twitter_username,omitempty"`
+	Website            string                 `protobuf:"bytes,11,opt,name=website,proto3" json:"website,omitempty"`
+	Telegram           string                 `protobuf:"bytes,12,opt,name=telegram,proto3" json:"telegram,omitempty"`
+	HoldCount          int64                  `protobuf:"varint,13,opt,name=hold_count,json=holdCount,proto3" json:"hold_count,omitempty"`
+	IsCheckCa          bool                   `protobuf:"varint,14,opt,name=is_check_ca,json=isCheckCa,proto3" json:"is_check_ca,omitempty"` // 是否完成合约检测，未完成时以下风险字段无意义
+	IsCaDropOwner      bool                   `protobuf:"varint,15,opt,name=is_ca_drop_owner,json=isCaDropOwner,proto3" json:"is_ca_drop_owner,omitempty"`
+	IsHoneyScam        bool                   `protobuf:"varint,16,opt,name=is_honey_scam,json=isHoneyScam,proto3" json:"is_honey_scam,omitempty"`
+	IsLiquidLock       bool                   `protobuf:"varint,17,opt,name=is_liquid_lock,json=isLiquidLock,proto3" json:"is_liquid_lock,omitempty"`
+	IsBurnPool         bool                   `protobuf:"varint,18,opt,name=is_burn_pool,json=isBurnPool,proto3" json:"is_burn_pool,omitempty"`
+	IsCanPauseTrade    bool                   `protobuf:"varint,19,opt,name=is_can_pause_trade,json=isCanPauseTrade,proto3" json:"is_can_pause_trade,omitempty"`
+	IsCanChangeTax     bool                   `protobuf:"varint,20,opt,name=is_can_change_tax,json=isCanChangeTax,proto3" json:"is_can_change_tax,omitempty"`
+	IsHaveBlackList    bool                   `protobuf:"varint,21,opt,name=is_have_black_list,json=isHaveBlackList,proto3" json:"is_have_black_list,omitempty"`
+	IsCanAllSell       bool                   `protobuf:"varint,22,opt,name=is_can_all_sell,json=isCanAllSell,proto3" json:"is_can_all_sell,omitempty"`
+	IsCanExternalCall  bool                   `protobuf:"varint,23,opt,name=is_can_external_call,json=isCanExternalCall,proto3" json:"is_can_external_call,omitempty"`
+	IsCanAddToken      bool                   `protobuf:"varint,24,opt,name=is_can_add_token,json=isCanAddToken,proto3" json:"is_can_add_token,omitempty"`
+	IsCanChangeToken   bool                   `protobuf:"varint,25,opt,name=is_can_change_token,json=isCanChangeToken,proto3" json:"is_can_change_token,omitempty"`
+	SellTax            float64                `protobuf:"fixed64,26,opt,name=sell_tax,json=sellTax,proto3" json:"sell_tax,omitempty"`
+	BuyTax             float64                `protobuf:"fixed64,27,opt,name=buy_tax,json=buyTax,proto3" json:"buy_tax,omitempty"`
+	SniperCount        int64                  `protobuf:"varint,28,opt,name=sniper_count,json=sniperCount,proto3" json:"sniper_count,omitempty"`
+	SniperHoldPercent  float64                `protobuf:"fixed64,29,opt,name=sniper_hold_percent,json=sniperHoldPercent,proto3" json:"sniper_hold_percent,omitempty"`
+	BundlerCount       int64                  `protobuf:"varint,30,opt,name=bundler_count,json=bundlerCount,proto3" json:"bundler_count,omitempty"`
+	BundlerHoldPercent float64                `protobuf:"fixed64,31,opt,name=bundler_hold_percent,json=bundlerHoldPercent,proto3" json:"bundler_hold_percent,omitempty"`
+	IsBundled          bool                   `protobuf:"varint,32,opt,name=is_bundled,json=isBundled,proto3" json:"is_bundled,omitempty"`
+	Slot               int64                  `protobuf:"varint,33,opt,name=slot,proto3" json:"slot,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Token) Reset() {
+	*x = Token{}
+	mi := &file_consumer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Token) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Token) ProtoMessage() {}
+
+func (x *Token) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Token.ProtoReflect.Descriptor instead.
+func (*Token) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Token) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Token) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Token) GetProgram() string {
+	if x != nil {
+		return x.Program
+	}
+	return ""
+}
+
+func (x *Token) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Token) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Token) GetDecimals() int64 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Token) GetTotalSupply() float64 {
+	if x != nil {
+		return x.TotalSupply
+	}
+	return 0
+}
+
+func (x *Token) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Token) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Token) GetTwitterUsername() string {
+	if x != nil {
+		return x.TwitterUsername
+	}
+	return ""
+}
+
+func (x *Token) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+func (x *Token) GetTelegram() string {
+	if x != nil {
+		return x.Telegram
+	}
+	return ""
+}
+
+func (x *Token) GetHoldCount() int64 {
+	if x != nil {
+		return x.HoldCount
+	}
+	return 0
+}
+
+func (x *Token) GetIsCheckCa() bool {
+	if x != nil {
+		return x.IsCheckCa
+	}
+	return false
+}
+
+func (x *Token) GetIsCaDropOwner() bool {
+	if x != nil {
+		return x.IsCaDropOwner
+	}
+	return false
+}
+
+func (x *Token) GetIsHoneyScam() bool {
+	if x != nil {
+		return x.IsHoneyScam
+	}
+	return false
+}
+
+func (x *Token) GetIsLiquidLock() bool {
+	if x != nil {
+		return x.IsLiquidLock
+	}
+	return false
+}
+
+func (x *Token) GetIsBurnPool() bool {
+	if x != nil {
+		return x.IsBurnPool
+	}
+	return false
+}
+
+func (x *Token) GetIsCanPauseTrade() bool {
+	if x != nil {
+		return x.IsCanPauseTrade
+	}
+	return false
+}
+
+func (x *Token) GetIsCanChangeTax() bool {
+	if x != nil {
+		return x.IsCanChangeTax
+	}
+	return false
+}
+
+func (x *Token) GetIsHaveBlackList() bool {
+	if x != nil {
+		return x.IsHaveBlackList
+	}
+	return false
+}
+
+func (x *Token) GetIsCanAllSell() bool {
+	if x != nil {
+		return x.IsCanAllSell
+	}
+	return false
+}
+
+func (x *Token) GetIsCanExternalCall() bool {
+	if x != nil {
+		return x.IsCanExternalCall
+	}
+	return false
+}
+
+func (x *Token) GetIsCanAddToken() bool {
+	if x != nil {
+		return x.IsCanAddToken
+	}
+	return false
+}
+
+func (x *Token) GetIsCanChangeToken() bool {
+	if x != nil {
+		return x.IsCanChangeToken
+	}
+	return false
+}
+
+func (x *Token) GetSellTax() float64 {
+	if x != nil {
+		return x.SellTax
+	}
+	return 0
+}
+
+func (x *Token) GetBuyTax() float64 {
+	if x != nil {
+		return x.BuyTax
+	}
+	return 0
+}
+
+func (x *Token) GetSniperCount() int64 {
+	if x != nil {
+		return x.SniperCount
+	}
+	return 0
+}
+
+func (x *Token) GetSniperHoldPercent() float64 {
+	if x != nil {
+		return x.SniperHoldPercent
+	}
+	return 0
+}
+
+func (x *Token) GetBundlerCount() int64 {
+	if x != nil {
+		return x.BundlerCount
+	}
+	return 0
+}
+
+func (x *Token) GetBundlerHoldPercent() float64 {
+	if x != nil {
+		return x.BundlerHoldPercent
+	}
+	return 0
+}
+
+func (x *Token) GetIsBundled() bool {
+	if x != nil {
+		return x.IsBundled
+	}
+	return false
+}
+
+func (x *Token) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+type GetTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenRequest) Reset() {
+	*x = GetTokenRequest{}
+	mi := &file_consumer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenRequest) ProtoMessage() {}
+
+func (x *GetTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTokenRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type GetTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *Token                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenResponse) Reset() {
+	*x = GetTokenResponse{}
+	mi := &file_consumer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenResponse) ProtoMessage() {}
+
+func (x *GetTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenResponse.ProtoReflect.Descriptor instead.
+func (*GetTokenResponse) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTokenResponse) GetToken() *Token {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+// 成交
+type Trade struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TxHash            string                 `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	HashId            string                 `protobuf:"bytes,3,opt,name=hash_id,json=hashId,proto3" json:"hash_id,omitempty"`
+	PairAddr          string                 `protobuf:"bytes,4,opt,name=pair_addr,json=pairAddr,proto3" json:"pair_addr,omitempty"`
+	Maker             string                 `protobuf:"bytes,5,opt,name=maker,proto3" json:"maker,omitempty"`
+	TradeType         string                 `protobuf:"bytes,6,opt,name=trade_type,json=tradeType,proto3" json:"trade_type,omitempty"`
+	BaseTokenAmount   float64                `protobuf:"fixed64,7,opt,name=base_token_amount,json=baseTokenAmount,proto3" json:"base_token_amount,omitempty"`
+	TokenAmount       float64                `protobuf:"fixed64,8,opt,name=token_amount,json=tokenAmount,proto3" json:"token_amount,omitempty"`
+	TokenTransferFee  float64                `protobuf:"fixed64,9,opt,name=token_transfer_fee,json=tokenTransferFee,proto3" json:"token_transfer_fee,omitempty"`
+	BaseTokenPriceUsd float64                `protobuf:"fixed64,10,opt,name=base_token_price_usd,json=baseTokenPriceUsd,proto3" json:"base_token_price_usd,omitempty"`
+	TotalUsd          float64                `protobuf:"fixed64,11,opt,name=total_usd,json=totalUsd,proto3" json:"total_usd,omitempty"`
+	TokenPriceUsd     float64                `protobuf:"fixed64,12,opt,name=token_price_usd,json=tokenPriceUsd,proto3" json:"token_price_usd,omitempty"`
+	To                string                 `protobuf:"bytes,13,opt,name=to,proto3" json:"to,omitempty"`
+	BlockNum          int64                  `protobuf:"varint,14,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	TransactionIndex  int64                  `protobuf:"varint,15,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	BlockTime         int64                  `protobuf:"varint,16,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"` // 秒
+	SwapName          string                 `protobuf:"bytes,17,opt,name=swap_name,json=swapName,proto3" json:"swap_name,omitempty"`
+	MevTag            string                 `protobuf:"bytes,18,opt,name=mev_tag,json=mevTag,proto3" json:"mev_tag,omitempty"`                  // sandwich_front / sandwich_back / sandwich_victim / self_trade / wash，空为正常成交
+	Platform          string                 `protobuf:"bytes,19,opt,name=platform,proto3" json:"platform,omitempty"`                            // 下单平台（交易机器人或前端），空为直接调用或未识别
+	LpFee             float64                `protobuf:"fixed64,20,opt,name=lp_fee,json=lpFee,proto3" json:"lp_fee,omitempty"`                   // LP 手续费（SOL）
+	ProtocolFee       float64                `protobuf:"fixed64,21,opt,name=protocol_fee,json=protocolFee,proto3" json:"protocol_fee,omitempty"` // 协议手续费（SOL）
+	CreatorFee        float64                `protobuf:"fixed64,22,opt,name=creator_fee,json=creatorFee,proto3" json:"creator_fee,omitempty"`    // 创建者手续费（SOL）
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_consumer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Trade) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Trade) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Trade) GetHashId() string {
+	if x != nil {
+		return x.HashId
+	}
+	return ""
+}
+
+func (x *Trade) GetPairAddr() string {
+	if x != nil {
+		return x.PairAddr
+	}
+	return ""
+}
+
+func (x *Trade) GetMaker() string {
+	if x != nil {
+		return x.Maker
+	}
+	return ""
+}
+
+func (x *Trade) GetTradeType() string {
+	if x != nil {
+		return x.TradeType
+	}
+	return ""
+}
+
+func (x *Trade) GetBaseTokenAmount() float64 {
+	if x != nil {
+		return x.BaseTokenAmount
+	}
+	return 0
+}
+
+func (x *Trade) GetTokenAmount() float64 {
+	if x != nil {
+		return x.TokenAmount
+	}
+	return 0
+}
+
+func (x *Trade) GetTokenTransferFee() float64 {
+	if x != nil {
+		return x.TokenTransferFee
+	}
+	return 0
+}
+
+func (x *Trade) GetBaseTokenPriceUsd() float64 {
+	if x != nil {
+		return x.BaseTokenPriceUsd
+	}
+	return 0
+}
+
+func (x *Trade) GetTotalUsd() float64 {
+	if x != nil {
+		return x.TotalUsd
+	}
+	return 0
+}
+
+func (x *Trade) GetTokenPriceUsd() float64 {
+	if x != nil {
+		return x.TokenPriceUsd
+	}
+	return 0
+}
+
+func (x *Trade) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Trade) GetBlockNum() int64 {
+	if x != nil {
+		return x.BlockNum
+	}
+	return 0
+}
+
+func (x *Trade) GetTransactionIndex() int64 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *Trade) GetBlockTime() int64 {
+	if x != nil {
+		return x.BlockTime
+	}
+	return 0
+}
+
+func (x *Trade) GetSwapName() string {
+	if x != nil {
+		return x.SwapName
+	}
+	return ""
+}
+
+func (x *Trade) GetMevTag() string {
+	if x != nil {
+		return x.MevTag
+	}
+	return ""
+}
+
+func (x *Trade) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *Trade) GetLpFee() float64 {
+	if x != nil {
+		return x.LpFee
+	}
+	return 0
+}
+
+func (x *Trade) GetProtocolFee() float64 {
+	if x != nil {
+		return x.ProtocolFee
+	}
+	return 0
+}
+
+func (x *Trade) GetCreatorFee() float64 {
+	if x != nil {
+		return x.CreatorFee
+	}
+	return 0
+}
+
+type ListTradesByPairRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PairAddress   string                 `protobuf:"bytes,1,opt,name=pair_address,json=pairAddress,proto3" json:"pair_address,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一页返回的 next_cursor，空表示从最新一条开始
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTradesByPairRequest) Reset() {
+	*x = ListTradesByPairRequest{}
+	mi := &file_consumer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTradesByPairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTradesByPairRequest) ProtoMessage() {}
+
+func (x *ListTradesByPairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTradesByPairRequest.ProtoReflect.Descriptor instead.
+func (*ListTradesByPairRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTradesByPairRequest) GetPairAddress() string {
+	if x != nil {
+		return x.PairAddress
+	}
+	return ""
+}
+
+func (x *ListTradesByPairRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTradesByPairRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTradesByTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一页返回的 next_cursor，空表示从最新一条开始
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTradesByTokenRequest) Reset() {
+	*x = ListTradesByTokenRequest{}
+	mi := &file_consumer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTradesByTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTradesByTokenRequest) ProtoMessage() {}
+
+func (x *ListTradesByTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTradesByTokenRequest.ProtoReflect.Descriptor instead.
+func (*ListTradesByTokenRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListTradesByTokenRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *ListTradesByTokenRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTradesByTokenRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTradesByWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wallet        string                 `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一页返回的 next_cursor，空表示从最新一条开始
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTradesByWalletRequest) Reset() {
+	*x = ListTradesByWalletRequest{}
+	mi := &file_consumer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTradesByWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTradesByWalletRequest) ProtoMessage() {}
+
+func (x *ListTradesByWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTradesByWalletRequest.ProtoReflect.Descriptor instead.
+func (*ListTradesByWalletRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListTradesByWalletRequest) GetWallet() string {
+	if x != nil {
+		return x.Wallet
+	}
+	return ""
+}
+
+func (x *ListTradesByWalletRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTradesByWalletRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTradesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	List          []*Trade               `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 空表示没有更多数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTradesResponse) Reset() {
+	*x = ListTradesResponse{}
+	mi := &file_consumer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTradesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTradesResponse) ProtoMessage() {}
+
+func (x *ListTradesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTradesResponse.ProtoReflect.Descriptor instead.
+func (*ListTradesResponse) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListTradesResponse) GetList() []*Trade {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *ListTradesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// 区块处理状态
+type GetBlockStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slot          int64                  `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockStatusRequest) Reset() {
+	*x = GetBlockStatusRequest{}
+	mi := &file_consumer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockStatusRequest) ProtoMessage() {}
+
+func (x *GetBlockStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockStatusRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetBlockStatusRequest) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+type GetBlockStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slot          int64                  `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	BlockHeight   int64                  `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockTime     int64                  `protobuf:"varint,3,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"` // 秒
+	Status        int64                  `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`                        // 1 已处理，2 处理失败，3 跳过
+	SolPrice      float64                `protobuf:"fixed64,5,opt,name=sol_price,json=solPrice,proto3" json:"sol_price,omitempty"`
+	ErrMessage    string                 `protobuf:"bytes,6,opt,name=err_message,json=errMessage,proto3" json:"err_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockStatusResponse) Reset() {
+	*x = GetBlockStatusResponse{}
+	mi := &file_consumer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockStatusResponse) ProtoMessage() {}
+
+func (x *GetBlockStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockStatusResponse) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetBlockStatusResponse) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *GetBlockStatusResponse) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *GetBlockStatusResponse) GetBlockTime() int64 {
+	if x != nil {
+		return x.BlockTime
+	}
+	return 0
+}
+
+func (x *GetBlockStatusResponse) GetStatus() int64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *GetBlockStatusResponse) GetSolPrice() float64 {
+	if x != nil {
+		return x.SolPrice
+	}
+	return 0
+}
+
+func (x *GetBlockStatusResponse) GetErrMessage() string {
+	if x != nil {
+		return x.ErrMessage
+	}
+	return ""
+}
+
+type GetIndexerLagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIndexerLagRequest) Reset() {
+	*x = GetIndexerLagRequest{}
+	mi := &file_consumer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIndexerLagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIndexerLagRequest) ProtoMessage() {}
+
+func (x *GetIndexerLagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIndexerLagRequest.ProtoReflect.Descriptor instead.
+func (*GetIndexerLagRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{17}
+}
+
+type GetIndexerLagResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ChainSlot        int64                  `protobuf:"varint,1,opt,name=chain_slot,json=chainSlot,proto3" json:"chain_slot,omitempty"`       // 链上最新 slot
+	IndexedSlot      int64                  `protobuf:"varint,2,opt,name=indexed_slot,json=indexedSlot,proto3" json:"indexed_slot,omitempty"` // 已处理的最新 slot
+	SlotLag          int64                  `protobuf:"varint,3,opt,name=slot_lag,json=slotLag,proto3" json:"slot_lag,omitempty"`
+	IndexedBlockTime int64                  `protobuf:"varint,4,opt,name=indexed_block_time,json=indexedBlockTime,proto3" json:"indexed_block_time,omitempty"` // 已处理最新区块的时间（秒）
+	TimeLagSeconds   int64                  `protobuf:"varint,5,opt,name=time_lag_seconds,json=timeLagSeconds,proto3" json:"time_lag_seconds,omitempty"`
+	FirstFailedSlot  int64                  `protobuf:"varint,6,opt,name=first_failed_slot,json=firstFailedSlot,proto3" json:"first_failed_slot,omitempty"` // 最早一个处理失败的 slot，0 表示没有
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetIndexerLagResponse) Reset() {
+	*x = GetIndexerLagResponse{}
+	mi := &file_consumer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIndexerLagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIndexerLagResponse) ProtoMessage() {}
+
+func (x *GetIndexerLagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIndexerLagResponse.ProtoReflect.Descriptor instead.
+func (*GetIndexerLagResponse) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetIndexerLagResponse) GetChainSlot() int64 {
+	if x != nil {
+		return x.ChainSlot
+	}
+	return 0
+}
+
+func (x *GetIndexerLagResponse) GetIndexedSlot() int64 {
+	if x != nil {
+		return x.IndexedSlot
+	}
+	return 0
+}
+
+func (x *GetIndexerLagResponse) GetSlotLag() int64 {
+	if x != nil {
+		return x.SlotLag
+	}
+	return 0
+}
+
+func (x *GetIndexerLagResponse) GetIndexedBlockTime() int64 {
+	if x != nil {
+		return x.IndexedBlockTime
+	}
+	return 0
+}
+
+func (x *GetIndexerLagResponse) GetTimeLagSeconds() int64 {
+	if x != nil {
+		return x.TimeLagSeconds
+	}
+	return 0
+}
+
+func (x *GetIndexerLagResponse) GetFirstFailedSlot() int64 {
+	if x != nil {
+		return x.FirstFailedSlot
+	}
+	return 0
+}
+
+// 下单平台（交易机器人、前端）成交统计
+type ListPlatformStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hours         int64                  `protobuf:"varint,1,opt,name=hours,proto3" json:"hours,omitempty"` // 统计最近多少小时，默认 24，最大 720
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlatformStatsRequest) Reset() {
+	*x = ListPlatformStatsRequest{}
+	mi := &file_consumer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlatformStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlatformStatsRequest) ProtoMessage() {}
+
+func (x *ListPlatformStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlatformStatsRequest.ProtoReflect.Descriptor instead.
+func (*ListPlatformStatsRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPlatformStatsRequest) GetHours() int64 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+type PlatformStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`                                  // 空表示直接调用或未识别
+	Volume        float64                `protobuf:"fixed64,2,opt,name=volume,proto3" json:"volume,omitempty"`                                    // 买卖成交额（USD）
+	OrganicVolume float64                `protobuf:"fixed64,3,opt,name=organic_volume,json=organicVolume,proto3" json:"organic_volume,omitempty"` // 排除夹子和刷量后的成交额
+	TradeCount    int64                  `protobuf:"varint,4,opt,name=trade_count,json=tradeCount,proto3" json:"trade_count,omitempty"`
+	MakerCount    int64                  `protobuf:"varint,5,opt,name=maker_count,json=makerCount,proto3" json:"maker_count,omitempty"`
+	VolumeShare   float64                `protobuf:"fixed64,6,opt,name=volume_share,json=volumeShare,proto3" json:"volume_share,omitempty"` // 占全部成交额的百分比
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlatformStat) Reset() {
+	*x = PlatformStat{}
+	mi := &file_consumer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlatformStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlatformStat) ProtoMessage() {}
+
+func (x *PlatformStat) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlatformStat.ProtoReflect.Descriptor instead.
+func (*PlatformStat) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PlatformStat) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *PlatformStat) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *PlatformStat) GetOrganicVolume() float64 {
+	if x != nil {
+		return x.OrganicVolume
+	}
+	return 0
+}
+
+func (x *PlatformStat) GetTradeCount() int64 {
+	if x != nil {
+		return x.TradeCount
+	}
+	return 0
+}
+
+func (x *PlatformStat) GetMakerCount() int64 {
+	if x != nil {
+		return x.MakerCount
+	}
+	return 0
+}
+
+func (x *PlatformStat) GetVolumeShare() float64 {
+	if x != nil {
+		return x.VolumeShare
+	}
+	return 0
+}
+
+type ListPlatformStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	List          []*PlatformStat        `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	TotalVolume   float64                `protobuf:"fixed64,2,opt,name=total_volume,json=totalVolume,proto3" json:"total_volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlatformStatsResponse) Reset() {
+	*x = ListPlatformStatsResponse{}
+	mi := &file_consumer_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlatformStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlatformStatsResponse) ProtoMessage() {}
+
+func (x *ListPlatformStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlatformStatsResponse.ProtoReflect.Descriptor instead.
+func (*ListPlatformStatsResponse) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListPlatformStatsResponse) GetList() []*PlatformStat {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *ListPlatformStatsResponse) GetTotalVolume() float64 {
+	if x != nil {
+		return x.TotalVolume
+	}
+	return 0
+}
+
+// 池子手续费：按日累计的 LP、协议和创建者手续费，APR 按统计区间内的 LP 手续费年化后除以当前流动性
+type GetPoolFeesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PairAddress   string                 `protobuf:"bytes,1,opt,name=pair_address,json=pairAddress,proto3" json:"pair_address,omitempty"`
+	Days          int64                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"` // 统计最近多少天（含当天），默认 7，最大 90
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoolFeesRequest) Reset() {
+	*x = GetPoolFeesRequest{}
+	mi := &file_consumer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoolFeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoolFeesRequest) ProtoMessage() {}
+
+func (x *GetPoolFeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoolFeesRequest.ProtoReflect.Descriptor instead.
+func (*GetPoolFeesRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetPoolFeesRequest) GetPairAddress() string {
+	if x != nil {
+		return x.PairAddress
+	}
+	return ""
+}
+
+func (x *GetPoolFeesRequest) GetDays() int64 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type PoolFeeDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`                                    // UTC 日期，如 2025-01-02
+	LpFee         float64                `protobuf:"fixed64,2,opt,name=lp_fee,json=lpFee,proto3" json:"lp_fee,omitempty"`                   // SOL
+	ProtocolFee   float64                `protobuf:"fixed64,3,opt,name=protocol_fee,json=protocolFee,proto3" json:"protocol_fee,omitempty"` // SOL
+	CreatorFee    float64                `protobuf:"fixed64,4,opt,name=creator_fee,json=creatorFee,proto3" json:"creator_fee,omitempty"`    // SOL
+	LpFeeUsd      float64                `protobuf:"fixed64,5,opt,name=lp_fee_usd,json=lpFeeUsd,proto3" json:"lp_fee_usd,omitempty"`
+	FeeUsd        float64                `protobuf:"fixed64,6,opt,name=fee_usd,json=feeUsd,proto3" json:"fee_usd,omitempty"` // 三项手续费合计（USD）
+	VolumeUsd     float64                `protobuf:"fixed64,7,opt,name=volume_usd,json=volumeUsd,proto3" json:"volume_usd,omitempty"`
+	TradeCount    int64                  `protobuf:"varint,8,opt,name=trade_count,json=tradeCount,proto3" json:"trade_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolFeeDay) Reset() {
+	*x = PoolFeeDay{}
+	mi := &file_consumer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolFeeDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolFeeDay) ProtoMessage() {}
+
+func (x *PoolFeeDay) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolFeeDay.ProtoReflect.Descriptor instead.
+func (*PoolFeeDay) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PoolFeeDay) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *PoolFeeDay) GetLpFee() float64 {
+	if x != nil {
+		return x.LpFee
+	}
+	return 0
+}
+
+func (x *PoolFeeDay) GetProtocolFee() float64 {
+	if x != nil {
+		return x.ProtocolFee
+	}
+	return 0
+}
+
+func (x *PoolFeeDay) GetCreatorFee() float64 {
+	if x != nil {
+		return x.CreatorFee
+	}
+	return 0
+}
+
+func (x *PoolFeeDay) GetLpFeeUsd() float64 {
+	if x != nil {
+		return x.LpFeeUsd
+	}
+	return 0
+}
+
+func (x *PoolFeeDay) GetFeeUsd() float64 {
+	if x != nil {
+		return x.FeeUsd
+	}
+	return 0
+}
+
+func (x *PoolFeeDay) GetVolumeUsd() float64 {
+	if x != nil {
+		return x.VolumeUsd
+	}
+	return 0
+}
+
+func (x *PoolFeeDay) GetTradeCount() int64 {
+	if x != nil {
+		return x.TradeCount
+	}
+	return 0
+}
+
+type GetPoolFeesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PairAddress   string                 `protobuf:"bytes,1,opt,name=pair_address,json=pairAddress,proto3" json:"pair_address,omitempty"`
+	CoinCreator   string                 `protobuf:"bytes,2,opt,name=coin_creator,json=coinCreator,proto3" json:"coin_creator,omitempty"`
+	LpFee         float64                `protobuf:"fixed64,3,opt,name=lp_fee,json=lpFee,proto3" json:"lp_fee,omitempty"` // 统计区间合计（SOL）
+	ProtocolFee   float64                `protobuf:"fixed64,4,opt,name=protocol_fee,json=protocolFee,proto3" json:"protocol_fee,omitempty"`
+	CreatorFee    float64                `protobuf:"fixed64,5,opt,name=creator_fee,json=creatorFee,proto3" json:"creator_fee,omitempty"`
+	LpFeeUsd      float64                `protobuf:"fixed64,6,opt,name=lp_fee_usd,json=lpFeeUsd,proto3" json:"lp_fee_usd,omitempty"`
+	FeeUsd        float64                `protobuf:"fixed64,7,opt,name=fee_usd,json=feeUsd,proto3" json:"fee_usd,omitempty"`
+	VolumeUsd     float64                `protobuf:"fixed64,8,opt,name=volume_usd,json=volumeUsd,proto3" json:"volume_usd,omitempty"`
+	Liquidity     float64                `protobuf:"fixed64,9,opt,name=liquidity,proto3" json:"liquidity,omitempty"` // 当前流动性（USD）
+	Apr           float64                `protobuf:"fixed64,10,opt,name=apr,proto3" json:"apr,omitempty"`            // LP 手续费年化收益率（%）
+	Days          []*PoolFeeDay          `protobuf:"bytes,11,rep,name=days,proto3" json:"days,omitempty"`            // 按日期倒序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoolFeesResponse) Reset() {
+	*x = GetPoolFeesResponse{}
+	mi := &file_consumer_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoolFeesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoolFeesResponse) ProtoMessage() {}
+
+func (x *GetPoolFeesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoolFeesResponse.ProtoReflect.Descriptor instead.
+func (*GetPoolFeesResponse) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetPoolFeesResponse) GetPairAddress() string {
+	if x != nil {
+		return x.PairAddress
+	}
+	return ""
+}
+
+func (x *GetPoolFeesResponse) GetCoinCreator() string {
+	if x != nil {
+		return x.CoinCreator
+	}
+	return ""
+}
+
+func (x *GetPoolFeesResponse) GetLpFee() float64 {
+	if x != nil {
+		return x.LpFee
+	}
+	return 0
+}
+
+func (x *GetPoolFeesResponse) GetProtocolFee() float64 {
+	if x != nil {
+		return x.ProtocolFee
+	}
+	return 0
+}
+
+func (x *GetPoolFeesResponse) GetCreatorFee() float64 {
+	if x != nil {
+		return x.CreatorFee
+	}
+	return 0
+}
+
+func (x *GetPoolFeesResponse) GetLpFeeUsd() float64 {
+	if x != nil {
+		return x.LpFeeUsd
+	}
+	return 0
+}
+
+func (x *GetPoolFeesResponse) GetFeeUsd() float64 {
+	if x != nil {
+		return x.FeeUsd
+	}
+	return 0
+}
+
+func (x *GetPoolFeesResponse) GetVolumeUsd() float64 {
+	if x != nil {
+		return x.VolumeUsd
+	}
+	return 0
+}
+
+func (x *GetPoolFeesResponse) GetLiquidity() float64 {
+	if x != nil {
+		return x.Liquidity
+	}
+	return 0
+}
+
+func (x *GetPoolFeesResponse) GetApr() float64 {
+	if x != nil {
+		return x.Apr
+	}
+	return 0
+}
+
+func (x *GetPoolFeesResponse) GetDays() []*PoolFeeDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+// 创建者手续费收益：累计产生的手续费从开始解析区块起计算，未领取金额为估算值
+type GetCreatorFeesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Creator       string                 `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 返回最近多少条领取记录，默认 50，最大 200
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCreatorFeesRequest) Reset() {
+	*x = GetCreatorFeesRequest{}
+	mi := &file_consumer_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCreatorFeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCreatorFeesRequest) ProtoMessage() {}
+
+func (x *GetCreatorFeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCreatorFeesRequest.ProtoReflect.Descriptor instead.
+func (*GetCreatorFeesRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetCreatorFeesRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *GetCreatorFeesRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CreatorFeeClaim struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxHash        string                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"` // SOL
+	AmountUsd     float64                `protobuf:"fixed64,3,opt,name=amount_usd,json=amountUsd,proto3" json:"amount_usd,omitempty"`
+	Slot          int64                  `protobuf:"varint,4,opt,name=slot,proto3" json:"slot,omitempty"`
+	BlockTime     int64                  `protobuf:"varint,5,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"` // 秒
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatorFeeClaim) Reset() {
+	*x = CreatorFeeClaim{}
+	mi := &file_consumer_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatorFeeClaim) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatorFeeClaim) ProtoMessage() {}
+
+func (x *CreatorFeeClaim) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatorFeeClaim.ProtoReflect.Descriptor instead.
+func (*CreatorFeeClaim) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreatorFeeClaim) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *CreatorFeeClaim) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreatorFeeClaim) GetAmountUsd() float64 {
+	if x != nil {
+		return x.AmountUsd
+	}
+	return 0
+}
+
+func (x *CreatorFeeClaim) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *CreatorFeeClaim) GetBlockTime() int64 {
+	if x != nil {
+		return x.BlockTime
+	}
+	return 0
+}
+
+type GetCreatorFeesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Creator       string                 `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	AccruedFee    float64                `protobuf:"fixed64,2,opt,name=accrued_fee,json=accruedFee,proto3" json:"accrued_fee,omitempty"` // 累计产生（SOL）
+	AccruedFeeUsd float64                `protobuf:"fixed64,3,opt,name=accrued_fee_usd,json=accruedFeeUsd,proto3" json:"accrued_fee_usd,omitempty"`
+	ClaimedFee    float64                `protobuf:"fixed64,4,opt,name=claimed_fee,json=claimedFee,proto3" json:"claimed_fee,omitempty"` // 累计领取（SOL）
+	ClaimedFeeUsd float64                `protobuf:"fixed64,5,opt,name=claimed_fee_usd,json=claimedFeeUsd,proto3" json:"claimed_fee_usd,omitempty"`
+	UnclaimedFee  float64                `protobuf:"fixed64,6,opt,name=unclaimed_fee,json=unclaimedFee,proto3" json:"unclaimed_fee,omitempty"` // 累计产生减累计领取，不小于 0
+	ClaimCount    int64                  `protobuf:"varint,7,opt,name=claim_count,json=claimCount,proto3" json:"claim_count,omitempty"`
+	LastClaimAt   int64                  `protobuf:"varint,8,opt,name=last_claim_at,json=lastClaimAt,proto3" json:"last_claim_at,omitempty"` // 秒
+	Claims        []*CreatorFeeClaim     `protobuf:"bytes,9,rep,name=claims,proto3" json:"claims,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCreatorFeesResponse) Reset() {
+	*x = GetCreatorFeesResponse{}
+	mi := &file_consumer_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCreatorFeesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCreatorFeesResponse) ProtoMessage() {}
+
+func (x *GetCreatorFeesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCreatorFeesResponse.ProtoReflect.Descriptor instead.
+func (*GetCreatorFeesResponse) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetCreatorFeesResponse) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *GetCreatorFeesResponse) GetAccruedFee() float64 {
+	if x != nil {
+		return x.AccruedFee
+	}
+	return 0
+}
+
+func (x *GetCreatorFeesResponse) GetAccruedFeeUsd() float64 {
+	if x != nil {
+		return x.AccruedFeeUsd
+	}
+	return 0
+}
+
+func (x *GetCreatorFeesResponse) GetClaimedFee() float64 {
+	if x != nil {
+		return x.ClaimedFee
+	}
+	return 0
+}
+
+func (x *GetCreatorFeesResponse) GetClaimedFeeUsd() float64 {
+	if x != nil {
+		return x.ClaimedFeeUsd
+	}
+	return 0
+}
+
+func (x *GetCreatorFeesResponse) GetUnclaimedFee() float64 {
+	if x != nil {
+		return x.UnclaimedFee
+	}
+	return 0
+}
+
+func (x *GetCreatorFeesResponse) GetClaimCount() int64 {
+	if x != nil {
+		return x.ClaimCount
+	}
+	return 0
+}
+
+func (x *GetCreatorFeesResponse) GetLastClaimAt() int64 {
+	if x != nil {
+		return x.LastClaimAt
+	}
+	return 0
+}
+
+func (x *GetCreatorFeesResponse) GetClaims() []*CreatorFeeClaim {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+// 实时推送：按交易对、代币、钱包订阅成交和交易对更新，或订阅新交易对
+type SubscribeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PairAddresses  []string               `protobuf:"bytes,1,rep,name=pair_addresses,json=pairAddresses,proto3" json:"pair_addresses,omitempty"`
+	TokenAddresses []string               `protobuf:"bytes,2,rep,name=token_addresses,json=tokenAddresses,proto3" json:"token_addresses,omitempty"`
+	Wallets        []string               `protobuf:"bytes,3,rep,name=wallets,proto3" json:"wallets,omitempty"` // 按成交发起地址过滤
+	NewPairs       bool                   `protobuf:"varint,4,opt,name=new_pairs,json=newPairs,proto3" json:"new_pairs,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_consumer_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SubscribeRequest) GetPairAddresses() []string {
+	if x != nil {
+		return x.PairAddresses
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetWallets() []string {
+	if x != nil {
+		return x.Wallets
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetNewPairs() bool {
+	if x != nil {
+		return x.NewPairs
+	}
+	return false
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // trade、pair（价格/流动性更新）、new_pair、heartbeat
+	Slot          int64                  `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	Time          int64                  `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"` // 事件生成时间（秒）
+	TokenAddress  string                 `protobuf:"bytes,4,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	Trade         *Trade                 `protobuf:"bytes,5,opt,name=trade,proto3" json:"trade,omitempty"`
+	Pair          *Pair                  `protobuf:"bytes,6,opt,name=pair,proto3" json:"pair,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_consumer_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_consumer_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_consumer_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *Event) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Event) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *Event) GetTrade() *Trade {
+	if x != nil {
+		return x.Trade
+	}
+	return nil
+}
+
+func (x *Event) GetPair() *Pair {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
 var File_consumer_proto protoreflect.FileDescriptor
 
 const file_consumer_proto_rawDesc = "" +
@@ -117,9 +2549,264 @@ const file_consumer_proto_rawDesc = "" +
 	"\aRequest\x12\x12\n" +
 	"\x04ping\x18\x01 \x01(\tR\x04ping\"\x1e\n" +
 	"\bResponse\x12\x12\n" +
-	"\x04pong\x18\x01 \x01(\tR\x04pong29\n" +
+	"\x04pong\x18\x01 \x01(\tR\x04pong\"\xbf\a\n" +
+	"\x04Pair\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12#\n" +
+	"\rtoken_address\x18\x04 \x01(\tR\ftokenAddress\x12,\n" +
+	"\x12base_token_address\x18\x05 \x01(\tR\x10baseTokenAddress\x12!\n" +
+	"\ftoken_symbol\x18\x06 \x01(\tR\vtokenSymbol\x12*\n" +
+	"\x11base_token_symbol\x18\a \x01(\tR\x0fbaseTokenSymbol\x12#\n" +
+	"\rtoken_decimal\x18\b \x01(\x03R\ftokenDecimal\x12,\n" +
+	"\x12base_token_decimal\x18\t \x01(\x03R\x10baseTokenDecimal\x120\n" +
+	"\x14current_token_amount\x18\n" +
+	" \x01(\x01R\x12currentTokenAmount\x129\n" +
+	"\x19current_base_token_amount\x18\v \x01(\x01R\x16currentBaseTokenAmount\x12\x1c\n" +
+	"\tliquidity\x18\f \x01(\x01R\tliquidity\x12\x1f\n" +
+	"\vtoken_price\x18\r \x01(\x01R\n" +
+	"tokenPrice\x12(\n" +
+	"\x10base_token_price\x18\x0e \x01(\x01R\x0ebaseTokenPrice\x12\x10\n" +
+	"\x03fdv\x18\x0f \x01(\x01R\x03fdv\x12\x17\n" +
+	"\amkt_cap\x18\x10 \x01(\x01R\x06mktCap\x12\x1d\n" +
+	"\n" +
+	"pump_point\x18\x11 \x01(\x01R\tpumpPoint\x12\x1f\n" +
+	"\vpump_status\x18\x12 \x01(\x03R\n" +
+	"pumpStatus\x12$\n" +
+	"\x0epump_pair_addr\x18\x13 \x01(\tR\fpumpPairAddr\x12\x1b\n" +
+	"\tblock_num\x18\x14 \x01(\x03R\bblockNum\x12\x1d\n" +
+	"\n" +
+	"block_time\x18\x15 \x01(\x03R\tblockTime\x12*\n" +
+	"\x11latest_trade_time\x18\x16 \x01(\x03R\x0flatestTradeTime\x12\x16\n" +
+	"\x06volume\x18\x17 \x01(\x01R\x06volume\x12\x15\n" +
+	"\x06is_rug\x18\x18 \x01(\bR\x05isRug\x12\x1d\n" +
+	"\n" +
+	"rug_reason\x18\x19 \x01(\tR\trugReason\x12\x15\n" +
+	"\x06rug_at\x18\x1a \x01(\x03R\x05rugAt\x12\x1e\n" +
+	"\vrug_tx_hash\x18\x1b \x01(\tR\trugTxHash\x12%\n" +
+	"\x0eorganic_volume\x18\x1c \x01(\x01R\rorganicVolume\"*\n" +
+	"\x0eGetPairRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"5\n" +
+	"\x0fGetPairResponse\x12\"\n" +
+	"\x04pair\x18\x01 \x01(\v2\x0e.consumer.PairR\x04pair\"\x9f\x02\n" +
+	"\x10ListPairsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12$\n" +
+	"\vpump_status\x18\x02 \x01(\x03H\x00R\n" +
+	"pumpStatus\x88\x01\x01\x12#\n" +
+	"\rmin_liquidity\x18\x03 \x01(\x01R\fminLiquidity\x12\x1d\n" +
+	"\n" +
+	"min_volume\x18\x04 \x01(\x01R\tminVolume\x12\x19\n" +
+	"\border_by\x18\x05 \x01(\tR\aorderBy\x12\x10\n" +
+	"\x03asc\x18\x06 \x01(\bR\x03asc\x12\x12\n" +
+	"\x04page\x18\a \x01(\x03R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x03R\bpageSize\x12\x1f\n" +
+	"\vexclude_rug\x18\t \x01(\bR\n" +
+	"excludeRugB\x0e\n" +
+	"\f_pump_status\"7\n" +
+	"\x11ListPairsResponse\x12\"\n" +
+	"\x04list\x18\x01 \x03(\v2\x0e.consumer.PairR\x04list\"\xf2\b\n" +
+	"\x05Token\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x18\n" +
+	"\aprogram\x18\x03 \x01(\tR\aprogram\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x05 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\x06 \x01(\x03R\bdecimals\x12!\n" +
+	"\ftotal_supply\x18\a \x01(\x01R\vtotalSupply\x12\x12\n" +
+	"\x04icon\x18\b \x01(\tR\x04icon\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12)\n" +
+	"\x10twitter_username\x18\n" +
+	" \x01(\tR\x0ftwitterUsername\x12\x18\n" +
+	"\awebsite\x18\v \x01(\tR\awebsite\x12\x1a\n" +
+	"\btelegram\x18\f \x01(\tR\btelegram\x12\x1d\n" +
+	"\n" +
+	"hold_count\x18\r \x01(\x03R\tholdCount\x12\x1e\n" +
+	"\vis_check_ca\x18\x0e \x01(\bR\tisCheckCa\x12'\n" +
+	"\x10is_ca_drop_owner\x18\x0f \x01(\bR\risCaDropOwner\x12\"\n" +
+	"\ris_honey_scam\x18\x10 \x01(\bR\visHoneyScam\x12$\n" +
+	"\x0eis_liquid_lock\x18\x11 \x01(\bR\fisLiquidLock\x12 \n" +
+	"\fis_burn_pool\x18\x12 \x01(\bR\n" +
+	"isBurnPool\x12+\n" +
+	"\x12is_can_pause_trade\x18\x13 \x01(\bR\x0fisCanPauseTrade\x12)\n" +
+	"\x11is_can_change_tax\x18\x14 \x01(\bR\x0eisCanChangeTax\x12+\n" +
+	"\x12is_have_black_list\x18\x15 \x01(\bR\x0fisHaveBlackList\x12%\n" +
+	"\x0fis_can_all_sell\x18\x16 \x01(\bR\fisCanAllSell\x12/\n" +
+	"\x14is_can_external_call\x18\x17 \x01(\bR\x11isCanExternalCall\x12'\n" +
+	"\x10is_can_add_token\x18\x18 \x01(\bR\risCanAddToken\x12-\n" +
+	"\x13is_can_change_token\x18\x19 \x01(\bR\x10isCanChangeToken\x12\x19\n" +
+	"\bsell_tax\x18\x1a \x01(\x01R\asellTax\x12\x17\n" +
+	"\abuy_tax\x18\x1b \x01(\x01R\x06buyTax\x12!\n" +
+	"\fsniper_count\x18\x1c \x01(\x03R\vsniperCount\x12.\n" +
+	"\x13sniper_hold_percent\x18\x1d \x01(\x01R\x11sniperHoldPercent\x12#\n" +
+	"\rbundler_count\x18\x1e \x01(\x03R\fbundlerCount\x120\n" +
+	"\x14bundler_hold_percent\x18\x1f \x01(\x01R\x12bundlerHoldPercent\x12\x1d\n" +
+	"\n" +
+	"is_bundled\x18  \x01(\bR\tisBundled\x12\x12\n" +
+	"\x04slot\x18! \x01(\x03R\x04slot\"+\n" +
+	"\x0fGetTokenRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"9\n" +
+	"\x10GetTokenResponse\x12%\n" +
+	"\x05token\x18\x01 \x01(\v2\x0f.consumer.TokenR\x05token\"\xb4\x05\n" +
+	"\x05Trade\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atx_hash\x18\x02 \x01(\tR\x06txHash\x12\x17\n" +
+	"\ahash_id\x18\x03 \x01(\tR\x06hashId\x12\x1b\n" +
+	"\tpair_addr\x18\x04 \x01(\tR\bpairAddr\x12\x14\n" +
+	"\x05maker\x18\x05 \x01(\tR\x05maker\x12\x1d\n" +
+	"\n" +
+	"trade_type\x18\x06 \x01(\tR\ttradeType\x12*\n" +
+	"\x11base_token_amount\x18\a \x01(\x01R\x0fbaseTokenAmount\x12!\n" +
+	"\ftoken_amount\x18\b \x01(\x01R\vtokenAmount\x12,\n" +
+	"\x12token_transfer_fee\x18\t \x01(\x01R\x10tokenTransferFee\x12/\n" +
+	"\x14base_token_price_usd\x18\n" +
+	" \x01(\x01R\x11baseTokenPriceUsd\x12\x1b\n" +
+	"\ttotal_usd\x18\v \x01(\x01R\btotalUsd\x12&\n" +
+	"\x0ftoken_price_usd\x18\f \x01(\x01R\rtokenPriceUsd\x12\x0e\n" +
+	"\x02to\x18\r \x01(\tR\x02to\x12\x1b\n" +
+	"\tblock_num\x18\x0e \x01(\x03R\bblockNum\x12+\n" +
+	"\x11transaction_index\x18\x0f \x01(\x03R\x10transactionIndex\x12\x1d\n" +
+	"\n" +
+	"block_time\x18\x10 \x01(\x03R\tblockTime\x12\x1b\n" +
+	"\tswap_name\x18\x11 \x01(\tR\bswapName\x12\x17\n" +
+	"\amev_tag\x18\x12 \x01(\tR\x06mevTag\x12\x1a\n" +
+	"\bplatform\x18\x13 \x01(\tR\bplatform\x12\x15\n" +
+	"\x06lp_fee\x18\x14 \x01(\x01R\x05lpFee\x12!\n" +
+	"\fprotocol_fee\x18\x15 \x01(\x01R\vprotocolFee\x12\x1f\n" +
+	"\vcreator_fee\x18\x16 \x01(\x01R\n" +
+	"creatorFee\"j\n" +
+	"\x17ListTradesByPairRequest\x12!\n" +
+	"\fpair_address\x18\x01 \x01(\tR\vpairAddress\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\"m\n" +
+	"\x18ListTradesByTokenRequest\x12#\n" +
+	"\rtoken_address\x18\x01 \x01(\tR\ftokenAddress\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\"a\n" +
+	"\x19ListTradesByWalletRequest\x12\x16\n" +
+	"\x06wallet\x18\x01 \x01(\tR\x06wallet\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\"Z\n" +
+	"\x12ListTradesResponse\x12#\n" +
+	"\x04list\x18\x01 \x03(\v2\x0f.consumer.TradeR\x04list\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"+\n" +
+	"\x15GetBlockStatusRequest\x12\x12\n" +
+	"\x04slot\x18\x01 \x01(\x03R\x04slot\"\xc4\x01\n" +
+	"\x16GetBlockStatusResponse\x12\x12\n" +
+	"\x04slot\x18\x01 \x01(\x03R\x04slot\x12!\n" +
+	"\fblock_height\x18\x02 \x01(\x03R\vblockHeight\x12\x1d\n" +
+	"\n" +
+	"block_time\x18\x03 \x01(\x03R\tblockTime\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\x03R\x06status\x12\x1b\n" +
+	"\tsol_price\x18\x05 \x01(\x01R\bsolPrice\x12\x1f\n" +
+	"\verr_message\x18\x06 \x01(\tR\n" +
+	"errMessage\"\x16\n" +
+	"\x14GetIndexerLagRequest\"\xf8\x01\n" +
+	"\x15GetIndexerLagResponse\x12\x1d\n" +
+	"\n" +
+	"chain_slot\x18\x01 \x01(\x03R\tchainSlot\x12!\n" +
+	"\findexed_slot\x18\x02 \x01(\x03R\vindexedSlot\x12\x19\n" +
+	"\bslot_lag\x18\x03 \x01(\x03R\aslotLag\x12,\n" +
+	"\x12indexed_block_time\x18\x04 \x01(\x03R\x10indexedBlockTime\x12(\n" +
+	"\x10time_lag_seconds\x18\x05 \x01(\x03R\x0etimeLagSeconds\x12*\n" +
+	"\x11first_failed_slot\x18\x06 \x01(\x03R\x0ffirstFailedSlot\"0\n" +
+	"\x18ListPlatformStatsRequest\x12\x14\n" +
+	"\x05hours\x18\x01 \x01(\x03R\x05hours\"\xce\x01\n" +
+	"\fPlatformStat\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x16\n" +
+	"\x06volume\x18\x02 \x01(\x01R\x06volume\x12%\n" +
+	"\x0eorganic_volume\x18\x03 \x01(\x01R\rorganicVolume\x12\x1f\n" +
+	"\vtrade_count\x18\x04 \x01(\x03R\n" +
+	"tradeCount\x12\x1f\n" +
+	"\vmaker_count\x18\x05 \x01(\x03R\n" +
+	"makerCount\x12!\n" +
+	"\fvolume_share\x18\x06 \x01(\x01R\vvolumeShare\"j\n" +
+	"\x19ListPlatformStatsResponse\x12*\n" +
+	"\x04list\x18\x01 \x03(\v2\x16.consumer.PlatformStatR\x04list\x12!\n" +
+	"\ftotal_volume\x18\x02 \x01(\x01R\vtotalVolume\"K\n" +
+	"\x12GetPoolFeesRequest\x12!\n" +
+	"\fpair_address\x18\x01 \x01(\tR\vpairAddress\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x03R\x04days\"\xf2\x01\n" +
+	"\n" +
+	"PoolFeeDay\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x15\n" +
+	"\x06lp_fee\x18\x02 \x01(\x01R\x05lpFee\x12!\n" +
+	"\fprotocol_fee\x18\x03 \x01(\x01R\vprotocolFee\x12\x1f\n" +
+	"\vcreator_fee\x18\x04 \x01(\x01R\n" +
+	"creatorFee\x12\x1c\n" +
+	"\n" +
+	"lp_fee_usd\x18\x05 \x01(\x01R\blpFeeUsd\x12\x17\n" +
+	"\afee_usd\x18\x06 \x01(\x01R\x06feeUsd\x12\x1d\n" +
+	"\n" +
+	"volume_usd\x18\a \x01(\x01R\tvolumeUsd\x12\x1f\n" +
+	"\vtrade_count\x18\b \x01(\x03R\n" +
+	"tradeCount\"\xe6\x02\n" +
+	"\x13GetPoolFeesResponse\x12!\n" +
+	"\fpair_address\x18\x01 \x01(\tR\vpairAddress\x12!\n" +
+	"\fcoin_creator\x18\x02 \x01(\tR\vcoinCreator\x12\x15\n" +
+	"\x06lp_fee\x18\x03 \x01(\x01R\x05lpFee\x12!\n" +
+	"\fprotocol_fee\x18\x04 \x01(\x01R\vprotocolFee\x12\x1f\n" +
+	"\vcreator_fee\x18\x05 \x01(\x01R\n" +
+	"creatorFee\x12\x1c\n" +
+	"\n" +
+	"lp_fee_usd\x18\x06 \x01(\x01R\blpFeeUsd\x12\x17\n" +
+	"\afee_usd\x18\a \x01(\x01R\x06feeUsd\x12\x1d\n" +
+	"\n" +
+	"volume_usd\x18\b \x01(\x01R\tvolumeUsd\x12\x1c\n" +
+	"\tliquidity\x18\t \x01(\x01R\tliquidity\x12\x10\n" +
+	"\x03apr\x18\n" +
+	" \x01(\x01R\x03apr\x12(\n" +
+	"\x04days\x18\v \x03(\v2\x14.consumer.PoolFeeDayR\x04days\"G\n" +
+	"\x15GetCreatorFeesRequest\x12\x18\n" +
+	"\acreator\x18\x01 \x01(\tR\acreator\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"\x94\x01\n" +
+	"\x0fCreatorFeeClaim\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\tR\x06txHash\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1d\n" +
+	"\n" +
+	"amount_usd\x18\x03 \x01(\x01R\tamountUsd\x12\x12\n" +
+	"\x04slot\x18\x04 \x01(\x03R\x04slot\x12\x1d\n" +
+	"\n" +
+	"block_time\x18\x05 \x01(\x03R\tblockTime\"\xe1\x02\n" +
+	"\x16GetCreatorFeesResponse\x12\x18\n" +
+	"\acreator\x18\x01 \x01(\tR\acreator\x12\x1f\n" +
+	"\vaccrued_fee\x18\x02 \x01(\x01R\n" +
+	"accruedFee\x12&\n" +
+	"\x0faccrued_fee_usd\x18\x03 \x01(\x01R\raccruedFeeUsd\x12\x1f\n" +
+	"\vclaimed_fee\x18\x04 \x01(\x01R\n" +
+	"claimedFee\x12&\n" +
+	"\x0fclaimed_fee_usd\x18\x05 \x01(\x01R\rclaimedFeeUsd\x12#\n" +
+	"\runclaimed_fee\x18\x06 \x01(\x01R\funclaimedFee\x12\x1f\n" +
+	"\vclaim_count\x18\a \x01(\x03R\n" +
+	"claimCount\x12\"\n" +
+	"\rlast_claim_at\x18\b \x01(\x03R\vlastClaimAt\x121\n" +
+	"\x06claims\x18\t \x03(\v2\x19.consumer.CreatorFeeClaimR\x06claims\"\x99\x01\n" +
+	"\x10SubscribeRequest\x12%\n" +
+	"\x0epair_addresses\x18\x01 \x03(\tR\rpairAddresses\x12'\n" +
+	"\x0ftoken_addresses\x18\x02 \x03(\tR\x0etokenAddresses\x12\x18\n" +
+	"\awallets\x18\x03 \x03(\tR\awallets\x12\x1b\n" +
+	"\tnew_pairs\x18\x04 \x01(\bR\bnewPairs\"\xb3\x01\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04slot\x18\x02 \x01(\x03R\x04slot\x12\x12\n" +
+	"\x04time\x18\x03 \x01(\x03R\x04time\x12#\n" +
+	"\rtoken_address\x18\x04 \x01(\tR\ftokenAddress\x12%\n" +
+	"\x05trade\x18\x05 \x01(\v2\x0f.consumer.TradeR\x05trade\x12\"\n" +
+	"\x04pair\x18\x06 \x01(\v2\x0e.consumer.PairR\x04pair2\xe9\a\n" +
 	"\bConsumer\x12-\n" +
-	"\x04Ping\x12\x11.consumer.Request\x1a\x12.consumer.ResponseB\fZ\n" +
+	"\x04Ping\x12\x11.consumer.Request\x1a\x12.consumer.Response\x12>\n" +
+	"\aGetPair\x12\x18.consumer.GetPairRequest\x1a\x19.consumer.GetPairResponse\x12D\n" +
+	"\tListPairs\x12\x1a.consumer.ListPairsRequest\x1a\x1b.consumer.ListPairsResponse\x12A\n" +
+	"\bGetToken\x12\x19.consumer.GetTokenRequest\x1a\x1a.consumer.GetTokenResponse\x12S\n" +
+	"\x10ListTradesByPair\x12!.consumer.ListTradesByPairRequest\x1a\x1c.consumer.ListTradesResponse\x12U\n" +
+	"\x11ListTradesByToken\x12\".consumer.ListTradesByTokenRequest\x1a\x1c.consumer.ListTradesResponse\x12W\n" +
+	"\x12ListTradesByWallet\x12#.consumer.ListTradesByWalletRequest\x1a\x1c.consumer.ListTradesResponse\x12S\n" +
+	"\x0eGetBlockStatus\x12\x1f.consumer.GetBlockStatusRequest\x1a .consumer.GetBlockStatusResponse\x12P\n" +
+	"\rGetIndexerLag\x12\x1e.consumer.GetIndexerLagRequest\x1a\x1f.consumer.GetIndexerLagResponse\x12\\\n" +
+	"\x11ListPlatformStats\x12\".consumer.ListPlatformStatsRequest\x1a#.consumer.ListPlatformStatsResponse\x12J\n" +
+	"\vGetPoolFees\x12\x1c.consumer.GetPoolFeesRequest\x1a\x1d.consumer.GetPoolFeesResponse\x12S\n" +
+	"\x0eGetCreatorFees\x12\x1f.consumer.GetCreatorFeesRequest\x1a .consumer.GetCreatorFeesResponse\x12:\n" +
+	"\tSubscribe\x12\x1a.consumer.SubscribeRequest\x1a\x0f.consumer.Event0\x01B\fZ\n" +
 	"./consumerb\x06proto3"
 
 var (
@@ -134,19 +2821,80 @@ func file_consumer_proto_rawDescGZIP() []byte {
 	return file_consumer_proto_rawDescData
 }
 
-var file_consumer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_consumer_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_consumer_proto_goTypes = []any{
-	(*Request)(nil),  // 0: consumer.Request
-	(*Response)(nil), // 1: consumer.Response
+	(*Request)(nil),                   // 0: consumer.Request
+	(*Response)(nil),                  // 1: consumer.Response
+	(*Pair)(nil),                      // 2: consumer.Pair
+	(*GetPairRequest)(nil),            // 3: consumer.GetPairRequest
+	(*GetPairResponse)(nil),           // 4: consumer.GetPairResponse
+	(*ListPairsRequest)(nil),          // 5: consumer.ListPairsRequest
+	(*ListPairsResponse)(nil),         // 6: consumer.ListPairsResponse
+	(*Token)(nil),                     // 7: consumer.Token
+	(*GetTokenRequest)(nil),           // 8: consumer.GetTokenRequest
+	(*GetTokenResponse)(nil),          // 9: consumer.GetTokenResponse
+	(*Trade)(nil),                     // 10: consumer.Trade
+	(*ListTradesByPairRequest)(nil),   // 11: consumer.ListTradesByPairRequest
+	(*ListTradesByTokenRequest)(nil),  // 12: consumer.ListTradesByTokenRequest
+	(*ListTradesByWalletRequest)(nil), // 13: consumer.ListTradesByWalletRequest
+	(*ListTradesResponse)(nil),        // 14: consumer.ListTradesResponse
+	(*GetBlockStatusRequest)(nil),     // 15: consumer.GetBlockStatusRequest
+	(*GetBlockStatusResponse)(nil),    // 16: consumer.GetBlockStatusResponse
+	(*GetIndexerLagRequest)(nil),      // 17: consumer.GetIndexerLagRequest
+	(*GetIndexerLagResponse)(nil),     // 18: consumer.GetIndexerLagResponse
+	(*ListPlatformStatsRequest)(nil),  // 19: consumer.ListPlatformStatsRequest
+	(*PlatformStat)(nil),              // 20: consumer.PlatformStat
+	(*ListPlatformStatsResponse)(nil), // 21: consumer.ListPlatformStatsResponse
+	(*GetPoolFeesRequest)(nil),        // 22: consumer.GetPoolFeesRequest
+	(*PoolFeeDay)(nil),                // 23: consumer.PoolFeeDay
+	(*GetPoolFeesResponse)(nil),       // 24: consumer.GetPoolFeesResponse
+	(*GetCreatorFeesRequest)(nil),     // 25: consumer.GetCreatorFeesRequest
+	(*CreatorFeeClaim)(nil),           // 26: consumer.CreatorFeeClaim
+	(*GetCreatorFeesResponse)(nil),    // 27: consumer.GetCreatorFeesResponse
+	(*SubscribeRequest)(nil),          // 28: consumer.SubscribeRequest
+	(*Event)(nil),                     // 29: consumer.Event
 }
 var file_consumer_proto_depIdxs = []int32{
-	0, // 0: consumer.Consumer.Ping:input_type -> consumer.Request
-	1, // 1: consumer.Consumer.Ping:output_type -> consumer.Response
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2,  // 0: consumer.GetPairResponse.pair:type_name -> consumer.Pair
+	2,  // 1: consumer.ListPairsResponse.list:type_name -> consumer.Pair
+	7,  // 2: consumer.GetTokenResponse.token:type_name -> consumer.Token
+	10, // 3: consumer.ListTradesResponse.list:type_name -> consumer.Trade
+	20, // 4: consumer.ListPlatformStatsResponse.list:type_name -> consumer.PlatformStat
+	23, // 5: consumer.GetPoolFeesResponse.days:type_name -> consumer.PoolFeeDay
+	26, // 6: consumer.GetCreatorFeesResponse.claims:type_name -> consumer.CreatorFeeClaim
+	10, // 7: consumer.Event.trade:type_name -> consumer.Trade
+	2,  // 8: consumer.Event.pair:type_name -> consumer.Pair
+	0,  // 9: consumer.Consumer.Ping:input_type -> consumer.Request
+	3,  // 10: consumer.Consumer.GetPair:input_type -> consumer.GetPairRequest
+	5,  // 11: consumer.Consumer.ListPairs:input_type -> consumer.ListPairsRequest
+	8,  // 12: consumer.Consumer.GetToken:input_type -> consumer.GetTokenRequest
+	11, // 13: consumer.Consumer.ListTradesByPair:input_type -> consumer.ListTradesByPairRequest
+	12, // 14: consumer.Consumer.ListTradesByToken:input_type -> consumer.ListTradesByTokenRequest
+	13, // 15: consumer.Consumer.ListTradesByWallet:input_type -> consumer.ListTradesByWalletRequest
+	15, // 16: consumer.Consumer.GetBlockStatus:input_type -> consumer.GetBlockStatusRequest
+	17, // 17: consumer.Consumer.GetIndexerLag:input_type -> consumer.GetIndexerLagRequest
+	19, // 18: consumer.Consumer.ListPlatformStats:input_type -> consumer.ListPlatformStatsRequest
+	22, // 19: consumer.Consumer.GetPoolFees:input_type -> consumer.GetPoolFeesRequest
+	25, // 20: consumer.Consumer.GetCreatorFees:input_type -> consumer.GetCreatorFeesRequest
+	28, // 21: consumer.Consumer.Subscribe:input_type -> consumer.SubscribeRequest
+	1,  // 22: consumer.Consumer.Ping:output_type -> consumer.Response
+	4,  // 23: consumer.Consumer.GetPair:output_type -> consumer.GetPairResponse
+	6,  // 24: consumer.Consumer.ListPairs:output_type -> consumer.ListPairsResponse
+	9,  // 25: consumer.Consumer.GetToken:output_type -> consumer.GetTokenResponse
+	14, // 26: consumer.Consumer.ListTradesByPair:output_type -> consumer.ListTradesResponse
+	14, // 27: consumer.Consumer.ListTradesByToken:output_type -> consumer.ListTradesResponse
+	14, // 28: consumer.Consumer.ListTradesByWallet:output_type -> consumer.ListTradesResponse
+	16, // 29: consumer.Consumer.GetBlockStatus:output_type -> consumer.GetBlockStatusResponse
+	18, // 30: consumer.Consumer.GetIndexerLag:output_type -> consumer.GetIndexerLagResponse
+	21, // 31: consumer.Consumer.ListPlatformStats:output_type -> consumer.ListPlatformStatsResponse
+	24, // 32: consumer.Consumer.GetPoolFees:output_type -> consumer.GetPoolFeesResponse
+	27, // 33: consumer.Consumer.GetCreatorFees:output_type -> consumer.GetCreatorFeesResponse
+	29, // 34: consumer.Consumer.Subscribe:output_type -> consumer.Event
+	22, // [22:35] is the sub-list for method output_type
+	9,  // [9:22] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_consumer_proto_init() }
@@ -154,13 +2902,14 @@ func file_consumer_proto_init() {
 	if File_consumer_proto != nil {
 		return
 	}
+	file_consumer_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_consumer_proto_rawDesc), len(file_consumer_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Consumer_Ping_FullMethodName = "/consumer.Consumer/Ping"
+	Consumer_Ping_FullMethodName               = "/consumer.Consumer/Ping"
+	Consumer_GetPair_FullMethodName            = "/consumer.Consumer/GetPair"
+	Consumer_ListPairs_FullMethodName          = "/consumer.Consumer/ListPairs"
+	Consumer_GetToken_FullMethodName           = "/consumer.Consumer/GetToken"
+	Consumer_ListTradesByPair_FullMethodName   = "/consumer.Consumer/ListTradesByPair"
+	Consumer_ListTradesByToken_FullMethodName  = "/consumer.Consumer/ListTradesByToken"
+	Consumer_ListTradesByWallet_FullMethodName = "/consumer.Consumer/ListTradesByWallet"
+	Consumer_GetBlockStatus_FullMethodName     = "/consumer.Consumer/GetBlockStatus"
+	Consumer_GetIndexerLag_FullMethodName      = "/consumer.Consumer/GetIndexerLag"
+	Consumer_ListPlatformStats_FullMethodName  = "/consumer.Consumer/ListPlatformStats"
+	Consumer_GetPoolFees_FullMethodName        = "/consumer.Consumer/GetPoolFees"
+	Consumer_GetCreatorFees_FullMethodName     = "/consumer.Consumer/GetCreatorFees"
+	Consumer_Subscribe_FullMethodName          = "/consumer.Consumer/Subscribe"
 )
 
 // ConsumerClient is the client API for Consumer service.
@@ -27,6 +39,18 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ConsumerClient interface {
 	Ping(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	GetPair(ctx context.Context, in *GetPairRequest, opts ...grpc.CallOption) (*GetPairResponse, error)
+	ListPairs(ctx context.Context, in *ListPairsRequest, opts ...grpc.CallOption) (*ListPairsResponse, error)
+	GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error)
+	ListTradesByPair(ctx context.Context, in *ListTradesByPairRequest, opts ...grpc.CallOption) (*ListTradesResponse, error)
+	ListTradesByToken(ctx context.Context, in *ListTradesByTokenRequest, opts ...grpc.CallOption) (*ListTradesResponse, error)
+	ListTradesByWallet(ctx context.Context, in *ListTradesByWalletRequest, opts ...grpc.CallOption) (*ListTradesResponse, error)
+	GetBlockStatus(ctx context.Context, in *GetBlockStatusRequest, opts ...grpc.CallOption) (*GetBlockStatusResponse, error)
+	GetIndexerLag(ctx context.Context, in *GetIndexerLagRequest, opts ...grpc.CallOption) (*GetIndexerLagResponse, error)
+	ListPlatformStats(ctx context.Context, in *ListPlatformStatsRequest, opts ...grpc.CallOption) (*ListPlatformStatsResponse, error)
+	GetPoolFees(ctx context.Context, in *GetPoolFeesRequest, opts ...grpc.CallOption) (*GetPoolFeesResponse, error)
+	GetCreatorFees(ctx context.Context, in *GetCreatorFeesRequest, opts ...grpc.CallOption) (*GetCreatorFeesResponse, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
 }
 
 type consumerClient struct {
@@ -47,11 +71,152 @@ func (c *consumerClient) Ping(ctx context.Context, in *Request, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *consumerClient) GetPair(ctx context.Context, in *GetPairRequest, opts ...grpc.CallOption) (*GetPairResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPairResponse)
+	err := c.cc.Invoke(ctx, Consumer_GetPair_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) ListPairs(ctx context.Context, in *ListPairsRequest, opts ...grpc.CallOption) (*ListPairsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPairsResponse)
+	err := c.cc.Invoke(ctx, Consumer_ListPairs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTokenResponse)
+	err := c.cc.Invoke(ctx, Consumer_GetToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) ListTradesByPair(ctx context.Context, in *ListTradesByPairRequest, opts ...grpc.CallOption) (*ListTradesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTradesResponse)
+	err := c.cc.Invoke(ctx, Consumer_ListTradesByPair_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) ListTradesByToken(ctx context.Context, in *ListTradesByTokenRequest, opts ...grpc.CallOption) (*ListTradesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTradesResponse)
+	err := c.cc.Invoke(ctx, Consumer_ListTradesByToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) ListTradesByWallet(ctx context.Context, in *ListTradesByWalletRequest, opts ...grpc.CallOption) (*ListTradesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTradesResponse)
+	err := c.cc.Invoke(ctx, Consumer_ListTradesByWallet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) GetBlockStatus(ctx context.Context, in *GetBlockStatusRequest, opts ...grpc.CallOption) (*GetBlockStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlockStatusResponse)
+	err := c.cc.Invoke(ctx, Consumer_GetBlockStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) GetIndexerLag(ctx context.Context, in *GetIndexerLagRequest, opts ...grpc.CallOption) (*GetIndexerLagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetIndexerLagResponse)
+	err := c.cc.Invoke(ctx, Consumer_GetIndexerLag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) ListPlatformStats(ctx context.Context, in *ListPlatformStatsRequest, opts ...grpc.CallOption) (*ListPlatformStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPlatformStatsResponse)
+	err := c.cc.Invoke(ctx, Consumer_ListPlatformStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) GetPoolFees(ctx context.Context, in *GetPoolFeesRequest, opts ...grpc.CallOption) (*GetPoolFeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPoolFeesResponse)
+	err := c.cc.Invoke(ctx, Consumer_GetPoolFees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) GetCreatorFees(ctx context.Context, in *GetCreatorFeesRequest, opts ...grpc.CallOption) (*GetCreatorFeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCreatorFeesResponse)
+	err := c.cc.Invoke(ctx, Consumer_GetCreatorFees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consumerClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Consumer_ServiceDesc.Streams[0], Consumer_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Consumer_SubscribeClient = grpc.ServerStreamingClient[Event]
+
 // ConsumerServer is the server API for Consumer service.
 // All implementations must embed UnimplementedConsumerServer
 // for forward compatibility.
 type ConsumerServer interface {
 	Ping(context.Context, *Request) (*Response, error)
+	GetPair(context.Context, *GetPairRequest) (*GetPairResponse, error)
+	ListPairs(context.Context, *ListPairsRequest) (*ListPairsResponse, error)
+	GetToken(context.Context, *GetTokenRequest) (*GetTokenResponse, error)
+	ListTradesByPair(context.Context, *ListTradesByPairRequest) (*ListTradesResponse, error)
+	ListTradesByToken(context.Context, *ListTradesByTokenRequest) (*ListTradesResponse, error)
+	ListTradesByWallet(context.Context, *ListTradesByWalletRequest) (*ListTradesResponse, error)
+	GetBlockStatus(context.Context, *GetBlockStatusRequest) (*GetBlockStatusResponse, error)
+	GetIndexerLag(context.Context, *GetIndexerLagRequest) (*GetIndexerLagResponse, error)
+	ListPlatformStats(context.Context, *ListPlatformStatsRequest) (*ListPlatformStatsResponse, error)
+	GetPoolFees(context.Context, *GetPoolFeesRequest) (*GetPoolFeesResponse, error)
+	GetCreatorFees(context.Context, *GetCreatorFeesRequest) (*GetCreatorFeesResponse, error)
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error
 	mustEmbedUnimplementedConsumerServer()
 }
 
@@ -65,6 +230,42 @@ type UnimplementedConsumerServer struct{}
 func (UnimplementedConsumerServer) Ping(context.Context, *Request) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedConsumerServer) GetPair(context.Context, *GetPairRequest) (*GetPairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPair not implemented")
+}
+func (UnimplementedConsumerServer) ListPairs(context.Context, *ListPairsRequest) (*ListPairsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPairs not implemented")
+}
+func (UnimplementedConsumerServer) GetToken(context.Context, *GetTokenRequest) (*GetTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetToken not implemented")
+}
+func (UnimplementedConsumerServer) ListTradesByPair(context.Context, *ListTradesByPairRequest) (*ListTradesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTradesByPair not implemented")
+}
+func (UnimplementedConsumerServer) ListTradesByToken(context.Context, *ListTradesByTokenRequest) (*ListTradesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTradesByToken not implemented")
+}
+func (UnimplementedConsumerServer) ListTradesByWallet(context.Context, *ListTradesByWalletRequest) (*ListTradesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTradesByWallet not implemented")
+}
+func (UnimplementedConsumerServer) GetBlockStatus(context.Context, *GetBlockStatusRequest) (*GetBlockStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockStatus not implemented")
+}
+func (UnimplementedConsumerServer) GetIndexerLag(context.Context, *GetIndexerLagRequest) (*GetIndexerLagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIndexerLag not implemented")
+}
+func (UnimplementedConsumerServer) ListPlatformStats(context.Context, *ListPlatformStatsRequest) (*ListPlatformStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlatformStats not implemented")
+}
+func (UnimplementedConsumerServer) GetPoolFees(context.Context, *GetPoolFeesRequest) (*GetPoolFeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoolFees not implemented")
+}
+func (UnimplementedConsumerServer) GetCreatorFees(context.Context, *GetCreatorFeesRequest) (*GetCreatorFeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCreatorFees not implemented")
+}
+func (UnimplementedConsumerServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
 func (UnimplementedConsumerServer) mustEmbedUnimplementedConsumerServer() {}
 func (UnimplementedConsumerServer) testEmbeddedByValue()                  {}
 
@@ -104,6 +305,215 @@ func _Consumer_Ping_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Consumer_GetPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).GetPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_GetPair_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).GetPair(ctx, req.(*GetPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_ListPairs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPairsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).ListPairs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_ListPairs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).ListPairs(ctx, req.(*ListPairsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_GetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).GetToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_GetToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).GetToken(ctx, req.(*GetTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_ListTradesByPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTradesByPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).ListTradesByPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_ListTradesByPair_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).ListTradesByPair(ctx, req.(*ListTradesByPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_ListTradesByToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTradesByTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).ListTradesByToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_ListTradesByToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).ListTradesByToken(ctx, req.(*ListTradesByTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_ListTradesByWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTradesByWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).ListTradesByWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_ListTradesByWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).ListTradesByWallet(ctx, req.(*ListTradesByWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_GetBlockStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).GetBlockStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_GetBlockStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).GetBlockStatus(ctx, req.(*GetBlockStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_GetIndexerLag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIndexerLagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).GetIndexerLag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_GetIndexerLag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).GetIndexerLag(ctx, req.(*GetIndexerLagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_ListPlatformStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlatformStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).ListPlatformStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_ListPlatformStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).ListPlatformStats(ctx, req.(*ListPlatformStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_GetPoolFees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoolFeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).GetPoolFees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_GetPoolFees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).GetPoolFees(ctx, req.(*GetPoolFeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_GetCreatorFees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCreatorFeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsumerServer).GetCreatorFees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Consumer_GetCreatorFees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsumerServer).GetCreatorFees(ctx, req.(*GetCreatorFeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Consumer_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConsumerServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Consumer_SubscribeServer = grpc.ServerStreamingServer[Event]
+
 // Consumer_ServiceDesc is the grpc.ServiceDesc for Consumer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +525,57 @@ var Consumer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _Consumer_Ping_Handler,
 		},
+		{
+			MethodName: "GetPair",
+			Handler:    _Consumer_GetPair_Handler,
+		},
+		{
+			MethodName: "ListPairs",
+			Handler:    _Consumer_ListPairs_Handler,
+		},
+		{
+			MethodName: "GetToken",
+			Handler:    _Consumer_GetToken_Handler,
+		},
+		{
+			MethodName: "ListTradesByPair",
+			Handler:    _Consumer_ListTradesByPair_Handler,
+		},
+		{
+			MethodName: "ListTradesByToken",
+			Handler:    _Consumer_ListTradesByToken_Handler,
+		},
+		{
+			MethodName: "ListTradesByWallet",
+			Handler:    _Consumer_ListTradesByWallet_Handler,
+		},
+		{
+			MethodName: "GetBlockStatus",
+			Handler:    _Consumer_GetBlockStatus_Handler,
+		},
+		{
+			MethodName: "GetIndexerLag",
+			Handler:    _Consumer_GetIndexerLag_Handler,
+		},
+		{
+			MethodName: "ListPlatformStats",
+			Handler:    _Consumer_ListPlatformStats_Handler,
+		},
+		{
+			MethodName: "GetPoolFees",
+			Handler:    _Consumer_GetPoolFees_Handler,
+		},
+		{
+			MethodName: "GetCreatorFees",
+			Handler:    _Consumer_GetCreatorFees_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Consumer_Subscribe_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "consumer.proto",
 }
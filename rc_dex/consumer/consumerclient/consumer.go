@@ -14,11 +14,51 @@ import (
 )
 
 type (
-	Request  = consumer.Request
-	Response = consumer.Response
+	CreatorFeeClaim           = consumer.CreatorFeeClaim
+	Event                     = consumer.Event
+	GetBlockStatusRequest     = consumer.GetBlockStatusRequest
+	GetBlockStatusResponse    = consumer.GetBlockStatusResponse
+	GetCreatorFeesRequest     = consumer.GetCreatorFeesRequest
+	GetCreatorFeesResponse    = consumer.GetCreatorFeesResponse
+	GetIndexerLagRequest      = consumer.GetIndexerLagRequest
+	GetIndexerLagResponse     = consumer.GetIndexerLagResponse
+	GetPairRequest            = consumer.GetPairRequest
+	GetPairResponse           = consumer.GetPairResponse
+	GetPoolFeesRequest        = consumer.GetPoolFeesRequest
+	GetPoolFeesResponse       = consumer.GetPoolFeesResponse
+	GetTokenRequest           = consumer.GetTokenRequest
+	GetTokenResponse          = consumer.GetTokenResponse
+	ListPairsRequest          = consumer.ListPairsRequest
+	ListPairsResponse         = consumer.ListPairsResponse
+	ListPlatformStatsRequest  = consumer.ListPlatformStatsRequest
+	ListPlatformStatsResponse = consumer.ListPlatformStatsResponse
+	ListTradesByPairRequest   = consumer.ListTradesByPairRequest
+	ListTradesByTokenRequest  = consumer.ListTradesByTokenRequest
+	ListTradesByWalletRequest = consumer.ListTradesByWalletRequest
+	ListTradesResponse        = consumer.ListTradesResponse
+	Pair                      = consumer.Pair
+	PlatformStat              = consumer.PlatformStat
+	PoolFeeDay                = consumer.PoolFeeDay
+	Request                   = consumer.Request
+	Response                  = consumer.Response
+	SubscribeRequest          = consumer.SubscribeRequest
+	Token                     = consumer.Token
+	Trade                     = consumer.Trade
 
 	Consumer interface {
 		Ping(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+		GetPair(ctx context.Context, in *GetPairRequest, opts ...grpc.CallOption) (*GetPairResponse, error)
+		ListPairs(ctx context.Context, in *ListPairsRequest, opts ...grpc.CallOption) (*ListPairsResponse, error)
+		GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error)
+		ListTradesByPair(ctx context.Context, in *ListTradesByPairRequest, opts ...grpc.CallOption) (*ListTradesResponse, error)
+		ListTradesByToken(ctx context.Context, in *ListTradesByTokenRequest, opts ...grpc.CallOption) (*ListTradesResponse, error)
+		ListTradesByWallet(ctx context.Context, in *ListTradesByWalletRequest, opts ...grpc.CallOption) (*ListTradesResponse, error)
+		GetBlockStatus(ctx context.Context, in *GetBlockStatusRequest, opts ...grpc.CallOption) (*GetBlockStatusResponse, error)
+		GetIndexerLag(ctx context.Context, in *GetIndexerLagRequest, opts ...grpc.CallOption) (*GetIndexerLagResponse, error)
+		ListPlatformStats(ctx context.Context, in *ListPlatformStatsRequest, opts ...grpc.CallOption) (*ListPlatformStatsResponse, error)
+		GetPoolFees(ctx context.Context, in *GetPoolFeesRequest, opts ...grpc.CallOption) (*GetPoolFeesResponse, error)
+		GetCreatorFees(ctx context.Context, in *GetCreatorFeesRequest, opts ...grpc.CallOption) (*GetCreatorFeesResponse, error)
+		Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (consumer.Consumer_SubscribeClient, error)
 	}
 
 	defaultConsumer struct {
@@ -36,3 +76,63 @@ func (m *defaultConsumer) Ping(ctx context.Context, in *Request, opts ...grpc.Ca
 	client := consumer.NewConsumerClient(m.cli.Conn())
 	return client.Ping(ctx, in, opts...)
 }
+
+func (m *defaultConsumer) GetPair(ctx context.Context, in *GetPairRequest, opts ...grpc.CallOption) (*GetPairResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.GetPair(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) ListPairs(ctx context.Context, in *ListPairsRequest, opts ...grpc.CallOption) (*ListPairsResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.ListPairs(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.GetToken(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) ListTradesByPair(ctx context.Context, in *ListTradesByPairRequest, opts ...grpc.CallOption) (*ListTradesResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.ListTradesByPair(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) ListTradesByToken(ctx context.Context, in *ListTradesByTokenRequest, opts ...grpc.CallOption) (*ListTradesResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.ListTradesByToken(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) ListTradesByWallet(ctx context.Context, in *ListTradesByWalletRequest, opts ...grpc.CallOption) (*ListTradesResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.ListTradesByWallet(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) GetBlockStatus(ctx context.Context, in *GetBlockStatusRequest, opts ...grpc.CallOption) (*GetBlockStatusResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.GetBlockStatus(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) GetIndexerLag(ctx context.Context, in *GetIndexerLagRequest, opts ...grpc.CallOption) (*GetIndexerLagResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.GetIndexerLag(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) ListPlatformStats(ctx context.Context, in *ListPlatformStatsRequest, opts ...grpc.CallOption) (*ListPlatformStatsResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.ListPlatformStats(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) GetPoolFees(ctx context.Context, in *GetPoolFeesRequest, opts ...grpc.CallOption) (*GetPoolFeesResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.GetPoolFees(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) GetCreatorFees(ctx context.Context, in *GetCreatorFeesRequest, opts ...grpc.CallOption) (*GetCreatorFeesResponse, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.GetCreatorFees(ctx, in, opts...)
+}
+
+func (m *defaultConsumer) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (consumer.Consumer_SubscribeClient, error) {
+	client := consumer.NewConsumerClient(m.cli.Conn())
+	return client.Subscribe(ctx, in, opts...)
+}
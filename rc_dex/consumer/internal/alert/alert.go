@@ -0,0 +1,281 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/logx"
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/pkg/types"
+)
+
+var ErrEngineStop = errors.New("alert engine stopped")
+
+// 交易对最近一次基础代币数量的缓存时间，超过后 liquidity_drop 从下一次更新重新开始比较
+const liquidityExpire = 24 * time.Hour
+
+// Alert 一条命中的告警，webhook 以 JSON 格式发送整条告警
+type Alert struct {
+	Rule         string `json:"rule"`
+	Event        string `json:"event"`
+	Subject      string `json:"subject"` // 冷却对象：交易对地址，成交为 钱包:交易对
+	Slot         int64  `json:"slot"`
+	Time         int64  `json:"time"`
+	PairAddress  string `json:"pair_address"`
+	TokenAddress string `json:"token_address"`
+	Maker        string `json:"maker,omitempty"`
+	TxHash       string `json:"tx_hash,omitempty"`
+	Text         string `json:"text"`
+}
+
+type pending struct {
+	alert *Alert
+	sinks []Sink
+}
+
+type liquidityState struct {
+	slot   int64
+	amount float64
+}
+
+// Engine 告警引擎：区块落库后同步匹配规则，命中的告警进入队列由后台协程发送，发送失败只记日志不重试
+//   - 去重：相同规则、对象和 slot（成交为交易哈希）的告警在 DedupeSeconds 内只发送一次，区块重复处理时不会重复告警
+//   - 冷却：同一规则对同一对象在 Cooldown 秒内只告警一次
+type Engine struct {
+	logx.Logger
+	rules     []config.AlertRule
+	ruleSinks [][]Sink
+	queue     chan *pending
+	dedupe    *collection.Cache
+	liquidity *collection.Cache // 交易对地址 -> liquidityState，仅配置了 liquidity_drop 规则时记录
+
+	lock     sync.Mutex
+	cooldown map[string]time.Time // 规则|对象 -> 冷却结束时间
+
+	ctx    context.Context
+	cancel func(err error)
+	done   chan struct{}
+}
+
+func NewEngine(conf config.AlertConfig) (*Engine, error) {
+	sinks := make(map[string]Sink, len(conf.Sinks))
+	all := make([]Sink, 0, len(conf.Sinks))
+	for _, item := range conf.Sinks {
+		if _, ok := sinks[item.Name]; ok {
+			return nil, fmt.Errorf("alert sink %s: duplicate name", item.Name)
+		}
+		sink, err := NewSink(item)
+		if err != nil {
+			return nil, err
+		}
+		sinks[item.Name] = sink
+		all = append(all, sink)
+	}
+
+	var trackLiquidity bool
+	ruleSinks := make([][]Sink, 0, len(conf.Rules))
+	for _, rule := range conf.Rules {
+		if rule.Event == EventLiquidityDrop {
+			trackLiquidity = true
+		}
+		if len(rule.Sinks) == 0 {
+			ruleSinks = append(ruleSinks, all)
+			continue
+		}
+		var items []Sink
+		for _, name := range rule.Sinks {
+			sink, ok := sinks[name]
+			if !ok {
+				return nil, fmt.Errorf("alert rule %s: sink %s not found", rule.Name, name)
+			}
+			items = append(items, sink)
+		}
+		ruleSinks = append(ruleSinks, items)
+	}
+
+	dedupe, err := collection.NewCache(time.Duration(conf.DedupeSeconds)*time.Second, collection.WithLimit(100000), collection.WithName("alert_dedupe"))
+	if err != nil {
+		return nil, err
+	}
+	var liquidity *collection.Cache
+	if trackLiquidity {
+		liquidity, err = collection.NewCache(liquidityExpire, collection.WithLimit(500000), collection.WithName("alert_liquidity"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &Engine{
+		Logger:    logx.WithContext(context.Background()).WithFields(logx.Field("service", "alert")),
+		rules:     conf.Rules,
+		ruleSinks: ruleSinks,
+		queue:     make(chan *pending, conf.QueueSize),
+		dedupe:    dedupe,
+		liquidity: liquidity,
+		cooldown:  make(map[string]time.Time),
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}, nil
+}
+
+func (e *Engine) Start() {
+	defer close(e.done)
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.ctx.Done():
+			// 发送队列中剩余的告警后退出
+			for {
+				select {
+				case item := <-e.queue:
+					e.send(item)
+				default:
+					return
+				}
+			}
+		case item := <-e.queue:
+			e.send(item)
+		case now := <-ticker.C:
+			e.cleanCooldown(now)
+		}
+	}
+}
+
+func (e *Engine) Stop() {
+	e.cancel(ErrEngineStop)
+	<-e.done
+	e.Info("alert engine stopped")
+}
+
+// Evaluate 按规则检查一个 slot 的交易对更新和成交，pairs 需先于 trades 传入，trades 按交易序号排列
+func (e *Engine) Evaluate(slot int64, pairs []PairUpdate, trades []*types.TradeWithPair) {
+	if len(e.rules) == 0 || e.ctx.Err() != nil {
+		return
+	}
+
+	for _, update := range pairs {
+		previous := e.swapLiquidity(slot, update)
+		for i := range e.rules {
+			rule := &e.rules[i]
+			var text string
+			var ok bool
+			switch rule.Event {
+			case EventNewPair, EventPair:
+				text, ok = matchPair(rule, update)
+			case EventLiquidityDrop:
+				text, ok = matchLiquidityDrop(rule, update.Pair, previous)
+			}
+			if !ok {
+				continue
+			}
+			e.fire(i, fmt.Sprintf("%s|%s|%d", rule.Name, update.Pair.Address, slot), &Alert{
+				Rule:         rule.Name,
+				Event:        rule.Event,
+				Subject:      update.Pair.Address,
+				Slot:         slot,
+				PairAddress:  update.Pair.Address,
+				TokenAddress: update.Pair.TokenAddress,
+				Text:         text,
+			})
+		}
+	}
+
+	for _, trade := range trades {
+		for i := range e.rules {
+			rule := &e.rules[i]
+			if rule.Event != EventTrade {
+				continue
+			}
+			text, ok := matchTrade(rule, trade)
+			if !ok {
+				continue
+			}
+			e.fire(i, fmt.Sprintf("%s|%s|%s|%s", rule.Name, trade.TxHash, trade.PairAddr, trade.Maker), &Alert{
+				Rule:         rule.Name,
+				Event:        rule.Event,
+				Subject:      trade.Maker + ":" + trade.PairAddr,
+				Slot:         slot,
+				PairAddress:  trade.PairAddr,
+				TokenAddress: trade.PairInfo.TokenAddr,
+				Maker:        trade.Maker,
+				TxHash:       trade.TxHash,
+				Text:         text,
+			})
+		}
+	}
+}
+
+// swapLiquidity 记录交易对本次的基础代币数量，返回更早 slot 中最近一次的数量，没有记录时返回 0
+// 多个协程并发处理区块，slot 不一定按顺序到达，较旧的 slot 不覆盖较新的记录
+func (e *Engine) swapLiquidity(slot int64, update PairUpdate) float64 {
+	if e.liquidity == nil {
+		return 0
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	var previous float64
+	if value, ok := e.liquidity.Get(update.Pair.Address); ok {
+		state := value.(liquidityState)
+		if state.slot > slot {
+			return 0
+		}
+		if state.slot < slot {
+			previous = state.amount
+		}
+	}
+	e.liquidity.Set(update.Pair.Address, liquidityState{slot: slot, amount: update.Pair.CurrentBaseTokenAmount})
+	return previous
+}
+
+// fire 去重和冷却检查通过后放入发送队列，队列写满时丢弃
+func (e *Engine) fire(index int, id string, alert *Alert) {
+	rule := &e.rules[index]
+	if _, ok := e.dedupe.Get(id); ok {
+		return
+	}
+	e.dedupe.Set(id, struct{}{})
+
+	now := time.Now()
+	key := rule.Name + "|" + alert.Subject
+	e.lock.Lock()
+	if until, ok := e.cooldown[key]; ok && now.Before(until) {
+		e.lock.Unlock()
+		return
+	}
+	e.cooldown[key] = now.Add(time.Duration(rule.Cooldown) * time.Second)
+	e.lock.Unlock()
+
+	alert.Time = now.Unix()
+	alert.Text = fmt.Sprintf("[%s] %s", rule.Name, alert.Text)
+	select {
+	case e.queue <- &pending{alert: alert, sinks: e.ruleSinks[index]}:
+	default:
+		e.Errorf("alert queue full, drop rule: %v, subject: %v, slot: %v", alert.Rule, alert.Subject, alert.Slot)
+	}
+}
+
+func (e *Engine) send(item *pending) {
+	for _, sink := range item.sinks {
+		if err := sink.Send(context.Background(), item.alert); err != nil {
+			e.Errorf("send alert err: %v, rule: %v, subject: %v, slot: %v", err, item.alert.Rule, item.alert.Subject, item.alert.Slot)
+		}
+	}
+}
+
+func (e *Engine) cleanCooldown(now time.Time) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for key, until := range e.cooldown {
+		if now.After(until) {
+			delete(e.cooldown, key)
+		}
+	}
+}
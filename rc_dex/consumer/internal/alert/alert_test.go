@@ -0,0 +1,98 @@
+package alert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+func newTestEngine(t *testing.T, rules ...config.AlertRule) *Engine {
+	engine, err := NewEngine(config.AlertConfig{
+		QueueSize:     100,
+		DedupeSeconds: 3600,
+		Sinks:         []config.AlertSinkConfig{{Name: "log", Type: "log"}},
+		Rules:         rules,
+	})
+	require.NoError(t, err)
+	return engine
+}
+
+func drain(e *Engine) []*Alert {
+	var alerts []*Alert
+	for {
+		select {
+		case item := <-e.queue:
+			alerts = append(alerts, item.alert)
+		default:
+			return alerts
+		}
+	}
+}
+
+func TestEngineNewPair(t *testing.T) {
+	e := newTestEngine(t, config.AlertRule{Name: "new_pool", Event: EventNewPair, Swap: "PumpSwap", MinBaseLiquidity: 50})
+
+	// 池子储备为 WSOL lamports：80 SOL 命中，10 SOL 不命中
+	e.Evaluate(1, []PairUpdate{
+		{Pair: &solmodel.Pair{Address: "a", Name: "PumpSwap", CurrentBaseTokenAmount: 80e9, BaseTokenSymbol: "SOL"}, Created: true},
+		{Pair: &solmodel.Pair{Address: "b", Name: "PumpSwap", CurrentBaseTokenAmount: 10e9}, Created: true},
+		{Pair: &solmodel.Pair{Address: "c", Name: "RaydiumV4", CurrentBaseTokenAmount: 80e9}, Created: true},
+		{Pair: &solmodel.Pair{Address: "d", Name: "PumpSwap", CurrentBaseTokenAmount: 80e9}},
+	}, nil)
+	alerts := drain(e)
+	require.Len(t, alerts, 1)
+	require.Equal(t, "a", alerts[0].PairAddress)
+	require.Contains(t, alerts[0].Text, "[new_pool]")
+	require.Contains(t, alerts[0].Text, "base liquidity: 80.00 SOL")
+}
+
+func TestEngineDedupeAndCooldown(t *testing.T) {
+	e := newTestEngine(t, config.AlertRule{Name: "kol_buy", Event: EventTrade, TradeType: types.TradeTypeBuy, Labels: []string{"kol"}, MinUsd: 5000, Cooldown: 300})
+	trade := func(tx string, usd float64) *types.TradeWithPair {
+		return &types.TradeWithPair{
+			TxHash: tx, PairAddr: "p", Maker: "m", Type: types.TradeTypeBuy, TotalUSD: usd,
+			TraderInfo: types.AddressInfo{WalletAddress: "m", AddressTag: "kol"},
+		}
+	}
+
+	e.Evaluate(1, nil, []*types.TradeWithPair{trade("tx1", 100), trade("tx2", 6000)})
+	require.Len(t, drain(e), 1)
+
+	// 区块重复处理：相同交易不再告警
+	e.Evaluate(1, nil, []*types.TradeWithPair{trade("tx2", 6000)})
+	require.Empty(t, drain(e))
+
+	// 冷却期内同一钱包在同一交易对的新成交不告警
+	e.Evaluate(2, nil, []*types.TradeWithPair{trade("tx3", 8000)})
+	require.Empty(t, drain(e))
+}
+
+func TestEngineLiquidityDrop(t *testing.T) {
+	e := newTestEngine(t, config.AlertRule{Name: "drop", Event: EventLiquidityDrop, MinDropPercent: 50, MinBaseLiquidity: 10})
+	pairUpdate := func(address string, amount float64) []PairUpdate {
+		return []PairUpdate{{Pair: &solmodel.Pair{Address: address, CurrentBaseTokenAmount: amount * 1e9, BaseTokenSymbol: "SOL"}}}
+	}
+	update := func(amount float64) []PairUpdate { return pairUpdate("p", amount) }
+
+	e.Evaluate(10, update(100), nil)
+	e.Evaluate(11, update(70), nil)
+	require.Empty(t, drain(e))
+
+	// 较旧的 slot 不参与比较，也不覆盖记录
+	e.Evaluate(9, update(1), nil)
+	require.Empty(t, drain(e))
+
+	e.Evaluate(12, update(30), nil)
+	alerts := drain(e)
+	require.Len(t, alerts, 1)
+	require.Equal(t, int64(12), alerts[0].Slot)
+	require.Contains(t, alerts[0].Text, "70.00 -> 30.00 SOL")
+
+	// 低于 MinBaseLiquidity（10 SOL）的池子不告警
+	e.Evaluate(13, pairUpdate("q", 5), nil)
+	e.Evaluate(14, pairUpdate("q", 1), nil)
+	require.Empty(t, drain(e))
+}
@@ -0,0 +1,96 @@
+package alert
+
+import (
+	"fmt"
+	"slices"
+
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+// 规则类型
+const (
+	EventNewPair       = "new_pair"
+	EventPair          = "pair"
+	EventTrade         = "trade"
+	EventLiquidityDrop = "liquidity_drop"
+)
+
+// baseReserveDecimals 交易对的 CurrentBaseTokenAmount 为未除精度的报价币数量（PumpSwap 为 WSOL lamports），
+// 规则中的 MinBaseLiquidity 以 SOL 为单位，比较和输出前先除精度
+const baseReserveDecimals = 1e9
+
+// PairUpdate 一次交易对写库结果
+type PairUpdate struct {
+	Pair    *solmodel.Pair
+	Created bool
+}
+
+// matchPair 检查 new_pair / pair 规则，命中时返回告警内容
+func matchPair(rule *config.AlertRule, update PairUpdate) (string, bool) {
+	pair := update.Pair
+	if rule.Event == EventNewPair && !update.Created {
+		return "", false
+	}
+	if rule.Swap != "" && rule.Swap != pair.Name {
+		return "", false
+	}
+	baseLiquidity := pair.CurrentBaseTokenAmount / baseReserveDecimals
+	if baseLiquidity < rule.MinBaseLiquidity || pair.Liquidity < rule.MinLiquidity || pair.PumpPoint < rule.MinPumpPoint {
+		return "", false
+	}
+
+	action := "pair update"
+	if update.Created {
+		action = "new pair"
+	}
+	return fmt.Sprintf("%s %s %s/%s, base liquidity: %.2f %s, liquidity: $%.0f, pump point: %.2f, price: $%.10g\npair: %s\ntoken: %s",
+		pair.Name, action, pair.TokenSymbol, pair.BaseTokenSymbol, baseLiquidity, pair.BaseTokenSymbol,
+		pair.Liquidity, pair.PumpPoint, pair.TokenPrice, pair.Address, pair.TokenAddress), true
+}
+
+// matchLiquidityDrop 检查 liquidity_drop 规则，previous 为上一次看到的池子基础代币数量（未除精度）
+func matchLiquidityDrop(rule *config.AlertRule, pair *solmodel.Pair, previous float64) (string, bool) {
+	if previous <= 0 || previous/baseReserveDecimals < rule.MinBaseLiquidity {
+		return "", false
+	}
+	if rule.Swap != "" && rule.Swap != pair.Name {
+		return "", false
+	}
+	drop := (previous - pair.CurrentBaseTokenAmount) / previous * 100
+	if drop <= 0 || drop < rule.MinDropPercent {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s/%s liquidity dropped %.1f%%: %.2f -> %.2f %s\npair: %s\ntoken: %s",
+		pair.Name, pair.TokenSymbol, pair.BaseTokenSymbol, drop, previous/baseReserveDecimals,
+		pair.CurrentBaseTokenAmount/baseReserveDecimals, pair.BaseTokenSymbol,
+		pair.Address, pair.TokenAddress), true
+}
+
+// matchTrade 检查 trade 规则
+func matchTrade(rule *config.AlertRule, trade *types.TradeWithPair) (string, bool) {
+	if trade.Type != types.TradeTypeBuy && trade.Type != types.TradeTypeSell {
+		return "", false
+	}
+	if rule.Swap != "" && rule.Swap != trade.SwapName {
+		return "", false
+	}
+	if rule.TradeType != "" && rule.TradeType != trade.Type {
+		return "", false
+	}
+	if len(rule.Labels) > 0 && !slices.Contains(rule.Labels, trade.TraderInfo.AddressTag) {
+		return "", false
+	}
+	if trade.TotalUSD < rule.MinUsd {
+		return "", false
+	}
+
+	maker := trade.Maker
+	if trade.TraderInfo.AddressTag != "" {
+		maker = fmt.Sprintf("%s (%s)", trade.Maker, trade.TraderInfo.AddressTag)
+	}
+	return fmt.Sprintf("%s $%.2f of %s on %s, price: $%.10g\nwallet: %s\npair: %s\ntx: %s",
+		trade.Type, trade.TotalUSD, trade.PairInfo.TokenSymbol, trade.SwapName, trade.TokenPriceUSD,
+		maker, trade.PairAddr, trade.TxHash), true
+}
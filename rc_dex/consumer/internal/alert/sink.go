@@ -0,0 +1,116 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"richcode.cc/dex/consumer/internal/config"
+)
+
+const defaultTelegramApi = "https://api.telegram.org"
+
+// Sink 告警发送渠道
+type Sink interface {
+	Send(ctx context.Context, alert *Alert) error
+}
+
+// NewSink 根据配置创建发送渠道
+func NewSink(conf config.AlertSinkConfig) (Sink, error) {
+	client := &http.Client{Timeout: time.Duration(conf.Timeout) * time.Second}
+	switch conf.Type {
+	case "log":
+		return LogSink{}, nil
+	case "webhook":
+		if conf.Url == "" {
+			return nil, fmt.Errorf("alert sink %s: webhook url is required", conf.Name)
+		}
+		return &WebhookSink{url: conf.Url, client: client}, nil
+	case "telegram":
+		if conf.Token == "" || conf.ChatId == "" {
+			return nil, fmt.Errorf("alert sink %s: telegram token and chat id are required", conf.Name)
+		}
+		api := conf.Url
+		if api == "" {
+			api = defaultTelegramApi
+		}
+		return &TelegramSink{
+			url:    fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimRight(api, "/"), conf.Token),
+			chatId: conf.ChatId,
+			client: client,
+		}, nil
+	}
+	return nil, fmt.Errorf("alert sink %s: unknown type %s", conf.Name, conf.Type)
+}
+
+// LogSink 只写日志，便于本地调试和审计
+type LogSink struct{}
+
+func (LogSink) Send(ctx context.Context, alert *Alert) error {
+	logx.WithContext(ctx).Infow("alert", logx.Field("rule", alert.Rule), logx.Field("text", alert.Text), logx.Field("slot", alert.Slot))
+	return nil
+}
+
+// WebhookSink 以 JSON 格式 POST 整条告警
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *WebhookSink) Send(ctx context.Context, alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, body)
+}
+
+// TelegramSink 通过 Bot API 的 sendMessage 发送文本，兼容同样接口的其他机器人服务
+type TelegramSink struct {
+	url    string
+	chatId string
+	client *http.Client
+}
+
+func (s *TelegramSink) Send(ctx context.Context, alert *Alert) error {
+	body, err := json.Marshal(map[string]any{
+		"chat_id":                  s.chatId,
+		"text":                     alert.Text,
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, body)
+}
+
+func post(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		// url.Error 会带上完整地址，telegram 的地址里有 bot token，不能写进日志
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("http status %d: %s", resp.StatusCode, data)
+	}
+	return nil
+}
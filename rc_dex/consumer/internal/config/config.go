@@ -4,6 +4,7 @@ import (
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/zrpc"
 	"richcode.cc/dex/pkg/constants"
+	"richcode.cc/dex/pkg/mq"
 )
 
 var Cfg Config
@@ -19,6 +20,24 @@ type Config struct {
 	Sol Chain `json:"Sol,optional"`
 
 	ConsumerConfig ConsumerConfig `json:"ConsumerConfig,optional"`
+
+	WalletLabel WalletLabelConfig `json:"WalletLabel,optional"`
+
+	Launch LaunchConfig `json:"Launch,optional"`
+
+	Security SecurityConfig `json:"Security,optional"`
+
+	Rug RugConfig `json:"Rug,optional"`
+
+	Platform PlatformConfig `json:"Platform,optional"`
+
+	Reconcile ReconcileConfig `json:"Reconcile,optional"`
+
+	Push PushConfig `json:"Push,optional"`
+
+	Mq MqConfig `json:"Mq,optional"`
+
+	Alert AlertConfig `json:"Alert,optional"`
 }
 
 type MySQLConfig struct {
@@ -34,6 +53,154 @@ type ConsumerConfig struct {
 	NotCompletedConcurrency int `json:"NotCompletedConcurrency" json:",env=CONSUMER_NOTCOMPLETED_CONCURRENCY"`
 }
 
+// WalletLabelConfig 钱包标签配置：人工标签在启动时写入 wallet_label 表，自动标签按周期从历史成交中重新计算
+type WalletLabelConfig struct {
+	Enable          bool          `json:"Enable,optional"`
+	RefreshInterval int64         `json:"RefreshInterval,default=600"` // 自动标签重新计算间隔（秒）
+	LookbackHours   int64         `json:"LookbackHours,default=72"`    // 统计最近多少小时的成交
+	CacheSeconds    int64         `json:"CacheSeconds,default=300"`    // 解析区块时钱包标签的本地缓存时间（秒）
+	LaunchSlots     int64         `json:"LaunchSlots,default=3"`       // 交易对出现后多少个 slot 内的买入算作开盘
+	SniperMinHits   int64         `json:"SniperMinHits,default=3"`     // 至少狙击多少个交易对才打 sniper 标签
+	BundlerMinSlot  int64         `json:"BundlerMinSlot,default=3"`    // 同一 slot 至少多少个钱包一起买入才视为捆绑
+	BundlerMinHits  int64         `json:"BundlerMinHits,default=2"`    // 至少参与多少次捆绑买入才打 bundler 标签
+	TopPnlSize      int           `json:"TopPnlSize,default=100"`      // 收益排行取前多少名
+	FreshMaxTrades  int64         `json:"FreshMaxTrades,default=3"`    // 新钱包的最大历史成交笔数
+	FreshHours      int64         `json:"FreshHours,default=24"`       // 首笔成交在多少小时以内算新钱包
+	Limit           int           `json:"Limit,default=5000"`          // 每类自动标签单轮最多写入的数量
+	Manual          []ManualLabel `json:"Manual,optional"`             // 人工标签：KOL、交易所热钱包、项目方等
+}
+
+type ManualLabel struct {
+	Address     string `json:"Address"`
+	Label       string `json:"Label"` // kol / cex / team
+	Name        string `json:"Name,optional"`
+	Icon        string `json:"Icon,optional"`
+	TwitterLink string `json:"TwitterLink,optional"`
+}
+
+// LaunchConfig 开盘分析配置：交易对创建后的前几个 slot 内识别狙击钱包和捆绑买入，结果写回 token 表
+type LaunchConfig struct {
+	Enable          bool    `json:"Enable,optional"`
+	Interval        int64   `json:"Interval,default=30"`        // 扫描待分析交易对的间隔（秒）
+	LaunchSlots     int64   `json:"LaunchSlots,default=3"`      // 交易对创建后多少个 slot 内的买入算作开盘
+	LookbackSlots   int64   `json:"LookbackSlots,default=9000"` // 只分析最近多少个 slot 内创建的交易对，超出后不再补算
+	PairLimit       int     `json:"PairLimit,default=50"`       // 单轮最多分析多少个交易对
+	BundleTxGap     int64   `json:"BundleTxGap,default=1"`      // 同一 slot 内交易序号相差不超过该值的买入视为连续买入
+	BundleMinSize   int     `json:"BundleMinSize,default=3"`    // 关联钱包数达到该值才算一组捆绑
+	BundledPercent  float64 `json:"BundledPercent,default=10"`  // 捆绑钱包持仓占比达到该百分比即标记为捆绑开盘
+	FunderLookup    bool    `json:"FunderLookup,default=true"`  // 是否通过 RPC 查询买入钱包的首笔 SOL 来源
+	FunderMaxWallet int     `json:"FunderMaxWallet,default=50"` // 每个交易对最多查询多少个钱包的资金来源
+	FunderMaxSigs   int     `json:"FunderMaxSigs,default=100"`  // 钱包在开盘前的签名数超过该值视为老钱包，不再追溯资金来源
+}
+
+// SecurityConfig 代币合约检测配置：读取增发/冻结权限、Token-2022 扩展和 LP 销毁锁定情况，写回 token 表的风险字段
+type SecurityConfig struct {
+	Enable        bool     `json:"Enable,optional"`
+	Interval      int64    `json:"Interval,default=10"`       // 扫描待检测代币的间隔（秒）
+	BatchSize     int      `json:"BatchSize,default=50"`      // 单轮最多检测多少个代币
+	RecheckSecond int64    `json:"RecheckSecond,default=600"` // 新代币重复检测的间隔（秒），用于跟踪 LP 销毁、权限放弃
+	RecheckHours  int64    `json:"RecheckHours,default=24"`   // 创建多少小时以内的代币会被重复检测
+	RetrySecond   int64    `json:"RetrySecond,default=60"`    // 检测失败（RPC 出错、mint 地址无效等）的代币多少秒后重试
+	LpSafePercent float64  `json:"LpSafePercent,default=95"`  // LP 销毁加锁仓占比达到该百分比视为流动性已锁定
+	LockOwners    []string `json:"LockOwners,optional"`       // 锁仓合约持有 LP 的金库地址
+}
+
+// RugConfig 跑路检测配置：区块解析时按交易对检查 PumpSwap 池子报价币（SOL）储备相对上次写入时和本 slot 内的变化，
+// 撤出流动性、创建者卖出或储备整体下降超过阈值时把交易对标记为已跑路，百分比均相对上次写入的储备和本 slot 内储备中的最高值
+type RugConfig struct {
+	Enable         bool    `json:"Enable,optional"`
+	RemovePercent  float64 `json:"RemovePercent,default=50"`  // 撤出流动性（LP withdraw）带走的储备占比
+	DevSellPercent float64 `json:"DevSellPercent,default=30"` // 创建者（PumpOwner）卖出带走的储备占比
+	DrainPercent   float64 `json:"DrainPercent,default=80"`   // 储备整体下降占比，覆盖多个钱包配合砸盘
+	MinReserve     float64 `json:"MinReserve,default=1"`      // 储备低于该数量（SOL）的小池子不做判定
+}
+
+// PlatformConfig 下单平台识别配置：交易机器人和前端（Photon、BullX、Axiom、Trojan 等）通常由自己的路由程序发起交易，
+// 或在同一笔交易内向固定地址转一笔手续费，按交易的外层程序 ID 和手续费转账的收款地址识别成交来源
+type PlatformConfig struct {
+	DisableDefaults bool           `json:"DisableDefaults,optional"` // 不使用内置的平台地址（block.DefaultPlatforms），只按 Platforms 识别
+	Platforms       []PlatformRule `json:"Platforms,optional"`       // 追加的平台规则，与内置的同名平台合并
+}
+
+type PlatformRule struct {
+	Name        string   `json:"Name"`                 // 平台名称，写入成交的 platform 字段
+	Programs    []string `json:"Programs,optional"`    // 平台路由程序 ID，交易外层指令调用这些程序时命中
+	FeeAccounts []string `json:"FeeAccounts,optional"` // 手续费收款地址（钱包或 WSOL 代币账户），交易内有 SOL/代币转入时命中
+}
+
+// ReconcileConfig 池子储备校对配置：周期性读取活跃 PumpSwap 池子的链上金库余额，与交易对表中由事件推算的储备比较，
+// 偏差超过阈值时记录日志和指标，开启 Correct 时用链上余额覆盖
+type ReconcileConfig struct {
+	Enable           bool    `json:"Enable,optional"`
+	Interval         int64   `json:"Interval,default=60"`          // 校对间隔（秒）
+	ActiveMinutes    int64   `json:"ActiveMinutes,default=30"`     // 最近多少分钟内有成交的交易对参与校对
+	BatchSize        int     `json:"BatchSize,default=200"`        // 单轮最多校对多少个交易对
+	TolerancePercent float64 `json:"TolerancePercent,default=0.5"` // 储备偏差超过该百分比视为不一致
+	Correct          bool    `json:"Correct,default=true"`         // 是否用链上余额修正交易对储备
+}
+
+// PushConfig 实时推送配置：区块落库后推送成交、交易对更新和新交易对，gRPC Subscribe 始终可用，WebSocket 服务需要开启
+type PushConfig struct {
+	Enable       bool   `json:"Enable,optional"`               // 是否开启 WebSocket 服务
+	ListenOn     string `json:"ListenOn,default=0.0.0.0:8081"` // WebSocket 监听地址
+	Path         string `json:"Path,default=/ws"`              // WebSocket 路径
+	BufferSize   int    `json:"BufferSize,default=256"`        // 每个订阅者的待发送队列长度，写满视为慢客户端并断开
+	Heartbeat    int64  `json:"Heartbeat,default=15"`          // 心跳间隔（秒），超过两个心跳间隔收不到 pong 的 WebSocket 连接会被关闭
+	WriteTimeout int64  `json:"WriteTimeout,default=10"`       // 单条消息的写超时（秒）
+	MaxKeys      int    `json:"MaxKeys,default=200"`           // 单个订阅最多的地址数
+}
+
+// MqConfig 消息队列配置：区块落库后把每个 slot 的代币、交易对更新和成交按顺序发布到队列，消息体见 types.MqMessage
+type MqConfig struct {
+	Enable       bool         `json:"Enable,optional"`
+	Transport    string       `json:"Transport,default=kafka,options=kafka|memory"` // memory 只保存在进程内，用于本地调试
+	Kafka        mq.KafkaConf `json:"Kafka,optional"`
+	TradeTopic   string       `json:"TradeTopic,default=sol_trade"` // 按交易对地址分区
+	PairTopic    string       `json:"PairTopic,default=sol_pair"`   // 按交易对地址分区
+	TokenTopic   string       `json:"TokenTopic,default=sol_token"` // 按代币地址分区
+	QueueSize    int          `json:"QueueSize,default=1000"`       // 待发送的 slot 数上限，写满后区块处理会等待
+	FlushTimeout int64        `json:"FlushTimeout,default=10"`      // 停止时发送剩余消息的最长时间（秒）
+}
+
+// AlertConfig 告警配置：区块落库后按规则检查本 slot 的新交易对、交易对更新和成交，命中后发送到指定渠道
+type AlertConfig struct {
+	Enable        bool              `json:"Enable,optional"`
+	QueueSize     int               `json:"QueueSize,default=1000"`     // 待发送告警的队列长度，写满后丢弃新告警
+	DedupeSeconds int64             `json:"DedupeSeconds,default=3600"` // 同一条告警（相同规则、对象和 slot/交易）在该时间内只发送一次，防止区块重复处理时重复告警
+	Sinks         []AlertSinkConfig `json:"Sinks,optional"`
+	Rules         []AlertRule       `json:"Rules,optional"`
+}
+
+// AlertSinkConfig 告警发送渠道
+type AlertSinkConfig struct {
+	Name    string `json:"Name"`
+	Type    string `json:"Type,options=log|webhook|telegram"`
+	Url     string `json:"Url,optional"`    // webhook 地址；telegram 为 Bot API 地址，默认 https://api.telegram.org
+	Token   string `json:"Token,optional"`  // telegram bot token
+	ChatId  string `json:"ChatId,optional"` // telegram 群组或频道
+	Timeout int64  `json:"Timeout,default=5"`
+}
+
+// AlertRule 告警规则，未配置的条件不参与判断
+//   - new_pair：新交易对，可按 Swap、MinBaseLiquidity、MinLiquidity 过滤
+//   - pair：交易对更新，可按 Swap、MinPumpPoint、MinBaseLiquidity、MinLiquidity 过滤
+//   - trade：成交，可按 Swap、TradeType、Labels、MinUsd 过滤
+//   - liquidity_drop：池子中基础代币数量相比上一次更新下降 MinDropPercent 以上，MinBaseLiquidity 作用于下降前的数量
+type AlertRule struct {
+	Name             string   `json:"Name"`
+	Event            string   `json:"Event,options=new_pair|pair|trade|liquidity_drop"`
+	Swap             string   `json:"Swap,optional"`             // DEX 名称，如 PumpSwap
+	TradeType        string   `json:"TradeType,optional"`        // buy / sell
+	Labels           []string `json:"Labels,optional"`           // 成交钱包的展示标签（优先级最高的一个），命中任意一个即可，如 kol、top_pnl
+	MinUsd           float64  `json:"MinUsd,optional"`           // 成交金额下限（USD）
+	MinBaseLiquidity float64  `json:"MinBaseLiquidity,optional"` // 池子中基础代币（SOL）数量下限
+	MinLiquidity     float64  `json:"MinLiquidity,optional"`     // 流动性下限（USD）
+	MinPumpPoint     float64  `json:"MinPumpPoint,optional"`     // pump 进度下限，1 表示已满
+	MinDropPercent   float64  `json:"MinDropPercent,optional"`   // 流动性下降百分比下限
+	Cooldown         int64    `json:"Cooldown,default=300"`      // 同一规则对同一对象（交易对或钱包）的最短告警间隔（秒）
+	Sinks            []string `json:"Sinks,optional"`            // 发送渠道名称，不配置时发送到全部渠道
+}
+
 // json 标签 → 匹配 YAML/JSON 配置文件
 // env 标签 → 指定环境变量名（用于覆盖配置）
 type Chain struct {
@@ -0,0 +1,23 @@
+package block
+
+import (
+	"richcode.cc/dex/consumer/internal/alert"
+)
+
+// evaluateAlerts 区块落库后按告警规则检查本 slot 的交易对变更和成交，命中的告警由告警引擎异步发送
+func (s *BlockService) evaluateAlerts(slot int64, updates *slotUpdates) {
+	if s.sc.Alert == nil {
+		return
+	}
+	updates.lock.Lock()
+	defer updates.lock.Unlock()
+	if len(updates.trades) == 0 && len(updates.pairs) == 0 {
+		return
+	}
+
+	pairs := make([]alert.PairUpdate, 0, len(updates.pairs))
+	for _, item := range updates.pairs {
+		pairs = append(pairs, alert.PairUpdate{Pair: item.pair, Created: item.created})
+	}
+	s.sc.Alert.Evaluate(slot, pairs, updates.sortedTrades())
+}
@@ -0,0 +1,99 @@
+package block
+
+import (
+	"context"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/token"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+)
+
+// Token-2022 扩展指令的一级编号及会改变代币风险的二级指令
+const (
+	token2022InstructionTransferFeeExtension         = 26
+	token2022InstructionDefaultAccountStateExtension = 28
+	token2022InstructionTransferHookExtension        = 36
+	token2022InstructionPausableExtension            = 44
+
+	transferFeeInstructionTransferCheckedWithFee = 1
+	transferFeeInstructionSetTransferFee         = 5
+	defaultAccountStateInstructionUpdate         = 1
+	transferHookInstructionUpdate                = 1
+	pausableInstructionPause                     = 1
+	pausableInstructionResume                    = 2
+)
+
+// DecodeAuthorityChanges 找出交易中会改变 mint 风险状态的指令（修改/放弃权限、修改手续费、修改 transfer hook、暂停等），返回对应的 mint 地址
+// SetAuthority 也可能作用于普通代币账户（AccountOwner、CloseAccount 两类），这两类不返回
+func DecodeAuthorityChanges(tx *client.BlockTransaction) (mints []string) {
+	if tx == nil || tx.Meta == nil || tx.Meta.Err != nil {
+		return
+	}
+
+	decode := func(instruction *solTypes.CompiledInstruction) {
+		if mint := decodeAuthorityChange(tx.AccountKeys, instruction); mint != "" {
+			mints = append(mints, mint)
+		}
+	}
+	for i := range tx.Transaction.Message.Instructions {
+		decode(&tx.Transaction.Message.Instructions[i])
+	}
+	for _, inner := range tx.Meta.InnerInstructions {
+		for i := range inner.Instructions {
+			decode(&inner.Instructions[i])
+		}
+	}
+	return
+}
+
+func decodeAuthorityChange(accountKeys []common.PublicKey, instruction *solTypes.CompiledInstruction) string {
+	if instruction.ProgramIDIndex >= len(accountKeys) || len(instruction.Data) < 2 || len(instruction.Accounts) < 1 {
+		return ""
+	}
+	if instruction.Accounts[0] >= len(accountKeys) {
+		return ""
+	}
+	program := accountKeys[instruction.ProgramIDIndex]
+	if program != common.TokenProgramID && program != common.Token2022ProgramID {
+		return ""
+	}
+
+	changed := false
+	switch instruction.Data[0] {
+	case byte(token.InstructionSetAuthority):
+		authorityType := token.AuthorityType(instruction.Data[1])
+		changed = authorityType != token.AuthorityTypeAccountOwner && authorityType != token.AuthorityTypeCloseAccount
+	case token2022InstructionTransferFeeExtension:
+		changed = program == common.Token2022ProgramID && instruction.Data[1] == transferFeeInstructionSetTransferFee
+	case token2022InstructionDefaultAccountStateExtension:
+		changed = program == common.Token2022ProgramID && instruction.Data[1] == defaultAccountStateInstructionUpdate
+	case token2022InstructionTransferHookExtension:
+		changed = program == common.Token2022ProgramID && instruction.Data[1] == transferHookInstructionUpdate
+	case token2022InstructionPausableExtension:
+		changed = program == common.Token2022ProgramID &&
+			(instruction.Data[1] == pausableInstructionPause || instruction.Data[1] == pausableInstructionResume)
+	}
+	if !changed {
+		return ""
+	}
+	return accountKeys[instruction.Accounts[0]].String()
+}
+
+// ResetTokenSecurityCheck 把发生权限变更的代币重新标记为待检测，由合约检测服务重新扫描
+func (s *BlockService) ResetTokenSecurityCheck(ctx context.Context, mints []string) {
+	if len(mints) == 0 {
+		return
+	}
+	if err := s.sc.TokenModel.ResetCheckCa(ctx, SolChainIdInt, mints); err != nil {
+		s.Errorf("ResetTokenSecurityCheck: ResetCheckCa err: %v, mints: %v", err, mints)
+		return
+	}
+	// 手续费等扩展可能已变更，清掉数量计算使用的扩展缓存
+	if s.sc.MintExtensionCache != nil {
+		for _, mint := range mints {
+			s.sc.MintExtensionCache.Del(mint)
+		}
+	}
+	s.Infof("ResetTokenSecurityCheck: mints: %v", mints)
+}
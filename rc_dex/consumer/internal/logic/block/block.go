@@ -32,6 +32,8 @@ var ErrServiceStop = errors.New("service stop")
 
 var ErrUnknowProgram = errors.New("unknow program")
 
+var ErrUnknowInstruction = errors.New("unknown instruction discriminator")
+
 type BlockService struct {
 	Name string
 	sc   *svc.ServiceContext
@@ -49,6 +51,7 @@ type BlockService struct {
 	Conn        *websocket.Conn
 	solPrice    float64
 	name        string
+	platforms   *PlatformMatcher
 }
 
 // Start implements service.Service.
@@ -84,6 +87,7 @@ func NewBlockService(sc *svc.ServiceContext, name string, slotChnanel chan uint6
 		name:        name,
 		workerPool:  pool,
 		slotChannel: slotChnanel,
+		platforms:   NewPlatformMatcher(sc.Config.Platform),
 		Logger:      logx.WithContext(context.Background()).WithFields(logx.Field("service", fmt.Sprintf("%s-%v", name, index))),
 		ctx:         ctx,
 		cancel:      cancel,
@@ -173,6 +177,10 @@ func (s *BlockService) ProcessBlock(ctx context.Context, slot int64) {
 	// 初始化一个存放交易信息的切片，初始容量设为1000
 	// 后续会把从区块中解析出来的交易(TradeWithPair)加入到这个切片中，便于统一处理（如分类落库等）
 	trades := make([]*types.TradeWithPair, 0, 1000)
+	// 区块内发生权限变更（放弃权限、修改手续费等）的 mint，需要重新做合约检测
+	var authorityMints []string
+	// 区块内钱包之间的转账，用于确认循环刷量的买卖双方有关联
+	walletLinks := NewWalletLinks()
 
 	// 通过slice组件遍历区块中的每一笔链上交易，进行处理，即遍历transactions，拿到每一个交易对象tx
 	slice.ForEach(blockInfo.Transactions, func(index int, tx client.BlockTransaction) {
@@ -181,6 +189,15 @@ func (s *BlockService) ProcessBlock(ctx context.Context, slot int64) {
 		// Tx                 当前遍历到的链上交易指针
 		// TxIndex            交易在区块内的序号
 		// TokenAccountMap    本次区块处理中维护的token账户（复用，提升效率）
+		authorityMints = append(authorityMints, DecodeAuthorityChanges(&tx)...)
+		walletLinks.AddTx(&tx)
+
+		// 增发/销毁指令单独解析，用于刷新代币总量
+		for _, item := range DecodeTokenSupplyChanges(block, &tx, index) {
+			s.FillTradeWithPairInfo(item, slot)
+			trades = append(trades, item)
+		}
+
 		decodeTx := &DecodedTx{
 			BlockDb:         block,
 			Tx:              &tx,
@@ -188,33 +205,25 @@ func (s *BlockService) ProcessBlock(ctx context.Context, slot int64) {
 			TokenAccountMap: tokenAccountMap,
 		}
 
-		// 解码链上交易，返回解码后的 TradeWithPair 切片
-		trade, err := DecodeTx(ctx, s.sc, decodeTx)
-		if err != nil {
-			// 如果是未识别的合约（unknow program），则直接跳过此条
-			if strings.Contains(err.Error(), "unknow program") {
-				return
-			}
-			// 其他解码出错则输出日志并跳过
-			fmt.Println("decode tx failed: ", err.Error())
-			return
-		}
-
-		// 对解码出来的 trade 结果再次过滤，只保留非空项；
-		// 并填充 TradeWithPair 的补充信息（如引用了Slot等元数据）
-		trade = slice.Filter(trade, func(index int, item *types.TradeWithPair) bool {
-			if item == nil {
-				return false
-			}
-			s.FillTradeWithPairInfo(item, slot)
-			return true
-		})
+		// 解码链上交易，返回补充了元数据和下单平台的 TradeWithPair 切片
+		trade := s.decodeTrades(ctx, slot, decodeTx)
 
 		// 将本笔交易对应的所有成交记入 trades 切片，后面统一处理
 		trades = append(trades, trade...)
 	})
 
+	s.ResetTokenSecurityCheck(ctx, slice.Unique(authorityMints))
+
+	// 给成交补充钱包标签（KOL、交易所、狙击、捆绑等），下游推送可据此高亮
+	s.FillTradeTraderInfo(ctx, trades)
+
+	// 区块内行为分析：按交易序号识别夹子、对倒和刷量，打标的成交不计入自然成交额
+	mevStat := AnalyzeMev(trades, walletLinks)
+	s.Infof("processBlock:%v analyzeMev sandwiches: %v, victims: %v, self trades: %v, washes: %v", slot, mevStat.Sandwiches, mevStat.Victims, mevStat.SelfTrades, mevStat.Washes)
+
 	// Step4: 将成交按 Pair 归类，方便后续批量写入
+	// updates 收集写库成功的代币、交易对和成交，区块落库后推送
+	updates := &slotUpdates{}
 	tradeMap := make(map[string][]*types.TradeWithPair)
 
 	pumpSwapCount := 0
@@ -239,36 +248,30 @@ func (s *BlockService) ProcessBlock(ctx context.Context, slot int64) {
 	}
 
 	{
-		// 额外挑出 Mint 行为，触发 Token 总量刷新
-		tokenMints := slice.Filter[*types.TradeWithPair](trades, func(_ int, item *types.TradeWithPair) bool {
-			if item != nil && item.Type == types.TradeTokenMint {
-				return true
-			}
-			return false
+		// 额外挑出 Mint / Burn 行为，按区块批量刷新 Token 总量
+		supplyChanges := slice.Filter[*types.TradeWithPair](trades, func(_ int, item *types.TradeWithPair) bool {
+			return supplyChangeMint(item) != ""
 		})
 
-		s.UpdateTokenMints(ctx, tokenMints)
-		s.Infof("processBlock:%v UpdateTokenMints size: %v, dur: %v, tokenMints: %v", slot, len(tokenMints), time.Since(beginTime), len(tokenMints))
+		s.UpdateTokenSupplies(ctx, supplyChanges, updates)
+		s.Infof("processBlock:%v UpdateTokenSupplies dur: %v, supplyChanges: %v", slot, time.Since(beginTime), len(supplyChanges))
 	}
 
 	{
-		// 额外挑出 Burn 行为，触发 Token 总量刷新
-		tokenBurns := slice.Filter[*types.TradeWithPair](trades, func(_ int, item *types.TradeWithPair) bool {
-			if item != nil && item.Type == types.TradeTokenBurn {
-				return true
-			}
-			return false
+		// 额外挑出创建者领取手续费，累加到创建者的已领取收益
+		claims := slice.Filter[*types.TradeWithPair](trades, func(_ int, item *types.TradeWithPair) bool {
+			return item != nil && item.Type == types.TradePumpAmmCollectCreatorFee
 		})
 
-		s.UpdateTokenBurns(ctx, tokenBurns)
-		s.Infof("processBlock:%v UpdateTokenBurns size: %v, dur: %v, tokenBurns: %v", slot, len(tokenBurns), time.Since(beginTime), len(tokenBurns))
+		s.SaveCreatorFeeClaims(ctx, claims)
+		s.Infof("processBlock:%v SaveCreatorFeeClaims dur: %v, claims: %v", slot, time.Since(beginTime), len(claims))
 	}
 
 	//并发处理： 保存交易信息，保存token账户信息
 	group := threading.NewRoutineGroup()
 	group.RunSafe(func() {
 		// Step5: 写入成交信息 & TokenAccount 快照
-		s.SaveTrades(ctx, constants.SolChainIdInt, tradeMap)
+		s.SaveTrades(ctx, constants.SolChainIdInt, tradeMap, updates)
 		s.Infof("processBlock:%v saveTrades tx_size: %v, dur: %v, trade_size: %v", slot, len(blockInfo.Transactions), time.Since(beginTime), len(trades))
 
 		s.SaveTokenAccounts(ctx, trades, tokenAccountMap)
@@ -297,6 +300,30 @@ func (s *BlockService) ProcessBlock(ctx context.Context, slot int64) {
 	if err != nil {
 		s.Error("insert block error", err)
 	}
+
+	// Step8: 推送本 slot 写库成功的成交和交易对变更，发布到消息队列并检查告警规则
+	s.publishSlot(slot, updates)
+	s.publishMq(ctx, slot, updates)
+	s.evaluateAlerts(slot, updates)
+}
+
+// decodeTrades 解码一笔交易的成交，过滤空项后补充 slot 等元数据（FillTradeWithPairInfo）和下单平台；
+// 未识别的程序和指令在 DecodeTx 中已跳过，出错说明已支持的 DEX 指令解码失败，记录日志后跳过整笔交易
+func (s *BlockService) decodeTrades(ctx context.Context, slot int64, dtx *DecodedTx) []*types.TradeWithPair {
+	trades, err := DecodeTx(ctx, s.sc, dtx)
+	if err != nil {
+		s.Errorf("processBlock:%v decode tx %v err: %v", slot, dtx.TxHash, err)
+		return nil
+	}
+	platform := s.platforms.Detect(dtx.Tx)
+	return slice.Filter(trades, func(_ int, item *types.TradeWithPair) bool {
+		if item == nil {
+			return false
+		}
+		s.FillTradeWithPairInfo(item, slot)
+		item.Platform = platform
+		return true
+	})
 }
 
 func DecodeTx(ctx context.Context, sc *svc.ServiceContext, dtx *DecodedTx) (trades []*types.TradeWithPair, err error) {
@@ -319,14 +346,67 @@ func DecodeTx(ctx context.Context, sc *svc.ServiceContext, dtx *DecodedTx) (trad
 		inst := &tx.Transaction.Message.Instructions[i]
 		var trade *types.TradeWithPair // tradewithpair ：对应交易对的数据，即池子的数据
 		trade, err = DecodeInstruction(ctx, sc, dtx, inst, i)
+		if errors.Is(err, ErrUnknowInstruction) {
+			// 已支持的 DEX 中不产生成交的指令
+			err = nil
+			continue
+		}
+		if errors.Is(err, ErrUnknowProgram) {
+			// 外层不是已支持的 DEX（ComputeBudget、System 转账、交易机器人的路由程序等）时不放弃整笔交易，
+			// 路由程序通过 CPI 调用 DEX，成交在它的内部指令中
+			var innerTrades []*types.TradeWithPair
+			innerTrades, err = DecodeInnerInstructions(ctx, sc, dtx, i)
+			if err != nil {
+				return nil, err
+			}
+			trades = append(trades, innerTrades...)
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
+		if trade != nil {
+			// 同一交易内可能有多笔成交，交易哈希加指令序号唯一确定一笔成交
+			trade.InstructionIndex = i
+		}
 		trades = append(trades, trade)
 	}
 	return
 }
 
+// innerInstructionIndexBase 内部指令成交的指令序号为 (外层序号+1)*innerInstructionIndexBase+内部序号，
+// 一笔交易的外层指令远少于这个数，不会与外层指令的序号重复
+const innerInstructionIndexBase = 1000
+
+// DecodeInnerInstructions 解析外层第 index 条指令的内部指令中对 PumpSwap 的 CPI 调用；
+// 其他程序的内部指令（代币转账、PumpSwap 自调用记录事件等）跳过
+func DecodeInnerInstructions(ctx context.Context, sc *svc.ServiceContext, dtx *DecodedTx, index int) (trades []*types.TradeWithPair, err error) {
+	inner := dtx.InnerInstructionMap[index]
+	if inner == nil {
+		return nil, nil
+	}
+	for j := range inner.Instructions {
+		inst := &inner.Instructions[j]
+		if int(inst.ProgramIDIndex) >= len(dtx.Tx.AccountKeys) || dtx.Tx.AccountKeys[inst.ProgramIDIndex].String() != ProgramStrPumpFunAMM {
+			continue
+		}
+		var trade *types.TradeWithPair
+		trade, err = DecodeInstruction(ctx, sc, dtx, inst, index)
+		if errors.Is(err, ErrUnknowInstruction) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if trade != nil {
+			trade.InstructionIndex = (index+1)*innerInstructionIndexBase + j
+			trades = append(trades, trade)
+		}
+	}
+	return
+}
+
 func DecodeInstruction(ctx context.Context, sc *svc.ServiceContext, dtx *DecodedTx, instruction *solTypes.CompiledInstruction, index int) (trade *types.TradeWithPair, err error) {
 	if len(dtx.Tx.AccountKeys) == 0 {
 		return nil, errors.New("account keys is empty")
@@ -552,8 +632,8 @@ func DecodeInitAccountInstruction(tx *client.BlockTransaction, tokenAccountMap m
 }
 
 // DecodeTokenTransfer 解析transfer指令
-func DecodeTokenTransfer(accountKeys []common.PublicKey, instruction *solTypes.CompiledInstruction) (transfer *token.TransferParam, err error) {
-	transfer = &token.TransferParam{}
+func DecodeTokenTransfer(accountKeys []common.PublicKey, instruction *solTypes.CompiledInstruction) (transfer *TokenTransfer, err error) {
+	transfer = &TokenTransfer{}
 	// 解析transfer指令
 	if accountKeys[instruction.ProgramIDIndex].String() == common.Token2022ProgramID.String() { // Token2022ProgramID
 		// Instruction Accounts 参与指令的账户在交易账户列表中的索引，source、destination、authority 三个账户
@@ -597,6 +677,22 @@ func DecodeTokenTransfer(accountKeys []common.PublicKey, instruction *solTypes.C
 			transfer.Auth = accountKeys[instruction.Accounts[3]]                 // 授权签名账户
 			transfer.Amount = binary.LittleEndian.Uint64(instruction.Data[1:10]) // 转账金额
 			// decimal := instruction.Data[10]
+		} else if instruction.Data[0] == token2022InstructionTransferFeeExtension && len(instruction.Data) > 1 &&
+			instruction.Data[1] == transferFeeInstructionTransferCheckedWithFee {
+			// transferCheckedWithFee：2 字节指令类型 + 8 字节金额 + 1 字节精度 + 8 字节手续费，账户与 transferChecked 相同
+			if len(instruction.Data) < 19 {
+				err = errors.New("data len less than 19")
+				return
+			}
+			if len(instruction.Accounts) < 4 {
+				err = errors.New("account len too small")
+				return
+			}
+			transfer.From = accountKeys[instruction.Accounts[0]]                 // 发送方账户
+			transfer.To = accountKeys[instruction.Accounts[2]]                   // 接收方账户
+			transfer.Auth = accountKeys[instruction.Accounts[3]]                 // 授权签名账户
+			transfer.Amount = binary.LittleEndian.Uint64(instruction.Data[2:10]) // 转账金额
+			transfer.Fee = binary.LittleEndian.Uint64(instruction.Data[11:19])   // 接收方被扣留的手续费
 		} else {
 			err = errors.New("not transfer Instruction")
 			return
@@ -0,0 +1,166 @@
+package block
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/rpc"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+	"github.com/zeromicro/go-zero/core/logx"
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/pumpfun/generated/pump_amm"
+	"richcode.cc/dex/pkg/types"
+)
+
+// anchorEventInstruction PumpSwap 通过自调用记录事件（emit_cpi）时指令数据的前 8 字节
+var anchorEventInstruction = []byte{0xe4, 0x45, 0xa5, 0x2e, 0x51, 0xcb, 0x9a, 0x1d}
+
+var (
+	testPool      = testKey(20)
+	testBaseMint  = testKey(21)
+	testComputeID = common.PublicKeyFromString("ComputeBudget111111111111111111111111111111")
+)
+
+// routerSwap 路由程序代 user 在 testPool 上买入或卖出 baseAmount 个代币（6 位精度），quoteAmount 为 SOL lamports
+type routerSwap struct {
+	router      common.PublicKey
+	user        byte
+	buy         bool
+	baseAmount  uint64
+	quoteAmount uint64
+	token2022   bool // base 代币属于 Token-2022
+}
+
+// routerSwapTx 构造一笔经路由程序 CPI 调用 PumpSwap 的交易：外层依次为 ComputeBudget、路由程序和一笔 System 转账，
+// 路由程序的内部指令为 PumpSwap 的 buy/sell 和它记录事件的自调用，事件写在日志中
+func routerSwapTx(t *testing.T, swap routerSwap) *client.BlockTransaction {
+	t.Helper()
+	user, baseAccount, quoteAccount := testKey(swap.user), testKey(swap.user+100), testKey(swap.user+150)
+	pumpAmm := common.PublicKeyFromString(ProgramStrPumpFunAMM)
+	accountKeys := []common.PublicKey{
+		user, swap.router, pumpAmm, testPool, baseAccount, quoteAccount, testBaseMint,
+		common.PublicKeyFromString(TokenStrWrapSol), common.TokenProgramID, common.SystemProgramID, testComputeID, testKey(30),
+	}
+	if swap.token2022 {
+		accountKeys = append(accountKeys, common.Token2022ProgramID)
+	}
+
+	// PumpSwap buy/sell 的账户：0 pool、1 user、3 base mint、4 quote mint、5/6 用户代币账户、11/12 base/quote 代币程序
+	accounts := make([]int, 23)
+	accounts[0], accounts[3], accounts[4], accounts[5], accounts[6], accounts[11], accounts[12] = 3, 6, 7, 4, 5, 8, 8
+	if swap.token2022 {
+		accounts[11] = 12
+	}
+	discriminator := pump_amm.Instruction_Sell[:]
+	if swap.buy {
+		discriminator = pump_amm.Instruction_Buy[:]
+	}
+
+	toSolana := func(key common.PublicKey) solana.PublicKey { return solana.PublicKeyFromBytes(key.Bytes()) }
+	var event []byte
+	if swap.buy {
+		data, err := pump_amm.BuyEvent{
+			BaseAmountOut: swap.baseAmount, QuoteAmountIn: swap.quoteAmount, QuoteAmountInWithLpFee: swap.quoteAmount,
+			PoolBaseTokenReserves: 500_000_000_000_000, PoolQuoteTokenReserves: 80_000_000_000,
+			Pool: toSolana(testPool), User: toSolana(user), UserBaseTokenAccount: toSolana(baseAccount), UserQuoteTokenAccount: toSolana(quoteAccount),
+		}.Marshal()
+		require.NoError(t, err)
+		event = append(pump_amm.Event_BuyEvent[:], data...)
+	} else {
+		data, err := pump_amm.SellEvent{
+			BaseAmountIn: swap.baseAmount, QuoteAmountOut: swap.quoteAmount,
+			PoolBaseTokenReserves: 500_000_000_000_000, PoolQuoteTokenReserves: 80_000_000_000,
+			Pool: toSolana(testPool), User: toSolana(user), UserBaseTokenAccount: toSolana(baseAccount), UserQuoteTokenAccount: toSolana(quoteAccount),
+		}.Marshal()
+		require.NoError(t, err)
+		event = append(pump_amm.Event_SellEvent[:], data...)
+	}
+
+	fee := make([]byte, 12)
+	binary.LittleEndian.PutUint32(fee, systemInstructionTransfer)
+	binary.LittleEndian.PutUint64(fee[4:], 1_000_000)
+	balance := func(index uint64, mint common.PublicKey, decimals uint8) rpc.TransactionMetaTokenBalance {
+		return rpc.TransactionMetaTokenBalance{
+			AccountIndex: index, Mint: mint.String(), Owner: user.String(),
+			UITokenAmount: rpc.TokenAccountBalance{Amount: "1", Decimals: decimals},
+		}
+	}
+	tx := &client.BlockTransaction{
+		AccountKeys: accountKeys,
+		Meta: &client.TransactionMeta{
+			PreTokenBalances:  []rpc.TransactionMetaTokenBalance{balance(4, testBaseMint, 6), balance(5, accountKeys[7], 9)},
+			PostTokenBalances: []rpc.TransactionMetaTokenBalance{balance(4, testBaseMint, 6), balance(5, accountKeys[7], 9)},
+			LogMessages:       []string{eventLogPrefix + base64.StdEncoding.EncodeToString(event)},
+			InnerInstructions: []client.InnerInstruction{{
+				Index: 1,
+				Instructions: []solTypes.CompiledInstruction{
+					{ProgramIDIndex: 2, Accounts: accounts, Data: append(discriminator, make([]byte, 16)...)},
+					{ProgramIDIndex: 2, Accounts: []int{3}, Data: append(anchorEventInstruction, event...)},
+				},
+			}},
+		},
+	}
+	// 签名（交易哈希）由成交内容决定，同一钱包的不同成交是不同的交易
+	signature := make([]byte, 64)
+	signature[0] = swap.user
+	binary.LittleEndian.PutUint64(signature[1:], swap.baseAmount)
+	binary.LittleEndian.PutUint64(signature[9:], swap.quoteAmount)
+	tx.Transaction.Signatures = []solTypes.Signature{signature}
+	tx.Transaction.Message.Instructions = []solTypes.CompiledInstruction{
+		{ProgramIDIndex: 10, Data: []byte{2, 0, 0, 0, 0}},
+		{ProgramIDIndex: 1, Accounts: []int{0, 2, 3}},
+		{ProgramIDIndex: 9, Accounts: []int{0, 11}, Data: fee},
+	}
+	return tx
+}
+
+// decodeRouterSwaps 按 ProcessBlock 的方式解码一组交易，txIndex 为交易在区块内的序号
+func decodeRouterSwaps(t *testing.T, s *BlockService, txs ...*client.BlockTransaction) (trades []*types.TradeWithPair) {
+	t.Helper()
+	block := &solmodel.Block{Slot: 100, BlockTime: time.Unix(1_760_000_000, 0), SolPrice: 150}
+	tokenAccountMap := make(map[string]*TokenAccount)
+	for index, tx := range txs {
+		FillTokenAccountMap(tx, tokenAccountMap)
+		trades = append(trades, s.decodeTrades(context.Background(), block.Slot, &DecodedTx{
+			BlockDb: block, Tx: tx, TxIndex: index, TokenAccountMap: tokenAccountMap,
+		})...)
+	}
+	return
+}
+
+func newTestBlockService() *BlockService {
+	return &BlockService{
+		Logger:    logx.WithContext(context.Background()),
+		platforms: NewPlatformMatcher(config.PlatformConfig{}),
+	}
+}
+
+func TestDecodeRouterWrappedSwap(t *testing.T) {
+	photon := common.PublicKeyFromString(DefaultPlatforms[0].Programs[0])
+	tx := routerSwapTx(t, routerSwap{router: photon, user: 1, buy: true, baseAmount: 2_000_000_000, quoteAmount: 300_000_000})
+
+	trades := decodeRouterSwaps(t, newTestBlockService(), tx)
+	require.Len(t, trades, 1)
+	trade := trades[0]
+	require.Equal(t, types.TradeTypeBuy, trade.Type)
+	require.Equal(t, PumpSwap, trade.SwapName)
+	require.Equal(t, testPool.String(), trade.PairAddr)
+	require.Equal(t, testKey(1).String(), trade.Maker)
+	require.Equal(t, int64(2_000_000_000), trade.TokenAmountInt)
+	require.Equal(t, PlatformPhoton, trade.Platform)
+	require.Equal(t, int64(100), trade.Slot)
+	// 路由程序是外层第 1 条指令，PumpSwap buy 是它的第 0 条内部指令
+	require.Equal(t, 2*innerInstructionIndexBase, trade.InstructionIndex)
+
+	// 没有调用 PumpSwap 的路由交易不产生成交
+	tx.Meta.InnerInstructions = nil
+	require.Empty(t, decodeRouterSwaps(t, newTestBlockService(), tx))
+}
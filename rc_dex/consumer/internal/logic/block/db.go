@@ -7,7 +7,6 @@ import (
 	"strings"
 	"time"
 
-	set "github.com/duke-git/lancet/v2/datastructure/set"
 	"github.com/duke-git/lancet/v2/slice"
 	"github.com/zeromicro/go-zero/core/threading"
 	"richcode.cc/dex/model/solmodel"
@@ -36,14 +35,21 @@ func (s *BlockService) NewTradeModel(trade *types.TradeWithPair) (tradeDb *solmo
 		TradeType:         trade.Type,
 		BaseTokenAmount:   trade.BaseTokenAmount,
 		TokenAmount:       trade.TokenAmount,
+		TokenTransferFee:  trade.TokenTransferFee,
 		BaseTokenPriceUsd: trade.BaseTokenPriceUSD,
 		TotalUsd:          trade.TotalUSD,
 		TokenPriceUsd:     trade.TokenPriceUSD,
 		To:                trade.To,
 		BlockNum:          trade.BlockNum,
+		TransactionIndex:  int64(trade.TransactionIndex),
 		BlockTime:         time.Unix(trade.BlockTime, 0),
 		BlockTimeStamp:    trade.BlockTime,
 		SwapName:          trade.SwapName,
+		MevTag:            trade.MevTag,
+		LpFee:             trade.LpFee,
+		ProtocolFee:       trade.ProtocolFee,
+		CreatorFee:        trade.CreatorFee,
+		Platform:          trade.Platform,
 
 		CreatedAt: now,
 		UpdatedAt: now,
@@ -55,14 +61,14 @@ func (s *BlockService) NewTradeModel(trade *types.TradeWithPair) (tradeDb *solmo
 }
 
 // SaveTrades 按交易对并行落库成交数据，同时过滤掉无效记录。
-func (s *BlockService) SaveTrades(ctx context.Context, chainId int64, tradeMap map[string][]*types.TradeWithPair) {
+func (s *BlockService) SaveTrades(ctx context.Context, chainId int64, tradeMap map[string][]*types.TradeWithPair, updates *slotUpdates) {
 	s.Infof("SaveTrades: Starting with %d pair addresses", len(tradeMap))
 
 	group := threading.NewRoutineGroup()
 	for key, trade := range tradeMap {
 		s.Infof("SaveTrades: Processing pair %s with %d trades", key, len(trade))
 
-		// 1. 预过滤：仅保留有效的买卖行为，避免写入无价格或类型异常的记录
+		// 1. 预过滤：仅保留有效的买卖行为和流动性变动，避免写入无价格或类型异常的记录
 		trade = slice.Filter[*types.TradeWithPair](trade, func(index int, item *types.TradeWithPair) bool {
 			if item == nil {
 				s.Infof("SaveTrades: Filtered out nil trade at index %d", index)
@@ -70,7 +76,7 @@ func (s *BlockService) SaveTrades(ctx context.Context, chainId int64, tradeMap m
 			}
 
 			// Normal filtering for buy/sell trades
-			if item.Type != types.TradeTypeBuy && item.Type != types.TradeTypeSell {
+			if item.Type != types.TradeTypeBuy && item.Type != types.TradeTypeSell && !isLiquidityTrade(item) {
 				s.Infof("SaveTrades: Filtered out trade with invalid type %s at index %d", item.Type, index)
 				return false
 			}
@@ -91,7 +97,7 @@ func (s *BlockService) SaveTrades(ctx context.Context, chainId int64, tradeMap m
 				})
 				s.Infof("SaveTrades: will BatchSaveByTrade key: %v, tx hashes: %v", key, txhashes)
 
-				err := s.BatchSaveByTrade(ctx, chainId, key, trade)
+				err := s.BatchSaveByTrade(ctx, chainId, key, trade, updates)
 				if err != nil {
 					s.Errorf("SaveTrades: BatchSaveByTrade err:%v, key:%v, tx hashes: %v", err, key, txhashes)
 				} else {
@@ -106,18 +112,26 @@ func (s *BlockService) SaveTrades(ctx context.Context, chainId int64, tradeMap m
 }
 
 // BatchSaveByTrade 针对单个交易对执行配对信息与成交数据的落库。
-func (s *BlockService) BatchSaveByTrade(ctx context.Context, chainId int64, pairAddress string, trades []*types.TradeWithPair) (err error) {
-	if err = s.SavePairInfo(ctx, chainId, pairAddress, trades); err != nil {
+// 流动性变动只用于刷新交易对储备和跑路检测，不写入成交表；
+// 成交写入成功后累加池子和创建者手续费；写入成功的代币、交易对和成交记入 updates，区块落库后统一推送
+func (s *BlockService) BatchSaveByTrade(ctx context.Context, chainId int64, pairAddress string, trades []*types.TradeWithPair, updates *slotUpdates) (err error) {
+	if err = s.SavePairInfo(ctx, chainId, pairAddress, trades, updates); err != nil {
 		s.Error(fmt.Errorf("batchSaveByTrade:savePairInfo err:%v", err))
 	}
+	trades = slice.Filter(trades, func(_ int, item *types.TradeWithPair) bool {
+		return !isLiquidityTrade(item)
+	})
 	if err = s.BatchSaveTrade(ctx, trades); err != nil {
 		s.Error(fmt.Errorf("batchSaveByTrade:saveTrade err:%w", err))
+	} else {
+		s.AccrueFees(ctx, trades)
+		updates.AddTrades(trades)
 	}
 	return
 }
 
 // SavePairInfo 负责同步交易对的基础信息以及关联 Token 信息。
-func (s *BlockService) SavePairInfo(ctx context.Context, chainId int64, pairAddress string, trades []*types.TradeWithPair) (err error) {
+func (s *BlockService) SavePairInfo(ctx context.Context, chainId int64, pairAddress string, trades []*types.TradeWithPair, updates *slotUpdates) (err error) {
 	fmt.Println("SavePairInfo: 开始保存pair信息")
 
 	if trades == nil {
@@ -149,10 +163,15 @@ func (s *BlockService) SavePairInfo(ctx context.Context, chainId int64, pairAddr
 		tradeInfo.PairInfo.TokenTotalSupply = tokenDb.TotalSupply
 	}
 
-	// 3. 同步或创建交易对信息，并记录 Pump 指标
-	_, err = s.SavePair(ctx, trade, tokenDb)
+	// 3. 同步或创建交易对信息，并记录 Pump 指标；随后按上次写入的储备和本 slot 的储备变化做跑路检测
+	updates.AddToken(tokenDb)
+	prevReserve := s.prevQuoteReserve(ctx, trade)
+	pair, created, err := s.SavePair(ctx, trade, tokenDb)
 	if err != nil {
-		fmt.Println("SavePair err: %v", err)
+		s.Errorf("SavePairInfo: SavePair err: %v, pair address: %v", err, pairAddress)
+	} else if pair != nil {
+		s.CheckRug(ctx, pair, prevReserve, trades)
+		updates.AddPair(pair, created)
 	}
 
 	// 4. 统一回写市值结果，确保 MQ / 存储端数据一致
@@ -212,61 +231,59 @@ func (s *BlockService) BatchSaveTrade(ctx context.Context, trades []*types.Trade
 	return nil
 }
 
-// UpdateTokenMints 扫描 Mint 交易并刷新 Token 的最新总发行量。
-func (s *BlockService) UpdateTokenMints(ctx context.Context, tokenMints []*types.TradeWithPair) {
-	client := s.sc.GetSolClient()
-
-	hashSet := set.New[string]()
-
-	slice.ForEach(tokenMints, func(_ int, item *types.TradeWithPair) {
-		if item != nil && item.Type == types.TradeTokenMint {
-			mintTo := item.InstructionMintTo
-			// 同一 Mint 在单批次内只需更新一次，避免重复请求链上数据
-			if hashSet.Contain(mintTo.Mint.String()) {
-				return
-			}
-			token, err := s.sc.TokenModel.FindOneByChainIdAddress(s.ctx, int64(item.ChainIdInt), mintTo.Mint.String())
-			if err == nil && token != nil {
-				totalSupply, err := sol.GetTokenTotalSupply(client, s.ctx, mintTo.Mint.String())
-				if err == nil && totalSupply.IsPositive() {
-					token.TotalSupply = totalSupply.InexactFloat64()
-					s.Infof("UpdateTokenMints: update totalSupply, token address: %v, total supply: %v, tx hash: %v", token.Address, token.TotalSupply, item.TxHash)
-					hashSet.Add(mintTo.Mint.String())
-					_ = s.sc.TokenModel.Update(ctx, token)
-				}
-			}
+// UpdateTokenSupplies 按区块内的增发/销毁记录刷新代币总发行量：涉及的 mint 去重后批量读取一次 mint 账户，
+// 不再为每条记录单独请求 getTokenSupply
+func (s *BlockService) UpdateTokenSupplies(ctx context.Context, changes []*types.TradeWithPair, updates *slotUpdates) {
+	txHashes := make(map[string]string)
+	var mints []string
+	for _, item := range changes {
+		mint := supplyChangeMint(item)
+		if mint == "" {
+			continue
 		}
-	})
-}
-
-// UpdateTokenBurns 扫描 Burn 交易并更新 Token 的发行量缓存。
-func (s *BlockService) UpdateTokenBurns(ctx context.Context, tokenBurns []*types.TradeWithPair) {
-	client := s.sc.GetSolClient()
-
-	hashSet := set.New[string]()
+		if _, ok := txHashes[mint]; !ok {
+			mints = append(mints, mint)
+		}
+		txHashes[mint] = item.TxHash
+	}
+	if len(mints) == 0 {
+		return
+	}
 
-	slice.ForEach(tokenBurns, func(_ int, item *types.TradeWithPair) {
-		if item == nil {
-			return
+	supplies, err := sol.GetTokenTotalSupplies(s.sc.GetSolClient(), ctx, mints)
+	if err != nil {
+		s.Errorf("UpdateTokenSupplies: GetTokenTotalSupplies err: %v, mints: %v", err, len(mints))
+		return
+	}
+	for _, mint := range mints {
+		totalSupply, ok := supplies[mint]
+		if !ok || !totalSupply.IsPositive() {
+			continue
 		}
-		if item != nil && (item.Type == types.TradeTokenBurn || item.Type == "token_burn") {
-			burn := item.InstructionBurn
-			// 过滤同一 Mint 的重复更新，减轻数据库与链上读取压力
-			if hashSet.Contain(burn.Mint.String()) {
-				return
-			}
-			token, err := s.sc.TokenModel.FindOneByChainIdAddress(s.ctx, int64(item.ChainIdInt), burn.Mint.String())
-			if err == nil && token != nil {
-				totalSupply, err := sol.GetTokenTotalSupply(client, s.ctx, burn.Mint.String())
-				if err == nil && totalSupply.IsPositive() {
-					token.TotalSupply = totalSupply.InexactFloat64()
-					s.Infof("UpdateTokenBurns: update totalSupply, token address: %v, total supply: %v, tx hash: %v", token.Address, token.TotalSupply, item.TxHash)
-					hashSet.Add(burn.Mint.String())
-					_ = s.sc.TokenModel.Update(ctx, token)
-				}
-			}
+		token, err := s.sc.TokenModel.FindOneByChainIdAddress(ctx, constants.SolChainIdInt, mint)
+		if err != nil || token == nil {
+			continue
 		}
-	})
+		token.TotalSupply = totalSupply.InexactFloat64()
+		s.Infof("UpdateTokenSupplies: update totalSupply, token address: %v, total supply: %v, tx hash: %v", token.Address, token.TotalSupply, txHashes[mint])
+		if err = s.sc.TokenModel.Update(ctx, token); err == nil {
+			updates.AddToken(token)
+		}
+	}
+}
+
+// supplyChangeMint 增发/销毁记录对应的 mint 地址，其他成交返回空
+func supplyChangeMint(item *types.TradeWithPair) string {
+	if item == nil {
+		return ""
+	}
+	switch item.Type {
+	case types.TradeTokenMint:
+		return item.InstructionMintTo.Mint.String()
+	case types.TradeTokenBurn:
+		return item.InstructionBurn.Mint.String()
+	}
+	return ""
 }
 
 // SavePumpSwapPoolInfo 将 PumpSwap 的池子元信息保存至数据库，避免重复写入。
@@ -0,0 +1,98 @@
+package block
+
+import (
+	"context"
+	"time"
+
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+// AccrueFees 成交写库成功后按池子和日期（UTC）累加手续费，并把创建者手续费累加到创建者收益；
+// 每笔成交按交易哈希和指令序号记录在 pool_fee_accrual，与累加在同一个事务内，区块重复处理时不会重复累加
+func (s *BlockService) AccrueFees(ctx context.Context, trades []*types.TradeWithPair) {
+	now := time.Now()
+	var fees []*solmodel.TradeFee
+	for _, trade := range trades {
+		if trade.Type != types.TradeTypeBuy && trade.Type != types.TradeTypeSell {
+			continue
+		}
+		if trade.LpFee == 0 && trade.ProtocolFee == 0 && trade.CreatorFee == 0 {
+			continue
+		}
+
+		fee := &solmodel.TradeFee{
+			Accrual: &solmodel.PoolFeeAccrual{
+				ChainId:          SolChainIdInt,
+				TxHash:           trade.TxHash,
+				InstructionIndex: int64(trade.InstructionIndex),
+				PairAddr:         trade.PairAddr,
+				Slot:             trade.Slot,
+				CreatedAt:        now,
+				UpdatedAt:        now,
+			},
+			PoolFee: &solmodel.PoolFee{
+				ChainId:      SolChainIdInt,
+				PairAddr:     trade.PairAddr,
+				TokenAddress: trade.PairInfo.TokenAddr,
+				CoinCreator:  trade.PumpOwner,
+				Date:         time.Unix(trade.BlockTime, 0).UTC().Truncate(24 * time.Hour),
+				LpFee:        trade.LpFee,
+				ProtocolFee:  trade.ProtocolFee,
+				CreatorFee:   trade.CreatorFee,
+				LpFeeUsd:     trade.LpFee * trade.BaseTokenPriceUSD,
+				FeeUsd:       (trade.LpFee + trade.ProtocolFee + trade.CreatorFee) * trade.BaseTokenPriceUSD,
+				VolumeUsd:    trade.TotalUSD,
+				TradeCount:   1,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			},
+		}
+		if trade.CreatorFee != 0 && trade.PumpOwner != "" {
+			fee.CreatorFee = &solmodel.CreatorFee{
+				ChainId:       SolChainIdInt,
+				Creator:       trade.PumpOwner,
+				AccruedFee:    trade.CreatorFee,
+				AccruedFeeUsd: trade.CreatorFee * trade.BaseTokenPriceUSD,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+		}
+		fees = append(fees, fee)
+	}
+	if len(fees) == 0 {
+		return
+	}
+
+	accrued, err := s.sc.PoolFeeAccrualModel.Accrue(ctx, fees)
+	if err != nil {
+		s.Errorf("AccrueFees: PoolFeeAccrualModel.Accrue err: %v, size: %v", err, len(fees))
+		return
+	}
+	if accrued < len(fees) {
+		s.Infof("AccrueFees: skipped %v already accrued trades", len(fees)-accrued)
+	}
+}
+
+// SaveCreatorFeeClaims 写入创建者领取手续费记录，首次写入的领取才累加到创建者的已领取收益，两者在同一事务内完成
+func (s *BlockService) SaveCreatorFeeClaims(ctx context.Context, claims []*types.TradeWithPair) {
+	now := time.Now()
+	for _, item := range claims {
+		claim := &solmodel.CreatorFeeClaim{
+			ChainId:      SolChainIdInt,
+			Creator:      item.Maker,
+			TxHash:       item.TxHash,
+			Amount:       item.BaseTokenAmount,
+			AmountUsd:    item.TotalUSD,
+			VaultAta:     item.CoinCreatorVaultAta,
+			TokenAccount: item.To,
+			Slot:         item.Slot,
+			BlockTime:    time.Unix(item.BlockTime, 0),
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if _, err := s.sc.CreatorFeeClaimModel.Claim(ctx, claim); err != nil {
+			s.Errorf("SaveCreatorFeeClaims: Claim err: %v, creator: %v, tx hash: %v", err, claim.Creator, claim.TxHash)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package block
+
+import (
+	"context"
+
+	"github.com/duke-git/lancet/v2/slice"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+	"richcode.cc/dex/pkg/types"
+)
+
+// FillTradeTraderInfo 按 maker 批量查询钱包标签，回填到成交的 TraderInfo 上，方便下游推送时高亮
+// 标签先查本地缓存，缓存未命中的地址合并成一次数据库查询；没有标签的地址也会缓存空结果，避免每个区块重复查库
+func (s *BlockService) FillTradeTraderInfo(ctx context.Context, trades []*types.TradeWithPair) {
+	if len(trades) == 0 || s.sc.WalletLabelModel == nil || s.sc.WalletLabelCache == nil {
+		return
+	}
+
+	labelMap := make(map[string][]*solmodel.WalletLabel)
+	var missing []string
+	for _, trade := range trades {
+		if trade == nil || trade.Maker == "" {
+			continue
+		}
+		if _, ok := labelMap[trade.Maker]; ok {
+			continue
+		}
+		if value, ok := s.sc.WalletLabelCache.Get(trade.Maker); ok {
+			labelMap[trade.Maker] = value.([]*solmodel.WalletLabel)
+			continue
+		}
+		labelMap[trade.Maker] = nil
+		missing = append(missing, trade.Maker)
+	}
+
+	if len(missing) > 0 {
+		labels, err := s.sc.WalletLabelModel.FindByChainIdAddresses(ctx, SolChainIdInt, missing)
+		if err != nil {
+			s.Errorf("FillTradeTraderInfo:FindByChainIdAddresses err: %v, size: %v", err, len(missing))
+			return
+		}
+		for _, label := range labels {
+			labelMap[label.Address] = append(labelMap[label.Address], label)
+		}
+		for _, address := range missing {
+			s.sc.WalletLabelCache.Set(address, labelMap[address])
+		}
+	}
+
+	for _, trade := range trades {
+		if trade == nil || trade.Maker == "" {
+			continue
+		}
+		trade.TraderInfo = NewAddressInfo(trade.Maker, labelMap[trade.Maker])
+	}
+}
+
+// NewAddressInfo 从钱包的全部标签中按优先级挑出一个展示标签；头像和 Twitter 取第一个有值的标签（通常来自人工标签）
+func NewAddressInfo(address string, labels []*solmodel.WalletLabel) types.AddressInfo {
+	info := types.AddressInfo{WalletAddress: address}
+	if len(labels) == 0 {
+		return info
+	}
+
+	for _, tag := range constants.WalletLabelPriority {
+		label, ok := slice.FindBy(labels, func(_ int, item *solmodel.WalletLabel) bool {
+			return item.Label == tag
+		})
+		if ok {
+			info.AddressTag = label.Label
+			break
+		}
+	}
+
+	for _, label := range labels {
+		if info.AddressIcon == "" && label.Icon != "" {
+			info.AddressIcon = label.Icon
+		}
+		if info.TwitterLink == "" && label.TwitterLink != "" {
+			info.TwitterLink = label.TwitterLink
+		}
+	}
+	return info
+}
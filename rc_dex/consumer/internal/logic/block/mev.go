@@ -0,0 +1,275 @@
+package block
+
+import (
+	"cmp"
+	"encoding/binary"
+	"slices"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/rpc"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+const (
+	// 夹子反向平仓的代币数量与抢跑数量之比的允许偏差，夹子通常卖出全部抢跑所得
+	sandwichAmountTolerance = 0.5
+	// 同一钱包 slot 内买卖数量之差不超过较大一方的该比例时视为往返刷量
+	washNetTolerance = 0.1
+)
+
+// MevStat 一个 slot 的分析结果
+type MevStat struct {
+	Sandwiches int // 夹子次数
+	Victims    int // 被夹成交数
+	SelfTrades int // 对倒成交数
+	Washes     int // 刷量成交数
+}
+
+// AnalyzeMev 按交易对分析一个 slot 内买卖成交的先后顺序，给成交打上 MevTag：
+//  1. 对倒：同一钱包在同一笔交易内既买又卖
+//  2. 夹子：同一钱包先抢跑，其他钱包随后同方向成交，该钱包再反向平仓，三者按交易序号依次排列
+//  3. 往返刷量：同一钱包在 slot 内买卖数量基本相抵
+//  4. 循环刷量：一个钱包买入后，另一个钱包卖出完全相同的代币数量（原始数量一致，通常是转账后卖出），
+//     且两个钱包在区块内有资金往来（links 中有直接转账或同一资金来源），没有关联的巧合不算
+//
+// 夹子的三方成交同时标记 Clamp，抢跑和平仓标记 Clipper
+func AnalyzeMev(trades []*types.TradeWithPair, links *WalletLinks) (stat MevStat) {
+	pairTrades := make(map[string][]*types.TradeWithPair)
+	for _, trade := range trades {
+		if trade == nil || trade.PairAddr == "" || (trade.Type != types.TradeTypeBuy && trade.Type != types.TradeTypeSell) {
+			continue
+		}
+		pairTrades[trade.PairAddr] = append(pairTrades[trade.PairAddr], trade)
+	}
+
+	for _, items := range pairTrades {
+		if len(items) < 2 {
+			continue
+		}
+		slices.SortStableFunc(items, func(a, b *types.TradeWithPair) int {
+			return cmp.Compare(a.TransactionIndex, b.TransactionIndex)
+		})
+		stat.SelfTrades += tagSelfTrades(items)
+		sandwiches, victims := tagSandwiches(items)
+		stat.Sandwiches += sandwiches
+		stat.Victims += victims
+		stat.Washes += tagRoundTrips(items) + tagCircular(items, links)
+	}
+	return
+}
+
+func tagSelfTrades(trades []*types.TradeWithPair) (count int) {
+	type key struct{ txHash, maker string }
+	sides := make(map[key]map[string]bool)
+	for _, trade := range trades {
+		k := key{trade.TxHash, trade.Maker}
+		if sides[k] == nil {
+			sides[k] = make(map[string]bool)
+		}
+		sides[k][trade.Type] = true
+	}
+	for _, trade := range trades {
+		side := sides[key{trade.TxHash, trade.Maker}]
+		if side[types.TradeTypeBuy] && side[types.TradeTypeSell] {
+			trade.MevTag = solmodel.TradeMevTagSelfTrade
+			count++
+		}
+	}
+	return
+}
+
+func tagSandwiches(trades []*types.TradeWithPair) (sandwiches, victims int) {
+	for i, front := range trades {
+		if front.MevTag != "" {
+			continue
+		}
+		for j := i + 1; j < len(trades); j++ {
+			back := trades[j]
+			if back.MevTag != "" || back.Maker != front.Maker || back.Type == front.Type ||
+				back.TransactionIndex == front.TransactionIndex || !sandwichAmountMatch(front, back) {
+				continue
+			}
+
+			var between []*types.TradeWithPair
+			for _, victim := range trades[i+1 : j] {
+				if victim.Maker != front.Maker && victim.Type == front.Type &&
+					victim.TransactionIndex > front.TransactionIndex && victim.TransactionIndex < back.TransactionIndex &&
+					(victim.MevTag == "" || victim.MevTag == solmodel.TradeMevTagSandwichVictim) {
+					between = append(between, victim)
+				}
+			}
+			if len(between) == 0 {
+				continue
+			}
+
+			front.MevTag, front.Clamp, front.Clipper = solmodel.TradeMevTagSandwichFront, true, true
+			back.MevTag, back.Clamp, back.Clipper = solmodel.TradeMevTagSandwichBack, true, true
+			for _, victim := range between {
+				if victim.MevTag == "" {
+					victims++
+				}
+				victim.MevTag, victim.Clamp = solmodel.TradeMevTagSandwichVictim, true
+			}
+			sandwiches++
+			break
+		}
+	}
+	return
+}
+
+func sandwichAmountMatch(front, back *types.TradeWithPair) bool {
+	if front.TokenAmountInt <= 0 {
+		return false
+	}
+	ratio := float64(back.TokenAmountInt) / float64(front.TokenAmountInt)
+	return ratio >= 1-sandwichAmountTolerance && ratio <= 1+sandwichAmountTolerance
+}
+
+func tagRoundTrips(trades []*types.TradeWithPair) (count int) {
+	bought := make(map[string]int64)
+	sold := make(map[string]int64)
+	for _, trade := range trades {
+		if trade.MevTag != "" {
+			continue
+		}
+		if trade.Type == types.TradeTypeBuy {
+			bought[trade.Maker] += trade.TokenAmountInt
+		} else {
+			sold[trade.Maker] += trade.TokenAmountInt
+		}
+	}
+
+	for _, trade := range trades {
+		if trade.MevTag != "" {
+			continue
+		}
+		buy, sell := bought[trade.Maker], sold[trade.Maker]
+		if buy <= 0 || sell <= 0 {
+			continue
+		}
+		if float64(max(buy-sell, sell-buy)) <= float64(max(buy, sell))*washNetTolerance {
+			trade.MevTag = solmodel.TradeMevTagWash
+			count++
+		}
+	}
+	return
+}
+
+func tagCircular(trades []*types.TradeWithPair, links *WalletLinks) (count int) {
+	if links == nil {
+		return
+	}
+	for i, buy := range trades {
+		if buy.MevTag != "" || buy.Type != types.TradeTypeBuy || buy.TokenAmountInt <= 0 {
+			continue
+		}
+		for _, sell := range trades[i+1:] {
+			if sell.MevTag != "" || sell.Type != types.TradeTypeSell || sell.Maker == buy.Maker ||
+				sell.TransactionIndex <= buy.TransactionIndex || sell.TokenAmountInt != buy.TokenAmountInt ||
+				!links.Linked(buy.Maker, sell.Maker) {
+				continue
+			}
+			buy.MevTag = solmodel.TradeMevTagWash
+			sell.MevTag = solmodel.TradeMevTagWash
+			count += 2
+			break
+		}
+	}
+	return
+}
+
+// WalletLinks 区块内钱包之间的资金往来，由成功交易中的 SOL 转账和代币转账汇总，用于确认循环刷量的买卖双方是关联钱包
+type WalletLinks struct {
+	received map[string]map[string]bool // 收款方 -> 付款方
+}
+
+func NewWalletLinks() *WalletLinks {
+	return &WalletLinks{received: make(map[string]map[string]bool)}
+}
+
+// Add 记录一笔 from 转给 to 的转账
+func (l *WalletLinks) Add(from, to string) {
+	if from == "" || to == "" || from == to {
+		return
+	}
+	if l.received[to] == nil {
+		l.received[to] = make(map[string]bool)
+	}
+	l.received[to][from] = true
+}
+
+// Linked 两个钱包之间有直接转账，或者收到过同一个钱包的转账（同一资金来源）
+func (l *WalletLinks) Linked(a, b string) bool {
+	fromA, fromB := l.received[a], l.received[b]
+	if fromA[b] || fromB[a] {
+		return true
+	}
+	for funder := range fromA {
+		if fromB[funder] {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTx 记录交易（含内部指令）中的 SOL 转账和代币转账，代币转账的收款方按交易前后的代币余额换算为账户所有者
+func (l *WalletLinks) AddTx(tx *client.BlockTransaction) {
+	if tx == nil || tx.Meta == nil || tx.Meta.Err != nil {
+		return
+	}
+	owners := tokenAccountOwners(tx)
+	add := func(instruction *solTypes.CompiledInstruction) {
+		from, to := decodeWalletTransfer(tx.AccountKeys, instruction, owners)
+		l.Add(from, to)
+	}
+	for i := range tx.Transaction.Message.Instructions {
+		add(&tx.Transaction.Message.Instructions[i])
+	}
+	for _, inner := range tx.Meta.InnerInstructions {
+		for i := range inner.Instructions {
+			add(&inner.Instructions[i])
+		}
+	}
+}
+
+// tokenAccountOwners 按交易前后的代币余额取出交易涉及的代币账户的所有者，代币账户地址 -> 所有者钱包
+func tokenAccountOwners(tx *client.BlockTransaction) map[string]string {
+	owners := make(map[string]string)
+	for _, balances := range [][]rpc.TransactionMetaTokenBalance{tx.Meta.PreTokenBalances, tx.Meta.PostTokenBalances} {
+		for _, balance := range balances {
+			if int(balance.AccountIndex) < len(tx.AccountKeys) && balance.Owner != "" {
+				owners[tx.AccountKeys[balance.AccountIndex].String()] = balance.Owner
+			}
+		}
+	}
+	return owners
+}
+
+// decodeWalletTransfer 解析 SOL 转账和代币转账，返回付款钱包和收款钱包；代币转账的付款方为签名的 authority
+func decodeWalletTransfer(accountKeys []common.PublicKey, instruction *solTypes.CompiledInstruction, owners map[string]string) (from, to string) {
+	if instruction.ProgramIDIndex >= len(accountKeys) {
+		return
+	}
+	for _, index := range instruction.Accounts {
+		if index >= len(accountKeys) {
+			return
+		}
+	}
+	switch accountKeys[instruction.ProgramIDIndex] {
+	case common.SystemProgramID:
+		if len(instruction.Data) != 12 || binary.LittleEndian.Uint32(instruction.Data[:4]) != systemInstructionTransfer || len(instruction.Accounts) < 2 {
+			return
+		}
+		return accountKeys[instruction.Accounts[0]].String(), accountKeys[instruction.Accounts[1]].String()
+	case common.TokenProgramID, common.Token2022ProgramID:
+		transfer, err := DecodeTokenTransfer(accountKeys, instruction)
+		if err != nil || transfer == nil {
+			return
+		}
+		return transfer.Auth.String(), owners[transfer.To.String()]
+	}
+	return
+}
@@ -0,0 +1,135 @@
+package block
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/token"
+	"github.com/blocto/solana-go-sdk/rpc"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+func mevTrade(pair, tx, maker, typ string, index int, amount int64) *types.TradeWithPair {
+	trade := &types.TradeWithPair{PairAddr: pair, TxHash: tx, Maker: maker, Type: typ, TransactionIndex: index}
+	trade.TokenAmountInt = amount
+	return trade
+}
+
+func TestAnalyzeMev(t *testing.T) {
+	links := NewWalletLinks()
+	links.Add("e", "f")
+	links.Add("funder", "i")
+	links.Add("funder", "j")
+
+	front := mevTrade("p1", "t1", "a", types.TradeTypeBuy, 1, 1000)
+	victim := mevTrade("p1", "t2", "b", types.TradeTypeBuy, 2, 300)
+	back := mevTrade("p1", "t3", "a", types.TradeTypeSell, 3, 980)
+	selfBuy := mevTrade("p2", "t4", "c", types.TradeTypeBuy, 4, 500)
+	selfSell := mevTrade("p2", "t4", "c", types.TradeTypeSell, 4, 500)
+	roundBuy := mevTrade("p3", "t5", "d", types.TradeTypeBuy, 5, 500)
+	roundSell := mevTrade("p3", "t6", "d", types.TradeTypeSell, 6, 480)
+	linkedBuy := mevTrade("p4", "t7", "e", types.TradeTypeBuy, 7, 777)
+	linkedSell := mevTrade("p4", "t8", "f", types.TradeTypeSell, 8, 777)
+	strangerBuy := mevTrade("p5", "t9", "g", types.TradeTypeBuy, 9, 888)
+	strangerSell := mevTrade("p5", "t10", "h", types.TradeTypeSell, 10, 888)
+	fundedBuy := mevTrade("p6", "t11", "i", types.TradeTypeBuy, 11, 999)
+	fundedSell := mevTrade("p6", "t12", "j", types.TradeTypeSell, 12, 999)
+	trades := []*types.TradeWithPair{
+		back, victim, front, selfBuy, selfSell, roundBuy, roundSell,
+		linkedBuy, linkedSell, strangerBuy, strangerSell, fundedBuy, fundedSell,
+		{PairAddr: "p1", Type: types.TradeTypeAddPosition},
+	}
+
+	stat := AnalyzeMev(trades, links)
+	require.Equal(t, MevStat{Sandwiches: 1, Victims: 1, SelfTrades: 2, Washes: 6}, stat)
+
+	require.Equal(t, solmodel.TradeMevTagSandwichFront, front.MevTag)
+	require.True(t, front.Clipper && front.Clamp)
+	require.Equal(t, solmodel.TradeMevTagSandwichVictim, victim.MevTag)
+	require.True(t, victim.Clamp && !victim.Clipper)
+	require.Equal(t, solmodel.TradeMevTagSandwichBack, back.MevTag)
+	for _, trade := range []*types.TradeWithPair{selfBuy, selfSell} {
+		require.Equal(t, solmodel.TradeMevTagSelfTrade, trade.MevTag)
+	}
+	for _, trade := range []*types.TradeWithPair{roundBuy, roundSell, linkedBuy, linkedSell, fundedBuy, fundedSell} {
+		require.Equal(t, solmodel.TradeMevTagWash, trade.MevTag, trade.TxHash)
+	}
+	// 数量相同但没有资金往来的两个钱包不算循环刷量
+	require.Empty(t, strangerBuy.MevTag)
+	require.Empty(t, strangerSell.MevTag)
+}
+
+func TestTagCircularWithoutLinks(t *testing.T) {
+	trades := []*types.TradeWithPair{
+		mevTrade("p", "t1", "e", types.TradeTypeBuy, 1, 777),
+		mevTrade("p", "t2", "f", types.TradeTypeSell, 2, 777),
+	}
+	require.Zero(t, tagCircular(trades, nil))
+	require.Zero(t, tagCircular(trades, NewWalletLinks()))
+	require.Empty(t, trades[0].MevTag)
+}
+
+func testKey(b byte) common.PublicKey {
+	return common.PublicKeyFromBytes(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestWalletLinksAddTx(t *testing.T) {
+	funder, buyer, seller, source, destination := testKey(1), testKey(2), testKey(3), testKey(4), testKey(5)
+	solTransfer := make([]byte, 12)
+	binary.LittleEndian.PutUint32(solTransfer, systemInstructionTransfer)
+	binary.LittleEndian.PutUint64(solTransfer[4:], 1_000_000_000)
+	tokenTransfer := make([]byte, 9)
+	tokenTransfer[0] = byte(token.InstructionTransfer)
+	binary.LittleEndian.PutUint64(tokenTransfer[1:], 777)
+
+	tx := client.BlockTransaction{
+		AccountKeys: []common.PublicKey{funder, buyer, common.SystemProgramID, common.TokenProgramID, source, destination},
+		Meta: &client.TransactionMeta{
+			PostTokenBalances: []rpc.TransactionMetaTokenBalance{
+				{AccountIndex: 4, Owner: buyer.String()},
+				{AccountIndex: 5, Owner: seller.String()},
+			},
+			InnerInstructions: []client.InnerInstruction{{
+				Instructions: []solTypes.CompiledInstruction{{ProgramIDIndex: 3, Accounts: []int{4, 5, 1}, Data: tokenTransfer}},
+			}},
+		},
+	}
+	tx.Transaction.Message.Instructions = []solTypes.CompiledInstruction{{ProgramIDIndex: 2, Accounts: []int{0, 1}, Data: solTransfer}}
+
+	links := NewWalletLinks()
+	links.AddTx(&tx)
+	require.True(t, links.Linked(funder.String(), buyer.String()))
+	require.True(t, links.Linked(seller.String(), buyer.String()))
+	require.False(t, links.Linked(funder.String(), seller.String()))
+
+	// 失败的交易不记录
+	failed := tx
+	failed.Meta = &client.TransactionMeta{Err: "failed", PostTokenBalances: tx.Meta.PostTokenBalances, InnerInstructions: tx.Meta.InnerInstructions}
+	links = NewWalletLinks()
+	links.AddTx(&failed)
+	require.False(t, links.Linked(seller.String(), buyer.String()))
+}
+
+func TestAnalyzeMevRouterWrapped(t *testing.T) {
+	// 夹子机器人和被夹用户都经路由程序 CPI 调用 PumpSwap，成交从内部指令中解析后参与分析
+	router := testKey(40)
+	trades := decodeRouterSwaps(t, newTestBlockService(),
+		routerSwapTx(t, routerSwap{router: router, user: 1, buy: true, baseAmount: 5_000_000_000, quoteAmount: 1_000_000_000}),
+		routerSwapTx(t, routerSwap{router: router, user: 2, buy: true, baseAmount: 800_000_000, quoteAmount: 170_000_000}),
+		routerSwapTx(t, routerSwap{router: router, user: 1, buy: false, baseAmount: 5_000_000_000, quoteAmount: 1_050_000_000}),
+	)
+	require.Len(t, trades, 3)
+
+	stat := AnalyzeMev(trades, NewWalletLinks())
+	require.Equal(t, 1, stat.Sandwiches)
+	require.Equal(t, 1, stat.Victims)
+	require.Equal(t, solmodel.TradeMevTagSandwichFront, trades[0].MevTag)
+	require.Equal(t, solmodel.TradeMevTagSandwichVictim, trades[1].MevTag)
+	require.Equal(t, solmodel.TradeMevTagSandwichBack, trades[2].MevTag)
+}
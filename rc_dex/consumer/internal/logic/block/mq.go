@@ -0,0 +1,95 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+	"richcode.cc/dex/pkg/mq"
+	"richcode.cc/dex/pkg/types"
+)
+
+// publishMq 区块落库后把本 slot 的代币更新、交易对更新和成交按顺序发布到消息队列，序号在 slot 内从 1 连续递增
+// 代币和交易对由各交易对的协程并发写入，这里按地址排序，重复处理同一 slot 时顺序和序号不变
+// 发布器按 slot 顺序逐批发送并在失败时重试；队列写满时这里会等待
+func (s *BlockService) publishMq(ctx context.Context, slot int64, updates *slotUpdates) {
+	if s.sc.Publisher == nil {
+		return
+	}
+	messages := s.buildMqMessages(slot, updates)
+	if len(messages) == 0 {
+		return
+	}
+
+	batch := &mq.Batch{
+		Name:     strconv.FormatInt(slot, 10),
+		Messages: messages,
+	}
+	if err := s.sc.Publisher.Publish(ctx, batch); err != nil {
+		s.Errorf("publishMq:%v publish err: %v, messages: %v", slot, err, len(messages))
+		return
+	}
+	s.Infof("publishMq:%v messages: %v", slot, len(messages))
+}
+
+func (s *BlockService) buildMqMessages(slot int64, updates *slotUpdates) []mq.Message {
+	updates.lock.Lock()
+	defer updates.lock.Unlock()
+
+	conf := s.sc.Config.Mq
+	trades := updates.sortedTrades()
+	tokens := slices.SortedFunc(slices.Values(updates.tokens), func(a, b *solmodel.Token) int {
+		return strings.Compare(a.Address, b.Address)
+	})
+	pairs := slices.SortedFunc(slices.Values(updates.pairs), func(a, b *pairUpdate) int {
+		return strings.Compare(a.pair.Address, b.pair.Address)
+	})
+	size := len(tokens) + len(pairs) + len(trades)
+	if size == 0 {
+		return nil
+	}
+
+	messages := make([]mq.Message, 0, size)
+	add := func(topic, key string, body *types.MqMessage, headers map[string]string) {
+		body.ChainId = constants.SolChainId
+		body.Slot = slot
+		body.Seq = len(messages) + 1
+		body.SlotSize = size
+		value, err := json.Marshal(body)
+		if err != nil {
+			// 序号必须连续，序列化失败时仍占位发送，消费端可根据 type 为空识别
+			s.Errorf("buildMqMessages:%v marshal %v err: %v", slot, body.Type, err)
+			value, _ = json.Marshal(&types.MqMessage{ChainId: body.ChainId, Slot: slot, Seq: body.Seq, SlotSize: size})
+		}
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers["type"] = body.Type
+		headers["slot"] = strconv.FormatInt(slot, 10)
+		headers["seq"] = strconv.Itoa(body.Seq)
+		headers["msg_id"] = body.MsgId()
+		messages = append(messages, mq.Message{
+			Topic:   topic,
+			Key:     key,
+			Value:   value,
+			Headers: headers,
+		})
+	}
+
+	for _, token := range tokens {
+		add(conf.TokenTopic, token.Address, &types.MqMessage{Type: types.MqMessageToken, Token: token}, nil)
+	}
+	for _, item := range pairs {
+		add(conf.PairTopic, item.pair.Address, &types.MqMessage{Type: types.MqMessagePair, Pair: item.pair, PairCreated: item.created}, map[string]string{
+			"created": strconv.FormatBool(item.created),
+		})
+	}
+	for _, trade := range trades {
+		add(conf.TradeTopic, trade.PairAddr, &types.MqMessage{Type: types.MqMessageTrade, Trade: trade}, trade.Tags())
+	}
+	return messages
+}
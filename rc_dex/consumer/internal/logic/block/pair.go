@@ -17,7 +17,7 @@ import (
 )
 
 // SavePair 负责写入或更新交易对基础信息，并同步 Pump 相关指标。
-func (s *BlockService) SavePair(ctx context.Context, trade *types.TradeWithPair, tokenDb *solmodel.Token) (pairAtDB *solmodel.Pair, err error) {
+func (s *BlockService) SavePair(ctx context.Context, trade *types.TradeWithPair, tokenDb *solmodel.Token) (pairAtDB *solmodel.Pair, created bool, err error) {
 	chainId := SolChainIdInt
 	var tokenTotalSupply float64
 	var tokenSymbol = trade.PairInfo.TokenSymbol
@@ -118,13 +118,7 @@ func (s *BlockService) SavePair(ctx context.Context, trade *types.TradeWithPair,
 			pairAtDB.InitTokenAmount = trade.PairInfo.InitTokenAmount
 		}
 
-		//you should push here
-		// if pairAtDB.Name =PumpFun and PumpPoint==0 ,这就是新token
-
-		// Push new pump.fun token creation to WebSocket
-		if pairAtDB.Name == constants.PumpFun || pairAtDB.Name == "PumpFun" && pairAtDB.PumpPoint == 0 {
-			fmt.Println("new token created")
-		}
+		// 新交易对在区块落库后以 new_pair 事件推送，见 publishSlot
 
 		err = s.sc.PairModel.Insert(ctx, pairAtDB)
 		if err != nil {
@@ -132,13 +126,14 @@ func (s *BlockService) SavePair(ctx context.Context, trade *types.TradeWithPair,
 				// db already exists
 				pairAtDB, err = s.sc.PairModel.FindOneByChainIdAddress(ctx, int64(chainId), trade.PairAddr)
 				if err != nil {
-					return nil, err
+					return nil, false, err
 				}
-				return pairAtDB, nil
+				return pairAtDB, false, nil
 			}
 			err = fmt.Errorf("PairModel.Insert err:%w", err)
 			return
 		}
+		created = true
 
     case err == nil:
         // 分支二：交易对已存在，直接更新核心指标
@@ -153,6 +148,10 @@ func (s *BlockService) SavePair(ctx context.Context, trade *types.TradeWithPair,
 		pairAtDB.Slot = trade.Slot
 		pairAtDB.BlockTime = time.Unix(trade.BlockTime, 0)
 		pairAtDB.Liquidity = liq
+		if pairAtDB.PumpOwner == "" {
+			// 早期写入的交易对没有创建者，后续成交事件带上 coin creator 时补齐，跑路检测依赖该字段
+			pairAtDB.PumpOwner = trade.PumpOwner
+		}
 
 		// 其它可选字段也可同步更新
 		// 保存到数据库
@@ -0,0 +1,120 @@
+package block
+
+import (
+	"slices"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+	"richcode.cc/dex/consumer/internal/config"
+)
+
+// System Program transfer 指令：4 字节指令类型（2）+ 8 字节 lamports，账户 [from, to]
+const systemInstructionTransfer = 2
+
+// 内置平台名称，写入成交的 platform 字段
+const (
+	PlatformPhoton = "photon"
+	PlatformBullX  = "bullx"
+	PlatformAxiom  = "axiom"
+	PlatformTrojan = "trojan"
+)
+
+// DefaultPlatforms 常见交易机器人和前端的路由程序 ID 和手续费收款地址，整理自公开的链上数据；
+// 平台会不定期启用新的收款地址，发现漏识别时在配置中追加，配置中的同名平台与内置地址合并
+var DefaultPlatforms = []config.PlatformRule{
+	{
+		Name:        PlatformPhoton,
+		Programs:    []string{"BSfD6SHZigAfDWSjzD5Q41jw8LmKwtmjskPH9XW1mrRW"},
+		FeeAccounts: []string{"AVUCZyuT35YSuj4RH7fwiyPu82Djn2Hfg7y2ND2XcnZH"},
+	},
+	{
+		Name: PlatformBullX,
+		FeeAccounts: []string{
+			"F4hJ3Ee3c5UuaorKAMfELBjYCjiiLH75haZTKqTywRP3",
+			"9RYJ3qr5eU5xAooqVcbmdeusjcViL5Nkiq7Gske3tiKq",
+		},
+	},
+	{
+		Name: PlatformAxiom,
+		FeeAccounts: []string{
+			"7LCZckF6XXGQ1hDY6HFXBKWAtiUgL9QY5vj1C4Bn1Qjj",
+			"4V65jvcDG9DSQioUVqVPiUcUY9v6sb6HKtMnsxSKEz5S",
+			"CeA3sPZfWWToFEBmw5n1Y93tnV66Vmp8LacLzsVprgxZ",
+		},
+	},
+	{
+		Name:        PlatformTrojan,
+		FeeAccounts: []string{"9yMwSPk9mrXSN7yDHUuZurAh1sjbJsfpUqjZ7SvVtdco"},
+	},
+}
+
+// PlatformMatcher 按内置地址和配置识别成交的下单平台（交易机器人或前端）
+type PlatformMatcher struct {
+	programs    map[string]string // 路由程序 ID -> 平台名称
+	feeAccounts map[string]string // 手续费收款地址 -> 平台名称
+}
+
+func NewPlatformMatcher(conf config.PlatformConfig) *PlatformMatcher {
+	m := &PlatformMatcher{
+		programs:    make(map[string]string),
+		feeAccounts: make(map[string]string),
+	}
+	rules := conf.Platforms
+	if !conf.DisableDefaults {
+		rules = append(slices.Clone(DefaultPlatforms), rules...)
+	}
+	for _, rule := range rules {
+		for _, program := range rule.Programs {
+			m.programs[program] = rule.Name
+		}
+		for _, account := range rule.FeeAccounts {
+			m.feeAccounts[account] = rule.Name
+		}
+	}
+	return m
+}
+
+// Detect 识别交易的下单平台，未识别返回空：
+//  1. 外层指令调用了平台的路由程序
+//  2. 外层或内部指令中有 SOL 转账（System transfer）转入平台的手续费收款地址，或代币转账转入该地址名下的代币账户
+func (m *PlatformMatcher) Detect(tx *client.BlockTransaction) string {
+	if tx == nil || tx.Meta == nil || (len(m.programs) == 0 && len(m.feeAccounts) == 0) {
+		return ""
+	}
+
+	for i := range tx.Transaction.Message.Instructions {
+		inst := &tx.Transaction.Message.Instructions[i]
+		if inst.ProgramIDIndex >= len(tx.AccountKeys) {
+			continue
+		}
+		if name, ok := m.programs[tx.AccountKeys[inst.ProgramIDIndex].String()]; ok {
+			return name
+		}
+	}
+
+	if len(m.feeAccounts) == 0 {
+		return ""
+	}
+	owners := tokenAccountOwners(tx)
+	for i := range tx.Transaction.Message.Instructions {
+		if name := m.feeRecipient(tx.AccountKeys, &tx.Transaction.Message.Instructions[i], owners); name != "" {
+			return name
+		}
+	}
+	for _, inner := range tx.Meta.InnerInstructions {
+		for i := range inner.Instructions {
+			if name := m.feeRecipient(tx.AccountKeys, &inner.Instructions[i], owners); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// feeRecipient 指令是转入手续费收款地址的 SOL 转账，或转入手续费收款地址名下代币账户的代币转账时返回对应平台；
+// 代币转账的收款方是代币账户，按 owners（代币账户 -> 所有者）换算为钱包后比较
+func (m *PlatformMatcher) feeRecipient(accountKeys []common.PublicKey, instruction *solTypes.CompiledInstruction, owners map[string]string) string {
+	_, to := decodeWalletTransfer(accountKeys, instruction, owners)
+	return m.feeAccounts[to]
+}
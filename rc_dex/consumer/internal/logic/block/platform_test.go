@@ -0,0 +1,97 @@
+package block
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/token"
+	"github.com/blocto/solana-go-sdk/rpc"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	"richcode.cc/dex/consumer/internal/config"
+)
+
+// platformTx 构造一笔交易：外层调用 program，并向 feeAccount 转一笔 SOL（为空时不转）
+func platformTx(program, feeAccount common.PublicKey) *client.BlockTransaction {
+	user := testKey(9)
+	tx := &client.BlockTransaction{
+		AccountKeys: []common.PublicKey{user, program, common.SystemProgramID, feeAccount},
+		Meta:        &client.TransactionMeta{},
+	}
+	tx.Transaction.Message.Instructions = []solTypes.CompiledInstruction{{ProgramIDIndex: 1, Accounts: []int{0}}}
+	if feeAccount != (common.PublicKey{}) {
+		data := make([]byte, 12)
+		binary.LittleEndian.PutUint32(data, systemInstructionTransfer)
+		binary.LittleEndian.PutUint64(data[4:], 1_000_000)
+		tx.Meta.InnerInstructions = []client.InnerInstruction{{
+			Instructions: []solTypes.CompiledInstruction{{ProgramIDIndex: 2, Accounts: []int{0, 3}, Data: data}},
+		}}
+	}
+	return tx
+}
+
+// platformTokenTx 构造一笔交易：外层调用 program，并向 feeWallet 名下的代币账户转一笔代币
+func platformTokenTx(program, feeWallet common.PublicKey) *client.BlockTransaction {
+	user, source, destination := testKey(9), testKey(10), testKey(11)
+	tx := &client.BlockTransaction{
+		AccountKeys: []common.PublicKey{user, program, common.TokenProgramID, source, destination},
+		Meta: &client.TransactionMeta{
+			PostTokenBalances: []rpc.TransactionMetaTokenBalance{
+				{AccountIndex: 3, Owner: user.String()},
+				{AccountIndex: 4, Owner: feeWallet.String()},
+			},
+		},
+	}
+	data := make([]byte, 9)
+	data[0] = byte(token.InstructionTransfer)
+	binary.LittleEndian.PutUint64(data[1:], 1_000_000)
+	tx.Transaction.Message.Instructions = []solTypes.CompiledInstruction{{ProgramIDIndex: 1, Accounts: []int{0}}}
+	tx.Meta.InnerInstructions = []client.InnerInstruction{{
+		Instructions: []solTypes.CompiledInstruction{{ProgramIDIndex: 2, Accounts: []int{3, 4, 0}, Data: data}},
+	}}
+	return tx
+}
+
+func TestDefaultPlatforms(t *testing.T) {
+	for _, rule := range DefaultPlatforms {
+		for _, address := range slices.Concat(rule.Programs, rule.FeeAccounts) {
+			// 长度不对或不是 base58 的地址转换后与原字符串不一致
+			require.Equal(t, address, common.PublicKeyFromString(address).String())
+		}
+	}
+}
+
+func TestPlatformMatcherDetect(t *testing.T) {
+	m := NewPlatformMatcher(config.PlatformConfig{
+		Platforms: []config.PlatformRule{{Name: PlatformAxiom, FeeAccounts: []string{testKey(7).String()}}},
+	})
+	unknownProgram := testKey(8)
+
+	// 每个内置平台的路由程序和每个手续费收款地址都能识别
+	for _, rule := range DefaultPlatforms {
+		for _, program := range rule.Programs {
+			require.Equal(t, rule.Name, m.Detect(platformTx(common.PublicKeyFromString(program), common.PublicKey{})), program)
+		}
+		for _, account := range rule.FeeAccounts {
+			require.Equal(t, rule.Name, m.Detect(platformTx(unknownProgram, common.PublicKeyFromString(account))), account)
+		}
+	}
+	// 代币手续费转入收款地址名下的代币账户，按代币账户的所有者识别
+	for _, rule := range DefaultPlatforms {
+		for _, account := range rule.FeeAccounts {
+			require.Equal(t, rule.Name, m.Detect(platformTokenTx(unknownProgram, common.PublicKeyFromString(account))), account)
+		}
+	}
+	require.Empty(t, m.Detect(platformTokenTx(unknownProgram, testKey(6))))
+	// 配置追加的地址与内置的同名平台合并
+	require.Equal(t, PlatformAxiom, m.Detect(platformTx(unknownProgram, testKey(7))))
+	require.Empty(t, m.Detect(platformTx(unknownProgram, testKey(6))))
+	require.Empty(t, m.Detect(platformTx(unknownProgram, common.PublicKey{})))
+
+	// 关闭内置地址后只按配置识别
+	m = NewPlatformMatcher(config.PlatformConfig{DisableDefaults: true})
+	require.Empty(t, m.Detect(platformTx(unknownProgram, common.PublicKeyFromString(DefaultPlatforms[0].FeeAccounts[0]))))
+}
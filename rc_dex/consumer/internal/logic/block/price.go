@@ -8,7 +8,6 @@ import (
 
 	"github.com/blocto/solana-go-sdk/client"
 	"github.com/blocto/solana-go-sdk/common"
-	"github.com/blocto/solana-go-sdk/program/token"
 	"github.com/blocto/solana-go-sdk/rpc"
 )
 
@@ -147,8 +146,8 @@ func GetBlockSolPriceByTransfer(accountKeys []common.PublicKey, innerInstruction
 	if innerInstructions == nil {
 		return
 	}
-	var transferSOL *token.TransferParam
-	var transferUSD *token.TransferParam
+	var transferSOL *TokenTransfer
+	var transferUSD *TokenTransfer
 	var connect bool
 	// 遍历内部指令中的指令，判断是否是稳定币交换指令
 	for j := range innerInstructions.Instructions {
@@ -178,7 +177,7 @@ func GetBlockSolPriceByTransfer(accountKeys []common.PublicKey, innerInstruction
 		if from.TokenAddress == TokenStrWrapSol { // 检查发送方是否是 sol 币
 			transferSOL = transfer
 			if connect && transferUSD != nil {
-				solPrice = float64(transferUSD.Amount) / float64(transferSOL.Amount) * 1000 // SPL 六位小数，SOL九位小数，所以需要*1000，保持对齐
+				solPrice = float64(transferUSD.NetAmount()) / float64(transferSOL.NetAmount()) * 1000 // SPL 六位小数，SOL九位小数，所以需要*1000，保持对齐
 				if IsSwapTransfer(transferSOL, transferUSD, tokenAccountMap) {
 					break
 				} else {
@@ -189,7 +188,7 @@ func GetBlockSolPriceByTransfer(accountKeys []common.PublicKey, innerInstruction
 		} else if from.TokenAddress == TokenStrUSDC || from.TokenAddress == TokenStrUSDT { // 检查发送方是否是 usdc 或 usdt 币
 			transferUSD = transfer
 			if connect && transferSOL != nil {
-				solPrice = float64(transferUSD.Amount) / float64(transferSOL.Amount) * 1000
+				solPrice = float64(transferUSD.NetAmount()) / float64(transferSOL.NetAmount()) * 1000
 				if IsSwapTransfer(transferSOL, transferUSD, tokenAccountMap) {
 					break
 				} else {
@@ -204,14 +203,14 @@ func GetBlockSolPriceByTransfer(accountKeys []common.PublicKey, innerInstruction
 		}
 	}
 	if transferSOL != nil && transferUSD != nil && connect {
-		solPrice = float64(transferUSD.Amount) / float64(transferSOL.Amount) * 1000
+		solPrice = float64(transferUSD.NetAmount()) / float64(transferSOL.NetAmount()) * 1000
 	} else {
 		solPrice = 0
 	}
 	return
 }
 
-func IsSwapTransfer(a, b *token.TransferParam, tokenAccountMap map[string]*TokenAccount) bool {
+func IsSwapTransfer(a, b *TokenTransfer, tokenAccountMap map[string]*TokenAccount) bool {
 	if a == nil || b == nil {
 		return false
 	}
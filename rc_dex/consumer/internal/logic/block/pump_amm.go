@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
 	solTypes "github.com/blocto/solana-go-sdk/types"
+	"github.com/gagliardetto/solana-go"
 	"github.com/shopspring/decimal"
 	"github.com/zeromicro/go-zero/core/logx"
 	"richcode.cc/dex/consumer/internal/svc"
@@ -44,8 +46,14 @@ func (decoder *PumpAmmDecoder) DecodePumpFunAMMInstruction() (*types.TradeWithPa
 		return decoder.DecodePumpFunAMMSellInstruction()
 	} else if bytes.Equal(discriminator, pump_amm.Instruction_CreatePool[:]) {
 		return decoder.DecodeCreatePoolInstruction(decoder.dtx.Tx.Meta.LogMessages)
-	}
-	return nil, errors.New("unknown instruction discriminator")
+	} else if bytes.Equal(discriminator, pump_amm.Instruction_Deposit[:]) {
+		return decoder.DecodeDepositInstruction()
+	} else if bytes.Equal(discriminator, pump_amm.Instruction_Withdraw[:]) {
+		return decoder.DecodeWithdrawInstruction()
+	} else if bytes.Equal(discriminator, pump_amm.Instruction_CollectCoinCreatorFee[:]) {
+		return decoder.DecodeCollectCoinCreatorFeeInstruction()
+	}
+	return nil, ErrUnknowInstruction
 }
 
 // DecodePumpFunAMMBuyInstruction 解析 PumpFun AMM 的买入事件并构造成交结构。
@@ -93,16 +101,27 @@ func (decoder *PumpAmmDecoder) DecodePumpFunAMMBuyInstruction() (*types.TradeWit
 	}
 
 	// 第三步：组装业务侧需要的成交结构
+	// Token-2022 转账手续费从用户收到的代币中扣留，成交数量按实际到账的净数量记录
+	baseMintExtension := decoder.baseMintExtension(baseTokenAccountInfo.TokenAddress)
+	transferFee := baseMintExtension.CalculateTransferFee(buyEvent.BaseAmountOut, trade.BlockNum)
+	baseAmountReceived := buyEvent.BaseAmountOut - transferFee
+
 	trade.Type = types.TradeTypeBuy
 	trade.BaseTokenAmount = uiAmount(buyEvent.QuoteAmountInWithLpFee, quoteTokenAccountInfo.TokenDecimal)
-	trade.TokenAmount = uiAmount(buyEvent.BaseAmountOut, baseTokenAccountInfo.TokenDecimal)
+	trade.TokenAmount = baseMintExtension.UiAmount(baseAmountReceived, baseTokenAccountInfo.TokenDecimal, trade.BlockTime)
 	trade.BaseTokenAmountInt = int64(buyEvent.QuoteAmountInWithLpFee)
-	trade.TokenAmountInt = int64(buyEvent.BaseAmountOut)
+	trade.TokenAmountInt = int64(baseAmountReceived)
+	trade.TokenTransferFee = baseMintExtension.UiAmount(transferFee, baseTokenAccountInfo.TokenDecimal, trade.BlockTime)
+	trade.TokenTransferFeeInt = int64(transferFee)
 	trade.To = buyEvent.UserQuoteTokenAccount.String()
+	trade.LpFee = uiAmount(buyEvent.LpFee, quoteTokenAccountInfo.TokenDecimal)
+	trade.ProtocolFee = uiAmount(buyEvent.ProtocolFee, quoteTokenAccountInfo.TokenDecimal)
+	trade.CreatorFee = uiAmount(buyEvent.CoinCreatorFee, quoteTokenAccountInfo.TokenDecimal)
 	trade.TokenAmount1 = buyEvent.BaseAmountOut
 	trade.TokenAmount2 = buyEvent.MaxQuoteAmountIn
 	trade.PoolBaseTokenReserves = buyEvent.PoolBaseTokenReserves
 	trade.PoolQuoteTokenReserves = buyEvent.PoolQuoteTokenReserves
+	trade.PumpOwner = coinCreator(buyEvent.CoinCreator)
 	trade.CurrentBaseTokenInPoolAmount = float64(buyEvent.PoolQuoteTokenReserves)
 	trade.CurrentTokenInPoolAmount = float64(buyEvent.PoolBaseTokenReserves)
 	trade.PairInfo.CurrentBaseTokenAmount = trade.CurrentBaseTokenInPoolAmount
@@ -113,7 +132,7 @@ func (decoder *PumpAmmDecoder) DecodePumpFunAMMBuyInstruction() (*types.TradeWit
 	solAmount := uiAmount(buyEvent.QuoteAmountIn, quoteTokenAccountInfo.TokenDecimal)
 	solPriceUSD := decoder.solPriceUSD()
 	totalUSD := decimal.NewFromFloat(solAmount).Mul(decimal.NewFromFloat(solPriceUSD)).InexactFloat64()
-	tokenAmount := uiAmount(buyEvent.BaseAmountOut, baseTokenAccountInfo.TokenDecimal)
+	tokenAmount := trade.TokenAmount
 	if tokenAmount == 0 {
 		logger.Infof("skip pump.fun buy: token amount is zero baseAccount=%s", baseTokenAccountInfo.TokenAccountAddress)
 		return nil, nil
@@ -197,6 +216,27 @@ func (decoder *PumpAmmDecoder) parsePumpAmmEvents(logMessages []string) (events
 			}
 			events = append(events, event)
 
+		case pump_amm.Event_DepositEvent:
+			event, parseErr := pump_amm.ParseEvent_DepositEvent(eventData)
+			if parseErr != nil {
+				continue
+			}
+			events = append(events, event)
+
+		case pump_amm.Event_WithdrawEvent:
+			event, parseErr := pump_amm.ParseEvent_WithdrawEvent(eventData)
+			if parseErr != nil {
+				continue
+			}
+			events = append(events, event)
+
+		case pump_amm.Event_CollectCoinCreatorFeeEvent:
+			event, parseErr := pump_amm.ParseEvent_CollectCoinCreatorFeeEvent(eventData)
+			if parseErr != nil {
+				continue
+			}
+			events = append(events, event)
+
 		default:
 			continue
 		}
@@ -253,16 +293,27 @@ func (decoder *PumpAmmDecoder) DecodePumpFunAMMSellInstruction() (*types.TradeWi
 	}
 
 	// 第三步：组装业务侧需要的成交结构
+	// Token-2022 转账手续费从池子收到的代币中扣留，成交数量和价格都按池子实际到账的净数量计算，与买入口径一致
+	baseMintExtension := decoder.baseMintExtension(baseTokenAccountInfo.TokenAddress)
+	transferFee := baseMintExtension.CalculateTransferFee(sellEvent.BaseAmountIn, trade.BlockNum)
+	baseAmountReceived := sellEvent.BaseAmountIn - transferFee
+
 	trade.Type = types.TradeTypeSell
 	trade.BaseTokenAmount = uiAmount(sellEvent.QuoteAmountOut, quoteTokenAccountInfo.TokenDecimal)
-	trade.TokenAmount = uiAmount(sellEvent.BaseAmountIn, baseTokenAccountInfo.TokenDecimal)
+	trade.TokenAmount = baseMintExtension.UiAmount(baseAmountReceived, baseTokenAccountInfo.TokenDecimal, trade.BlockTime)
 	trade.BaseTokenAmountInt = int64(sellEvent.QuoteAmountOut)
-	trade.TokenAmountInt = int64(sellEvent.BaseAmountIn)
+	trade.TokenAmountInt = int64(baseAmountReceived)
+	trade.TokenTransferFee = baseMintExtension.UiAmount(transferFee, baseTokenAccountInfo.TokenDecimal, trade.BlockTime)
+	trade.TokenTransferFeeInt = int64(transferFee)
 	trade.To = sellEvent.UserQuoteTokenAccount.String()
+	trade.LpFee = uiAmount(sellEvent.LpFee, quoteTokenAccountInfo.TokenDecimal)
+	trade.ProtocolFee = uiAmount(sellEvent.ProtocolFee, quoteTokenAccountInfo.TokenDecimal)
+	trade.CreatorFee = uiAmount(sellEvent.CoinCreatorFee, quoteTokenAccountInfo.TokenDecimal)
 	trade.TokenAmount1 = sellEvent.BaseAmountIn
 	trade.TokenAmount2 = sellEvent.MinQuoteAmountOut
 	trade.PoolBaseTokenReserves = sellEvent.PoolBaseTokenReserves
 	trade.PoolQuoteTokenReserves = sellEvent.PoolQuoteTokenReserves
+	trade.PumpOwner = coinCreator(sellEvent.CoinCreator)
 	trade.CurrentBaseTokenInPoolAmount = float64(sellEvent.PoolQuoteTokenReserves)
 	trade.CurrentTokenInPoolAmount = float64(sellEvent.PoolBaseTokenReserves)
 	trade.PairInfo.CurrentBaseTokenAmount = trade.CurrentBaseTokenInPoolAmount
@@ -273,7 +324,7 @@ func (decoder *PumpAmmDecoder) DecodePumpFunAMMSellInstruction() (*types.TradeWi
 	solAmount := uiAmount(sellEvent.QuoteAmountOut, quoteTokenAccountInfo.TokenDecimal)
 	solPriceUSD := decoder.solPriceUSD()
 	totalUSD := decimal.NewFromFloat(solAmount).Mul(decimal.NewFromFloat(solPriceUSD)).InexactFloat64()
-	tokenAmount := uiAmount(sellEvent.BaseAmountIn, baseTokenAccountInfo.TokenDecimal)
+	tokenAmount := trade.TokenAmount
 	if tokenAmount == 0 {
 		logger.Infof("skip pump.fun sell: token amount is zero baseAccount=%s", baseTokenAccountInfo.TokenAccountAddress)
 		return nil, nil
@@ -412,6 +463,7 @@ func (decoder *PumpAmmDecoder) DecodeCreatePoolInstruction(logMessages []string)
 		BlockNum:         decoder.dtx.BlockDb.Slot,
 	}
 	trade.Maker = createPoolEvent.Creator.String()
+	trade.PumpOwner = coinCreator(createPoolEvent.CoinCreator)
 	trade.Type = types.TradePumpAmmCreatePool
 	trade.LpMintAddress = createPoolEvent.LpMint.String()
 	trade.PoolBaseTokenReserves = createPoolEvent.PoolBaseAmount
@@ -419,6 +471,151 @@ func (decoder *PumpAmmDecoder) DecodeCreatePoolInstruction(logMessages []string)
 	return trade, nil
 }
 
+// DecodeDepositInstruction 解析 PumpSwap 添加流动性，记为 add 类型的流动性变动
+func (decoder *PumpAmmDecoder) DecodeDepositInstruction() (*types.TradeWithPair, error) {
+	events, err := decoder.parsePumpAmmEvents(decoder.dtx.Tx.Meta.LogMessages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deposit events: %w", err)
+	}
+	for _, rawEvent := range events {
+		if event, ok := rawEvent.(*pump_amm.DepositEvent); ok {
+			return decoder.newLiquidityTrade(types.TradeTypeAddPosition, event.Pool.String(), event.User.String(),
+				event.UserBaseTokenAccount.String(), event.UserQuoteTokenAccount.String(),
+				event.BaseAmountIn, event.QuoteAmountIn, event.PoolBaseTokenReserves, event.PoolQuoteTokenReserves)
+		}
+	}
+	return nil, errors.New("deposit event not found in logs")
+}
+
+// DecodeWithdrawInstruction 解析 PumpSwap 撤出流动性，记为 remove 类型的流动性变动，跑路检测依赖该事件
+func (decoder *PumpAmmDecoder) DecodeWithdrawInstruction() (*types.TradeWithPair, error) {
+	events, err := decoder.parsePumpAmmEvents(decoder.dtx.Tx.Meta.LogMessages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse withdraw events: %w", err)
+	}
+	for _, rawEvent := range events {
+		if event, ok := rawEvent.(*pump_amm.WithdrawEvent); ok {
+			return decoder.newLiquidityTrade(types.TradeTypeRemovePosition, event.Pool.String(), event.User.String(),
+				event.UserBaseTokenAccount.String(), event.UserQuoteTokenAccount.String(),
+				event.BaseAmountOut, event.QuoteAmountOut, event.PoolBaseTokenReserves, event.PoolQuoteTokenReserves)
+		}
+	}
+	return nil, errors.New("withdraw event not found in logs")
+}
+
+// DecodeCollectCoinCreatorFeeInstruction 解析创建者领取手续费，不关联交易对，只用于累计创建者的已领取收益
+// 金库为报价币（WSOL）代币账户，交易内没有记录余额变化时按 9 位精度计算
+func (decoder *PumpAmmDecoder) DecodeCollectCoinCreatorFeeInstruction() (*types.TradeWithPair, error) {
+	events, err := decoder.parsePumpAmmEvents(decoder.dtx.Tx.Meta.LogMessages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse collect coin creator fee events: %w", err)
+	}
+	var event *pump_amm.CollectCoinCreatorFeeEvent
+	for _, rawEvent := range events {
+		if item, ok := rawEvent.(*pump_amm.CollectCoinCreatorFeeEvent); ok {
+			event = item
+			break
+		}
+	}
+	if event == nil {
+		return nil, errors.New("collect coin creator fee event not found in logs")
+	}
+	if event.CoinCreatorFee == 0 {
+		return nil, nil
+	}
+
+	decimals := uint8(9)
+	if info, err := decoder.getTokenAccount(event.CoinCreatorVaultAta.String()); err == nil {
+		decimals = info.TokenDecimal
+	}
+	block := decoder.dtx.BlockDb
+	solPriceUSD := decoder.solPriceUSD()
+	trade := &types.TradeWithPair{
+		ChainId:            SolChainId,
+		ChainIdInt:         SolChainIdInt,
+		TxHash:             decoder.dtx.TxHash,
+		Maker:              event.CoinCreator.String(),
+		Type:               types.TradePumpAmmCollectCreatorFee,
+		Slot:               block.Slot,
+		BlockNum:           block.Slot,
+		BlockTime:          block.BlockTime.Unix(),
+		TransactionIndex:   decoder.dtx.TxIndex,
+		SwapName:           PumpSwap,
+		BaseTokenAmount:    uiAmount(event.CoinCreatorFee, decimals),
+		BaseTokenAmountInt: int64(event.CoinCreatorFee),
+		BaseTokenPriceUSD:  solPriceUSD,
+		PumpOwner:          event.CoinCreator.String(),
+		To:                 event.CoinCreatorTokenAccount.String(),
+	}
+	trade.TotalUSD = decimal.NewFromFloat(trade.BaseTokenAmount).Mul(decimal.NewFromFloat(solPriceUSD)).InexactFloat64()
+	trade.CoinCreatorVaultAta = event.CoinCreatorVaultAta.String()
+
+	decoder.logger().Infof("decoded pump.fun AMM collect coin creator fee creator=%s fee=%d tx=%s",
+		trade.Maker, event.CoinCreatorFee, trade.TxHash)
+	return trade, nil
+}
+
+// newLiquidityTrade 构造流动性变动，价格按变动后的池子储备计算，便于交易对按最新储备刷新
+// 与买卖一致：BaseTokenAmountInt 为报价币（SOL）数量，CurrentBaseTokenInPoolAmount 为池子中的报价币储备
+func (decoder *PumpAmmDecoder) newLiquidityTrade(tradeType, poolAddr, maker, baseAccountAddr, quoteAccountAddr string,
+	baseAmount, quoteAmount, poolBaseReserves, poolQuoteReserves uint64) (*types.TradeWithPair, error) {
+	baseTokenAccountInfo, err := decoder.getTokenAccount(baseAccountAddr)
+	if err != nil {
+		decoder.logger().Infof("skip pump.fun %s liquidity: %v", tradeType, err)
+		return nil, nil
+	}
+	quoteTokenAccountInfo, err := decoder.getTokenAccount(quoteAccountAddr)
+	if err != nil {
+		decoder.logger().Infof("skip pump.fun %s liquidity: %v", tradeType, err)
+		return nil, nil
+	}
+
+	trade, err := decoder.newPumpTrade(poolAddr, maker, baseTokenAccountInfo, quoteTokenAccountInfo)
+	if err != nil {
+		return nil, err
+	}
+	trade.Type = tradeType
+	trade.BaseTokenAmount = uiAmount(quoteAmount, quoteTokenAccountInfo.TokenDecimal)
+	trade.TokenAmount = uiAmount(baseAmount, baseTokenAccountInfo.TokenDecimal)
+	trade.BaseTokenAmountInt = int64(quoteAmount)
+	trade.TokenAmountInt = int64(baseAmount)
+	trade.PoolBaseTokenReserves = poolBaseReserves
+	trade.PoolQuoteTokenReserves = poolQuoteReserves
+	trade.CurrentBaseTokenInPoolAmount = float64(poolQuoteReserves)
+	trade.CurrentTokenInPoolAmount = float64(poolBaseReserves)
+	trade.PairInfo.CurrentBaseTokenAmount = trade.CurrentBaseTokenInPoolAmount
+	trade.PairInfo.CurrentTokenAmount = trade.CurrentTokenInPoolAmount
+
+	solPriceUSD := decoder.solPriceUSD()
+	poolTokenAmount := uiAmount(poolBaseReserves, baseTokenAccountInfo.TokenDecimal)
+	if poolTokenAmount > 0 {
+		poolSolAmount := uiAmount(poolQuoteReserves, quoteTokenAccountInfo.TokenDecimal)
+		trade.TokenPriceUSD = decimal.NewFromFloat(poolSolAmount).Mul(decimal.NewFromFloat(solPriceUSD)).
+			Div(decimal.NewFromFloat(poolTokenAmount)).InexactFloat64()
+	}
+	trade.TotalUSD = decimal.NewFromFloat(trade.BaseTokenAmount).Mul(decimal.NewFromFloat(solPriceUSD)).
+		Add(decimal.NewFromFloat(trade.TokenAmount).Mul(decimal.NewFromFloat(trade.TokenPriceUSD))).InexactFloat64()
+
+	trade.PumpPoint = calculatePumpPoint(trade.CurrentTokenInPoolAmount)
+	trade.PumpMarketCap = decimal.NewFromFloat(trade.TokenPriceUSD).Mul(decimal.NewFromFloat(trade.PairInfo.TokenTotalSupply)).InexactFloat64()
+	if trade.PumpPoint >= 0.999 {
+		trade.PumpStatus = PumpStatusMigrating
+		trade.PumpPoint = 1
+	}
+
+	decoder.logger().Infof("decoded pump.fun AMM %s liquidity pool=%s maker=%s base=%d quote=%d poolQuote=%d",
+		tradeType, poolAddr, maker, baseAmount, quoteAmount, poolQuoteReserves)
+	return trade, nil
+}
+
+// coinCreator 旧版本池子的事件中 coin creator 为空
+func coinCreator(key solana.PublicKey) string {
+	if key.IsZero() {
+		return ""
+	}
+	return key.String()
+}
+
 // logger 返回带上下文的日志对象，统一日志格式。
 func (decoder *PumpAmmDecoder) logger() logx.Logger {
 	if decoder.ctx != nil {
@@ -470,6 +667,22 @@ func (decoder *PumpAmmDecoder) newPumpAmmInfo(
 	}, nil
 }
 
+// baseMintExtension 当前指令的 base 代币属于 Token-2022 时读取其 mint 扩展；
+// 普通 SPL Token 返回 nil，读取失败时同样按普通代币计算，只记录日志
+func (decoder *PumpAmmDecoder) baseMintExtension(mint string) *MintExtension {
+	accounts := decoder.compiledInstruction.Accounts
+	accountKeys := decoder.dtx.Tx.AccountKeys
+	if len(accounts) <= 11 || accounts[11] >= len(accountKeys) || accountKeys[accounts[11]] != common.Token2022ProgramID {
+		return nil
+	}
+	ext, err := GetMintExtension(decoder.ctx, decoder.svcCtx, mint)
+	if err != nil {
+		decoder.logger().Errorf("pump.fun AMM: GetMintExtension err: %v, mint: %v", err, mint)
+		return nil
+	}
+	return ext
+}
+
 // calculatePumpPoint 根据池子剩余 Token 量计算 Pump 曲线进度。
 func calculatePumpPoint(poolTokenReserves float64) float64 {
 	if poolTokenReserves <= 0 {
@@ -0,0 +1,90 @@
+package block
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/stream"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+// slotUpdates 收集单个 slot 中写库成功的代币、交易对和成交，SaveTrades 按交易对并发写入，需要加锁
+type slotUpdates struct {
+	lock   sync.Mutex
+	trades []*types.TradeWithPair
+	pairs  []*pairUpdate
+	tokens []*solmodel.Token
+}
+
+type pairUpdate struct {
+	pair    *solmodel.Pair
+	created bool
+}
+
+func (u *slotUpdates) AddTrades(trades []*types.TradeWithPair) {
+	u.lock.Lock()
+	u.trades = append(u.trades, trades...)
+	u.lock.Unlock()
+}
+
+// AddToken 同一代币在一个 slot 内只保留最后一次更新
+func (u *slotUpdates) AddToken(token *solmodel.Token) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	for i, item := range u.tokens {
+		if item.Address == token.Address {
+			u.tokens[i] = token
+			return
+		}
+	}
+	u.tokens = append(u.tokens, token)
+}
+
+// AddPair 同一交易对在一个 slot 内只保留最后一次更新，任一次为新建即视为新建
+func (u *slotUpdates) AddPair(pair *solmodel.Pair, created bool) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	for _, item := range u.pairs {
+		if item.pair.Address == pair.Address {
+			item.pair = pair
+			item.created = item.created || created
+			return
+		}
+	}
+	u.pairs = append(u.pairs, &pairUpdate{pair: pair, created: created})
+}
+
+// sortedTrades 按交易在区块内的序号和指令序号排序，调用方需持有锁
+func (u *slotUpdates) sortedTrades() []*types.TradeWithPair {
+	trades := make([]*types.TradeWithPair, len(u.trades))
+	copy(trades, u.trades)
+	slices.SortStableFunc(trades, func(a, b *types.TradeWithPair) int {
+		return cmp.Or(cmp.Compare(a.TransactionIndex, b.TransactionIndex), cmp.Compare(a.InstructionIndex, b.InstructionIndex))
+	})
+	return trades
+}
+
+// publishSlot 区块落库后推送本 slot 的事件：先推新交易对和交易对更新，再按交易序号推送成交
+func (s *BlockService) publishSlot(slot int64, updates *slotUpdates) {
+	updates.lock.Lock()
+	defer updates.lock.Unlock()
+	if len(updates.trades) == 0 && len(updates.pairs) == 0 {
+		return
+	}
+
+	events := make([]*consumer.Event, 0, len(updates.pairs)+len(updates.trades))
+	for _, item := range updates.pairs {
+		events = append(events, stream.NewPairEvent(item.pair, slot, item.created))
+	}
+
+	trades := updates.sortedTrades()
+	for _, trade := range trades {
+		events = append(events, stream.NewTradeEvent(trade))
+	}
+
+	s.sc.Stream.Publish(events)
+	s.Infof("publishSlot:%v pairs: %v, trades: %v, subscribers: %v", slot, len(updates.pairs), len(trades), s.sc.Stream.Count())
+}
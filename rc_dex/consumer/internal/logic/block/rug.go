@@ -0,0 +1,136 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+// 跑路原因
+const (
+	RugReasonLpRemoved        = "lp_removed"        // 撤出流动性
+	RugReasonDevDump          = "dev_dump"          // 创建者砸盘
+	RugReasonLiquidityDrained = "liquidity_drained" // 储备被抽干（多个钱包配合砸盘等）
+)
+
+// PumpSwap 池子的报价币为 WSOL
+const quoteReserveDecimals = 1e9
+
+// Rug 一次跑路判定
+type Rug struct {
+	Reason    string
+	Percent   float64 // 带走的储备占最高储备（上次写入的储备和本 slot 内的储备取最大）的百分比
+	TxHash    string
+	BlockTime int64
+}
+
+// isLiquidityTrade 添加/撤出流动性，只刷新交易对储备，不写入成交表
+func isLiquidityTrade(trade *types.TradeWithPair) bool {
+	return trade.Type == types.TradeTypeAddPosition || trade.Type == types.TradeTypeRemovePosition
+}
+
+// reserveBefore 由事件后的报价币储备和变动数量推算事件前的储备
+func reserveBefore(trade *types.TradeWithPair) uint64 {
+	amount := uint64(max(trade.BaseTokenAmountInt, 0))
+	switch trade.Type {
+	case types.TradeTypeBuy, types.TradeTypeAddPosition:
+		if trade.PoolQuoteTokenReserves < amount {
+			return 0
+		}
+		return trade.PoolQuoteTokenReserves - amount
+	case types.TradeTypeSell, types.TradeTypeRemovePosition:
+		return trade.PoolQuoteTokenReserves + amount
+	}
+	return trade.PoolQuoteTokenReserves
+}
+
+// DetectRug 按交易对在一个 slot 内的事件（按交易序号排列）检查是否跑路，依次判断撤出流动性、创建者卖出和储备整体下降；
+// 只处理带池子储备的事件（目前为 PumpSwap），owner 为交易对的创建者，prevReserve 为本 slot 之前写入的报价币储备（未知时为 0），
+// 储备在两个 slot 之间被抽走时也能和上次的储备比较
+func DetectRug(owner string, prevReserve uint64, events []*types.TradeWithPair, conf config.RugConfig) *Rug {
+	peak := prevReserve
+	var final uint64
+	var removed, devSold uint64
+	var removedTx, devSoldTx, lastTx *types.TradeWithPair
+	for _, event := range events {
+		if event == nil || event.SwapName != PumpSwap {
+			continue
+		}
+		peak = max(peak, reserveBefore(event), event.PoolQuoteTokenReserves)
+		final = event.PoolQuoteTokenReserves
+		lastTx = event
+
+		amount := uint64(max(event.BaseTokenAmountInt, 0))
+		switch {
+		case event.Type == types.TradeTypeRemovePosition:
+			removed += amount
+			removedTx = event
+		case event.Type == types.TradeTypeSell && owner != "" && event.Maker == owner:
+			devSold += amount
+			devSoldTx = event
+		}
+	}
+	if lastTx == nil || float64(peak)/quoteReserveDecimals < conf.MinReserve {
+		return nil
+	}
+
+	percent := func(amount uint64) float64 {
+		return float64(amount) / float64(peak) * 100
+	}
+	if removed > 0 && percent(removed) >= conf.RemovePercent {
+		return &Rug{Reason: RugReasonLpRemoved, Percent: percent(removed), TxHash: removedTx.TxHash, BlockTime: removedTx.BlockTime}
+	}
+	if devSold > 0 && percent(devSold) >= conf.DevSellPercent {
+		return &Rug{Reason: RugReasonDevDump, Percent: percent(devSold), TxHash: devSoldTx.TxHash, BlockTime: devSoldTx.BlockTime}
+	}
+	if peak > final && percent(peak-final) >= conf.DrainPercent {
+		return &Rug{Reason: RugReasonLiquidityDrained, Percent: percent(peak - final), TxHash: lastTx.TxHash, BlockTime: lastTx.BlockTime}
+	}
+	return nil
+}
+
+// prevQuoteReserve 读取交易对本 slot 之前写入的报价币储备，需在 SavePair 覆盖交易对之前调用；
+// 交易对不存在、已判定跑路或库中的数据不早于本 slot 时返回 0，只按本 slot 内的储备判定
+func (s *BlockService) prevQuoteReserve(ctx context.Context, trade *types.TradeWithPair) uint64 {
+	if !s.sc.Config.Rug.Enable || trade.SwapName != PumpSwap {
+		return 0
+	}
+	pair, err := s.sc.PairModel.FindOneByChainIdAddress(ctx, SolChainIdInt, trade.PairAddr)
+	if err != nil {
+		if !errors.Is(err, solmodel.ErrNotFound) && !strings.Contains(err.Error(), "record not found") {
+			s.Errorf("prevQuoteReserve: FindOneByChainIdAddress err: %v, pair address: %v", err, trade.PairAddr)
+		}
+		return 0
+	}
+	if pair.IsRug == 1 || pair.Slot >= trade.Slot || pair.CurrentBaseTokenAmount <= 0 {
+		return 0
+	}
+	return uint64(pair.CurrentBaseTokenAmount)
+}
+
+// CheckRug 交易对写库后按本 slot 的事件和写库前的储备做跑路检测，命中时写回交易对；已判定跑路的交易对不再重复判定
+func (s *BlockService) CheckRug(ctx context.Context, pair *solmodel.Pair, prevReserve uint64, events []*types.TradeWithPair) {
+	conf := s.sc.Config.Rug
+	if !conf.Enable || pair.IsRug == 1 {
+		return
+	}
+
+	rug := DetectRug(pair.PumpOwner, prevReserve, events, conf)
+	if rug == nil {
+		return
+	}
+	pair.IsRug = 1
+	pair.RugReason = rug.Reason
+	pair.RugAt = rug.BlockTime
+	pair.RugTxHash = rug.TxHash
+	if err := s.sc.PairModel.Update(ctx, pair); err != nil {
+		s.Errorf("CheckRug: PairModel.Update err: %v, pair address: %v", err, pair.Address)
+		return
+	}
+	s.Infof("CheckRug: pair %v marked as rug, reason: %v, percent: %.2f, owner: %v, tx hash: %v",
+		pair.Address, rug.Reason, rug.Percent, pair.PumpOwner, rug.TxHash)
+}
@@ -0,0 +1,90 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/pkg/constants"
+	"richcode.cc/dex/pkg/types"
+)
+
+const testOwner = "owner"
+
+var testRugConfig = config.RugConfig{Enable: true, RemovePercent: 50, DevSellPercent: 30, DrainPercent: 80, MinReserve: 1}
+
+// rugEvent 构造一笔 PumpSwap 事件，amount 和 reserve 为 SOL，reserve 为事件后的池子储备
+func rugEvent(tx, typ, maker string, amount, reserve float64) *types.TradeWithPair {
+	event := &types.TradeWithPair{TxHash: tx, Type: typ, Maker: maker, SwapName: PumpSwap, BlockTime: 100}
+	event.BaseTokenAmountInt = int64(amount * quoteReserveDecimals)
+	event.PoolQuoteTokenReserves = uint64(reserve * quoteReserveDecimals)
+	return event
+}
+
+func TestDetectRug(t *testing.T) {
+	cases := []struct {
+		name    string
+		prev    float64 // 上次写入的储备（SOL）
+		events  []*types.TradeWithPair
+		reason  string
+		percent float64
+		tx      string
+	}{
+		{
+			name:   "normal trading",
+			prev:   100,
+			events: []*types.TradeWithPair{rugEvent("a", types.TradeTypeBuy, "x", 5, 105), rugEvent("b", types.TradeTypeSell, "y", 10, 95)},
+		},
+		{
+			name:    "lp removed within slot",
+			events:  []*types.TradeWithPair{rugEvent("a", types.TradeTypeBuy, "x", 10, 100), rugEvent("b", types.TradeTypeRemovePosition, testOwner, 60, 40)},
+			reason:  RugReasonLpRemoved,
+			percent: 60,
+			tx:      "b",
+		},
+		{
+			name:    "dev dump",
+			prev:    100,
+			events:  []*types.TradeWithPair{rugEvent("a", types.TradeTypeSell, testOwner, 40, 60)},
+			reason:  RugReasonDevDump,
+			percent: 40,
+			tx:      "a",
+		},
+		{
+			// 储备在上一个 slot 之后被抽走：本 slot 内只剩一笔小额卖出，和上次写入的 100 SOL 比较
+			name:    "drained across slots",
+			prev:    100,
+			events:  []*types.TradeWithPair{rugEvent("a", types.TradeTypeSell, "x", 1, 10)},
+			reason:  RugReasonLiquidityDrained,
+			percent: 90,
+			tx:      "a",
+		},
+		{
+			name:   "drained across slots without previous reserve",
+			events: []*types.TradeWithPair{rugEvent("a", types.TradeTypeSell, "x", 1, 10)},
+		},
+		{
+			name:   "small pool",
+			prev:   0.5,
+			events: []*types.TradeWithPair{rugEvent("a", types.TradeTypeRemovePosition, testOwner, 0.5, 0)},
+		},
+		{
+			name:   "other dex ignored",
+			prev:   100,
+			events: []*types.TradeWithPair{{TxHash: "a", Type: types.TradeTypeRemovePosition, SwapName: constants.PumpFun}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rug := DetectRug(testOwner, uint64(c.prev*quoteReserveDecimals), c.events, testRugConfig)
+			if c.reason == "" {
+				require.Nil(t, rug)
+				return
+			}
+			require.NotNil(t, rug)
+			require.Equal(t, c.reason, rug.Reason)
+			require.InDelta(t, c.percent, rug.Percent, 0.01)
+			require.Equal(t, c.tx, rug.TxHash)
+		})
+	}
+}
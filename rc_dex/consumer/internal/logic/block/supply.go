@@ -0,0 +1,88 @@
+package block
+
+import (
+	"encoding/binary"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/token"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+	"github.com/mr-tron/base58"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+// DecodeTokenSupplyChanges 解析交易中（含内部指令）Token / Token-2022 程序的 MintTo、MintToChecked、Burn、BurnChecked 指令，
+// 每条指令生成一条 TradeTokenMint / TradeTokenBurn 记录，供 UpdateTokenSupplies 刷新代币总量；
+// 指令序号与成交一致：外层指令为其序号，内部指令为 (外层序号+1)*innerInstructionIndexBase+内部序号
+// 这类指令与具体 DEX 无关，不经过 DecodeTx，单独解析
+func DecodeTokenSupplyChanges(block *solmodel.Block, tx *client.BlockTransaction, txIndex int) (trades []*types.TradeWithPair) {
+	if tx == nil || tx.Meta == nil || tx.Meta.Err != nil || len(tx.Transaction.Signatures) == 0 {
+		return
+	}
+
+	txHash := base58.Encode(tx.Transaction.Signatures[0])
+	decode := func(instruction *solTypes.CompiledInstruction, instructionIndex int) {
+		trade := decodeTokenSupplyChange(tx.AccountKeys, instruction)
+		if trade == nil {
+			return
+		}
+		trade.TxHash = txHash
+		trade.TransactionIndex = txIndex
+		trade.InstructionIndex = instructionIndex
+		trade.BlockTime = block.BlockTime.Unix()
+		trades = append(trades, trade)
+	}
+	for i := range tx.Transaction.Message.Instructions {
+		decode(&tx.Transaction.Message.Instructions[i], i)
+	}
+	for _, inner := range tx.Meta.InnerInstructions {
+		for j := range inner.Instructions {
+			decode(&inner.Instructions[j], (int(inner.Index)+1)*innerInstructionIndexBase+j)
+		}
+	}
+	return
+}
+
+// decodeTokenSupplyChange 解析单条增发/销毁指令，两个程序的指令布局一致：
+//   - MintTo / MintToChecked：1 字节指令类型 + 8 字节数量（Checked 另有 1 字节精度），账户 [mint, destination, authority]
+//   - Burn / BurnChecked：1 字节指令类型 + 8 字节数量（Checked 另有 1 字节精度），账户 [account, mint, authority]
+func decodeTokenSupplyChange(accountKeys []common.PublicKey, instruction *solTypes.CompiledInstruction) *types.TradeWithPair {
+	if instruction.ProgramIDIndex >= len(accountKeys) || len(instruction.Data) < 9 || len(instruction.Accounts) < 3 {
+		return nil
+	}
+	program := accountKeys[instruction.ProgramIDIndex]
+	if program != common.TokenProgramID && program != common.Token2022ProgramID {
+		return nil
+	}
+	for _, index := range instruction.Accounts[:3] {
+		if index >= len(accountKeys) {
+			return nil
+		}
+	}
+
+	amount := binary.LittleEndian.Uint64(instruction.Data[1:9])
+	switch token.Instruction(instruction.Data[0]) {
+	case token.InstructionMintTo, token.InstructionMintToChecked:
+		return &types.TradeWithPair{
+			Type:  types.TradeTokenMint,
+			Maker: accountKeys[instruction.Accounts[2]].String(),
+			InstructionMintTo: types.InstructionMintTo{
+				Mint:   accountKeys[instruction.Accounts[0]],
+				To:     accountKeys[instruction.Accounts[1]],
+				Amount: amount,
+			},
+		}
+	case token.InstructionBurn, token.InstructionBurnChecked:
+		return &types.TradeWithPair{
+			Type:  types.TradeTokenBurn,
+			Maker: accountKeys[instruction.Accounts[2]].String(),
+			InstructionBurn: types.InstructionBurn{
+				Mint:    accountKeys[instruction.Accounts[1]],
+				Account: accountKeys[instruction.Accounts[0]],
+				Amount:  amount,
+			},
+		}
+	}
+	return nil
+}
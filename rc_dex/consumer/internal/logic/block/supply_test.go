@@ -0,0 +1,83 @@
+package block
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/token"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+func supplyChangeData(instruction token.Instruction, amount uint64, checked bool) []byte {
+	data := make([]byte, 9, 10)
+	data[0] = byte(instruction)
+	binary.LittleEndian.PutUint64(data[1:], amount)
+	if checked {
+		data = append(data, 6)
+	}
+	return data
+}
+
+// supplyChangeTx 构造一笔交易：外层为一次 MintTo 和一个调用 Token-2022 BurnChecked 的程序，
+// 账户依次为 0 authority、1 mint、2 代币账户、3 Token、4 Token-2022、5 外部程序
+func supplyChangeTx() *client.BlockTransaction {
+	tx := &client.BlockTransaction{
+		AccountKeys: []common.PublicKey{testKey(1), testBaseMint, testKey(2), common.TokenProgramID, common.Token2022ProgramID, testKey(30)},
+		Meta: &client.TransactionMeta{
+			InnerInstructions: []client.InnerInstruction{{
+				Index: 1,
+				Instructions: []solTypes.CompiledInstruction{
+					{ProgramIDIndex: 3, Accounts: []int{2, 0}, Data: supplyChangeData(token.InstructionTransfer, 1, false)},
+					{ProgramIDIndex: 4, Accounts: []int{2, 1, 0}, Data: supplyChangeData(token.InstructionBurnChecked, 300, true)},
+				},
+			}},
+		},
+	}
+	tx.Transaction.Signatures = []solTypes.Signature{make([]byte, 64)}
+	tx.Transaction.Message.Instructions = []solTypes.CompiledInstruction{
+		{ProgramIDIndex: 3, Accounts: []int{1, 2, 0}, Data: supplyChangeData(token.InstructionMintTo, 1_000, false)},
+		{ProgramIDIndex: 5, Accounts: []int{0}},
+	}
+	return tx
+}
+
+func TestDecodeTokenSupplyChanges(t *testing.T) {
+	block := &solmodel.Block{BlockTime: time.Unix(1_760_000_000, 0)}
+	trades := DecodeTokenSupplyChanges(block, supplyChangeTx(), 7)
+	require.Len(t, trades, 2)
+
+	mint, burn := trades[0], trades[1]
+	require.Equal(t, types.TradeTokenMint, mint.Type)
+	require.Equal(t, testBaseMint, mint.InstructionMintTo.Mint)
+	require.Equal(t, testKey(2), mint.InstructionMintTo.To)
+	require.Equal(t, uint64(1_000), mint.InstructionMintTo.Amount)
+	require.Equal(t, testKey(1).String(), mint.Maker)
+	require.Equal(t, 0, mint.InstructionIndex)
+
+	require.Equal(t, types.TradeTokenBurn, burn.Type)
+	require.Equal(t, testBaseMint, burn.InstructionBurn.Mint)
+	require.Equal(t, testKey(2), burn.InstructionBurn.Account)
+	require.Equal(t, uint64(300), burn.InstructionBurn.Amount)
+	// 外层第 1 条指令的第 1 条内部指令
+	require.Equal(t, 2*innerInstructionIndexBase+1, burn.InstructionIndex)
+
+	for _, trade := range trades {
+		require.Equal(t, 7, trade.TransactionIndex)
+		require.Equal(t, int64(1_760_000_000), trade.BlockTime)
+		require.NotEmpty(t, trade.TxHash)
+		require.Equal(t, testBaseMint.String(), supplyChangeMint(trade))
+	}
+	require.Empty(t, supplyChangeMint(&types.TradeWithPair{Type: types.TradeTypeBuy}))
+
+	// 失败的交易不会改变总量
+	failed := supplyChangeTx()
+	failed.Meta.Err = errors.New("custom program error")
+	require.Empty(t, DecodeTokenSupplyChanges(block, failed, 7))
+}
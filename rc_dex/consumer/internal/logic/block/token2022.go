@@ -0,0 +1,90 @@
+package block
+
+import (
+	"context"
+	"errors"
+
+	"github.com/blocto/solana-go-sdk/program/token"
+	"github.com/gagliardetto/solana-go"
+	"github.com/shopspring/decimal"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/pkg/sol"
+	"richcode.cc/dex/pkg/sol/token2022"
+)
+
+// SlotsPerEpoch 每个 epoch 的 slot 数，用于由成交 slot 推算当时生效的转账手续费
+const SlotsPerEpoch = 432000
+
+// TokenTransfer 代币转账指令的解析结果
+// Amount 为转出数量；Fee 为 TransferCheckedWithFee 指令中声明的手续费，由接收方账户扣留，接收方实际到账 Amount - Fee
+type TokenTransfer struct {
+	token.TransferParam
+	Fee uint64
+}
+
+// NetAmount 接收方实际到账的数量
+func (transfer *TokenTransfer) NetAmount() uint64 {
+	if transfer.Fee > transfer.Amount {
+		return 0
+	}
+	return transfer.Amount - transfer.Fee
+}
+
+// MintExtension Token-2022 mint 上影响成交数量的扩展，为空的字段表示没有该扩展
+type MintExtension struct {
+	TransferFeeConfig     *token2022.State // transferFeeConfig：转账时按费率扣留手续费
+	InterestBearingConfig *token2022.State // interestBearingConfig：展示数量随时间按利率增长
+}
+
+// CalculateTransferFee 计算 slot 时转账 amount 被扣留的手续费
+func (ext *MintExtension) CalculateTransferFee(amount uint64, slot int64) uint64 {
+	if ext == nil || ext.TransferFeeConfig == nil || slot < 0 {
+		return 0
+	}
+	fee := ext.TransferFeeConfig.CurrentTransferFee(uint64(slot) / SlotsPerEpoch).Calculate(amount)
+	return min(fee, amount)
+}
+
+// UiAmount 把原始数量换算为展示数量，计息代币按 unixTimestamp 时的累计利率放大
+func (ext *MintExtension) UiAmount(amount uint64, decimals uint8, unixTimestamp int64) float64 {
+	ui := uiAmount(amount, decimals)
+	if ext == nil || ext.InterestBearingConfig == nil {
+		return ui
+	}
+	scale := ext.InterestBearingConfig.InterestScale(unixTimestamp)
+	return decimal.NewFromFloat(ui).Mul(decimal.NewFromFloat(scale)).InexactFloat64()
+}
+
+// GetMintExtension 读取 Token-2022 mint 的转账手续费和计息扩展，结果按 mint 缓存在 MintExtensionCache 中
+// 只应对 Token-2022 代币调用；没有相关扩展时返回的 MintExtension 字段均为空，按普通代币计算
+func GetMintExtension(ctx context.Context, sc *svc.ServiceContext, mint string) (*MintExtension, error) {
+	if sc.MintExtensionCache == nil {
+		return nil, errors.New("mint extension cache not initialized")
+	}
+	value, err := sc.MintExtensionCache.Take(mint, func() (any, error) {
+		if sc.MintRpcClient == nil {
+			return nil, errors.New("mint rpc client not initialized")
+		}
+		address, err := solana.PublicKeyFromBase58(mint)
+		if err != nil {
+			return nil, err
+		}
+		info, _, err := sol.GetMintInfo(sc.MintRpcClient, ctx, address)
+		if err != nil {
+			return nil, err
+		}
+
+		ext := &MintExtension{}
+		if item := info.FindExtension(token2022.ExtensionTransferFeeConfig); item != nil {
+			ext.TransferFeeConfig = &item.State
+		}
+		if item := info.FindExtension(token2022.ExtensionInterestBearingConfig); item != nil {
+			ext.InterestBearingConfig = &item.State
+		}
+		return ext, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*MintExtension), nil
+}
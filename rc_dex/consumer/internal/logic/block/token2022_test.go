@@ -0,0 +1,106 @@
+package block
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/common"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/zeromicro/go-zero/core/collection"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/pkg/sol/token2022"
+	"richcode.cc/dex/pkg/types"
+)
+
+func transferCheckedWithFeeData(amount uint64, decimals uint8, fee uint64) []byte {
+	data := make([]byte, 19)
+	data[0], data[1] = token2022InstructionTransferFeeExtension, transferFeeInstructionTransferCheckedWithFee
+	binary.LittleEndian.PutUint64(data[2:], amount)
+	data[10] = decimals
+	binary.LittleEndian.PutUint64(data[11:], fee)
+	return data
+}
+
+func TestDecodeTransferCheckedWithFee(t *testing.T) {
+	accountKeys := []common.PublicKey{testKey(1), testKey(2), testKey(3), testKey(4), common.Token2022ProgramID}
+	instruction := &solTypes.CompiledInstruction{
+		ProgramIDIndex: 4,
+		Accounts:       []int{0, 1, 2, 3},
+		Data:           transferCheckedWithFeeData(1_000_000, 6, 2_500),
+	}
+
+	transfer, err := DecodeTokenTransfer(accountKeys, instruction)
+	require.NoError(t, err)
+	require.Equal(t, testKey(1), transfer.From)
+	require.Equal(t, testKey(3), transfer.To)
+	require.Equal(t, testKey(4), transfer.Auth)
+	require.Equal(t, uint64(1_000_000), transfer.Amount)
+	require.Equal(t, uint64(2_500), transfer.Fee)
+	require.Equal(t, uint64(997_500), transfer.NetAmount())
+
+	// 声明的手续费超过转账数量时到账为 0
+	require.Equal(t, uint64(0), (&TokenTransfer{Fee: 10}).NetAmount())
+
+	instruction.Data = instruction.Data[:18]
+	_, err = DecodeTokenTransfer(accountKeys, instruction)
+	require.Error(t, err)
+}
+
+func TestMintExtension(t *testing.T) {
+	ext := &MintExtension{
+		TransferFeeConfig: &token2022.State{
+			OlderTransferFee: &token2022.TransferFee{Epoch: 0, TransferFeeBasisPoints: 100, MaximumFee: 5_000},
+			NewerTransferFee: &token2022.TransferFee{Epoch: 2, TransferFeeBasisPoints: 300, MaximumFee: 1_000_000},
+		},
+	}
+	// 新费率在生效 epoch 之前仍按旧费率和旧上限计算
+	require.Equal(t, uint64(1_000), ext.CalculateTransferFee(100_000, SlotsPerEpoch))
+	require.Equal(t, uint64(5_000), ext.CalculateTransferFee(10_000_000, 2*SlotsPerEpoch-1))
+	require.Equal(t, uint64(300_000), ext.CalculateTransferFee(10_000_000, 2*SlotsPerEpoch))
+	require.Equal(t, uint64(0), ext.CalculateTransferFee(10_000_000, -1))
+	require.Equal(t, uint64(0), (*MintExtension)(nil).CalculateTransferFee(10_000_000, 0))
+
+	require.Equal(t, 1.5, (*MintExtension)(nil).UiAmount(1_500_000, 6, 0))
+	require.Equal(t, 1.5, ext.UiAmount(1_500_000, 6, 0))
+
+	// 年化 5% 连续复利一年
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	interest := &MintExtension{InterestBearingConfig: &token2022.State{
+		InitializationTimestamp: start, LastUpdateTimestamp: start, CurrentRate: 500,
+	}}
+	require.InDelta(t, 100*math.Exp(0.05), interest.UiAmount(100_000_000, 6, start+int64(token2022.SecondsPerYear)), 1e-6)
+}
+
+// TestDecodeToken2022Swap Token-2022 代币的转账手续费：买卖的成交数量都按实际到账的净数量记录，单价按同一数量计算
+func TestDecodeToken2022Swap(t *testing.T) {
+	cache, err := collection.NewCache(time.Minute)
+	require.NoError(t, err)
+	cache.Set(testBaseMint.String(), &MintExtension{TransferFeeConfig: &token2022.State{
+		NewerTransferFee: &token2022.TransferFee{TransferFeeBasisPoints: 100, MaximumFee: math.MaxUint64},
+	}})
+	s := newTestBlockService()
+	s.sc = &svc.ServiceContext{MintExtensionCache: cache}
+
+	router := common.PublicKeyFromString(DefaultPlatforms[0].Programs[0])
+	trades := decodeRouterSwaps(t, s,
+		routerSwapTx(t, routerSwap{router: router, user: 1, buy: true, baseAmount: 2_000_000_000, quoteAmount: 300_000_000, token2022: true}),
+		routerSwapTx(t, routerSwap{router: router, user: 2, baseAmount: 1_000_000_000, quoteAmount: 150_000_000, token2022: true}),
+	)
+	require.Len(t, trades, 2)
+
+	buy, sell := trades[0], trades[1]
+	require.Equal(t, types.TradeTypeBuy, buy.Type)
+	require.Equal(t, int64(1_980_000_000), buy.TokenAmountInt)
+	require.Equal(t, int64(20_000_000), buy.TokenTransferFeeInt)
+	require.InDelta(t, 1980, buy.TokenAmount, 1e-9)
+
+	require.Equal(t, types.TradeTypeSell, sell.Type)
+	require.Equal(t, int64(990_000_000), sell.TokenAmountInt)
+	require.Equal(t, int64(10_000_000), sell.TokenTransferFeeInt)
+	require.InDelta(t, 990, sell.TokenAmount, 1e-9)
+	require.InDelta(t, 10, sell.TokenTransferFee, 1e-9)
+	require.InDelta(t, sell.TotalUSD/sell.TokenAmount, sell.TokenPriceUSD, 1e-12)
+}
@@ -0,0 +1,146 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/stream"
+	"richcode.cc/dex/model/solmodel"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+
+	// volumeWindow 交易对成交额的统计窗口
+	volumeWindow = 24 * time.Hour
+)
+
+// pageLimit 把请求中的分页大小限制在 [1, maxPageSize]，未传时使用默认值
+func pageLimit(size int64) int {
+	if size <= 0 {
+		return defaultPageSize
+	}
+	return int(min(size, maxPageSize))
+}
+
+// queryError 把模型层错误转换为 gRPC 状态码
+func queryError(err error, what string) error {
+	if errors.Is(err, solmodel.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	}
+	return status.Errorf(codes.Internal, "query %s: %v", what, err)
+}
+
+// encodeTradeCursor 以本页最后一条成交生成下一页游标，格式为 "block_num_id"
+func encodeTradeCursor(trades []*solmodel.Trade, limit int) string {
+	if len(trades) < limit {
+		return ""
+	}
+	last := trades[len(trades)-1]
+	return fmt.Sprintf("%d_%d", last.BlockNum, last.Id)
+}
+
+func decodeTradeCursor(cursor string) (solmodel.TradeCursor, error) {
+	var result solmodel.TradeCursor
+	if cursor == "" {
+		return result, nil
+	}
+	blockNum, id, ok := strings.Cut(cursor, "_")
+	if !ok {
+		return result, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	var err error
+	if result.BlockNum, err = strconv.ParseInt(blockNum, 10, 64); err != nil || result.BlockNum <= 0 {
+		return result, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if result.Id, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return result, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	return result, nil
+}
+
+// toPair volume 为空时成交额按 0 返回
+func toPair(pair *solmodel.Pair, volume *solmodel.PairVolume) *consumer.Pair {
+	result := stream.ToPair(pair)
+	if volume != nil {
+		result.Volume = volume.Volume
+		result.OrganicVolume = volume.OrganicVolume
+	}
+	return result
+}
+
+func toToken(token *solmodel.Token) *consumer.Token {
+	return &consumer.Token{
+		ChainId:            token.ChainId,
+		Address:            token.Address,
+		Program:            token.Program,
+		Name:               token.Name,
+		Symbol:             token.Symbol,
+		Decimals:           token.Decimals,
+		TotalSupply:        token.TotalSupply,
+		Icon:               token.Icon,
+		Description:        token.Description,
+		TwitterUsername:    token.TwitterUsername,
+		Website:            token.Website,
+		Telegram:           token.Telegram,
+		HoldCount:          token.HoldCount,
+		IsCheckCa:          token.IsCheckCa == 1,
+		IsCaDropOwner:      token.IsCaDropOwner == 1,
+		IsHoneyScam:        token.IsHoneyScam == 1,
+		IsLiquidLock:       token.IsLiquidLock == 1,
+		IsBurnPool:         token.IsBurnPool == 1,
+		IsCanPauseTrade:    token.IsCanPauseTrade == 1,
+		IsCanChangeTax:     token.IsCanChangeTax == 1,
+		IsHaveBlackList:    token.IsHaveBlackList == 1,
+		IsCanAllSell:       token.IsCanAllSell == 1,
+		IsCanExternalCall:  token.IsCanExternalCall == 1,
+		IsCanAddToken:      token.IsCanAddToken == 1,
+		IsCanChangeToken:   token.IsCanChangeToken == 1,
+		SellTax:            token.SellTax,
+		BuyTax:             token.BuyTax,
+		SniperCount:        token.SniperCount,
+		SniperHoldPercent:  token.SniperHoldPercent,
+		BundlerCount:       token.BundlerCount,
+		BundlerHoldPercent: token.BundlerHoldPercent,
+		IsBundled:          token.IsBundled == 1,
+		Slot:               token.Slot,
+	}
+}
+
+func toTrades(trades []*solmodel.Trade) []*consumer.Trade {
+	list := make([]*consumer.Trade, 0, len(trades))
+	for _, trade := range trades {
+		list = append(list, &consumer.Trade{
+			Id:                trade.Id,
+			TxHash:            trade.TxHash,
+			HashId:            trade.HashId,
+			PairAddr:          trade.PairAddr,
+			Maker:             trade.Maker,
+			TradeType:         trade.TradeType,
+			BaseTokenAmount:   trade.BaseTokenAmount,
+			TokenAmount:       trade.TokenAmount,
+			TokenTransferFee:  trade.TokenTransferFee,
+			BaseTokenPriceUsd: trade.BaseTokenPriceUsd,
+			TotalUsd:          trade.TotalUsd,
+			TokenPriceUsd:     trade.TokenPriceUsd,
+			To:                trade.To,
+			BlockNum:          trade.BlockNum,
+			TransactionIndex:  trade.TransactionIndex,
+			BlockTime:         trade.BlockTimeStamp,
+			SwapName:          trade.SwapName,
+			MevTag:            trade.MevTag,
+			LpFee:             trade.LpFee,
+			ProtocolFee:       trade.ProtocolFee,
+			CreatorFee:        trade.CreatorFee,
+			Platform:          trade.Platform,
+		})
+	}
+	return list
+}
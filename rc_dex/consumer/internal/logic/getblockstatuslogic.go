@@ -0,0 +1,46 @@
+package logic
+
+import (
+	"context"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GetBlockStatusLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetBlockStatusLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetBlockStatusLogic {
+	return &GetBlockStatusLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetBlockStatus 查询单个 slot 的处理状态
+func (l *GetBlockStatusLogic) GetBlockStatus(in *consumer.GetBlockStatusRequest) (*consumer.GetBlockStatusResponse, error) {
+	if in.Slot <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "slot is required")
+	}
+
+	block, err := l.svcCtx.BlockModel.FindOneBySlot(l.ctx, in.Slot)
+	if err != nil {
+		return nil, queryError(err, "block")
+	}
+	return &consumer.GetBlockStatusResponse{
+		Slot:        block.Slot,
+		BlockHeight: block.BlockHeight,
+		BlockTime:   block.BlockTime.Unix(),
+		Status:      block.Status,
+		SolPrice:    block.SolPrice,
+		ErrMessage:  block.ErrMessage,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package logic
+
+import (
+	"context"
+	"errors"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GetCreatorFeesLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetCreatorFeesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCreatorFeesLogic {
+	return &GetCreatorFeesLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetCreatorFees 查询创建者累计产生和领取的手续费，以及最近的领取记录；没有任何记录时返回全 0
+func (l *GetCreatorFeesLogic) GetCreatorFees(in *consumer.GetCreatorFeesRequest) (*consumer.GetCreatorFeesResponse, error) {
+	if in.Creator == "" {
+		return nil, status.Error(codes.InvalidArgument, "creator is required")
+	}
+
+	resp := &consumer.GetCreatorFeesResponse{Creator: in.Creator}
+	fee, err := l.svcCtx.CreatorFeeModel.FindOneByChainIdCreator(l.ctx, constants.SolChainIdInt, in.Creator)
+	switch {
+	case err == nil:
+		resp.AccruedFee = fee.AccruedFee
+		resp.AccruedFeeUsd = fee.AccruedFeeUsd
+		resp.ClaimedFee = fee.ClaimedFee
+		resp.ClaimedFeeUsd = fee.ClaimedFeeUsd
+		resp.UnclaimedFee = max(fee.AccruedFee-fee.ClaimedFee, 0)
+		resp.ClaimCount = fee.ClaimCount
+		resp.LastClaimAt = fee.LastClaimAt
+	case errors.Is(err, solmodel.ErrNotFound):
+	default:
+		return nil, queryError(err, "creator fee")
+	}
+
+	claims, err := l.svcCtx.CreatorFeeClaimModel.FindByCreator(l.ctx, constants.SolChainIdInt, in.Creator, pageLimit(in.Limit))
+	if err != nil {
+		return nil, queryError(err, "creator fee claims")
+	}
+	resp.Claims = make([]*consumer.CreatorFeeClaim, 0, len(claims))
+	for _, claim := range claims {
+		resp.Claims = append(resp.Claims, &consumer.CreatorFeeClaim{
+			TxHash:    claim.TxHash,
+			Amount:    claim.Amount,
+			AmountUsd: claim.AmountUsd,
+			Slot:      claim.Slot,
+			BlockTime: claim.BlockTime.Unix(),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/model/solmodel"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GetIndexerLagLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetIndexerLagLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetIndexerLagLogic {
+	return &GetIndexerLagLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetIndexerLag 比较链上最新 slot 与已处理的最新区块，返回 slot 和时间上的落后程度
+func (l *GetIndexerLagLogic) GetIndexerLag(in *consumer.GetIndexerLagRequest) (*consumer.GetIndexerLagResponse, error) {
+	chainSlot, err := l.svcCtx.GetSolClient().GetSlot(l.ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "get chain slot: %v", err)
+	}
+
+	resp := &consumer.GetIndexerLagResponse{
+		ChainSlot: int64(chainSlot),
+	}
+
+	latest, err := l.svcCtx.BlockModel.FindOneByNearSlot(l.ctx, math.MaxInt64)
+	switch {
+	case err == nil:
+		resp.IndexedSlot = latest.Slot
+		resp.IndexedBlockTime = latest.BlockTime.Unix()
+		resp.SlotLag = max(resp.ChainSlot-latest.Slot, 0)
+		resp.TimeLagSeconds = max(time.Now().Unix()-resp.IndexedBlockTime, 0)
+	case errors.Is(err, solmodel.ErrNotFound):
+		// 还没有处理过任何区块
+		resp.SlotLag = resp.ChainSlot
+	default:
+		return nil, queryError(err, "latest block")
+	}
+
+	failed, err := l.svcCtx.BlockModel.FindFirstFailBlock(l.ctx)
+	switch {
+	case err == nil:
+		resp.FirstFailedSlot = failed.Slot
+	case errors.Is(err, solmodel.ErrNotFound):
+	default:
+		return nil, queryError(err, "failed block")
+	}
+	return resp, nil
+}
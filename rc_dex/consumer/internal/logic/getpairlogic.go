@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/pkg/constants"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GetPairLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetPairLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetPairLogic {
+	return &GetPairLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetPair 按地址查询交易对，附带近 24 小时成交额和自然成交额
+func (l *GetPairLogic) GetPair(in *consumer.GetPairRequest) (*consumer.GetPairResponse, error) {
+	if in.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "address is required")
+	}
+
+	pair, err := l.svcCtx.PairModel.FindOneByChainIdAddress(l.ctx, constants.SolChainIdInt, in.Address)
+	if err != nil {
+		return nil, queryError(err, "pair")
+	}
+
+	volume, err := l.svcCtx.TradeModel.SumVolumeByPairAddr(l.ctx, constants.SolChainIdInt, pair.Address, time.Now().Add(-volumeWindow))
+	if err != nil {
+		// 成交额只是附加信息，查询失败不影响返回交易对
+		l.Errorf("GetPair: SumVolumeByPairAddr err: %v, pair address: %v", err, pair.Address)
+	}
+
+	return &consumer.GetPairResponse{Pair: toPair(pair, volume)}, nil
+}
@@ -0,0 +1,92 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/pkg/constants"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPoolFeeDays = 7
+	maxPoolFeeDays     = 90
+)
+
+type GetPoolFeesLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetPoolFeesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetPoolFeesLogic {
+	return &GetPoolFeesLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetPoolFees 查询池子最近 days 天（含当天）的每日手续费，APR 按区间内已经过的时间把 LP 手续费年化后除以当前流动性
+func (l *GetPoolFeesLogic) GetPoolFees(in *consumer.GetPoolFeesRequest) (*consumer.GetPoolFeesResponse, error) {
+	if in.PairAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "pair_address is required")
+	}
+	days := in.Days
+	if days <= 0 {
+		days = defaultPoolFeeDays
+	}
+	days = min(days, maxPoolFeeDays)
+
+	pair, err := l.svcCtx.PairModel.FindOneByChainIdAddress(l.ctx, constants.SolChainIdInt, in.PairAddress)
+	if err != nil {
+		return nil, queryError(err, "pair")
+	}
+
+	now := time.Now().UTC()
+	since := now.Truncate(24*time.Hour).AddDate(0, 0, -int(days-1))
+	fees, err := l.svcCtx.PoolFeeModel.FindByPairAddrSince(l.ctx, constants.SolChainIdInt, pair.Address, since)
+	if err != nil {
+		return nil, queryError(err, "pool fees")
+	}
+
+	resp := &consumer.GetPoolFeesResponse{
+		PairAddress: pair.Address,
+		CoinCreator: pair.PumpOwner,
+		Liquidity:   pair.Liquidity,
+		Days:        make([]*consumer.PoolFeeDay, 0, len(fees)),
+	}
+	for _, fee := range fees {
+		resp.LpFee += fee.LpFee
+		resp.ProtocolFee += fee.ProtocolFee
+		resp.CreatorFee += fee.CreatorFee
+		resp.LpFeeUsd += fee.LpFeeUsd
+		resp.FeeUsd += fee.FeeUsd
+		resp.VolumeUsd += fee.VolumeUsd
+		resp.Days = append(resp.Days, &consumer.PoolFeeDay{
+			Date:        fee.Date.Format(time.DateOnly),
+			LpFee:       fee.LpFee,
+			ProtocolFee: fee.ProtocolFee,
+			CreatorFee:  fee.CreatorFee,
+			LpFeeUsd:    fee.LpFeeUsd,
+			FeeUsd:      fee.FeeUsd,
+			VolumeUsd:   fee.VolumeUsd,
+			TradeCount:  fee.TradeCount,
+		})
+	}
+
+	// 池子在区间内创建时从创建时间开始计算，避免新池子的 APR 被低估；不足一小时按一小时计算
+	start := since
+	if pair.BlockTime.After(start) {
+		start = pair.BlockTime
+	}
+	if elapsed := max(now.Sub(start), time.Hour).Hours() / 24; pair.Liquidity > 0 {
+		resp.Apr = resp.LpFeeUsd / elapsed * 365 / pair.Liquidity * 100
+	}
+	return resp, nil
+}
@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"context"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/pkg/constants"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GetTokenLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetTokenLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetTokenLogic {
+	return &GetTokenLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetToken 按地址查询代币，包含合约检测和开盘分析结果
+func (l *GetTokenLogic) GetToken(in *consumer.GetTokenRequest) (*consumer.GetTokenResponse, error) {
+	if in.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "address is required")
+	}
+
+	token, err := l.svcCtx.TokenModel.FindOneByChainIdAddress(l.ctx, constants.SolChainIdInt, in.Address)
+	if err != nil {
+		return nil, queryError(err, "token")
+	}
+	return &consumer.GetTokenResponse{Token: toToken(token)}, nil
+}
@@ -0,0 +1,163 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/duke-git/lancet/v2/slice"
+	"github.com/zeromicro/go-zero/core/logx"
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+)
+
+var ErrServiceStop = errors.New("wallet label service stopped")
+
+// WalletLabelService 钱包标签服务
+// 1. 启动时把配置中的人工标签（KOL、交易所热钱包、项目方）写入 wallet_label 表
+// 2. 按周期从历史成交重新计算自动标签（sniper、bundler、top_pnl、fresh_wallet），未再命中的自动标签会被清理
+type WalletLabelService struct {
+	sc *svc.ServiceContext
+	logx.Logger
+	ctx    context.Context
+	cancel func(err error)
+}
+
+func NewWalletLabelService(sc *svc.ServiceContext) *WalletLabelService {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &WalletLabelService{
+		sc:     sc,
+		Logger: logx.WithContext(context.Background()).WithFields(logx.Field("service", "wallet-label")),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (s *WalletLabelService) Start() {
+	conf := s.sc.Config.WalletLabel
+	if !conf.Enable {
+		s.Info("wallet label service disabled")
+		return
+	}
+
+	s.SaveManualLabels(conf.Manual)
+	s.RefreshAutoLabels()
+
+	interval := time.Duration(conf.RefreshInterval) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.Info("wallet label service stop succeed")
+			return
+		case <-ticker.C:
+			s.RefreshAutoLabels()
+		}
+	}
+}
+
+func (s *WalletLabelService) Stop() {
+	s.cancel(ErrServiceStop)
+}
+
+// SaveManualLabels 写入人工标签，同一地址同一标签重复配置时以最后一条为准
+func (s *WalletLabelService) SaveManualLabels(manual []config.ManualLabel) {
+	now := time.Now()
+	labels := slice.Map(manual, func(_ int, item config.ManualLabel) *solmodel.WalletLabel {
+		return &solmodel.WalletLabel{
+			ChainId:     constants.SolChainIdInt,
+			Address:     item.Address,
+			Label:       item.Label,
+			Source:      constants.WalletLabelSourceManual,
+			Name:        item.Name,
+			Icon:        item.Icon,
+			TwitterLink: item.TwitterLink,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+	})
+	labels = slice.Filter(labels, func(_ int, item *solmodel.WalletLabel) bool {
+		return item.Address != "" && item.Label != ""
+	})
+	if len(labels) == 0 {
+		return
+	}
+
+	if err := s.sc.WalletLabelModel.BatchUpsertManualLabels(s.ctx, labels); err != nil {
+		s.Errorf("SaveManualLabels: BatchUpsertManualLabels err: %v", err)
+		return
+	}
+	for _, label := range labels {
+		s.sc.WalletLabelCache.Del(label.Address)
+	}
+	s.Infof("SaveManualLabels: saved %v manual labels", len(labels))
+}
+
+// RefreshAutoLabels 重新计算全部自动标签
+func (s *WalletLabelService) RefreshAutoLabels() {
+	conf := s.sc.Config.WalletLabel
+	chainId := int64(constants.SolChainIdInt)
+	since := time.Now().Add(-time.Duration(conf.LookbackHours) * time.Hour)
+
+	s.refresh(constants.WalletLabelSniper, func() ([]*solmodel.MakerStat, error) {
+		return s.sc.TradeModel.FindSniperMakers(s.ctx, chainId, since, conf.LaunchSlots, conf.SniperMinHits, conf.Limit)
+	})
+	s.refresh(constants.WalletLabelBundler, func() ([]*solmodel.MakerStat, error) {
+		return s.sc.TradeModel.FindBundlerMakers(s.ctx, chainId, since, conf.LaunchSlots, conf.BundlerMinSlot, conf.BundlerMinHits, conf.Limit)
+	})
+	s.refresh(constants.WalletLabelTopPnl, func() ([]*solmodel.MakerStat, error) {
+		return s.sc.TradeModel.FindTopPnlMakers(s.ctx, chainId, since, conf.TopPnlSize)
+	})
+	s.refresh(constants.WalletLabelFresh, func() ([]*solmodel.MakerStat, error) {
+		freshSince := time.Now().Add(-time.Duration(conf.FreshHours) * time.Hour)
+		return s.sc.TradeModel.FindFreshMakers(s.ctx, chainId, freshSince, conf.FreshMaxTrades, conf.Limit)
+	})
+}
+
+// refresh 计算单类自动标签：写入本轮命中的钱包，再删除本轮开始前未被刷新的旧标签
+func (s *WalletLabelService) refresh(label string, find func() ([]*solmodel.MakerStat, error)) {
+	// updated_at 是秒级精度，截断到秒避免刚写入的标签被当成旧标签删掉
+	beginTime := time.Now().Truncate(time.Second)
+
+	stats, err := find()
+	if err != nil {
+		s.Errorf("RefreshAutoLabels: find %v makers err: %v", label, err)
+		return
+	}
+
+	labels := slice.Map(stats, func(_ int, stat *solmodel.MakerStat) *solmodel.WalletLabel {
+		return &solmodel.WalletLabel{
+			ChainId:   constants.SolChainIdInt,
+			Address:   stat.Maker,
+			Label:     label,
+			Source:    constants.WalletLabelSourceAuto,
+			Score:     stat.Value,
+			CreatedAt: beginTime,
+			UpdatedAt: beginTime,
+		}
+	})
+	if err = s.sc.WalletLabelModel.BatchUpsertAutoLabels(s.ctx, labels); err != nil {
+		s.Errorf("RefreshAutoLabels: upsert %v labels err: %v", label, err)
+		return
+	}
+	stale, err := s.sc.WalletLabelModel.DeleteStaleAutoLabels(s.ctx, constants.SolChainIdInt, label, beginTime)
+	if err != nil {
+		s.Errorf("RefreshAutoLabels: delete stale %v labels err: %v", label, err)
+	}
+	// 新命中和被删除标签的钱包都要清掉缓存，下次打标时重新读取
+	for _, item := range labels {
+		s.sc.WalletLabelCache.Del(item.Address)
+	}
+	for _, address := range stale {
+		s.sc.WalletLabelCache.Del(address)
+	}
+
+	s.Infof("RefreshAutoLabels: label: %v, size: %v, stale: %v, dur: %v", label, len(labels), len(stale), time.Since(beginTime))
+}
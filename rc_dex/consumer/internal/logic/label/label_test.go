@@ -0,0 +1,135 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeromicro/go-zero/core/collection"
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+)
+
+// fakeLabelModel 在内存中保存标签，只实现标签任务用到的方法
+type fakeLabelModel struct {
+	solmodel.WalletLabelModel
+	labels map[string]*solmodel.WalletLabel // address/label -> 标签
+}
+
+func labelKey(address, label string) string { return address + "/" + label }
+
+func (m *fakeLabelModel) BatchUpsertManualLabels(_ context.Context, labels []*solmodel.WalletLabel) error {
+	for _, label := range labels {
+		m.labels[labelKey(label.Address, label.Label)] = label
+	}
+	return nil
+}
+
+func (m *fakeLabelModel) BatchUpsertAutoLabels(_ context.Context, labels []*solmodel.WalletLabel) error {
+	for _, label := range labels {
+		if old, ok := m.labels[labelKey(label.Address, label.Label)]; ok {
+			old.Score, old.UpdatedAt = label.Score, label.UpdatedAt
+			continue
+		}
+		m.labels[labelKey(label.Address, label.Label)] = label
+	}
+	return nil
+}
+
+func (m *fakeLabelModel) DeleteStaleAutoLabels(_ context.Context, chainId int64, label string, before time.Time) ([]string, error) {
+	var addresses []string
+	for key, item := range m.labels {
+		if item.ChainId == chainId && item.Label == label && item.Source == constants.WalletLabelSourceAuto && item.UpdatedAt.Before(before) {
+			delete(m.labels, key)
+			addresses = append(addresses, item.Address)
+		}
+	}
+	return addresses, nil
+}
+
+func newTestLabelService(t *testing.T, labels ...*solmodel.WalletLabel) (*WalletLabelService, *fakeLabelModel) {
+	t.Helper()
+	cache, err := collection.NewCache(time.Minute)
+	require.NoError(t, err)
+	model := &fakeLabelModel{labels: make(map[string]*solmodel.WalletLabel)}
+	for _, label := range labels {
+		model.labels[labelKey(label.Address, label.Label)] = label
+		cache.Set(label.Address, []*solmodel.WalletLabel{label})
+	}
+	return NewWalletLabelService(&svc.ServiceContext{WalletLabelModel: model, WalletLabelCache: cache}), model
+}
+
+func autoLabel(address, label string, updatedAt time.Time) *solmodel.WalletLabel {
+	return &solmodel.WalletLabel{
+		ChainId: constants.SolChainIdInt, Address: address, Label: label, Source: constants.WalletLabelSourceAuto, UpdatedAt: updatedAt,
+	}
+}
+
+func cached(s *WalletLabelService, address string) bool {
+	_, ok := s.sc.WalletLabelCache.Get(address)
+	return ok
+}
+
+func TestRefreshAutoLabels(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	manual := &solmodel.WalletLabel{
+		ChainId: constants.SolChainIdInt, Address: "kol", Label: constants.WalletLabelKOL, Source: constants.WalletLabelSourceManual, UpdatedAt: old,
+	}
+	s, model := newTestLabelService(t,
+		autoLabel("stale", constants.WalletLabelSniper, old),
+		autoLabel("again", constants.WalletLabelSniper, old),
+		autoLabel("bundler", constants.WalletLabelBundler, old),
+		manual,
+	)
+
+	s.refresh(constants.WalletLabelSniper, func() ([]*solmodel.MakerStat, error) {
+		return []*solmodel.MakerStat{{Maker: "again", Value: 3}, {Maker: "new", Value: 2}}, nil
+	})
+
+	require.Contains(t, model.labels, labelKey("again", constants.WalletLabelSniper))
+	require.Contains(t, model.labels, labelKey("new", constants.WalletLabelSniper))
+	require.NotContains(t, model.labels, labelKey("stale", constants.WalletLabelSniper))
+	require.Equal(t, float64(3), model.labels[labelKey("again", constants.WalletLabelSniper)].Score)
+	// 其他类型的自动标签和人工标签不受影响
+	require.Contains(t, model.labels, labelKey("bundler", constants.WalletLabelBundler))
+	require.Contains(t, model.labels, labelKey("kol", constants.WalletLabelKOL))
+
+	// 被删除和重新命中的钱包都清掉了缓存，不相关的钱包缓存保留
+	require.False(t, cached(s, "stale"))
+	require.False(t, cached(s, "again"))
+	require.True(t, cached(s, "bundler"))
+	require.True(t, cached(s, "kol"))
+}
+
+func TestRefreshAutoLabelsFindFailed(t *testing.T) {
+	s, model := newTestLabelService(t, autoLabel("stale", constants.WalletLabelSniper, time.Now().Add(-time.Hour)))
+
+	s.refresh(constants.WalletLabelSniper, func() ([]*solmodel.MakerStat, error) {
+		return nil, errors.New("db down")
+	})
+
+	// 查询失败时不能把上一轮的标签当成过期删掉
+	require.Contains(t, model.labels, labelKey("stale", constants.WalletLabelSniper))
+	require.True(t, cached(s, "stale"))
+}
+
+func TestSaveManualLabels(t *testing.T) {
+	s, model := newTestLabelService(t, autoLabel("hot", constants.WalletLabelTopPnl, time.Now()))
+
+	s.SaveManualLabels([]config.ManualLabel{
+		{Address: "hot", Label: constants.WalletLabelCEX, Name: "Binance"},
+		{Address: "", Label: constants.WalletLabelKOL},
+		{Address: "team", Label: ""},
+	})
+
+	require.Len(t, model.labels, 2)
+	label := model.labels[labelKey("hot", constants.WalletLabelCEX)]
+	require.NotNil(t, label)
+	require.EqualValues(t, constants.WalletLabelSourceManual, label.Source)
+	require.Equal(t, "Binance", label.Name)
+	require.False(t, cached(s, "hot"))
+}
@@ -0,0 +1,196 @@
+package launch
+
+import (
+	"math"
+	"sort"
+
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+// JitoBundleMaxTx Jito 单个 bundle 最多包含的交易数，同一 slot 内连续买入超过该长度时更像是多方抢跑，不按连号归组
+const JitoBundleMaxTx = 5
+
+const (
+	lamportsPerSol = 1e9
+	// roundAmountLamports 买入金额是 0.01 SOL 的整数倍时多为手动输入的整数金额，不同钱包金额相同很常见，不作为关联依据
+	roundAmountLamports = 10_000_000
+)
+
+// LaunchResult 单个交易对的开盘分析结果
+type LaunchResult struct {
+	Snipers            []string   // 开盘窗口内买入的全部钱包，按首次买入顺序
+	Bundlers           []string   // 被归入捆绑分组的钱包
+	Groups             [][]string // 捆绑分组，每组是一批关联钱包
+	SniperTokenAmount  float64    // 狙击钱包在开盘窗口内的净买入数量
+	BundlerTokenAmount float64    // 捆绑钱包在开盘窗口内的净买入数量
+}
+
+// AnalyzeLaunch 对开盘窗口内的成交做狙击和捆绑识别
+// 满足以下任一条件的买入钱包会被归为同一组，组内钱包数达到 BundleMinSize 即视为捆绑：
+// 1. 同一 slot 内交易序号连续（相差不超过 BundleTxGap），符合 Jito bundle 的落块特征
+// 2. 首笔 SOL 来自同一个资金钱包（funders: 钱包 -> 资金来源）
+// 3. 花费的 SOL 数量精确到 lamport 完全一致（0.01 SOL 整数倍的整数金额除外）
+// trades 需按 slot、交易序号升序排列，excludes 中的钱包（如池子创建者）不参与统计
+func AnalyzeLaunch(trades []*solmodel.Trade, funders map[string]string, excludes []string, conf config.LaunchConfig) *LaunchResult {
+	result := &LaunchResult{}
+
+	excludeSet := make(map[string]struct{}, len(excludes))
+	for _, address := range excludes {
+		if address != "" {
+			excludeSet[address] = struct{}{}
+		}
+	}
+
+	// 按钱包累计净买入，同时收集买入成交
+	netAmount := make(map[string]float64)
+	var buys []*solmodel.Trade
+	for _, trade := range trades {
+		if trade == nil || trade.Maker == "" {
+			continue
+		}
+		if _, ok := excludeSet[trade.Maker]; ok {
+			continue
+		}
+		switch trade.TradeType {
+		case types.TradeTypeBuy:
+			if _, ok := netAmount[trade.Maker]; !ok {
+				result.Snipers = append(result.Snipers, trade.Maker)
+			}
+			netAmount[trade.Maker] += trade.TokenAmount
+			buys = append(buys, trade)
+		case types.TradeTypeSell:
+			if _, ok := netAmount[trade.Maker]; ok {
+				netAmount[trade.Maker] -= trade.TokenAmount
+			}
+		}
+	}
+	if len(buys) == 0 {
+		return result
+	}
+
+	uf := newUnionFind(result.Snipers)
+
+	// 1. 同一 slot 内交易序号连续的买入
+	gap := conf.BundleTxGap
+	if gap <= 0 {
+		gap = 1
+	}
+	runStart := 0
+	for i := 1; i <= len(buys); i++ {
+		if i < len(buys) && buys[i].BlockNum == buys[i-1].BlockNum && buys[i].TransactionIndex-buys[i-1].TransactionIndex <= gap {
+			continue
+		}
+		if run := buys[runStart:i]; len(run) > 1 && len(run) <= JitoBundleMaxTx {
+			for _, trade := range run[1:] {
+				uf.union(run[0].Maker, trade.Maker)
+			}
+		}
+		runStart = i
+	}
+
+	// 2. 共同资金来源
+	funderMakers := make(map[string]string)
+	for _, maker := range result.Snipers {
+		funder := funders[maker]
+		if funder == "" {
+			continue
+		}
+		if first, ok := funderMakers[funder]; ok {
+			uf.union(first, maker)
+			continue
+		}
+		funderMakers[funder] = maker
+	}
+
+	// 3. 相同的买入金额
+	amountMakers := make(map[int64]string)
+	for _, trade := range buys {
+		key := int64(math.Round(trade.BaseTokenAmount * lamportsPerSol))
+		if key <= 0 || key%roundAmountLamports == 0 {
+			continue
+		}
+		if first, ok := amountMakers[key]; ok {
+			uf.union(first, trade.Maker)
+			continue
+		}
+		amountMakers[key] = trade.Maker
+	}
+
+	minSize := conf.BundleMinSize
+	if minSize < 2 {
+		minSize = 2
+	}
+	for _, group := range uf.groups(result.Snipers) {
+		if len(group) < minSize {
+			continue
+		}
+		result.Groups = append(result.Groups, group)
+		result.Bundlers = append(result.Bundlers, group...)
+	}
+	sort.Slice(result.Groups, func(i, j int) bool {
+		return len(result.Groups[i]) > len(result.Groups[j])
+	})
+
+	for _, maker := range result.Snipers {
+		result.SniperTokenAmount += max(netAmount[maker], 0)
+	}
+	for _, maker := range result.Bundlers {
+		result.BundlerTokenAmount += max(netAmount[maker], 0)
+	}
+	return result
+}
+
+// HoldPercent 开盘净买入占总量的百分比，总量未知时返回 0
+func HoldPercent(amount, totalSupply float64) float64 {
+	if amount <= 0 || totalSupply <= 0 {
+		return 0
+	}
+	return min(amount/totalSupply*100, 100)
+}
+
+// unionFind 按钱包地址做并查集，用于把多种关联关系合并成分组
+type unionFind struct {
+	parent map[string]string
+}
+
+func newUnionFind(items []string) *unionFind {
+	uf := &unionFind{parent: make(map[string]string, len(items))}
+	for _, item := range items {
+		uf.parent[item] = item
+	}
+	return uf
+}
+
+func (uf *unionFind) find(item string) string {
+	for uf.parent[item] != item {
+		uf.parent[item] = uf.parent[uf.parent[item]]
+		item = uf.parent[item]
+	}
+	return item
+}
+
+func (uf *unionFind) union(a, b string) {
+	rootA, rootB := uf.find(a), uf.find(b)
+	if rootA != rootB {
+		uf.parent[rootB] = rootA
+	}
+}
+
+// groups 按 items 的顺序输出分组，组内顺序同样保持 items 中的先后
+func (uf *unionFind) groups(items []string) [][]string {
+	index := make(map[string]int)
+	var groups [][]string
+	for _, item := range items {
+		root := uf.find(item)
+		i, ok := index[root]
+		if !ok {
+			i = len(groups)
+			index[root] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], item)
+	}
+	return groups
+}
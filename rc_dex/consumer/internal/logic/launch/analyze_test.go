@@ -0,0 +1,113 @@
+package launch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+var testLaunchConfig = config.LaunchConfig{BundleTxGap: 1, BundleMinSize: 3}
+
+func launchBuy(maker string, slot, txIndex int64, sol, tokens float64) *solmodel.Trade {
+	return &solmodel.Trade{
+		Maker: maker, TradeType: types.TradeTypeBuy, BlockNum: slot, TransactionIndex: txIndex,
+		BaseTokenAmount: sol, TokenAmount: tokens,
+	}
+}
+
+func TestAnalyzeLaunch(t *testing.T) {
+	tests := []struct {
+		name     string
+		trades   []*solmodel.Trade
+		funders  map[string]string
+		bundlers []string
+		groups   int
+	}{
+		{
+			name: "consecutive transactions in one slot",
+			trades: []*solmodel.Trade{
+				launchBuy("a", 10, 3, 0.5, 100), launchBuy("b", 10, 4, 0.7, 100), launchBuy("c", 10, 5, 0.9, 100),
+				launchBuy("d", 11, 20, 0.3, 100),
+			},
+			bundlers: []string{"a", "b", "c"},
+			groups:   1,
+		},
+		{
+			name: "runs longer than a jito bundle are not grouped",
+			trades: []*solmodel.Trade{
+				launchBuy("a", 10, 1, 0.11, 1), launchBuy("b", 10, 2, 0.12, 1), launchBuy("c", 10, 3, 0.13, 1),
+				launchBuy("d", 10, 4, 0.14, 1), launchBuy("e", 10, 5, 0.15, 1), launchBuy("f", 10, 6, 0.16, 1),
+			},
+		},
+		{
+			name: "shared funder",
+			trades: []*solmodel.Trade{
+				launchBuy("a", 10, 1, 0.5, 100), launchBuy("b", 10, 10, 0.7, 100), launchBuy("c", 11, 1, 0.9, 100),
+			},
+			funders:  map[string]string{"a": "funder", "b": "funder", "c": "funder"},
+			bundlers: []string{"a", "b", "c"},
+			groups:   1,
+		},
+		{
+			name: "identical lamport amount",
+			trades: []*solmodel.Trade{
+				launchBuy("a", 10, 1, 0.123456789, 100), launchBuy("b", 10, 10, 0.123456789, 100), launchBuy("c", 11, 1, 0.123456789, 100),
+			},
+			bundlers: []string{"a", "b", "c"},
+			groups:   1,
+		},
+		{
+			name: "amounts equal only to 6 decimals",
+			trades: []*solmodel.Trade{
+				launchBuy("a", 10, 1, 0.123456781, 100), launchBuy("b", 10, 10, 0.123456782, 100), launchBuy("c", 11, 1, 0.123456783, 100),
+			},
+		},
+		{
+			name: "round amounts are not linked",
+			trades: []*solmodel.Trade{
+				launchBuy("a", 10, 1, 1, 100), launchBuy("b", 10, 10, 1, 100), launchBuy("c", 11, 1, 1, 100),
+			},
+		},
+		{
+			name: "group smaller than BundleMinSize",
+			trades: []*solmodel.Trade{
+				launchBuy("a", 10, 1, 0.5, 100), launchBuy("b", 10, 2, 0.7, 100),
+			},
+		},
+		{
+			name: "excluded creator",
+			trades: []*solmodel.Trade{
+				launchBuy("creator", 10, 1, 0.5, 100), launchBuy("a", 10, 2, 0.7, 100), launchBuy("b", 10, 3, 0.9, 100),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := AnalyzeLaunch(tt.trades, tt.funders, []string{"creator"}, testLaunchConfig)
+			require.Equal(t, tt.bundlers, result.Bundlers)
+			require.Len(t, result.Groups, tt.groups)
+		})
+	}
+}
+
+func TestAnalyzeLaunchHoldAmount(t *testing.T) {
+	trades := []*solmodel.Trade{
+		launchBuy("a", 10, 1, 0.5, 100), launchBuy("b", 10, 2, 0.7, 200), launchBuy("c", 10, 3, 0.9, 300),
+		launchBuy("d", 11, 1, 0.3, 50),
+		{Maker: "a", TradeType: types.TradeTypeSell, BlockNum: 11, TransactionIndex: 2, TokenAmount: 40},
+		{Maker: "d", TradeType: types.TradeTypeSell, BlockNum: 11, TransactionIndex: 3, TokenAmount: 80},
+		// 开盘前没有买入的钱包卖出不影响统计
+		{Maker: "e", TradeType: types.TradeTypeSell, BlockNum: 11, TransactionIndex: 4, TokenAmount: 10},
+	}
+
+	result := AnalyzeLaunch(trades, nil, nil, testLaunchConfig)
+	require.Equal(t, []string{"a", "b", "c", "d"}, result.Snipers)
+	require.Equal(t, [][]string{{"a", "b", "c"}}, result.Groups)
+	require.InDelta(t, 560, result.SniperTokenAmount, 1e-9)
+	require.InDelta(t, 560, result.BundlerTokenAmount, 1e-9)
+	require.InDelta(t, 5.6, HoldPercent(result.BundlerTokenAmount, 10_000), 1e-9)
+}
@@ -0,0 +1,68 @@
+package launch
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/system"
+	"github.com/blocto/solana-go-sdk/rpc"
+	solTypes "github.com/blocto/solana-go-sdk/types"
+)
+
+// FindFunder 追溯钱包在 beforeTx 之前最早的一笔交易，返回其中向该钱包转入 SOL 的地址
+// 钱包在 beforeTx 之前的签名数达到 maxSigs 时视为老钱包，不再追溯，返回空字符串
+func FindFunder(ctx context.Context, c *client.Client, wallet, beforeTx string, maxSigs int) (string, error) {
+	sigs, err := c.GetSignaturesForAddressWithConfig(ctx, wallet, client.GetSignaturesForAddressConfig{
+		Before:     beforeTx,
+		Limit:      maxSigs,
+		Commitment: rpc.CommitmentConfirmed,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(sigs) == 0 || len(sigs) >= maxSigs {
+		return "", nil
+	}
+
+	tx, err := c.GetTransaction(ctx, sigs[len(sigs)-1].Signature)
+	if err != nil {
+		return "", err
+	}
+	if tx == nil || tx.Meta == nil || tx.Meta.Err != nil {
+		return "", nil
+	}
+
+	for i := range tx.Transaction.Message.Instructions {
+		if from := decodeSolTransferTo(tx.AccountKeys, &tx.Transaction.Message.Instructions[i], wallet); from != "" {
+			return from, nil
+		}
+	}
+	for _, inner := range tx.Meta.InnerInstructions {
+		for i := range inner.Instructions {
+			if from := decodeSolTransferTo(tx.AccountKeys, &inner.Instructions[i], wallet); from != "" {
+				return from, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// decodeSolTransferTo 解析 System Program 的 Transfer 指令，收款方为 wallet 时返回付款方地址
+// Transfer 指令数据：4 字节指令类型 + 8 字节 lamports，账户：[from, to]
+func decodeSolTransferTo(accountKeys []common.PublicKey, instruction *solTypes.CompiledInstruction, wallet string) string {
+	if int(instruction.ProgramIDIndex) >= len(accountKeys) || accountKeys[instruction.ProgramIDIndex] != common.SystemProgramID {
+		return ""
+	}
+	if len(instruction.Data) < 12 || binary.LittleEndian.Uint32(instruction.Data[:4]) != uint32(system.InstructionTransfer) {
+		return ""
+	}
+	if len(instruction.Accounts) < 2 || instruction.Accounts[0] >= len(accountKeys) || instruction.Accounts[1] >= len(accountKeys) {
+		return ""
+	}
+	if accountKeys[instruction.Accounts[1]].String() != wallet {
+		return ""
+	}
+	return accountKeys[instruction.Accounts[0]].String()
+}
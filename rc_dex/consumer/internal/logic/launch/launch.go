@@ -0,0 +1,163 @@
+package launch
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+	"richcode.cc/dex/pkg/types"
+)
+
+var ErrServiceStop = errors.New("launch service stopped")
+
+// LaunchService 开盘分析服务
+// 周期性扫描最近创建、且开盘窗口（创建后 LaunchSlots 个 slot）已经解析完成的 PumpSwap / Pump.fun 交易对，
+// 识别窗口内的狙击钱包和捆绑买入，把数量、持仓占比以及是否捆绑开盘写回 token 表；每个代币只分析一次
+type LaunchService struct {
+	sc *svc.ServiceContext
+	logx.Logger
+	ctx    context.Context
+	cancel func(err error)
+}
+
+func NewLaunchService(sc *svc.ServiceContext) *LaunchService {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &LaunchService{
+		sc:     sc,
+		Logger: logx.WithContext(context.Background()).WithFields(logx.Field("service", "launch")),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (s *LaunchService) Start() {
+	conf := s.sc.Config.Launch
+	if !conf.Enable {
+		s.Info("launch service disabled")
+		return
+	}
+
+	interval := time.Duration(conf.Interval) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.Info("launch service stop succeed")
+			return
+		case <-ticker.C:
+			s.AnalyzeUncheckedPairs()
+		}
+	}
+}
+
+func (s *LaunchService) Stop() {
+	s.cancel(ErrServiceStop)
+}
+
+// AnalyzeUncheckedPairs 取出开盘窗口已经完整落库、但还没有分析过的交易对逐个分析
+func (s *LaunchService) AnalyzeUncheckedPairs() {
+	conf := s.sc.Config.Launch
+
+	latest, err := s.sc.BlockModel.FindOneByNearSlot(s.ctx, math.MaxInt64)
+	if err != nil {
+		s.Errorf("AnalyzeUncheckedPairs: FindOneByNearSlot err: %v", err)
+		return
+	}
+
+	fromSlot := latest.Slot - conf.LookbackSlots
+	toSlot := latest.Slot - conf.LaunchSlots
+	pairs, err := s.sc.PairModel.FindLaunchUncheckedPairs(s.ctx, constants.SolChainIdInt, []string{constants.PumpSwap, constants.PumpFun}, fromSlot, toSlot, conf.PairLimit)
+	if err != nil {
+		s.Errorf("AnalyzeUncheckedPairs: FindLaunchUncheckedPairs err: %v", err)
+		return
+	}
+
+	for _, pair := range pairs {
+		if err = s.AnalyzePair(pair); err != nil {
+			s.Errorf("AnalyzeUncheckedPairs: AnalyzePair err: %v, pair address: %v", err, pair.Address)
+		}
+	}
+}
+
+// AnalyzePair 分析单个交易对的开盘窗口，并把结果写回对应代币
+func (s *LaunchService) AnalyzePair(pair *solmodel.Pair) error {
+	beginTime := time.Now()
+	conf := s.sc.Config.Launch
+
+	tokenDB, err := s.sc.TokenModel.FindOneByChainIdAddress(s.ctx, constants.SolChainIdInt, pair.TokenAddress)
+	if err != nil {
+		return err
+	}
+
+	trades, err := s.sc.TradeModel.FindByPairAddrSlotRange(s.ctx, constants.SolChainIdInt, pair.Address, pair.BlockNum, pair.BlockNum+conf.LaunchSlots)
+	if err != nil {
+		return err
+	}
+
+	var funders map[string]string
+	if conf.FunderLookup {
+		funders = s.findFunders(trades)
+	}
+
+	result := AnalyzeLaunch(trades, funders, []string{pair.PumpOwner}, conf)
+
+	tokenDB.SniperCount = int64(len(result.Snipers))
+	tokenDB.SniperHoldPercent = HoldPercent(result.SniperTokenAmount, tokenDB.TotalSupply)
+	tokenDB.BundlerCount = int64(len(result.Bundlers))
+	tokenDB.BundlerHoldPercent = HoldPercent(result.BundlerTokenAmount, tokenDB.TotalSupply)
+	tokenDB.IsBundled = 0
+	if len(result.Groups) > 0 && (tokenDB.TotalSupply <= 0 || tokenDB.BundlerHoldPercent >= conf.BundledPercent) {
+		// 总量未知时无法计算占比，只要出现捆绑分组就标记
+		tokenDB.IsBundled = 1
+	}
+	tokenDB.LaunchCheckAt = time.Now().Unix()
+
+	if err = s.sc.TokenModel.UpdateLaunchMetrics(s.ctx, tokenDB); err != nil {
+		return err
+	}
+
+	s.Infof("AnalyzePair: pair: %v, token: %v, trades: %v, snipers: %v (%.2f%%), bundlers: %v (%.2f%%), groups: %v, bundled: %v, dur: %v",
+		pair.Address, tokenDB.Address, len(trades), tokenDB.SniperCount, tokenDB.SniperHoldPercent,
+		tokenDB.BundlerCount, tokenDB.BundlerHoldPercent, len(result.Groups), tokenDB.IsBundled, time.Since(beginTime))
+	return nil
+}
+
+// findFunders 查询开盘买入钱包的首笔 SOL 来源，以钱包的第一笔开盘买入交易为截止点向前追溯
+func (s *LaunchService) findFunders(trades []*solmodel.Trade) map[string]string {
+	conf := s.sc.Config.Launch
+	funders := make(map[string]string)
+	visited := make(map[string]struct{})
+
+	for _, trade := range trades {
+		if trade.TradeType != types.TradeTypeBuy || trade.Maker == "" {
+			continue
+		}
+		if _, ok := visited[trade.Maker]; ok {
+			continue
+		}
+		if len(visited) >= conf.FunderMaxWallet {
+			break
+		}
+		visited[trade.Maker] = struct{}{}
+
+		funder, err := FindFunder(s.ctx, s.sc.GetSolClient(), trade.Maker, trade.TxHash, conf.FunderMaxSigs)
+		if err != nil {
+			s.Errorf("findFunders: FindFunder err: %v, wallet: %v", err, trade.Maker)
+			continue
+		}
+		if funder != "" {
+			funders[trade.Maker] = funder
+		}
+	}
+	return funders
+}
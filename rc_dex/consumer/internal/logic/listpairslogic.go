@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListPairsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListPairsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListPairsLogic {
+	return &ListPairsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ListPairs 按 DEX、Pump 状态、流动性和成交额筛选交易对，按流动性、成交额或创建时间排序分页
+func (l *ListPairsLogic) ListPairs(in *consumer.ListPairsRequest) (*consumer.ListPairsResponse, error) {
+	limit := pageLimit(in.PageSize)
+	page := max(in.Page, 1)
+
+	pairs, err := l.svcCtx.PairModel.FindPairList(l.ctx, constants.SolChainIdInt, &solmodel.PairListFilter{
+		Name:         in.Name,
+		PumpStatus:   in.PumpStatus,
+		MinLiquidity: in.MinLiquidity,
+		MinVolume:    in.MinVolume,
+		ExcludeRug:   in.ExcludeRug,
+		VolumeSince:  time.Now().Add(-volumeWindow),
+		OrderBy:      in.OrderBy,
+		Asc:          in.Asc,
+		Offset:       int(page-1) * limit,
+		Limit:        limit,
+	})
+	if err != nil {
+		return nil, queryError(err, "pairs")
+	}
+
+	list := make([]*consumer.Pair, 0, len(pairs))
+	for _, pair := range pairs {
+		list = append(list, toPair(&pair.Pair, &solmodel.PairVolume{Volume: pair.Volume, OrganicVolume: pair.OrganicVolume}))
+	}
+	return &consumer.ListPairsResponse{List: list}, nil
+}
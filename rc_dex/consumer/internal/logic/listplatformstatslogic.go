@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/pkg/constants"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	defaultPlatformStatsHours = 24
+	maxPlatformStatsHours     = 720
+)
+
+type ListPlatformStatsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListPlatformStatsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListPlatformStatsLogic {
+	return &ListPlatformStatsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ListPlatformStats 按下单平台（交易机器人、前端）统计最近 hours 小时的成交额和占比，按成交额倒序
+func (l *ListPlatformStatsLogic) ListPlatformStats(in *consumer.ListPlatformStatsRequest) (*consumer.ListPlatformStatsResponse, error) {
+	hours := in.Hours
+	if hours <= 0 {
+		hours = defaultPlatformStatsHours
+	}
+	hours = min(hours, maxPlatformStatsHours)
+
+	volumes, err := l.svcCtx.TradeModel.SumVolumeByPlatform(l.ctx, constants.SolChainIdInt, time.Now().Add(-time.Duration(hours)*time.Hour))
+	if err != nil {
+		return nil, queryError(err, "platform stats")
+	}
+
+	resp := &consumer.ListPlatformStatsResponse{List: make([]*consumer.PlatformStat, 0, len(volumes))}
+	for _, volume := range volumes {
+		resp.TotalVolume += volume.Volume
+	}
+	for _, volume := range volumes {
+		stat := &consumer.PlatformStat{
+			Platform:      volume.Platform,
+			Volume:        volume.Volume,
+			OrganicVolume: volume.OrganicVolume,
+			TradeCount:    volume.TradeCount,
+			MakerCount:    volume.MakerCount,
+		}
+		if resp.TotalVolume > 0 {
+			stat.VolumeShare = volume.Volume / resp.TotalVolume * 100
+		}
+		resp.List = append(resp.List, stat)
+	}
+	return resp, nil
+}
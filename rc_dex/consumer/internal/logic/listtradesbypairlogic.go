@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/pkg/constants"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ListTradesByPairLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListTradesByPairLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListTradesByPairLogic {
+	return &ListTradesByPairLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ListTradesByPair 按 slot 倒序游标分页查询交易对的成交
+func (l *ListTradesByPairLogic) ListTradesByPair(in *consumer.ListTradesByPairRequest) (*consumer.ListTradesResponse, error) {
+	if in.PairAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "pair_address is required")
+	}
+	cursor, err := decodeTradeCursor(in.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := pageLimit(in.Limit)
+	trades, err := l.svcCtx.TradeModel.FindByPairAddrCursor(l.ctx, constants.SolChainIdInt, in.PairAddress, cursor, limit)
+	if err != nil {
+		return nil, queryError(err, "trades")
+	}
+	return &consumer.ListTradesResponse{
+		List:       toTrades(trades),
+		NextCursor: encodeTradeCursor(trades, limit),
+	}, nil
+}
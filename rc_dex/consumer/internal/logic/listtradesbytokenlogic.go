@@ -0,0 +1,60 @@
+package logic
+
+import (
+	"context"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/pkg/constants"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ListTradesByTokenLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListTradesByTokenLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListTradesByTokenLogic {
+	return &ListTradesByTokenLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ListTradesByToken 按 slot 倒序游标分页查询代币在全部交易对上的成交
+func (l *ListTradesByTokenLogic) ListTradesByToken(in *consumer.ListTradesByTokenRequest) (*consumer.ListTradesResponse, error) {
+	if in.TokenAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "token_address is required")
+	}
+	cursor, err := decodeTradeCursor(in.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := l.svcCtx.PairModel.FindByChainIdTokenAddress(l.ctx, constants.SolChainIdInt, in.TokenAddress)
+	if err != nil {
+		return nil, queryError(err, "pairs")
+	}
+	if len(pairs) == 0 {
+		return nil, status.Error(codes.NotFound, "token pairs not found")
+	}
+	pairAddrs := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		pairAddrs = append(pairAddrs, pair.Address)
+	}
+
+	limit := pageLimit(in.Limit)
+	trades, err := l.svcCtx.TradeModel.FindByPairAddrsCursor(l.ctx, constants.SolChainIdInt, pairAddrs, cursor, limit)
+	if err != nil {
+		return nil, queryError(err, "trades")
+	}
+	return &consumer.ListTradesResponse{
+		List:       toTrades(trades),
+		NextCursor: encodeTradeCursor(trades, limit),
+	}, nil
+}
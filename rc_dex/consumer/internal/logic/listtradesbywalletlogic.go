@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/pkg/constants"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ListTradesByWalletLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListTradesByWalletLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListTradesByWalletLogic {
+	return &ListTradesByWalletLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ListTradesByWallet 按 slot 倒序游标分页查询钱包的成交
+func (l *ListTradesByWalletLogic) ListTradesByWallet(in *consumer.ListTradesByWalletRequest) (*consumer.ListTradesResponse, error) {
+	if in.Wallet == "" {
+		return nil, status.Error(codes.InvalidArgument, "wallet is required")
+	}
+	cursor, err := decodeTradeCursor(in.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := pageLimit(in.Limit)
+	trades, err := l.svcCtx.TradeModel.FindByMakerCursor(l.ctx, constants.SolChainIdInt, in.Wallet, cursor, limit)
+	if err != nil {
+		return nil, queryError(err, "trades")
+	}
+	return &consumer.ListTradesResponse{
+		List:       toTrades(trades),
+		NextCursor: encodeTradeCursor(trades, limit),
+	}, nil
+}
@@ -0,0 +1,176 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+	"google.golang.org/protobuf/encoding/protojson"
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/stream"
+	"richcode.cc/dex/consumer/internal/svc"
+)
+
+var ErrServiceStop = errors.New("push service stopped")
+
+const maxMessageSize = 64 * 1024
+
+var marshaler = protojson.MarshalOptions{EmitUnpopulated: true}
+
+// PushService WebSocket 实时推送服务
+// 客户端连接后发送 JSON 格式的 SubscribeRequest（如 {"pairAddresses":["..."],"newPairs":true}）订阅，
+// 再次发送会替换原有订阅；服务端推送 JSON 格式的 Event，与 gRPC Subscribe 的事件一致。
+// 服务端按心跳间隔发送 ping，两个间隔内收不到 pong 或待发送队列写满的连接会被关闭
+type PushService struct {
+	sc *svc.ServiceContext
+	logx.Logger
+	ctx      context.Context
+	cancel   func(err error)
+	server   *http.Server
+	upgrader websocket.Upgrader
+}
+
+func NewPushService(sc *svc.ServiceContext) *PushService {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	s := &PushService{
+		sc:     sc,
+		Logger: logx.WithContext(context.Background()).WithFields(logx.Field("service", "push")),
+		ctx:    ctx,
+		cancel: cancel,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+			// 推送的是公开行情数据，不限制来源
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(sc.Config.Push.Path, s.ServeWs)
+	s.server = &http.Server{
+		Addr:    sc.Config.Push.ListenOn,
+		Handler: mux,
+	}
+	return s
+}
+
+func (s *PushService) Start() {
+	if !s.sc.Config.Push.Enable {
+		s.Info("push service disabled")
+		return
+	}
+
+	s.Infof("push service listen on %s%s", s.server.Addr, s.sc.Config.Push.Path)
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.Errorf("push service listen err: %v", err)
+	}
+}
+
+func (s *PushService) Stop() {
+	s.cancel(ErrServiceStop)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = s.server.Shutdown(ctx)
+}
+
+// ServeWs 处理单个 WebSocket 连接：读协程负责订阅消息和 pong，当前协程负责写事件和 ping
+func (s *PushService) ServeWs(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.Errorf("ServeWs: upgrade err: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	conf := s.sc.Config.Push
+	heartbeat := time.Duration(conf.Heartbeat) * time.Second
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	writeTimeout := time.Duration(conf.WriteTimeout) * time.Second
+	if writeTimeout <= 0 {
+		writeTimeout = 10 * time.Second
+	}
+
+	// 未发送订阅消息前不会收到任何事件
+	sub := s.sc.Stream.Subscribe(nil)
+	defer s.sc.Stream.Unsubscribe(sub)
+
+	closed := make(chan struct{})
+	threading.GoSafe(func() {
+		defer close(closed)
+		s.readLoop(conn, sub, 2*heartbeat, writeTimeout)
+	})
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.writeClose(conn, websocket.CloseGoingAway, "server shutdown", writeTimeout)
+			return
+		case <-closed:
+			return
+		case <-sub.Done():
+			// 待发送队列写满，客户端消费过慢
+			s.writeClose(conn, websocket.CloseTryAgainLater, sub.Err().Error(), writeTimeout)
+			return
+		case event := <-sub.Events():
+			if err = s.writeEvent(conn, event, writeTimeout); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readLoop 读取客户端的订阅消息，读超时随 pong 顺延
+func (s *PushService) readLoop(conn *websocket.Conn, sub *stream.Subscriber, readTimeout, writeTimeout time.Duration) {
+	conn.SetReadLimit(maxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+
+		var in consumer.SubscribeRequest
+		if err = protojson.Unmarshal(message, &in); err != nil {
+			s.writeClose(conn, websocket.CloseUnsupportedData, "invalid subscribe message", writeTimeout)
+			return
+		}
+		filter, err := stream.NewFilter(&in, s.sc.Config.Push.MaxKeys)
+		if err != nil {
+			s.writeClose(conn, websocket.ClosePolicyViolation, err.Error(), writeTimeout)
+			return
+		}
+		sub.SetFilter(filter)
+	}
+}
+
+func (s *PushService) writeEvent(conn *websocket.Conn, event *consumer.Event, writeTimeout time.Duration) error {
+	data, err := marshaler.Marshal(event)
+	if err != nil {
+		s.Errorf("writeEvent: marshal err: %v", err)
+		return nil
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// writeClose 发送关闭帧，WriteControl 可以与写协程并发调用
+func (s *PushService) writeClose(conn *websocket.Conn, code int, reason string, writeTimeout time.Duration) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeTimeout))
+}
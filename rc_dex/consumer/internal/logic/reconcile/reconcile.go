@@ -0,0 +1,231 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	ag_rpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+	"richcode.cc/dex/pkg/sol"
+)
+
+var ErrServiceStop = errors.New("reconcile service stopped")
+
+const metricNamespace = "reconcile"
+
+var (
+	metricChecked = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Name:      "checked_total",
+		Help:      "pairs compared with on-chain pool reserves",
+		Labels:    []string{"dex"},
+	})
+	metricMismatch = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Name:      "mismatch_total",
+		Help:      "pair reserves drifting from on-chain pool reserves beyond tolerance",
+		Labels:    []string{"dex", "field"},
+	})
+	metricCorrected = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: metricNamespace,
+		Name:      "corrected_total",
+		Help:      "pair reserves overwritten with on-chain pool reserves",
+		Labels:    []string{"dex"},
+	})
+	metricMismatchRatio = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: metricNamespace,
+		Name:      "mismatch_ratio",
+		Help:      "percentage of mismatched pairs in the last round",
+		Labels:    []string{"dex"},
+	})
+)
+
+// 储备字段，用于日志和指标标签
+const (
+	fieldQuote = "quote" // 报价币（SOL）储备，对应 Pair.CurrentBaseTokenAmount
+	fieldBase  = "base"  // 代币储备，对应 Pair.CurrentTokenAmount
+)
+
+// ReconcileService 池子储备校对服务
+// 交易对的储备完全由成交事件推算，漏解析或乱序写入都会让储备偏离链上状态；
+// 周期性读取活跃 PumpSwap 池子账户和两个金库的余额，与交易对表比较，偏差记日志和指标，并按配置修正
+type ReconcileService struct {
+	sc *svc.ServiceContext
+	logx.Logger
+	ctx    context.Context
+	cancel func(err error)
+}
+
+func NewReconcileService(sc *svc.ServiceContext) *ReconcileService {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &ReconcileService{
+		sc:     sc,
+		Logger: logx.WithContext(context.Background()).WithFields(logx.Field("service", "reconcile")),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (s *ReconcileService) Start() {
+	conf := s.sc.Config.Reconcile
+	if !conf.Enable {
+		s.Info("reconcile service disabled")
+		return
+	}
+
+	interval := time.Duration(conf.Interval) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.Info("reconcile service stop succeed")
+			return
+		case <-ticker.C:
+			s.ReconcilePumpSwap()
+		}
+	}
+}
+
+func (s *ReconcileService) Stop() {
+	s.cancel(ErrServiceStop)
+}
+
+// Mismatch 一个交易对的储备偏差，百分比相对链上余额
+type Mismatch struct {
+	QuoteDrift float64
+	BaseDrift  float64
+}
+
+// CompareReserves 比较交易对储备与链上金库余额（均为未除精度的原始数量），任一储备偏差超过 tolerance（百分比）时返回偏差
+func CompareReserves(pair *solmodel.Pair, state *sol.PumpSwapPoolState, tolerance float64) *Mismatch {
+	mismatch := &Mismatch{
+		QuoteDrift: driftPercent(pair.CurrentBaseTokenAmount, state.QuoteReserves),
+		BaseDrift:  driftPercent(pair.CurrentTokenAmount, state.BaseReserves),
+	}
+	if mismatch.QuoteDrift <= tolerance && mismatch.BaseDrift <= tolerance {
+		return nil
+	}
+	return mismatch
+}
+
+func driftPercent(recorded float64, actual uint64) float64 {
+	if actual == 0 {
+		if recorded == 0 {
+			return 0
+		}
+		return 100
+	}
+	return math.Abs(recorded-float64(actual)) / float64(actual) * 100
+}
+
+// poolState 取出交易对的链上状态；池子没有读到，或者交易对最近一次写入的 slot 晚于读取金库的 slot 时返回 nil，本轮跳过
+func poolState(pair *solmodel.Pair, states map[solana.PublicKey]*sol.PumpSwapPoolState, slot uint64) *sol.PumpSwapPoolState {
+	pool, err := solana.PublicKeyFromBase58(pair.Address)
+	if err != nil || pair.Slot > int64(slot) {
+		return nil
+	}
+	return states[pool]
+}
+
+// ReconcilePumpSwap 校对一轮最近有成交的 PumpSwap 交易对；
+// 链上余额读取时的 slot 早于交易对最近一次写入的 slot 时跳过，避免用旧状态覆盖区块解析的结果
+func (s *ReconcileService) ReconcilePumpSwap() {
+	conf := s.sc.Config.Reconcile
+	now := time.Now()
+	pairs, err := s.sc.PairModel.FindActivePairs(s.ctx, constants.SolChainIdInt, constants.PumpSwap,
+		now.Add(-time.Duration(conf.ActiveMinutes)*time.Minute), conf.BatchSize)
+	if err != nil {
+		s.Errorf("ReconcilePumpSwap: FindActivePairs err: %v", err)
+		return
+	}
+	if len(pairs) == 0 {
+		return
+	}
+
+	pools := make([]solana.PublicKey, 0, len(pairs))
+	for _, pair := range pairs {
+		pool, err := solana.PublicKeyFromBase58(pair.Address)
+		if err != nil {
+			continue
+		}
+		pools = append(pools, pool)
+	}
+	states, slot, err := sol.GetPumpSwapPoolStates(s.newRpcClient(), s.ctx, pools)
+	if err != nil {
+		s.Errorf("ReconcilePumpSwap: GetPumpSwapPoolStates err: %v, size: %v", err, len(pools))
+		return
+	}
+
+	var checked, mismatched, corrected, skipped int
+	for _, pair := range pairs {
+		state := poolState(pair, states, slot)
+		if state == nil {
+			skipped++
+			continue
+		}
+		checked++
+		metricChecked.Inc(constants.PumpSwap)
+
+		mismatch := CompareReserves(pair, state, conf.TolerancePercent)
+		if mismatch == nil {
+			continue
+		}
+		mismatched++
+		if mismatch.QuoteDrift > conf.TolerancePercent {
+			metricMismatch.Inc(constants.PumpSwap, fieldQuote)
+		}
+		if mismatch.BaseDrift > conf.TolerancePercent {
+			metricMismatch.Inc(constants.PumpSwap, fieldBase)
+		}
+		s.Infof("ReconcilePumpSwap: reserves mismatch pair: %v, slot: %v, pair slot: %v, quote: %.0f -> %v (%.2f%%), base: %.0f -> %v (%.2f%%)",
+			pair.Address, slot, pair.Slot, pair.CurrentBaseTokenAmount, state.QuoteReserves, mismatch.QuoteDrift,
+			pair.CurrentTokenAmount, state.BaseReserves, mismatch.BaseDrift)
+
+		if !conf.Correct {
+			continue
+		}
+		// 流动性与区块解析时的算法保持一致：两边储备分别乘以当前价格
+		pair.CurrentBaseTokenAmount = float64(state.QuoteReserves)
+		pair.CurrentTokenAmount = float64(state.BaseReserves)
+		pair.Liquidity = pair.CurrentBaseTokenAmount*pair.BaseTokenPrice + pair.CurrentTokenAmount*pair.TokenPrice
+		ok, err := s.sc.PairModel.CorrectReserves(s.ctx, pair, int64(slot))
+		if err != nil {
+			s.Errorf("ReconcilePumpSwap: CorrectReserves err: %v, pair address: %v", err, pair.Address)
+			continue
+		}
+		if ok {
+			corrected++
+			metricCorrected.Inc(constants.PumpSwap)
+		}
+	}
+
+	if checked > 0 {
+		metricMismatchRatio.Set(float64(mismatched)/float64(checked)*100, constants.PumpSwap)
+	}
+	s.Infof("ReconcilePumpSwap: slot: %v, pairs: %v, checked: %v, mismatched: %v, corrected: %v, skipped: %v, dur: %v",
+		slot, len(pairs), checked, mismatched, corrected, skipped, time.Since(now))
+}
+
+func (s *ReconcileService) newRpcClient() *ag_rpc.Client {
+	opts := &jsonrpc.RPCClientOpts{
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+	return ag_rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(config.FindChainRpcByChainId(constants.SolChainIdInt), opts))
+}
@@ -0,0 +1,109 @@
+package reconcile
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/sol"
+)
+
+func TestDriftPercent(t *testing.T) {
+	tests := []struct {
+		name     string
+		recorded float64
+		actual   uint64
+		drift    float64
+	}{
+		{name: "equal", recorded: 1_000, actual: 1_000, drift: 0},
+		{name: "recorded higher", recorded: 1_050, actual: 1_000, drift: 5},
+		{name: "recorded lower", recorded: 950, actual: 1_000, drift: 5},
+		{name: "both zero", recorded: 0, actual: 0, drift: 0},
+		{name: "vault drained", recorded: 1_000, actual: 0, drift: 100},
+		{name: "never recorded", recorded: 0, actual: 1_000, drift: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.drift, driftPercent(tt.recorded, tt.actual), 1e-9)
+		})
+	}
+}
+
+func TestCompareReserves(t *testing.T) {
+	const tolerance = 1.0
+	state := &sol.PumpSwapPoolState{QuoteReserves: 80_000_000_000, BaseReserves: 500_000_000_000_000}
+	tests := []struct {
+		name       string
+		quote      float64
+		base       float64
+		state      *sol.PumpSwapPoolState
+		mismatch   bool
+		quoteDrift float64
+		baseDrift  float64
+	}{
+		{name: "in sync", quote: 80_000_000_000, base: 500_000_000_000_000, state: state},
+		{name: "quote at tolerance", quote: 80_800_000_000, base: 500_000_000_000_000, state: state},
+		{name: "base at tolerance", quote: 80_000_000_000, base: 495_000_000_000_000, state: state},
+		{
+			name: "quote just above tolerance", quote: 80_800_000_001, base: 500_000_000_000_000, state: state,
+			mismatch: true, quoteDrift: 1.00000000125,
+		},
+		{
+			name: "base just above tolerance", quote: 80_000_000_000, base: 494_999_999_999_999, state: state,
+			mismatch: true, baseDrift: 1.0000000000002,
+		},
+		{name: "both zero", state: &sol.PumpSwapPoolState{}},
+		{
+			name: "pool drained on chain", quote: 80_000_000_000, base: 500_000_000_000_000, state: &sol.PumpSwapPoolState{},
+			mismatch: true, quoteDrift: 100, baseDrift: 100,
+		},
+		{
+			name: "reserves never recorded", state: state,
+			mismatch: true, quoteDrift: 100, baseDrift: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := &solmodel.Pair{CurrentBaseTokenAmount: tt.quote, CurrentTokenAmount: tt.base}
+			mismatch := CompareReserves(pair, tt.state, tolerance)
+			if !tt.mismatch {
+				require.Nil(t, mismatch)
+				return
+			}
+			require.NotNil(t, mismatch)
+			require.InDelta(t, tt.quoteDrift, mismatch.QuoteDrift, 1e-9)
+			require.InDelta(t, tt.baseDrift, mismatch.BaseDrift, 1e-9)
+		})
+	}
+}
+
+func TestPoolState(t *testing.T) {
+	pool := solana.NewWallet().PublicKey()
+	state := &sol.PumpSwapPoolState{QuoteReserves: 1, BaseReserves: 2}
+	states := map[solana.PublicKey]*sol.PumpSwapPoolState{pool: state}
+	const slot = 1_000
+
+	tests := []struct {
+		name    string
+		address string
+		slot    int64
+		found   bool
+	}{
+		{name: "written before the read", address: pool.String(), slot: slot - 1, found: true},
+		{name: "written in the read slot", address: pool.String(), slot: slot, found: true},
+		{name: "written after the read", address: pool.String(), slot: slot + 1},
+		{name: "pool not read", address: solana.NewWallet().PublicKey().String(), slot: slot - 1},
+		{name: "invalid address", address: "not-a-pool", slot: slot - 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := poolState(&solmodel.Pair{Address: tt.address, Slot: tt.slot}, states, slot)
+			if tt.found {
+				require.Same(t, state, got)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
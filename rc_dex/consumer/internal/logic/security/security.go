@@ -0,0 +1,201 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	ag_rpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/zeromicro/go-zero/core/logx"
+	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/consumer/internal/svc"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+	"richcode.cc/dex/pkg/sol"
+	"richcode.cc/dex/pkg/sol/token2022"
+	"richcode.cc/dex/pkg/util"
+)
+
+var ErrServiceStop = errors.New("security service stopped")
+
+// SecurityService 代币合约检测服务
+// 周期性取出 is_check_ca = 0 的代币（新代币，或区块解析时发现权限变更被重置的代币）以及上线初期需要复查的代币，
+// 读取链上 mint 权限、Token-2022 扩展和 PumpSwap 池子的 LP 销毁/锁仓情况，写回 token 表的风险字段
+type SecurityService struct {
+	sc *svc.ServiceContext
+	logx.Logger
+	ctx    context.Context
+	cancel func(err error)
+}
+
+func NewSecurityService(sc *svc.ServiceContext) *SecurityService {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &SecurityService{
+		sc:     sc,
+		Logger: logx.WithContext(context.Background()).WithFields(logx.Field("service", "security")),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (s *SecurityService) Start() {
+	conf := s.sc.Config.Security
+	if !conf.Enable {
+		s.Info("security service disabled")
+		return
+	}
+
+	interval := time.Duration(conf.Interval) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.Info("security service stop succeed")
+			return
+		case <-ticker.C:
+			s.ScanTokens()
+		}
+	}
+}
+
+func (s *SecurityService) Stop() {
+	s.cancel(ErrServiceStop)
+}
+
+// ScanTokens 检测一批待检测的代币
+func (s *SecurityService) ScanTokens() {
+	conf := s.sc.Config.Security
+	now := time.Now()
+	checkBefore := now.Add(-time.Duration(conf.RecheckSecond) * time.Second).Unix()
+	retryBefore := now.Add(-time.Duration(conf.RetrySecond) * time.Second).Unix()
+	createdAfter := now.Add(-time.Duration(conf.RecheckHours) * time.Hour)
+
+	tokens, err := s.sc.TokenModel.FindCheckCaTokens(s.ctx, constants.SolChainIdInt, checkBefore, retryBefore, createdAfter, conf.BatchSize)
+	if err != nil {
+		s.Errorf("ScanTokens: FindCheckCaTokens err: %v", err)
+		return
+	}
+	if len(tokens) == 0 {
+		return
+	}
+
+	client := s.newRpcClient()
+	for _, tokenDB := range tokens {
+		if err = s.ScanToken(client, tokenDB); err != nil {
+			s.Errorf("ScanTokens: ScanToken err: %v, token address: %v", err, tokenDB.Address)
+			// 记下本次尝试时间，RetrySecond 之后再重试，失败的代币不会一直占住队首
+			if err = s.sc.TokenModel.MarkCheckCaFailed(s.ctx, constants.SolChainIdInt, tokenDB.Address, time.Now().Unix()); err != nil {
+				s.Errorf("ScanTokens: MarkCheckCaFailed err: %v, token address: %v", err, tokenDB.Address)
+			}
+		}
+	}
+	s.Infof("ScanTokens: size: %v, dur: %v", len(tokens), time.Since(now))
+}
+
+// ScanToken 检测单个代币并写回风险字段
+func (s *SecurityService) ScanToken(client *ag_rpc.Client, tokenDB *solmodel.Token) error {
+	mint, err := solana.PublicKeyFromBase58(tokenDB.Address)
+	if err != nil {
+		return err
+	}
+
+	security, err := sol.GetTokenSecurity(client, s.ctx, mint)
+	if err != nil {
+		return err
+	}
+	ApplyTokenSecurity(tokenDB, security)
+
+	burnedPercent, lockedPercent, err := s.getLiquidityStatus(client, tokenDB.Address)
+	if err != nil {
+		// LP 状态读取失败不影响权限类风险字段，沿用上次结果
+		s.Errorf("ScanToken: getLiquidityStatus err: %v, token address: %v", err, tokenDB.Address)
+	} else {
+		safePercent := s.sc.Config.Security.LpSafePercent
+		tokenDB.IsBurnPool = util.BoolToInt64(burnedPercent >= safePercent)
+		tokenDB.IsLiquidLock = util.BoolToInt64(burnedPercent+lockedPercent >= safePercent)
+	}
+
+	tokenDB.IsCheckCa = 1
+	tokenDB.CheckCaAt = time.Now().Unix()
+	return s.sc.TokenModel.UpdateSecurity(s.ctx, tokenDB)
+}
+
+// getLiquidityStatus 汇总代币全部 PumpSwap 池子中销毁和锁仓占比最高的一个；
+// 只有 Pump.fun 内盘时流动性在联合曲线程序里、任何人都无法撤出，视为全部锁定
+func (s *SecurityService) getLiquidityStatus(client *ag_rpc.Client, tokenAddress string) (burnedPercent, lockedPercent float64, err error) {
+	pairs, err := s.sc.PairModel.FindByChainIdTokenAddress(s.ctx, constants.SolChainIdInt, tokenAddress)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var hasPumpSwap, hasPumpFun bool
+	for _, pair := range pairs {
+		switch pair.Name {
+		case constants.PumpFun:
+			hasPumpFun = true
+		case constants.PumpSwap:
+			hasPumpSwap = true
+			pool, err := solana.PublicKeyFromBase58(pair.Address)
+			if err != nil {
+				return 0, 0, err
+			}
+			status, err := sol.GetPumpSwapLpStatus(client, s.ctx, pool, s.sc.Config.Security.LockOwners)
+			if err != nil {
+				return 0, 0, err
+			}
+			if status.BurnedPercent+status.LockedPercent > burnedPercent+lockedPercent {
+				burnedPercent, lockedPercent = status.BurnedPercent, status.LockedPercent
+			}
+		}
+	}
+	if !hasPumpSwap && hasPumpFun {
+		return 100, 0, nil
+	}
+	return burnedPercent, lockedPercent, nil
+}
+
+func (s *SecurityService) newRpcClient() *ag_rpc.Client {
+	opts := &jsonrpc.RPCClientOpts{
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+	return ag_rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(config.FindChainRpcByChainId(constants.SolChainIdInt), opts))
+}
+
+// ApplyTokenSecurity 把链上权限和扩展映射为 token 表的风险字段
+//   - 增发权限未放弃：可增发
+//   - 冻结权限未放弃或新账户默认冻结：存在黑名单
+//   - 存在暂停扩展：可暂停交易；已暂停、不可转让或手续费 100% 视为蜜罐（无法卖出）
+//   - 手续费权限未放弃：可修改税率；当前手续费同时作为买卖税率
+//   - 永久代理：可修改余额；transfer hook：转账会调用外部合约
+func ApplyTokenSecurity(tokenDB *solmodel.Token, security *sol.TokenSecurity) {
+	if security.Program != "" {
+		tokenDB.Program = security.Program
+	}
+
+	tokenDB.IsCanAddToken = util.BoolToInt64(security.MintAuthority != "")
+	tokenDB.IsHaveBlackList = util.BoolToInt64(security.FreezeAuthority != "" || security.DefaultFrozen)
+	tokenDB.IsCaDropOwner = util.BoolToInt64(security.MintAuthority == "" && security.FreezeAuthority == "" &&
+		security.TransferFeeAuthority == "" && security.TransferHookAuthority == "" && security.PauseAuthority == "")
+
+	tokenDB.IsCanPauseTrade = util.BoolToInt64(security.Pausable)
+	tokenDB.IsCanChangeTax = util.BoolToInt64(security.TransferFeeAuthority != "")
+	tokenDB.IsCanChangeToken = util.BoolToInt64(security.PermanentDelegate != "")
+	tokenDB.IsCanExternalCall = util.BoolToInt64(security.TransferHookProgram != "")
+
+	tokenDB.SellTax = security.TransferFeeRate()
+	tokenDB.BuyTax = tokenDB.SellTax
+
+	honey := security.Paused || security.NonTransferable || security.TransferFeeBasisPoints >= token2022.MaxFeeBasisPoints
+	tokenDB.IsHoneyScam = util.BoolToInt64(honey)
+	tokenDB.IsCanAllSell = util.BoolToInt64(!honey)
+}
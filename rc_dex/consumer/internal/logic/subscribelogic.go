@@ -0,0 +1,69 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/consumer/internal/stream"
+	"richcode.cc/dex/consumer/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SubscribeLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSubscribeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SubscribeLogic {
+	return &SubscribeLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Subscribe 按订阅条件持续推送区块落库后的成交、交易对更新和新交易对，空闲时定期发送 heartbeat 事件
+// 客户端接收过慢导致待发送队列写满时返回 ResourceExhausted，客户端需要重新订阅
+func (l *SubscribeLogic) Subscribe(in *consumer.SubscribeRequest, server consumer.Consumer_SubscribeServer) error {
+	conf := l.svcCtx.Config.Push
+	filter, err := stream.NewFilter(in, conf.MaxKeys)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if filter.Empty() {
+		return status.Error(codes.InvalidArgument, "subscription is empty")
+	}
+
+	sub := l.svcCtx.Stream.Subscribe(filter)
+	defer l.svcCtx.Stream.Unsubscribe(sub)
+
+	heartbeat := time.Duration(conf.Heartbeat) * time.Second
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return nil
+		case <-sub.Done():
+			return status.Error(codes.ResourceExhausted, sub.Err().Error())
+		case event := <-sub.Events():
+			if err = server.Send(event); err != nil {
+				return err
+			}
+			ticker.Reset(heartbeat)
+		case <-ticker.C:
+			if err = server.Send(stream.NewHeartbeatEvent()); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -27,3 +27,63 @@ func (s *ConsumerServer) Ping(ctx context.Context, in *consumer.Request) (*consu
 	l := logic.NewPingLogic(ctx, s.svcCtx)
 	return l.Ping(in)
 }
+
+func (s *ConsumerServer) GetPair(ctx context.Context, in *consumer.GetPairRequest) (*consumer.GetPairResponse, error) {
+	l := logic.NewGetPairLogic(ctx, s.svcCtx)
+	return l.GetPair(in)
+}
+
+func (s *ConsumerServer) ListPairs(ctx context.Context, in *consumer.ListPairsRequest) (*consumer.ListPairsResponse, error) {
+	l := logic.NewListPairsLogic(ctx, s.svcCtx)
+	return l.ListPairs(in)
+}
+
+func (s *ConsumerServer) GetToken(ctx context.Context, in *consumer.GetTokenRequest) (*consumer.GetTokenResponse, error) {
+	l := logic.NewGetTokenLogic(ctx, s.svcCtx)
+	return l.GetToken(in)
+}
+
+func (s *ConsumerServer) ListTradesByPair(ctx context.Context, in *consumer.ListTradesByPairRequest) (*consumer.ListTradesResponse, error) {
+	l := logic.NewListTradesByPairLogic(ctx, s.svcCtx)
+	return l.ListTradesByPair(in)
+}
+
+func (s *ConsumerServer) ListTradesByToken(ctx context.Context, in *consumer.ListTradesByTokenRequest) (*consumer.ListTradesResponse, error) {
+	l := logic.NewListTradesByTokenLogic(ctx, s.svcCtx)
+	return l.ListTradesByToken(in)
+}
+
+func (s *ConsumerServer) ListTradesByWallet(ctx context.Context, in *consumer.ListTradesByWalletRequest) (*consumer.ListTradesResponse, error) {
+	l := logic.NewListTradesByWalletLogic(ctx, s.svcCtx)
+	return l.ListTradesByWallet(in)
+}
+
+func (s *ConsumerServer) GetBlockStatus(ctx context.Context, in *consumer.GetBlockStatusRequest) (*consumer.GetBlockStatusResponse, error) {
+	l := logic.NewGetBlockStatusLogic(ctx, s.svcCtx)
+	return l.GetBlockStatus(in)
+}
+
+func (s *ConsumerServer) GetIndexerLag(ctx context.Context, in *consumer.GetIndexerLagRequest) (*consumer.GetIndexerLagResponse, error) {
+	l := logic.NewGetIndexerLagLogic(ctx, s.svcCtx)
+	return l.GetIndexerLag(in)
+}
+
+func (s *ConsumerServer) ListPlatformStats(ctx context.Context, in *consumer.ListPlatformStatsRequest) (*consumer.ListPlatformStatsResponse, error) {
+	l := logic.NewListPlatformStatsLogic(ctx, s.svcCtx)
+	return l.ListPlatformStats(in)
+}
+
+func (s *ConsumerServer) GetPoolFees(ctx context.Context, in *consumer.GetPoolFeesRequest) (*consumer.GetPoolFeesResponse, error) {
+	l := logic.NewGetPoolFeesLogic(ctx, s.svcCtx)
+	return l.GetPoolFees(in)
+}
+
+func (s *ConsumerServer) GetCreatorFees(ctx context.Context, in *consumer.GetCreatorFeesRequest) (*consumer.GetCreatorFeesResponse, error) {
+	l := logic.NewGetCreatorFeesLogic(ctx, s.svcCtx)
+	return l.GetCreatorFees(in)
+}
+
+func (s *ConsumerServer) Subscribe(in *consumer.SubscribeRequest, stream consumer.Consumer_SubscribeServer) error {
+	l := logic.NewSubscribeLogic(stream.Context(), s.svcCtx)
+	return l.Subscribe(in, stream)
+}
@@ -0,0 +1,103 @@
+package stream
+
+import (
+	"time"
+
+	"richcode.cc/dex/consumer/consumer"
+	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/types"
+)
+
+const (
+	EventTrade     = "trade"
+	EventPair      = "pair"
+	EventNewPair   = "new_pair"
+	EventHeartbeat = "heartbeat"
+)
+
+// NewTradeEvent 由落库成功的成交生成推送事件
+func NewTradeEvent(trade *types.TradeWithPair) *consumer.Event {
+	return &consumer.Event{
+		Type:         EventTrade,
+		Slot:         trade.Slot,
+		Time:         time.Now().Unix(),
+		TokenAddress: trade.PairInfo.TokenAddr,
+		Trade: &consumer.Trade{
+			TxHash:            trade.TxHash,
+			HashId:            trade.HashId,
+			PairAddr:          trade.PairAddr,
+			Maker:             trade.Maker,
+			TradeType:         trade.Type,
+			BaseTokenAmount:   trade.BaseTokenAmount,
+			TokenAmount:       trade.TokenAmount,
+			TokenTransferFee:  trade.TokenTransferFee,
+			BaseTokenPriceUsd: trade.BaseTokenPriceUSD,
+			TotalUsd:          trade.TotalUSD,
+			TokenPriceUsd:     trade.TokenPriceUSD,
+			To:                trade.To,
+			BlockNum:          trade.BlockNum,
+			TransactionIndex:  int64(trade.TransactionIndex),
+			BlockTime:         trade.BlockTime,
+			SwapName:          trade.SwapName,
+			MevTag:            trade.MevTag,
+			LpFee:             trade.LpFee,
+			ProtocolFee:       trade.ProtocolFee,
+			CreatorFee:        trade.CreatorFee,
+			Platform:          trade.Platform,
+		},
+	}
+}
+
+// NewPairEvent 由落库后的交易对生成推送事件，created 表示本次新建
+func NewPairEvent(pair *solmodel.Pair, slot int64, created bool) *consumer.Event {
+	eventType := EventPair
+	if created {
+		eventType = EventNewPair
+	}
+	return &consumer.Event{
+		Type:         eventType,
+		Slot:         slot,
+		Time:         time.Now().Unix(),
+		TokenAddress: pair.TokenAddress,
+		Pair:         ToPair(pair),
+	}
+}
+
+func NewHeartbeatEvent() *consumer.Event {
+	return &consumer.Event{
+		Type: EventHeartbeat,
+		Time: time.Now().Unix(),
+	}
+}
+
+// ToPair 把交易对转换为接口结构，成交额需要调用方另行填充
+func ToPair(pair *solmodel.Pair) *consumer.Pair {
+	return &consumer.Pair{
+		ChainId:                pair.ChainId,
+		Address:                pair.Address,
+		Name:                   pair.Name,
+		TokenAddress:           pair.TokenAddress,
+		BaseTokenAddress:       pair.BaseTokenAddress,
+		TokenSymbol:            pair.TokenSymbol,
+		BaseTokenSymbol:        pair.BaseTokenSymbol,
+		TokenDecimal:           pair.TokenDecimal,
+		BaseTokenDecimal:       pair.BaseTokenDecimal,
+		CurrentTokenAmount:     pair.CurrentTokenAmount,
+		CurrentBaseTokenAmount: pair.CurrentBaseTokenAmount,
+		Liquidity:              pair.Liquidity,
+		TokenPrice:             pair.TokenPrice,
+		BaseTokenPrice:         pair.BaseTokenPrice,
+		Fdv:                    pair.Fdv,
+		MktCap:                 pair.MktCap,
+		PumpPoint:              pair.PumpPoint,
+		PumpStatus:             pair.PumpStatus,
+		PumpPairAddr:           pair.PumpPairAddr,
+		BlockNum:               pair.BlockNum,
+		BlockTime:              pair.BlockTime.Unix(),
+		LatestTradeTime:        pair.LatestTradeTime.Unix(),
+		IsRug:                  pair.IsRug == 1,
+		RugReason:              pair.RugReason,
+		RugAt:                  pair.RugAt,
+		RugTxHash:              pair.RugTxHash,
+	}
+}
@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"fmt"
+
+	"richcode.cc/dex/consumer/consumer"
+)
+
+// Filter 订阅条件，各条件之间为“或”的关系：
+//   - trade：交易对、代币或发起钱包任一命中
+//   - pair：交易对或代币命中
+//   - new_pair：订阅了新交易对，或代币命中
+type Filter struct {
+	pairs    map[string]struct{}
+	tokens   map[string]struct{}
+	wallets  map[string]struct{}
+	newPairs bool
+}
+
+// NewFilter 根据订阅请求生成订阅条件，maxKeys 限制地址总数，0 表示不限制
+func NewFilter(in *consumer.SubscribeRequest, maxKeys int) (*Filter, error) {
+	count := len(in.PairAddresses) + len(in.TokenAddresses) + len(in.Wallets)
+	if maxKeys > 0 && count > maxKeys {
+		return nil, fmt.Errorf("too many addresses: %d, max: %d", count, maxKeys)
+	}
+	return &Filter{
+		pairs:    toSet(in.PairAddresses),
+		tokens:   toSet(in.TokenAddresses),
+		wallets:  toSet(in.Wallets),
+		newPairs: in.NewPairs,
+	}, nil
+}
+
+// Empty 没有任何订阅条件
+func (f *Filter) Empty() bool {
+	return f == nil || len(f.pairs) == 0 && len(f.tokens) == 0 && len(f.wallets) == 0 && !f.newPairs
+}
+
+func (f *Filter) Match(event *consumer.Event) bool {
+	if f.Empty() {
+		return false
+	}
+	if _, ok := f.tokens[event.TokenAddress]; ok && event.TokenAddress != "" {
+		return true
+	}
+
+	switch event.Type {
+	case EventTrade:
+		if event.Trade == nil {
+			return false
+		}
+		if _, ok := f.pairs[event.Trade.PairAddr]; ok {
+			return true
+		}
+		_, ok := f.wallets[event.Trade.Maker]
+		return ok
+	case EventPair:
+		if event.Pair == nil {
+			return false
+		}
+		_, ok := f.pairs[event.Pair.Address]
+		return ok
+	case EventNewPair:
+		return f.newPairs
+	}
+	return false
+}
+
+func toSet(list []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(list))
+	for _, item := range list {
+		if item != "" {
+			set[item] = struct{}{}
+		}
+	}
+	return set
+}
@@ -0,0 +1,153 @@
+package stream
+
+import (
+	"errors"
+	"sync"
+
+	"richcode.cc/dex/consumer/consumer"
+)
+
+// ErrSlowConsumer 订阅者的待发送队列已满，说明客户端消费速度跟不上，直接断开，避免拖慢区块处理
+var ErrSlowConsumer = errors.New("subscriber is too slow")
+
+// ErrUnsubscribed 订阅者主动取消订阅
+var ErrUnsubscribed = errors.New("unsubscribed")
+
+// Hub 实时推送的订阅中心
+// 区块落库后由区块服务调用 Publish 发布事件，WebSocket 连接和 gRPC Subscribe 各自持有一个 Subscriber；
+// Publish 不会阻塞，订阅者队列写满时会被断开
+type Hub struct {
+	lock        sync.RWMutex
+	subscribers map[*Subscriber]struct{}
+	bufferSize  int
+}
+
+func NewHub(bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = 256
+	}
+	return &Hub{
+		subscribers: make(map[*Subscriber]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe 注册一个订阅者，使用完毕后需要调用 Unsubscribe
+func (h *Hub) Subscribe(filter *Filter) *Subscriber {
+	sub := &Subscriber{
+		events: make(chan *consumer.Event, h.bufferSize),
+		done:   make(chan struct{}),
+		filter: filter,
+	}
+	h.lock.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.lock.Unlock()
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscriber) {
+	h.remove(sub, ErrUnsubscribed)
+}
+
+// Count 当前订阅者数量
+func (h *Hub) Count() int {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return len(h.subscribers)
+}
+
+// Publish 按订阅条件把事件分发给订阅者，同一订阅者收到的事件顺序与入参顺序一致
+func (h *Hub) Publish(events []*consumer.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	var slow []*Subscriber
+	h.lock.RLock()
+	for sub := range h.subscribers {
+		for _, event := range events {
+			if !sub.Match(event) {
+				continue
+			}
+			if !sub.offer(event) {
+				slow = append(slow, sub)
+				break
+			}
+		}
+	}
+	h.lock.RUnlock()
+
+	for _, sub := range slow {
+		h.remove(sub, ErrSlowConsumer)
+	}
+}
+
+func (h *Hub) remove(sub *Subscriber, err error) {
+	h.lock.Lock()
+	delete(h.subscribers, sub)
+	h.lock.Unlock()
+	sub.close(err)
+}
+
+// Subscriber 单个订阅者，事件从 Events 读取，Done 关闭后不再有新事件
+type Subscriber struct {
+	events chan *consumer.Event
+	done   chan struct{}
+	once   sync.Once
+	err    error
+
+	lock   sync.RWMutex
+	filter *Filter
+}
+
+func (s *Subscriber) Events() <-chan *consumer.Event {
+	return s.events
+}
+
+func (s *Subscriber) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err 订阅结束的原因，Done 关闭之前返回 nil
+func (s *Subscriber) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// SetFilter 替换订阅条件，WebSocket 客户端重新发送订阅消息时使用
+func (s *Subscriber) SetFilter(filter *Filter) {
+	s.lock.Lock()
+	s.filter = filter
+	s.lock.Unlock()
+}
+
+func (s *Subscriber) Match(event *consumer.Event) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.filter.Match(event)
+}
+
+func (s *Subscriber) offer(event *consumer.Event) bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+	}
+	select {
+	case s.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Subscriber) close(err error) {
+	s.once.Do(func() {
+		s.err = err
+		close(s.done)
+	})
+}
@@ -11,12 +11,19 @@ import (
 	"github.com/blocto/solana-go-sdk/client"
 	solclient "github.com/blocto/solana-go-sdk/client"
 	"github.com/blocto/solana-go-sdk/rpc"
+	ag_rpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/zeromicro/go-zero/core/collection"
 	"github.com/zeromicro/go-zero/core/logx"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"richcode.cc/dex/consumer/internal/alert"
 	"richcode.cc/dex/consumer/internal/config"
+	"richcode.cc/dex/consumer/internal/stream"
 	"richcode.cc/dex/model/solmodel"
+	"richcode.cc/dex/pkg/constants"
+	"richcode.cc/dex/pkg/mq"
 )
 
 // ServiceContext - 服务上下文结构体
@@ -32,6 +39,8 @@ type ServiceContext struct {
 	solClient      *solclient.Client
 	solClients     []*solclient.Client
 
+	MintRpcClient *ag_rpc.Client /* 读取 Token-2022 mint 扩展的 RPC 客户端，区块解析时共用，避免每次缓存未命中都新建连接 */
+
 	/* 各种模型 */
 	BlockModel           solmodel.BlockModel           /* 区块模型 */
 	PairModel            solmodel.PairModel            /* 交易对模型 or 池子模型？？ */
@@ -39,6 +48,18 @@ type ServiceContext struct {
 	TradeModel           solmodel.TradeModel           /* 交易模型 */
 	PumpAmmInfoModel     solmodel.PumpAmmInfoModel     /* Pump AMM 信息模型 */
 	SolTokenAccountModel solmodel.SolTokenAccountModel /* SOL 账户模型 */
+	WalletLabelModel     solmodel.WalletLabelModel     /* 钱包标签模型 */
+	PoolFeeModel         solmodel.PoolFeeModel         /* 池子手续费按日累计模型 */
+	PoolFeeAccrualModel  solmodel.PoolFeeAccrualModel  /* 已累加手续费的成交，手续费累加去重 */
+	CreatorFeeModel      solmodel.CreatorFeeModel      /* 创建者手续费累计模型 */
+	CreatorFeeClaimModel solmodel.CreatorFeeClaimModel /* 创建者手续费领取记录模型 */
+
+	WalletLabelCache   *collection.Cache /* 钱包地址 -> 标签列表的本地缓存，区块解析时给成交打标使用 */
+	MintExtensionCache *collection.Cache /* Token-2022 mint 地址 -> 转账手续费/计息扩展的本地缓存，区块解析时计算净数量使用 */
+
+	Stream    *stream.Hub   /* 实时推送订阅中心，区块落库后发布事件，WebSocket 和 gRPC Subscribe 订阅 */
+	Publisher *mq.Publisher /* 消息队列发布器，未开启时为 nil */
+	Alert     *alert.Engine /* 告警引擎，区块落库后检查告警规则，未开启时为 nil */
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -84,19 +105,71 @@ func NewSolServiceContext(c config.Config) *ServiceContext {
 		panic(fmt.Sprintf("failed to connect database: %v", err))
 	}
 
+	mintRpcClient := ag_rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(config.FindChainRpcByChainId(constants.SolChainIdInt), &jsonrpc.RPCClientOpts{
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}))
+
 	// Initialize BlockModel
 	blockModel := solmodel.NewBlockModel(db)
 
+	cacheSeconds := c.WalletLabel.CacheSeconds
+	if cacheSeconds <= 0 {
+		cacheSeconds = 300
+	}
+	walletLabelCache, err := collection.NewCache(time.Duration(cacheSeconds)*time.Second, collection.WithLimit(100000), collection.WithName("wallet_label"))
+	if err != nil {
+		panic(fmt.Sprintf("failed to create wallet label cache: %v", err))
+	}
+
+	// 手续费调整要等两个 epoch 才生效，利率调整较少，10 分钟过期足够；区块解析发现扩展变更时也会主动删除
+	mintExtensionCache, err := collection.NewCache(10*time.Minute, collection.WithLimit(100000), collection.WithName("mint_extension"))
+	if err != nil {
+		panic(fmt.Sprintf("failed to create mint extension cache: %v", err))
+	}
+
+	var publisher *mq.Publisher
+	if c.Mq.Enable {
+		var transport mq.Transport
+		switch c.Mq.Transport {
+		case "memory":
+			transport = mq.NewMemoryTransport()
+		default:
+			transport = mq.NewKafkaTransport(c.Mq.Kafka)
+		}
+		publisher = mq.NewPublisher(transport, c.Mq.QueueSize, time.Duration(c.Mq.FlushTimeout)*time.Second)
+	}
+
+	var alertEngine *alert.Engine
+	if c.Alert.Enable {
+		alertEngine, err = alert.NewEngine(c.Alert)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create alert engine: %v", err))
+		}
+	}
+
 	fmt.Println("solClients: ", c.Sol.NodeUrl)
 	return &ServiceContext{
 		Config:               c,
 		solClients:           solClients,
+		MintRpcClient:        mintRpcClient,
 		BlockModel:           blockModel,
 		PairModel:            solmodel.NewPairModel(db),
 		TokenModel:           solmodel.NewTokenModel(db),
 		TradeModel:           solmodel.NewTradeModel(db),
 		PumpAmmInfoModel:     solmodel.NewPumpAmmInfoModel(db),
 		SolTokenAccountModel: solmodel.NewSolTokenAccountModel(db),
+		WalletLabelModel:     solmodel.NewWalletLabelModel(db),
+		PoolFeeModel:         solmodel.NewPoolFeeModel(db),
+		PoolFeeAccrualModel:  solmodel.NewPoolFeeAccrualModel(db),
+		CreatorFeeModel:      solmodel.NewCreatorFeeModel(db),
+		CreatorFeeClaimModel: solmodel.NewCreatorFeeClaimModel(db),
+		WalletLabelCache:     walletLabelCache,
+		MintExtensionCache:   mintExtensionCache,
+		Stream:               stream.NewHub(c.Push.BufferSize),
+		Publisher:            publisher,
+		Alert:                alertEngine,
 	}
 }
 
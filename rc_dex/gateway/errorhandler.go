@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/core/logc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorResponse 网关统一的错误响应，code 为 gRPC 状态码，HTTP 状态码按 code 映射
+type ErrorResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// errorHandler 把上游 gRPC 错误和网关自身的请求解析错误统一转换为 ErrorResponse
+func errorHandler(ctx context.Context, err error) (int, any) {
+	st, ok := status.FromError(err)
+	if !ok {
+		// 非 gRPC 错误只会来自网关解析请求参数
+		return http.StatusBadRequest, ErrorResponse{
+			Code: int(codes.InvalidArgument),
+			Msg:  err.Error(),
+		}
+	}
+
+	msg := st.Message()
+	switch st.Code() {
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		// 内部错误可能带有数据库等细节，只记录日志不返回给调用方
+		logc.Errorf(ctx, "upstream error: %v", err)
+		msg = "internal error"
+	}
+	return httpStatusFromCode(st.Code()), ErrorResponse{
+		Code: int(st.Code()),
+		Msg:  msg,
+	}
+}
+
+// httpStatusFromCode gRPC 状态码到 HTTP 状态码的映射，参考 google/rpc/code.proto
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Canceled:
+		return http.StatusRequestTimeout
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
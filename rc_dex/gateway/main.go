@@ -5,6 +5,7 @@ import (
 
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/gateway"
+	"github.com/zeromicro/go-zero/rest/httpx"
 )
 
 var configFile = flag.String("f", "etc/gateway.yaml", "config file")
@@ -14,7 +15,8 @@ func main() {
 
 	var c gateway.GatewayConf
 	conf.MustLoad(*configFile, &c)
-	gw := gateway.MustNewServer(c)
+	httpx.SetErrorHandlerCtx(errorHandler)
+	gw := gateway.MustNewServer(c, gateway.WithMiddleware(queryToBody))
 	defer gw.Stop()
 	gw.Start()
 }
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// queryToBody 把 GET 请求的查询参数转换为 JSON 请求体
+// 网关默认把查询参数按字符串数组传给上游，无法映射到 proto 的标量字段（如 limit、cursor、page_size），
+// 这里改为单值按字符串传递（jsonpb 支持带引号的数字），true / false 按布尔值传递，多值保持数组
+func queryToBody(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.RawQuery == "" {
+			next(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		params := make(map[string]any, len(query))
+		for name, values := range query {
+			switch {
+			case len(values) == 0 || values[0] == "":
+				continue
+			case len(values) > 1:
+				params[name] = values
+			case values[0] == "true":
+				params[name] = true
+			case values[0] == "false":
+				params[name] = false
+			default:
+				params[name] = values[0]
+			}
+		}
+		body, err := json.Marshal(params)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		req := r.Clone(r.Context())
+		req.URL.RawQuery = ""
+		req.Form = nil
+		req.Body = http.NoBody
+		if len(params) > 0 {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			req.ContentLength = int64(len(body))
+		}
+		next(w, req)
+	}
+}
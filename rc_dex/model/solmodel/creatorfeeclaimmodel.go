@@ -0,0 +1,89 @@
+package solmodel
+
+import (
+	"context"
+
+	. "github.com/klen-ygs/gorm-zero/gormc/sql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// avoid unused err
+var _ = InitField
+var _ CreatorFeeClaimModel = (*customCreatorFeeClaimModel)(nil)
+
+type (
+	// CreatorFeeClaimModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customCreatorFeeClaimModel.
+	CreatorFeeClaimModel interface {
+		creatorFeeClaimModel
+		customCreatorFeeClaimLogicModel
+	}
+
+	customCreatorFeeClaimLogicModel interface {
+		WithSession(tx *gorm.DB) CreatorFeeClaimModel
+		InsertIgnore(ctx context.Context, data *CreatorFeeClaim) (bool, error)
+		Claim(ctx context.Context, data *CreatorFeeClaim) (bool, error)
+		FindByCreator(ctx context.Context, chainId int64, creator string, limit int) ([]*CreatorFeeClaim, error)
+	}
+
+	customCreatorFeeClaimModel struct {
+		*defaultCreatorFeeClaimModel
+	}
+)
+
+func (c customCreatorFeeClaimModel) WithSession(tx *gorm.DB) CreatorFeeClaimModel {
+	newModel := *c.defaultCreatorFeeClaimModel
+	c.defaultCreatorFeeClaimModel = &newModel
+	c.conn = tx
+	return c
+}
+
+// NewCreatorFeeClaimModel returns a model for the database table.
+func NewCreatorFeeClaimModel(conn *gorm.DB) CreatorFeeClaimModel {
+	return &customCreatorFeeClaimModel{
+		defaultCreatorFeeClaimModel: newCreatorFeeClaimModel(conn),
+	}
+}
+
+func (m *defaultCreatorFeeClaimModel) customCacheKeys(data *CreatorFeeClaim) []string {
+	if data == nil {
+		return []string{}
+	}
+	return []string{}
+}
+
+// InsertIgnore 按 (tx_hash, creator) 唯一键写入领取记录，已存在时不写入并返回 false，区块重复处理时据此避免重复累加
+func (m *defaultCreatorFeeClaimModel) InsertIgnore(ctx context.Context, data *CreatorFeeClaim) (bool, error) {
+	result := m.conn.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(data)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Claim 在一个事务内写入领取记录并累加到创建者的已领取金额，已记录过的领取跳过并返回 false；
+// 两次写入同时成功或同时回滚，累加失败或进程中断后重复处理区块时仍会补上这次领取
+func (m *defaultCreatorFeeClaimModel) Claim(ctx context.Context, data *CreatorFeeClaim) (bool, error) {
+	var inserted bool
+	err := m.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		inserted, err = NewCreatorFeeClaimModel(tx).InsertIgnore(ctx, data)
+		if err != nil || !inserted {
+			return err
+		}
+		return NewCreatorFeeModel(tx).AddClaim(ctx, data)
+	})
+	if err != nil {
+		return false, err
+	}
+	return inserted, nil
+}
+
+// FindByCreator 按区块时间倒序查询创建者最近的领取记录
+func (m *defaultCreatorFeeClaimModel) FindByCreator(ctx context.Context, chainId int64, creator string, limit int) ([]*CreatorFeeClaim, error) {
+	var resp []*CreatorFeeClaim
+	err := m.conn.WithContext(ctx).Model(&CreatorFeeClaim{}).
+		Where("`chain_id` = ? and `creator` = ?", chainId, creator).
+		Order("`block_time` desc, `id` desc").
+		Limit(limit).
+		Find(&resp).Error
+	return resp, err
+}
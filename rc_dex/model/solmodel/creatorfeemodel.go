@@ -0,0 +1,89 @@
+package solmodel
+
+import (
+	"context"
+
+	. "github.com/klen-ygs/gorm-zero/gormc/sql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// avoid unused err
+var _ = InitField
+var _ CreatorFeeModel = (*customCreatorFeeModel)(nil)
+
+type (
+	// CreatorFeeModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customCreatorFeeModel.
+	CreatorFeeModel interface {
+		creatorFeeModel
+		customCreatorFeeLogicModel
+	}
+
+	customCreatorFeeLogicModel interface {
+		WithSession(tx *gorm.DB) CreatorFeeModel
+		BatchAccrue(ctx context.Context, fees []*CreatorFee) error
+		AddClaim(ctx context.Context, claim *CreatorFeeClaim) error
+	}
+
+	customCreatorFeeModel struct {
+		*defaultCreatorFeeModel
+	}
+)
+
+func (c customCreatorFeeModel) WithSession(tx *gorm.DB) CreatorFeeModel {
+	newModel := *c.defaultCreatorFeeModel
+	c.defaultCreatorFeeModel = &newModel
+	c.conn = tx
+	return c
+}
+
+// NewCreatorFeeModel returns a model for the database table.
+func NewCreatorFeeModel(conn *gorm.DB) CreatorFeeModel {
+	return &customCreatorFeeModel{
+		defaultCreatorFeeModel: newCreatorFeeModel(conn),
+	}
+}
+
+func (m *defaultCreatorFeeModel) customCacheKeys(data *CreatorFee) []string {
+	if data == nil {
+		return []string{}
+	}
+	return []string{}
+}
+
+// BatchAccrue 按 (chain_id, creator) 唯一键累加成交产生的创建者手续费
+func (m *defaultCreatorFeeModel) BatchAccrue(ctx context.Context, fees []*CreatorFee) error {
+	if len(fees) == 0 {
+		return nil
+	}
+	return m.conn.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"accrued_fee":     gorm.Expr("`accrued_fee` + values(`accrued_fee`)"),
+			"accrued_fee_usd": gorm.Expr("`accrued_fee_usd` + values(`accrued_fee_usd`)"),
+			"updated_at":      gorm.Expr("values(`updated_at`)"),
+		}),
+	}).CreateInBatches(fees, 500).Error
+}
+
+// AddClaim 把一次领取累加到创建者的已领取金额，调用方需保证同一笔领取只调用一次
+func (m *defaultCreatorFeeModel) AddClaim(ctx context.Context, claim *CreatorFeeClaim) error {
+	return m.conn.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"claimed_fee":     gorm.Expr("`claimed_fee` + values(`claimed_fee`)"),
+			"claimed_fee_usd": gorm.Expr("`claimed_fee_usd` + values(`claimed_fee_usd`)"),
+			"claim_count":     gorm.Expr("`claim_count` + 1"),
+			"last_claim_at":   gorm.Expr("greatest(`last_claim_at`, values(`last_claim_at`))"),
+			"updated_at":      gorm.Expr("values(`updated_at`)"),
+		}),
+	}).Create(&CreatorFee{
+		ChainId:       claim.ChainId,
+		Creator:       claim.Creator,
+		ClaimedFee:    claim.Amount,
+		ClaimedFeeUsd: claim.AmountUsd,
+		ClaimCount:    1,
+		LastClaimAt:   claim.BlockTime.Unix(),
+		CreatedAt:     claim.CreatedAt,
+		UpdatedAt:     claim.UpdatedAt,
+	}).Error
+}
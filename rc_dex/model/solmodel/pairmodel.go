@@ -1,6 +1,9 @@
 package solmodel
 
 import (
+	"context"
+	"time"
+
 	. "github.com/klen-ygs/gorm-zero/gormc/sql"
 	"gorm.io/gorm"
 )
@@ -19,6 +22,11 @@ type (
 
 	customPairLogicModel interface {
 		WithSession(tx *gorm.DB) PairModel
+		FindByChainIdTokenAddress(ctx context.Context, chainId int64, tokenAddress string) ([]*Pair, error)
+		FindLaunchUncheckedPairs(ctx context.Context, chainId int64, names []string, fromSlot, toSlot int64, limit int) ([]*Pair, error)
+		FindPairList(ctx context.Context, chainId int64, filter *PairListFilter) ([]*PairWithVolume, error)
+		FindActivePairs(ctx context.Context, chainId int64, name string, since time.Time, limit int) ([]*Pair, error)
+		CorrectReserves(ctx context.Context, pair *Pair, maxSlot int64) (bool, error)
 	}
 
 	customPairModel struct {
@@ -46,3 +54,161 @@ func (m *defaultPairModel) customCacheKeys(data *Pair) []string {
 	}
 	return []string{}
 }
+
+// FindLaunchUncheckedPairs 查询在 [fromSlot, toSlot] 内创建、且对应代币尚未做过开盘分析的交易对，按创建 slot 升序返回
+func (m *defaultPairModel) FindLaunchUncheckedPairs(ctx context.Context, chainId int64, names []string, fromSlot, toSlot int64, limit int) ([]*Pair, error) {
+	var resp []*Pair
+	err := m.conn.WithContext(ctx).Model(&Pair{}).
+		Joins("join `token` t on t.`chain_id` = `pair`.`chain_id` and t.`address` = `pair`.`token_address`").
+		Where("`pair`.`chain_id` = ? and `pair`.`name` in ? and `pair`.`block_num` between ? and ? and t.`launch_check_at` = 0", chainId, names, fromSlot, toSlot).
+		Order("`pair`.`block_num` asc").
+		Limit(limit).
+		Find(&resp).Error
+	return resp, err
+}
+
+// FindByChainIdTokenAddress 查询代币的全部交易对
+func (m *defaultPairModel) FindByChainIdTokenAddress(ctx context.Context, chainId int64, tokenAddress string) ([]*Pair, error) {
+	var resp []*Pair
+	err := m.conn.WithContext(ctx).Model(&Pair{}).
+		Where("`chain_id` = ? and `token_address` = ?", chainId, tokenAddress).
+		Find(&resp).Error
+	return resp, err
+}
+
+// FindActivePairs 查询 since 之后有成交的交易对，按最新成交时间倒序
+func (m *defaultPairModel) FindActivePairs(ctx context.Context, chainId int64, name string, since time.Time, limit int) ([]*Pair, error) {
+	var resp []*Pair
+	err := m.conn.WithContext(ctx).Model(&Pair{}).
+		Where("`chain_id` = ? and `name` = ? and `latest_trade_time` >= ?", chainId, name, since).
+		Order("`latest_trade_time` desc").
+		Limit(limit).
+		Find(&resp).Error
+	return resp, err
+}
+
+// CorrectReserves 用链上读取的储备覆盖交易对的当前储备和流动性；交易对在 maxSlot 之后已被区块解析更新时不覆盖，返回 false
+func (m *defaultPairModel) CorrectReserves(ctx context.Context, pair *Pair, maxSlot int64) (bool, error) {
+	result := m.conn.WithContext(ctx).Model(&Pair{}).
+		Where("`id` = ? and `slot` <= ?", pair.Id, maxSlot).
+		Updates(map[string]any{
+			"current_base_token_amount": pair.CurrentBaseTokenAmount,
+			"current_token_amount":      pair.CurrentTokenAmount,
+			"liquidity":                 pair.Liquidity,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// 交易对列表排序字段
+const (
+	PairOrderLiquidity = "liquidity"
+	PairOrderVolume    = "volume"
+	PairOrderOrganic   = "organic_volume"
+	PairOrderCreated   = "created"
+)
+
+// PairListFilter 交易对列表的筛选、排序与分页条件；成交额统计 VolumeSince 之后的成交
+type PairListFilter struct {
+	Name         string // DEX 名称，空表示全部
+	PumpStatus   *int64 // 为空表示全部
+	MinLiquidity float64
+	MinVolume    float64
+	ExcludeRug   bool // 排除已判定跑路的交易对
+	VolumeSince  time.Time
+	OrderBy      string // PairOrderLiquidity / PairOrderVolume / PairOrderOrganic / PairOrderCreated
+	Asc          bool
+	Offset       int
+	Limit        int
+}
+
+// PairWithVolume 交易对及其 VolumeSince 之后的成交额和自然成交额（USD）
+type PairWithVolume struct {
+	Pair          `gorm:"embedded"`
+	Volume        float64 `gorm:"column:volume"`
+	OrganicVolume float64 `gorm:"column:organic_volume"`
+}
+
+// FindPairList 按条件分页查询交易对，成交额由 trade 表按 block_time 聚合：
+// 按成交额筛选或排序时需要全部交易对的成交额，聚合窗口内的全部成交（chain_id_block_time_pair_addr_index）；
+// 否则先分页查交易对，只聚合本页交易对的成交（pair_addr_block_time_index）
+func (m *defaultPairModel) FindPairList(ctx context.Context, chainId int64, filter *PairListFilter) ([]*PairWithVolume, error) {
+	byVolume := filter.MinVolume > 0 || filter.OrderBy == PairOrderVolume || filter.OrderBy == PairOrderOrganic
+	db := m.conn.WithContext(ctx).Table("`pair`").Where("`pair`.`chain_id` = ?", chainId)
+	if byVolume {
+		db = db.Select("`pair`.*, ifnull(v.`volume`, 0) as volume, ifnull(v.`organic_volume`, 0) as organic_volume").
+			Joins("left join (select `pair_addr`, sum(`total_usd`) as volume, "+organicVolumeExpr+" as organic_volume from `trade` where `chain_id` = ? and `block_time` >= ? group by `pair_addr`) v on v.`pair_addr` = `pair`.`address`",
+				chainId, filter.VolumeSince)
+	} else {
+		db = db.Select("`pair`.*")
+	}
+	if filter.Name != "" {
+		db = db.Where("`pair`.`name` = ?", filter.Name)
+	}
+	if filter.PumpStatus != nil {
+		db = db.Where("`pair`.`pump_status` = ?", *filter.PumpStatus)
+	}
+	if filter.MinLiquidity > 0 {
+		db = db.Where("`pair`.`liquidity` >= ?", filter.MinLiquidity)
+	}
+	if filter.MinVolume > 0 {
+		db = db.Where("ifnull(v.`volume`, 0) >= ?", filter.MinVolume)
+	}
+	if filter.ExcludeRug {
+		db = db.Where("`pair`.`is_rug` = 0")
+	}
+
+	var column string
+	switch filter.OrderBy {
+	case PairOrderVolume:
+		column = "volume"
+	case PairOrderOrganic:
+		column = "organic_volume"
+	case PairOrderCreated:
+		column = "`pair`.`block_num`"
+	default:
+		column = "`pair`.`liquidity`"
+	}
+	direction := "desc"
+	if filter.Asc {
+		direction = "asc"
+	}
+
+	var resp []*PairWithVolume
+	err := db.Order(column + " " + direction + ", `pair`.`id` " + direction).
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Scan(&resp).Error
+	if err != nil || byVolume || len(resp) == 0 {
+		return resp, err
+	}
+	return resp, m.fillVolumes(ctx, chainId, filter.VolumeSince, resp)
+}
+
+// fillVolumes 聚合本页交易对 since 之后的成交额和自然成交额
+func (m *defaultPairModel) fillVolumes(ctx context.Context, chainId int64, since time.Time, pairs []*PairWithVolume) error {
+	addresses := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		addresses = append(addresses, pair.Address)
+	}
+	var volumes []struct {
+		PairAddr   string `gorm:"column:pair_addr"`
+		PairVolume `gorm:"embedded"`
+	}
+	err := m.conn.WithContext(ctx).Table("`trade`").
+		Select("`pair_addr`, sum(`total_usd`) as volume, "+organicVolumeExpr+" as organic_volume").
+		Where("`chain_id` = ? and `pair_addr` in ? and `block_time` >= ?", chainId, addresses, since).
+		Group("`pair_addr`").
+		Scan(&volumes).Error
+	if err != nil {
+		return err
+	}
+	byAddress := make(map[string]PairVolume, len(volumes))
+	for _, v := range volumes {
+		byAddress[v.PairAddr] = v.PairVolume
+	}
+	for _, pair := range pairs {
+		v := byAddress[pair.Address]
+		pair.Volume, pair.OrganicVolume = v.Volume, v.OrganicVolume
+	}
+	return nil
+}
@@ -74,6 +74,10 @@ type (
 		Slot                         int64        `gorm:"column:slot"`
 		Liquidity                    float64      `gorm:"column:liquidity"`         // 池子流动性
 		LaunchPadStatus              int64        `gorm:"column:launch_pad_status"` // LaunchPad 状态：0-非 LaunchPad，1-新建，2-进行中，3-已完成
+		IsRug                        int64        `gorm:"column:is_rug"`            // 是否已跑路（撤池子/开发者砸盘/流动性抽干）
+		RugReason                    string       `gorm:"column:rug_reason"`        // 跑路原因：lp_removed/dev_dump/liquidity_drained
+		RugAt                        int64        `gorm:"column:rug_at"`            // 跑路区块时间戳
+		RugTxHash                    string       `gorm:"column:rug_tx_hash"`       // 判定跑路的交易哈希
 	}
 )
 
@@ -0,0 +1,164 @@
+package solmodel
+
+import (
+	"context"
+	"time"
+
+	. "github.com/klen-ygs/gorm-zero/gormc/sql"
+	"gorm.io/gorm"
+)
+
+// avoid unused err
+var _ = InitField
+var _ PoolFeeAccrualModel = (*customPoolFeeAccrualModel)(nil)
+
+type (
+	// PoolFeeAccrualModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customPoolFeeAccrualModel.
+	PoolFeeAccrualModel interface {
+		poolFeeAccrualModel
+		customPoolFeeAccrualLogicModel
+	}
+
+	customPoolFeeAccrualLogicModel interface {
+		WithSession(tx *gorm.DB) PoolFeeAccrualModel
+		Accrue(ctx context.Context, fees []*TradeFee) (int, error)
+	}
+
+	customPoolFeeAccrualModel struct {
+		*defaultPoolFeeAccrualModel
+	}
+)
+
+// TradeFee 一笔成交的手续费：Accrual 为去重键，PoolFee 为该成交对池子当天的累计（TradeCount 为 1），
+// CreatorFee 为创建者手续费，没有时为 nil
+type TradeFee struct {
+	Accrual    *PoolFeeAccrual
+	PoolFee    *PoolFee
+	CreatorFee *CreatorFee
+}
+
+func (c customPoolFeeAccrualModel) WithSession(tx *gorm.DB) PoolFeeAccrualModel {
+	newModel := *c.defaultPoolFeeAccrualModel
+	c.defaultPoolFeeAccrualModel = &newModel
+	c.conn = tx
+	return c
+}
+
+// NewPoolFeeAccrualModel returns a model for the database table.
+func NewPoolFeeAccrualModel(conn *gorm.DB) PoolFeeAccrualModel {
+	return &customPoolFeeAccrualModel{
+		defaultPoolFeeAccrualModel: newPoolFeeAccrualModel(conn),
+	}
+}
+
+func (m *defaultPoolFeeAccrualModel) customCacheKeys(data *PoolFeeAccrual) []string {
+	if data == nil {
+		return []string{}
+	}
+	return []string{}
+}
+
+// Accrue 在一个事务内记录成交的去重键并累加池子和创建者手续费，已按 (chain_id, tx_hash, instruction_index) 记录过的成交跳过，
+// 区块重复处理时不会重复累加；并发处理同一区块时后提交的事务因唯一键冲突整体回滚。返回实际累加的成交数
+func (m *defaultPoolFeeAccrualModel) Accrue(ctx context.Context, fees []*TradeFee) (int, error) {
+	if len(fees) == 0 {
+		return 0, nil
+	}
+	var accrued int
+	err := m.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		type key struct {
+			chainId          int64
+			txHash           string
+			instructionIndex int64
+		}
+		hashes := make([]string, 0, len(fees))
+		for _, fee := range fees {
+			hashes = append(hashes, fee.Accrual.TxHash)
+		}
+		var existing []*PoolFeeAccrual
+		if err := tx.Model(&PoolFeeAccrual{}).
+			Select("`chain_id`, `tx_hash`, `instruction_index`").
+			Where("`chain_id` = ? and `tx_hash` in ?", fees[0].Accrual.ChainId, hashes).
+			Find(&existing).Error; err != nil {
+			return err
+		}
+		seen := make(map[key]bool, len(existing)+len(fees))
+		for _, item := range existing {
+			seen[key{item.ChainId, item.TxHash, item.InstructionIndex}] = true
+		}
+
+		var accruals []*PoolFeeAccrual
+		poolFees := make(map[string]*PoolFee)
+		creatorFees := make(map[string]*CreatorFee)
+		for _, fee := range fees {
+			k := key{fee.Accrual.ChainId, fee.Accrual.TxHash, fee.Accrual.InstructionIndex}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			accruals = append(accruals, fee.Accrual)
+			mergePoolFee(poolFees, fee.PoolFee)
+			if fee.CreatorFee != nil {
+				mergeCreatorFee(creatorFees, fee.CreatorFee)
+			}
+		}
+		if len(accruals) == 0 {
+			return nil
+		}
+
+		if err := tx.CreateInBatches(accruals, 500).Error; err != nil {
+			return err
+		}
+		if err := NewPoolFeeModel(tx).BatchAccrue(ctx, mapValues(poolFees)); err != nil {
+			return err
+		}
+		if err := NewCreatorFeeModel(tx).BatchAccrue(ctx, mapValues(creatorFees)); err != nil {
+			return err
+		}
+		accrued = len(accruals)
+		return nil
+	})
+	return accrued, err
+}
+
+// mergePoolFee 按池子和日期合并手续费，一个区块内同一池子只写一行
+func mergePoolFee(fees map[string]*PoolFee, fee *PoolFee) {
+	k := fee.PairAddr + "|" + fee.Date.Format(time.DateOnly)
+	merged, ok := fees[k]
+	if !ok {
+		copied := *fee
+		fees[k] = &copied
+		return
+	}
+	if fee.CoinCreator != "" {
+		merged.CoinCreator = fee.CoinCreator
+	}
+	merged.LpFee += fee.LpFee
+	merged.ProtocolFee += fee.ProtocolFee
+	merged.CreatorFee += fee.CreatorFee
+	merged.LpFeeUsd += fee.LpFeeUsd
+	merged.FeeUsd += fee.FeeUsd
+	merged.VolumeUsd += fee.VolumeUsd
+	merged.TradeCount += fee.TradeCount
+}
+
+// mergeCreatorFee 按创建者合并手续费
+func mergeCreatorFee(fees map[string]*CreatorFee, fee *CreatorFee) {
+	merged, ok := fees[fee.Creator]
+	if !ok {
+		copied := *fee
+		fees[fee.Creator] = &copied
+		return
+	}
+	merged.AccruedFee += fee.AccruedFee
+	merged.AccruedFeeUsd += fee.AccruedFeeUsd
+}
+
+func mapValues[T any](m map[string]*T) []*T {
+	list := make([]*T, 0, len(m))
+	for _, item := range m {
+		list = append(list, item)
+	}
+	return list
+}
@@ -0,0 +1,84 @@
+package solmodel
+
+import (
+	"context"
+	"time"
+
+	. "github.com/klen-ygs/gorm-zero/gormc/sql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// avoid unused err
+var _ = InitField
+var _ PoolFeeModel = (*customPoolFeeModel)(nil)
+
+type (
+	// PoolFeeModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customPoolFeeModel.
+	PoolFeeModel interface {
+		poolFeeModel
+		customPoolFeeLogicModel
+	}
+
+	customPoolFeeLogicModel interface {
+		WithSession(tx *gorm.DB) PoolFeeModel
+		BatchAccrue(ctx context.Context, fees []*PoolFee) error
+		FindByPairAddrSince(ctx context.Context, chainId int64, pairAddr string, since time.Time) ([]*PoolFee, error)
+	}
+
+	customPoolFeeModel struct {
+		*defaultPoolFeeModel
+	}
+)
+
+func (c customPoolFeeModel) WithSession(tx *gorm.DB) PoolFeeModel {
+	newModel := *c.defaultPoolFeeModel
+	c.defaultPoolFeeModel = &newModel
+	c.conn = tx
+	return c
+}
+
+// NewPoolFeeModel returns a model for the database table.
+func NewPoolFeeModel(conn *gorm.DB) PoolFeeModel {
+	return &customPoolFeeModel{
+		defaultPoolFeeModel: newPoolFeeModel(conn),
+	}
+}
+
+func (m *defaultPoolFeeModel) customCacheKeys(data *PoolFee) []string {
+	if data == nil {
+		return []string{}
+	}
+	return []string{}
+}
+
+// BatchAccrue 按 (chain_id, pair_addr, date) 唯一键累加手续费、成交额和成交笔数，创建者为空时保留已有值
+func (m *defaultPoolFeeModel) BatchAccrue(ctx context.Context, fees []*PoolFee) error {
+	if len(fees) == 0 {
+		return nil
+	}
+	return m.conn.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"coin_creator": gorm.Expr("if(values(`coin_creator`) = '', `coin_creator`, values(`coin_creator`))"),
+			"lp_fee":       gorm.Expr("`lp_fee` + values(`lp_fee`)"),
+			"protocol_fee": gorm.Expr("`protocol_fee` + values(`protocol_fee`)"),
+			"creator_fee":  gorm.Expr("`creator_fee` + values(`creator_fee`)"),
+			"lp_fee_usd":   gorm.Expr("`lp_fee_usd` + values(`lp_fee_usd`)"),
+			"fee_usd":      gorm.Expr("`fee_usd` + values(`fee_usd`)"),
+			"volume_usd":   gorm.Expr("`volume_usd` + values(`volume_usd`)"),
+			"trade_count":  gorm.Expr("`trade_count` + values(`trade_count`)"),
+			"updated_at":   gorm.Expr("values(`updated_at`)"),
+		}),
+	}).CreateInBatches(fees, 500).Error
+}
+
+// FindByPairAddrSince 按日期倒序查询池子 since 当天及之后的每日手续费
+func (m *defaultPoolFeeModel) FindByPairAddrSince(ctx context.Context, chainId int64, pairAddr string, since time.Time) ([]*PoolFee, error) {
+	var resp []*PoolFee
+	err := m.conn.WithContext(ctx).Model(&PoolFee{}).
+		Where("`chain_id` = ? and `pair_addr` = ? and `date` >= ?", chainId, pairAddr, since.Format(time.DateOnly)).
+		Order("`date` desc").
+		Find(&resp).Error
+	return resp, err
+}
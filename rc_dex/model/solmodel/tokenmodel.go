@@ -1,6 +1,9 @@
 package solmodel
 
 import (
+	"context"
+	"time"
+
 	. "github.com/klen-ygs/gorm-zero/gormc/sql"
 	"gorm.io/gorm"
 )
@@ -19,6 +22,11 @@ type (
 
 	customTokenLogicModel interface {
 		WithSession(tx *gorm.DB) TokenModel
+		UpdateLaunchMetrics(ctx context.Context, data *Token) error
+		FindCheckCaTokens(ctx context.Context, chainId int64, checkBefore, retryBefore int64, createdAfter time.Time, limit int) ([]*Token, error)
+		UpdateSecurity(ctx context.Context, data *Token) error
+		MarkCheckCaFailed(ctx context.Context, chainId int64, address string, checkAt int64) error
+		ResetCheckCa(ctx context.Context, chainId int64, addresses []string) error
 	}
 
 	customTokenModel struct {
@@ -46,3 +54,73 @@ func (m *defaultTokenModel) customCacheKeys(data *Token) []string {
 	}
 	return []string{}
 }
+
+// UpdateLaunchMetrics 只更新开盘分析相关字段，避免覆盖区块解析过程中并发写入的其他代币信息
+func (m *defaultTokenModel) UpdateLaunchMetrics(ctx context.Context, data *Token) error {
+	return m.conn.WithContext(ctx).Model(&Token{}).
+		Where("`chain_id` = ? and `address` = ?", data.ChainId, data.Address).
+		Updates(map[string]interface{}{
+			"sniper_count":         data.SniperCount,
+			"sniper_hold_percent":  data.SniperHoldPercent,
+			"bundler_count":        data.BundlerCount,
+			"bundler_hold_percent": data.BundlerHoldPercent,
+			"is_bundled":           data.IsBundled,
+			"launch_check_at":      data.LaunchCheckAt,
+		}).Error
+}
+
+// FindCheckCaTokens 查询待做合约检测的代币：未检测过且上次尝试早于 retryBefore 的，
+// 以及 createdAfter 之后创建、上次检测早于 checkBefore 的（LP 销毁、权限放弃多发生在上线初期）
+func (m *defaultTokenModel) FindCheckCaTokens(ctx context.Context, chainId int64, checkBefore, retryBefore int64, createdAfter time.Time, limit int) ([]*Token, error) {
+	var resp []*Token
+	err := m.conn.WithContext(ctx).Model(&Token{}).
+		Where("`chain_id` = ? and ((`is_check_ca` = 0 and `check_ca_at` < ?) or (`check_ca_at` < ? and `created_at` >= ?))", chainId, retryBefore, checkBefore, createdAfter).
+		Order("`check_ca_at` asc").
+		Limit(limit).
+		Find(&resp).Error
+	return resp, err
+}
+
+// UpdateSecurity 只更新合约检测相关字段
+func (m *defaultTokenModel) UpdateSecurity(ctx context.Context, data *Token) error {
+	return m.conn.WithContext(ctx).Model(&Token{}).
+		Where("`chain_id` = ? and `address` = ?", data.ChainId, data.Address).
+		Updates(map[string]interface{}{
+			"program":              data.Program,
+			"is_ca_drop_owner":     data.IsCaDropOwner,
+			"is_honey_scam":        data.IsHoneyScam,
+			"is_liquid_lock":       data.IsLiquidLock,
+			"is_can_pause_trade":   data.IsCanPauseTrade,
+			"is_can_change_tax":    data.IsCanChangeTax,
+			"is_have_black_list":   data.IsHaveBlackList,
+			"is_can_all_sell":      data.IsCanAllSell,
+			"is_can_external_call": data.IsCanExternalCall,
+			"is_can_add_token":     data.IsCanAddToken,
+			"is_can_change_token":  data.IsCanChangeToken,
+			"sell_tax":             data.SellTax,
+			"buy_tax":              data.BuyTax,
+			"is_burn_pool":         data.IsBurnPool,
+			"is_check_ca":          data.IsCheckCa,
+			"check_ca_at":          data.CheckCaAt,
+		}).Error
+}
+
+// MarkCheckCaFailed 检测失败时只推迟下次检测时间，不改风险字段，避免失败的代币一直排在队首
+func (m *defaultTokenModel) MarkCheckCaFailed(ctx context.Context, chainId int64, address string, checkAt int64) error {
+	return m.conn.WithContext(ctx).Model(&Token{}).
+		Where("`chain_id` = ? and `address` = ?", chainId, address).
+		Update("check_ca_at", checkAt).Error
+}
+
+// ResetCheckCa 链上出现权限变更时把代币重新标记为待检测，清空检测时间使其立即排入下一轮
+func (m *defaultTokenModel) ResetCheckCa(ctx context.Context, chainId int64, addresses []string) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+	return m.conn.WithContext(ctx).Model(&Token{}).
+		Where("`chain_id` = ? and `address` in ?", chainId, addresses).
+		Updates(map[string]interface{}{
+			"is_check_ca": 0,
+			"check_ca_at": 0,
+		}).Error
+}
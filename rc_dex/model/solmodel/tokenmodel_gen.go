@@ -34,43 +34,49 @@ type (
 	}
 
 	Token struct {
-		Id                int64        `gorm:"column:id"`
-		ChainId           int64        `gorm:"column:chain_id"`             // 链 ID
-		Address           string       `gorm:"column:address"`              // 代币合约地址
-		Program           string       `gorm:"column:program"`              // 所属 Program
-		Name              string       `gorm:"column:name"`                 // 代币名称
-		Symbol            string       `gorm:"column:symbol"`               // 代币符号
-		Decimals          int64        `gorm:"column:decimals"`             // 代币精度
-		TotalSupply       float64      `gorm:"column:total_supply"`         // 总代币发行量
-		Icon              string       `gorm:"column:icon"`                 // 代币图标地址
-		Description       string       `gorm:"column:description"`          // 代币简介
-		HoldCount         int64        `gorm:"column:hold_count"`           // 持币地址数量
-		IsCaDropOwner     int64        `gorm:"column:is_ca_drop_owner"`     // 合约是否放弃权限
-		IsCaVerify        int64        `gorm:"column:is_ca_verify"`         // 合约是否已验证
-		IsHoneyScam       int64        `gorm:"column:is_honey_scam"`        // 是否检测为蜜罐（无法卖出）
-		IsLiquidLock      int64        `gorm:"column:is_liquid_lock"`       // 流动性是否锁定
-		IsCanPauseTrade   int64        `gorm:"column:is_can_pause_trade"`   // 是否可暂停交易
-		IsCanChangeTax    int64        `gorm:"column:is_can_change_tax"`    // 是否可修改税率
-		IsHaveBlackList   int64        `gorm:"column:is_have_black_list"`   // 是否存在黑名单机制
-		IsCanAllSell      int64        `gorm:"column:is_can_all_sell"`      // 是否可一次性卖出全部
-		IsHaveProxy       int64        `gorm:"column:is_have_proxy"`        // 是否存在代理合约
-		IsCanExternalCall int64        `gorm:"column:is_can_external_call"` // 合约是否可外部调用
-		IsCanAddToken     int64        `gorm:"column:is_can_add_token"`     // 合约是否可增发
-		IsCanChangeToken  int64        `gorm:"column:is_can_change_token"`  // 合约是否可修改余额
-		SellTax           float64      `gorm:"column:sell_tax"`             // 卖出税率
-		BuyTax            float64      `gorm:"column:buy_tax"`              // 买入税率
-		TwitterUsername   string       `gorm:"column:twitter_username"`     // Twitter 用户名
-		Website           string       `gorm:"column:website"`              // 官网地址
-		Telegram          string       `gorm:"column:telegram"`             // Telegram 链接
-		CreatedAt         time.Time    `gorm:"column:created_at"`
-		UpdatedAt         time.Time    `gorm:"column:updated_at"`
-		DeletedAt         sql.NullTime `gorm:"column:deleted_at;index"`
-		IsCheckCa         int64        `gorm:"column:is_check_ca"`  // 是否完成合约检测
-		CheckCaAt         int64        `gorm:"column:check_ca_at"`  // 合约检测时间戳
-		IsBurnPool        int64        `gorm:"column:is_burn_pool"` // 是否进入燃烧池
-		IsTopTen          int64        `gorm:"column:is_top_ten"`   // 是否位列市值前十
-		AuditSource       string       `gorm:"column:audit_source"` // 审计来源
-		Slot              int64        `gorm:"column:slot"`
+		Id                 int64        `gorm:"column:id"`
+		ChainId            int64        `gorm:"column:chain_id"`             // 链 ID
+		Address            string       `gorm:"column:address"`              // 代币合约地址
+		Program            string       `gorm:"column:program"`              // 所属 Program
+		Name               string       `gorm:"column:name"`                 // 代币名称
+		Symbol             string       `gorm:"column:symbol"`               // 代币符号
+		Decimals           int64        `gorm:"column:decimals"`             // 代币精度
+		TotalSupply        float64      `gorm:"column:total_supply"`         // 总代币发行量
+		Icon               string       `gorm:"column:icon"`                 // 代币图标地址
+		Description        string       `gorm:"column:description"`          // 代币简介
+		HoldCount          int64        `gorm:"column:hold_count"`           // 持币地址数量
+		IsCaDropOwner      int64        `gorm:"column:is_ca_drop_owner"`     // 合约是否放弃权限
+		IsCaVerify         int64        `gorm:"column:is_ca_verify"`         // 合约是否已验证
+		IsHoneyScam        int64        `gorm:"column:is_honey_scam"`        // 是否检测为蜜罐（无法卖出）
+		IsLiquidLock       int64        `gorm:"column:is_liquid_lock"`       // 流动性是否锁定
+		IsCanPauseTrade    int64        `gorm:"column:is_can_pause_trade"`   // 是否可暂停交易
+		IsCanChangeTax     int64        `gorm:"column:is_can_change_tax"`    // 是否可修改税率
+		IsHaveBlackList    int64        `gorm:"column:is_have_black_list"`   // 是否存在黑名单机制
+		IsCanAllSell       int64        `gorm:"column:is_can_all_sell"`      // 是否可一次性卖出全部
+		IsHaveProxy        int64        `gorm:"column:is_have_proxy"`        // 是否存在代理合约
+		IsCanExternalCall  int64        `gorm:"column:is_can_external_call"` // 合约是否可外部调用
+		IsCanAddToken      int64        `gorm:"column:is_can_add_token"`     // 合约是否可增发
+		IsCanChangeToken   int64        `gorm:"column:is_can_change_token"`  // 合约是否可修改余额
+		SellTax            float64      `gorm:"column:sell_tax"`             // 卖出税率
+		BuyTax             float64      `gorm:"column:buy_tax"`              // 买入税率
+		TwitterUsername    string       `gorm:"column:twitter_username"`     // Twitter 用户名
+		Website            string       `gorm:"column:website"`              // 官网地址
+		Telegram           string       `gorm:"column:telegram"`             // Telegram 链接
+		CreatedAt          time.Time    `gorm:"column:created_at"`
+		UpdatedAt          time.Time    `gorm:"column:updated_at"`
+		DeletedAt          sql.NullTime `gorm:"column:deleted_at;index"`
+		IsCheckCa          int64        `gorm:"column:is_check_ca"`  // 是否完成合约检测
+		CheckCaAt          int64        `gorm:"column:check_ca_at"`  // 合约检测时间戳
+		IsBurnPool         int64        `gorm:"column:is_burn_pool"` // 是否进入燃烧池
+		IsTopTen           int64        `gorm:"column:is_top_ten"`   // 是否位列市值前十
+		AuditSource        string       `gorm:"column:audit_source"` // 审计来源
+		Slot               int64        `gorm:"column:slot"`
+		SniperCount        int64        `gorm:"column:sniper_count"`         // 开盘狙击钱包数量
+		SniperHoldPercent  float64      `gorm:"column:sniper_hold_percent"`  // 狙击钱包开盘持仓占总量百分比
+		BundlerCount       int64        `gorm:"column:bundler_count"`        // 开盘捆绑钱包数量
+		BundlerHoldPercent float64      `gorm:"column:bundler_hold_percent"` // 捆绑钱包开盘持仓占总量百分比
+		IsBundled          int64        `gorm:"column:is_bundled"`           // 是否检测为捆绑开盘
+		LaunchCheckAt      int64        `gorm:"column:launch_check_at"`      // 开盘分析时间戳
 	}
 )
 
@@ -1,6 +1,9 @@
 package solmodel
 
 import (
+	"context"
+	"time"
+
 	. "github.com/klen-ygs/gorm-zero/gormc/sql"
 	"gorm.io/gorm"
 )
@@ -19,6 +22,16 @@ type (
 
 	customTradeLogicModel interface {
 		WithSession(tx *gorm.DB) TradeModel
+		FindSniperMakers(ctx context.Context, chainId int64, since time.Time, launchSlots int64, minHits int64, limit int) ([]*MakerStat, error)
+		FindBundlerMakers(ctx context.Context, chainId int64, since time.Time, launchSlots int64, minMakersPerSlot int64, minHits int64, limit int) ([]*MakerStat, error)
+		FindTopPnlMakers(ctx context.Context, chainId int64, since time.Time, limit int) ([]*MakerStat, error)
+		FindFreshMakers(ctx context.Context, chainId int64, since time.Time, maxTradeCount int64, limit int) ([]*MakerStat, error)
+		FindByPairAddrSlotRange(ctx context.Context, chainId int64, pairAddr string, fromSlot, toSlot int64) ([]*Trade, error)
+		FindByPairAddrCursor(ctx context.Context, chainId int64, pairAddr string, cursor TradeCursor, limit int) ([]*Trade, error)
+		FindByPairAddrsCursor(ctx context.Context, chainId int64, pairAddrs []string, cursor TradeCursor, limit int) ([]*Trade, error)
+		FindByMakerCursor(ctx context.Context, chainId int64, maker string, cursor TradeCursor, limit int) ([]*Trade, error)
+		SumVolumeByPairAddr(ctx context.Context, chainId int64, pairAddr string, since time.Time) (*PairVolume, error)
+		SumVolumeByPlatform(ctx context.Context, chainId int64, since time.Time) ([]*PlatformVolume, error)
 	}
 
 	customTradeModel struct {
@@ -46,3 +59,157 @@ func (m *defaultTradeModel) customCacheKeys(data *Trade) []string {
 	}
 	return []string{}
 }
+
+// MakerStat 按钱包聚合的成交统计结果，Value 的含义由具体查询决定（命中次数、收益、成交笔数）
+type MakerStat struct {
+	Maker string  `gorm:"column:maker"`
+	Value float64 `gorm:"column:value"`
+}
+
+// FindSniperMakers 统计在交易对出现后 launchSlots 个 slot 内买入的钱包，Value 为命中的交易对数量
+func (m *defaultTradeModel) FindSniperMakers(ctx context.Context, chainId int64, since time.Time, launchSlots int64, minHits int64, limit int) ([]*MakerStat, error) {
+	var resp []*MakerStat
+	err := m.conn.WithContext(ctx).Raw(
+		"select t.`maker` as maker, count(distinct t.`pair_addr`) as value from `trade` t "+
+			"join `pair` p on p.`chain_id` = t.`chain_id` and p.`address` = t.`pair_addr` "+
+			"where t.`chain_id` = ? and t.`trade_type` = 'buy' and t.`block_time` >= ? and t.`block_num` <= p.`block_num` + ? "+
+			"group by t.`maker` having value >= ? order by value desc limit ?",
+		chainId, since, launchSlots, minHits, limit,
+	).Scan(&resp).Error
+	return resp, err
+}
+
+// FindBundlerMakers 统计开盘阶段与其他钱包在同一 slot 集中买入的钱包，Value 为命中的交易对数量
+func (m *defaultTradeModel) FindBundlerMakers(ctx context.Context, chainId int64, since time.Time, launchSlots int64, minMakersPerSlot int64, minHits int64, limit int) ([]*MakerStat, error) {
+	var resp []*MakerStat
+	err := m.conn.WithContext(ctx).Raw(
+		"select t.`maker` as maker, count(distinct t.`pair_addr`) as value from `trade` t "+
+			"join `pair` p on p.`chain_id` = t.`chain_id` and p.`address` = t.`pair_addr` "+
+			"join (select `pair_addr`, `block_num` from `trade` where `chain_id` = ? and `trade_type` = 'buy' and `block_time` >= ? "+
+			"group by `pair_addr`, `block_num` having count(distinct `maker`) >= ?) g on g.`pair_addr` = t.`pair_addr` and g.`block_num` = t.`block_num` "+
+			"where t.`chain_id` = ? and t.`trade_type` = 'buy' and t.`block_num` <= p.`block_num` + ? "+
+			"group by t.`maker` having value >= ? order by value desc limit ?",
+		chainId, since, minMakersPerSlot, chainId, launchSlots, minHits, limit,
+	).Scan(&resp).Error
+	return resp, err
+}
+
+// FindTopPnlMakers 按卖出所得减买入成本（USD）统计已实现收益排名，Value 为收益
+func (m *defaultTradeModel) FindTopPnlMakers(ctx context.Context, chainId int64, since time.Time, limit int) ([]*MakerStat, error) {
+	var resp []*MakerStat
+	err := m.conn.WithContext(ctx).Raw(
+		"select `maker` as maker, sum(case when `trade_type` = 'sell' then `total_usd` else -`total_usd` end) as value from `trade` "+
+			"where `chain_id` = ? and `block_time` >= ? and `trade_type` in ('buy', 'sell') "+
+			"group by `maker` having value > 0 order by value desc limit ?",
+		chainId, since, limit,
+	).Scan(&resp).Error
+	return resp, err
+}
+
+// FindFreshMakers 查找首笔成交发生在 since 之后、且历史成交笔数不超过 maxTradeCount 的钱包，Value 为成交笔数
+func (m *defaultTradeModel) FindFreshMakers(ctx context.Context, chainId int64, since time.Time, maxTradeCount int64, limit int) ([]*MakerStat, error) {
+	var resp []*MakerStat
+	err := m.conn.WithContext(ctx).Raw(
+		"select `maker` as maker, count(*) as value from `trade` "+
+			"where `chain_id` = ? and `maker` in (select distinct `maker` from `trade` where `chain_id` = ? and `block_time` >= ?) "+
+			"group by `maker` having min(`block_time`) >= ? and value <= ? limit ?",
+		chainId, chainId, since, since, maxTradeCount, limit,
+	).Scan(&resp).Error
+	return resp, err
+}
+
+// FindByPairAddrSlotRange 按 slot、交易序号顺序查询交易对在 [fromSlot, toSlot] 内的全部成交，用于开盘分析
+func (m *defaultTradeModel) FindByPairAddrSlotRange(ctx context.Context, chainId int64, pairAddr string, fromSlot, toSlot int64) ([]*Trade, error) {
+	var resp []*Trade
+	err := m.conn.WithContext(ctx).Model(&Trade{}).
+		Where("`chain_id` = ? and `pair_addr` = ? and `block_num` between ? and ?", chainId, pairAddr, fromSlot, toSlot).
+		Order("`block_num` asc, `transaction_index` asc, `id` asc").
+		Find(&resp).Error
+	return resp, err
+}
+
+// TradeCursor 成交翻页游标，按 (block_num, id) 倒序翻页；BlockNum 为 0 表示从最新一条开始
+type TradeCursor struct {
+	BlockNum int64
+	Id       int64
+}
+
+// FindByPairAddrCursor 按 slot 倒序分页查询交易对的成交
+func (m *defaultTradeModel) FindByPairAddrCursor(ctx context.Context, chainId int64, pairAddr string, cursor TradeCursor, limit int) ([]*Trade, error) {
+	db := m.conn.WithContext(ctx).Model(&Trade{}).Where("`chain_id` = ? and `pair_addr` = ?", chainId, pairAddr)
+	return m.findByCursor(db, cursor, limit)
+}
+
+// FindByPairAddrsCursor 按 slot 倒序分页查询多个交易对的成交，用于查询代币在全部交易对上的成交
+func (m *defaultTradeModel) FindByPairAddrsCursor(ctx context.Context, chainId int64, pairAddrs []string, cursor TradeCursor, limit int) ([]*Trade, error) {
+	if len(pairAddrs) == 0 {
+		return nil, nil
+	}
+	db := m.conn.WithContext(ctx).Model(&Trade{}).Where("`chain_id` = ? and `pair_addr` in ?", chainId, pairAddrs)
+	return m.findByCursor(db, cursor, limit)
+}
+
+// FindByMakerCursor 按 slot 倒序分页查询钱包的成交
+func (m *defaultTradeModel) FindByMakerCursor(ctx context.Context, chainId int64, maker string, cursor TradeCursor, limit int) ([]*Trade, error) {
+	db := m.conn.WithContext(ctx).Model(&Trade{}).Where("`chain_id` = ? and `maker` = ?", chainId, maker)
+	return m.findByCursor(db, cursor, limit)
+}
+
+func (m *defaultTradeModel) findByCursor(db *gorm.DB, cursor TradeCursor, limit int) ([]*Trade, error) {
+	if cursor.BlockNum > 0 {
+		db = db.Where("(`block_num` < ? or (`block_num` = ? and `id` < ?))", cursor.BlockNum, cursor.BlockNum, cursor.Id)
+	}
+	var resp []*Trade
+	err := db.Order("`block_num` desc, `id` desc").Limit(limit).Find(&resp).Error
+	return resp, err
+}
+
+// 成交的区块内行为标记，夹子的前后两笔和刷量成交不计入自然成交额
+const (
+	TradeMevTagSandwichFront  = "sandwich_front"  // 夹子抢跑买入（或卖出）
+	TradeMevTagSandwichBack   = "sandwich_back"   // 夹子反向平仓
+	TradeMevTagSandwichVictim = "sandwich_victim" // 被夹的成交，计入自然成交额
+	TradeMevTagSelfTrade      = "self_trade"      // 同一钱包在同一笔交易内对倒
+	TradeMevTagWash           = "wash"            // 刷量：同一钱包 slot 内往返，或代币在钱包之间循环后卖出
+)
+
+// organicVolumeExpr 自然成交额：排除夹子前后腿和刷量成交
+const organicVolumeExpr = "sum(case when `mev_tag` in ('', '" + TradeMevTagSandwichVictim + "') then `total_usd` else 0 end)"
+
+// PairVolume 交易对成交额（USD）
+type PairVolume struct {
+	Volume        float64 `gorm:"column:volume"`
+	OrganicVolume float64 `gorm:"column:organic_volume"`
+}
+
+// SumVolumeByPairAddr 统计交易对 since 之后的成交额和自然成交额（USD）
+func (m *defaultTradeModel) SumVolumeByPairAddr(ctx context.Context, chainId int64, pairAddr string, since time.Time) (*PairVolume, error) {
+	var resp PairVolume
+	err := m.conn.WithContext(ctx).Model(&Trade{}).
+		Select("ifnull(sum(`total_usd`), 0) as volume, ifnull("+organicVolumeExpr+", 0) as organic_volume").
+		Where("`chain_id` = ? and `pair_addr` = ? and `block_time` >= ?", chainId, pairAddr, since).
+		Scan(&resp).Error
+	return &resp, err
+}
+
+// PlatformVolume 下单平台成交统计，Platform 为空表示直接调用或未识别
+type PlatformVolume struct {
+	Platform      string  `gorm:"column:platform"`
+	Volume        float64 `gorm:"column:volume"`
+	OrganicVolume float64 `gorm:"column:organic_volume"`
+	TradeCount    int64   `gorm:"column:trade_count"`
+	MakerCount    int64   `gorm:"column:maker_count"`
+}
+
+// SumVolumeByPlatform 按下单平台统计 since 之后的买卖成交额、自然成交额、成交笔数和钱包数，按成交额倒序
+func (m *defaultTradeModel) SumVolumeByPlatform(ctx context.Context, chainId int64, since time.Time) ([]*PlatformVolume, error) {
+	var resp []*PlatformVolume
+	err := m.conn.WithContext(ctx).Model(&Trade{}).
+		Select("`platform` as platform, ifnull(sum(`total_usd`), 0) as volume, ifnull("+organicVolumeExpr+", 0) as organic_volume, "+
+			"count(*) as trade_count, count(distinct `maker`) as maker_count").
+		Where("`chain_id` = ? and `block_time` >= ? and `trade_type` in ('buy', 'sell')", chainId, since).
+		Group("`platform`").
+		Order("volume desc").
+		Scan(&resp).Error
+	return resp, err
+}
@@ -42,14 +42,21 @@ type (
 		TradeType         string       `gorm:"column:trade_type"`           // 成交类型
 		BaseTokenAmount   float64      `gorm:"column:base_token_amount"`    // 本次成交基础币数量
 		TokenAmount       float64      `gorm:"column:token_amount"`         // 本次成交代币数量
+		TokenTransferFee  float64      `gorm:"column:token_transfer_fee"`   // Token-2022 转账手续费（被扣留的代币数量）
 		BaseTokenPriceUsd float64      `gorm:"column:base_token_price_usd"` // 基础币美元单价
 		TotalUsd          float64      `gorm:"column:total_usd"`            // 成交总金额（USD）
 		TokenPriceUsd     float64      `gorm:"column:token_price_usd"`      // 代币美元单价
 		To                string       `gorm:"column:to"`                   // 接收地址
 		BlockNum          int64        `gorm:"column:block_num"`            // 区块高度
+		TransactionIndex  int64        `gorm:"column:transaction_index"`    // 交易在区块内的序号
 		BlockTime         time.Time    `gorm:"column:block_time"`           // 区块时间
 		BlockTimeStamp    int64        `gorm:"column:block_time_stamp"`     // 成交时间戳
 		SwapName          string       `gorm:"column:swap_name"`            // 所属 DEX
+		MevTag            string       `gorm:"column:mev_tag"`              // 区块内行为标记：sandwich_front/sandwich_back/sandwich_victim/self_trade/wash，空为正常成交
+		LpFee             float64      `gorm:"column:lp_fee"`               // LP 手续费（SOL）
+		ProtocolFee       float64      `gorm:"column:protocol_fee"`         // 协议手续费（SOL）
+		CreatorFee        float64      `gorm:"column:creator_fee"`          // 创建者手续费（SOL）
+		Platform          string       `gorm:"column:platform"`             // 下单平台（交易机器人或前端，如 photon、bullx），空为直接调用或未识别
 		CreatedAt         time.Time    `gorm:"column:created_at"`
 		UpdatedAt         time.Time    `gorm:"column:updated_at"`
 		DeletedAt         sql.NullTime `gorm:"column:deleted_at;index"`
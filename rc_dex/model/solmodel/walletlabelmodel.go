@@ -0,0 +1,114 @@
+package solmodel
+
+import (
+	"context"
+	"time"
+
+	. "github.com/klen-ygs/gorm-zero/gormc/sql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"richcode.cc/dex/pkg/constants"
+)
+
+// avoid unused err
+var _ = InitField
+var _ WalletLabelModel = (*customWalletLabelModel)(nil)
+
+type (
+	// WalletLabelModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customWalletLabelModel.
+	WalletLabelModel interface {
+		walletLabelModel
+		customWalletLabelLogicModel
+	}
+
+	customWalletLabelLogicModel interface {
+		WithSession(tx *gorm.DB) WalletLabelModel
+		FindByChainIdAddresses(ctx context.Context, chainId int64, addresses []string) ([]*WalletLabel, error)
+		BatchUpsertManualLabels(ctx context.Context, labels []*WalletLabel) error
+		BatchUpsertAutoLabels(ctx context.Context, labels []*WalletLabel) error
+		DeleteStaleAutoLabels(ctx context.Context, chainId int64, label string, before time.Time) ([]string, error)
+	}
+
+	customWalletLabelModel struct {
+		*defaultWalletLabelModel
+	}
+)
+
+func (c customWalletLabelModel) WithSession(tx *gorm.DB) WalletLabelModel {
+	newModel := *c.defaultWalletLabelModel
+	c.defaultWalletLabelModel = &newModel
+	c.conn = tx
+	return c
+}
+
+// NewWalletLabelModel returns a model for the database table.
+func NewWalletLabelModel(conn *gorm.DB) WalletLabelModel {
+	return &customWalletLabelModel{
+		defaultWalletLabelModel: newWalletLabelModel(conn),
+	}
+}
+
+func (m *defaultWalletLabelModel) customCacheKeys(data *WalletLabel) []string {
+	if data == nil {
+		return []string{}
+	}
+	return []string{}
+}
+
+// FindByChainIdAddresses 批量查询一组钱包的全部标签，用于区块解析时给成交打标
+func (m *defaultWalletLabelModel) FindByChainIdAddresses(ctx context.Context, chainId int64, addresses []string) ([]*WalletLabel, error) {
+	var resp []*WalletLabel
+	if len(addresses) == 0 {
+		return resp, nil
+	}
+	err := m.conn.WithContext(ctx).Model(&WalletLabel{}).
+		Where("`chain_id` = ? and `address` in ?", chainId, addresses).
+		Find(&resp).Error
+	return resp, err
+}
+
+// BatchUpsertManualLabels 按 (chain_id, address, label) 唯一键写入人工标签，已存在时覆盖展示信息并转为人工来源
+func (m *defaultWalletLabelModel) BatchUpsertManualLabels(ctx context.Context, labels []*WalletLabel) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	return m.conn.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"source", "name", "icon", "twitter_link", "updated_at"}),
+	}).CreateInBatches(labels, 500).Error
+}
+
+// BatchUpsertAutoLabels 写入自动计算的标签，已存在时只刷新分数和更新时间，不覆盖人工维护的展示信息
+func (m *defaultWalletLabelModel) BatchUpsertAutoLabels(ctx context.Context, labels []*WalletLabel) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	return m.conn.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"score", "updated_at"}),
+	}).CreateInBatches(labels, 500).Error
+}
+
+// DeleteStaleAutoLabels 删除本轮计算未再命中的自动标签（updated_at 早于本轮开始时间），人工标签不受影响；
+// 返回被删除标签的钱包地址，调用方据此清理标签缓存
+func (m *defaultWalletLabelModel) DeleteStaleAutoLabels(ctx context.Context, chainId int64, label string, before time.Time) ([]string, error) {
+	var addresses []string
+	err := m.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stale []*WalletLabel
+		err := tx.Model(&WalletLabel{}).Select("`id`", "`address`").
+			Where("`chain_id` = ? and `label` = ? and `source` = ? and `updated_at` < ?", chainId, label, constants.WalletLabelSourceAuto, before).
+			Find(&stale).Error
+		if err != nil || len(stale) == 0 {
+			return err
+		}
+		ids := make([]int64, 0, len(stale))
+		for _, item := range stale {
+			ids = append(ids, item.Id)
+			addresses = append(addresses, item.Address)
+		}
+		return tx.Where("`id` in ?", ids).Delete(&WalletLabel{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
@@ -0,0 +1,8 @@
+-- 交易对跑路标记：撤池子、开发者砸盘或流动性抽干时记录原因、时间和判定交易
+-- 已有库执行一次，新库由 sol.sql 建表时创建
+ALTER TABLE `pair`
+  ADD COLUMN `is_rug` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否已跑路（撤池子/开发者砸盘/流动性抽干）' AFTER `launch_pad_status`,
+  ADD COLUMN `rug_reason` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '跑路原因：lp_removed/dev_dump/liquidity_drained' AFTER `is_rug`,
+  ADD COLUMN `rug_at` bigint NOT NULL DEFAULT '0' COMMENT '跑路区块时间戳' AFTER `rug_reason`,
+  ADD COLUMN `rug_tx_hash` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '判定跑路的交易哈希' AFTER `rug_at`,
+  ADD KEY `is_rug_index` (`is_rug`) USING BTREE;
@@ -0,0 +1,15 @@
+-- 手续费累加去重：记录已累加手续费的成交（交易哈希 + 指令序号），区块重复处理时跳过
+-- 已有库执行一次，新库由 sol.sql 建表时创建
+CREATE TABLE IF NOT EXISTS `pool_fee_accrual` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `chain_id` int NOT NULL DEFAULT '0' COMMENT '链 ID',
+  `tx_hash` varchar(100) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '交易哈希',
+  `instruction_index` int NOT NULL DEFAULT '0' COMMENT '成交指令在交易内的序号',
+  `pair_addr` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '交易对（池子）地址',
+  `slot` bigint NOT NULL DEFAULT '0' COMMENT 'slot',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `chain_id_tx_hash_instruction_index_index` (`chain_id`,`tx_hash`,`instruction_index`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci ROW_FORMAT=DYNAMIC COMMENT='已累加手续费的成交，按交易哈希和指令序号去重，区块重复处理时不重复累加';
@@ -0,0 +1,12 @@
+-- 开盘分析：token 表记录狙击/捆绑钱包统计，trade 表记录交易在区块内的序号用于识别连号买入
+-- 已有库执行一次，新库由 sol.sql 建表时创建
+ALTER TABLE `token`
+  ADD COLUMN `sniper_count` int NOT NULL DEFAULT '0' COMMENT '开盘狙击钱包数量' AFTER `slot`,
+  ADD COLUMN `sniper_hold_percent` decimal(10,4) NOT NULL DEFAULT '0.0000' COMMENT '狙击钱包开盘持仓占总量百分比' AFTER `sniper_count`,
+  ADD COLUMN `bundler_count` int NOT NULL DEFAULT '0' COMMENT '开盘捆绑钱包数量' AFTER `sniper_hold_percent`,
+  ADD COLUMN `bundler_hold_percent` decimal(10,4) NOT NULL DEFAULT '0.0000' COMMENT '捆绑钱包开盘持仓占总量百分比' AFTER `bundler_count`,
+  ADD COLUMN `is_bundled` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否检测为捆绑开盘' AFTER `bundler_hold_percent`,
+  ADD COLUMN `launch_check_at` bigint NOT NULL DEFAULT '0' COMMENT '开盘分析时间戳' AFTER `is_bundled`;
+
+ALTER TABLE `trade`
+  ADD COLUMN `transaction_index` int NOT NULL DEFAULT '0' COMMENT '交易在区块内的序号' AFTER `block_num`;
@@ -0,0 +1,5 @@
+-- 交易对列表的 24 小时成交额：按成交额排序时扫描窗口内全部成交，否则只聚合本页交易对的成交
+-- 已有库执行一次，新库由 sol.sql 建表时创建
+ALTER TABLE `trade`
+  ADD KEY `pair_addr_block_time_index` (`pair_addr`,`block_time`),
+  ADD KEY `chain_id_block_time_pair_addr_index` (`chain_id`,`block_time`,`pair_addr`);
@@ -55,6 +55,12 @@ CREATE TABLE `token` (
   `is_top_ten` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否位列市值前十',
   `audit_source` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '审计来源',
   `slot` bigint NOT NULL DEFAULT '0',
+  `sniper_count` int NOT NULL DEFAULT '0' COMMENT '开盘狙击钱包数量',
+  `sniper_hold_percent` decimal(10,4) NOT NULL DEFAULT '0.0000' COMMENT '狙击钱包开盘持仓占总量百分比',
+  `bundler_count` int NOT NULL DEFAULT '0' COMMENT '开盘捆绑钱包数量',
+  `bundler_hold_percent` decimal(10,4) NOT NULL DEFAULT '0.0000' COMMENT '捆绑钱包开盘持仓占总量百分比',
+  `is_bundled` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否检测为捆绑开盘',
+  `launch_check_at` bigint NOT NULL DEFAULT '0' COMMENT '开盘分析时间戳',
   PRIMARY KEY (`id`) USING BTREE,
   UNIQUE KEY `chain_id_address_index` (`chain_id`,`address`) USING BTREE,
   UNIQUE KEY `chain_id_address_symbol_index` (`chain_id`,`address`,`symbol`) USING BTREE,
@@ -73,21 +79,32 @@ CREATE TABLE `trade` (
   `trade_type` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '成交类型',
   `base_token_amount` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '本次成交基础币数量',
   `token_amount` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '本次成交代币数量',
+  `token_transfer_fee` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT 'Token-2022 转账手续费（被扣留的代币数量）',
   `base_token_price_usd` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '基础币美元单价',
   `total_usd` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '成交总金额（USD）',
   `token_price_usd` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '代币美元单价',
   `to` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '接收地址',
   `block_num` bigint NOT NULL DEFAULT '0' COMMENT '区块高度',
+  `transaction_index` int NOT NULL DEFAULT '0' COMMENT '交易在区块内的序号',
   `block_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '区块时间',
   `block_time_stamp` bigint NOT NULL DEFAULT '0' COMMENT '成交时间戳',
   `swap_name` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '所属 DEX',
+  `mev_tag` varchar(32) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '区块内行为标记：sandwich_front/sandwich_back/sandwich_victim/self_trade/wash，空为正常成交',
+  `lp_fee` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT 'LP 手续费（SOL）',
+  `protocol_fee` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '协议手续费（SOL）',
+  `creator_fee` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '创建者手续费（SOL）',
+  `platform` varchar(32) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '下单平台（交易机器人或前端，如 photon、bullx），空为直接调用或未识别',
   `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   `deleted_at` timestamp NULL DEFAULT NULL,
   PRIMARY KEY (`id`),
   UNIQUE KEY `hash_id_index` (`hash_id`),
-  KEY `marker_index` (`maker`),
-  KEY `block_time_index` (`block_time`)
+  KEY `maker_block_num_index` (`maker`,`block_num`),
+  KEY `block_time_index` (`block_time`),
+  KEY `platform_block_time_index` (`platform`,`block_time`),
+  KEY `pair_addr_block_num_index` (`pair_addr`,`block_num`),
+  KEY `pair_addr_block_time_index` (`pair_addr`,`block_time`),
+  KEY `chain_id_block_time_pair_addr_index` (`chain_id`,`block_time`,`pair_addr`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='成交记录表';
 
 CREATE TABLE `pair` (
@@ -132,6 +149,10 @@ CREATE TABLE `pair` (
   `slot` bigint NOT NULL DEFAULT '0',
   `liquidity` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '池子流动性',
   `launch_pad_status` int NOT NULL DEFAULT '0' COMMENT 'LaunchPad 状态：0-非 LaunchPad，1-新建，2-进行中，3-已完成',
+  `is_rug` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否已跑路（撤池子/开发者砸盘/流动性抽干）',
+  `rug_reason` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '跑路原因：lp_removed/dev_dump/liquidity_drained',
+  `rug_at` bigint NOT NULL DEFAULT '0' COMMENT '跑路区块时间戳',
+  `rug_tx_hash` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '判定跑路的交易哈希',
   PRIMARY KEY (`id`) USING BTREE,
   UNIQUE KEY `chain_id_address_index` (`chain_id`,`address`) USING BTREE,
   KEY `name_index` (`name`) USING BTREE,
@@ -140,7 +161,10 @@ CREATE TABLE `pair` (
   KEY `pump_point_index` (`pump_point`) USING BTREE,
   KEY `block_num_index` (`block_num`) USING BTREE,
   KEY `pump_status_index` (`pump_status`) USING BTREE,
-  KEY `block_time_index` (`block_time`) USING BTREE
+  KEY `liquidity_index` (`liquidity`) USING BTREE,
+  KEY `block_time_index` (`block_time`) USING BTREE,
+  KEY `is_rug_index` (`is_rug`) USING BTREE,
+  KEY `latest_trade_time_index` (`latest_trade_time`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=1099042 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci ROW_FORMAT=DYNAMIC COMMENT='交易对表';
 
  CREATE TABLE `sol_account`
@@ -184,3 +208,93 @@ CREATE TABLE `sol_token_account` (
   UNIQUE KEY `sol_token_account_owner_address_token_account_address_uindex` (`owner_address`,`token_account_address`) USING BTREE,
   KEY `chainid_tokenaddress_balance_index` (`chain_id`,`token_address`,`balance`) USING BTREE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci ROW_FORMAT=DYNAMIC COMMENT='SOL TokenAccount 表';
+
+CREATE TABLE `wallet_label` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `chain_id` int NOT NULL DEFAULT '0' COMMENT '链 ID',
+  `address` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '钱包地址',
+  `label` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '标签：kol/cex/team/sniper/bundler/top_pnl/fresh_wallet',
+  `source` tinyint NOT NULL DEFAULT '0' COMMENT '来源：1-人工，2-自动计算',
+  `name` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '展示名称',
+  `icon` varchar(512) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '头像地址',
+  `twitter_link` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'Twitter 链接',
+  `score` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '自动标签的依据数值（命中次数、收益等）',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `chain_id_address_label_index` (`chain_id`,`address`,`label`) USING BTREE,
+  KEY `label_source_index` (`label`,`source`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci ROW_FORMAT=DYNAMIC COMMENT='钱包标签表';
+
+CREATE TABLE `pool_fee` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `chain_id` int NOT NULL DEFAULT '0' COMMENT '链 ID',
+  `pair_addr` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '交易对（池子）地址',
+  `token_address` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '代币地址',
+  `coin_creator` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '代币创建者（收取创建者手续费）',
+  `date` date NOT NULL COMMENT '日期（UTC）',
+  `lp_fee` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT 'LP 手续费（SOL）',
+  `protocol_fee` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '协议手续费（SOL）',
+  `creator_fee` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '创建者手续费（SOL）',
+  `lp_fee_usd` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT 'LP 手续费（USD），用于计算池子 APR',
+  `fee_usd` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '三项手续费合计（USD）',
+  `volume_usd` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '买卖成交额（USD）',
+  `trade_count` bigint NOT NULL DEFAULT '0' COMMENT '成交笔数',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `chain_id_pair_addr_date_index` (`chain_id`,`pair_addr`,`date`) USING BTREE,
+  KEY `coin_creator_date_index` (`coin_creator`,`date`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci ROW_FORMAT=DYNAMIC COMMENT='池子手续费按日累计表';
+
+CREATE TABLE `pool_fee_accrual` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `chain_id` int NOT NULL DEFAULT '0' COMMENT '链 ID',
+  `tx_hash` varchar(100) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '交易哈希',
+  `instruction_index` int NOT NULL DEFAULT '0' COMMENT '成交指令在交易内的序号',
+  `pair_addr` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '交易对（池子）地址',
+  `slot` bigint NOT NULL DEFAULT '0' COMMENT 'slot',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `chain_id_tx_hash_instruction_index_index` (`chain_id`,`tx_hash`,`instruction_index`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci ROW_FORMAT=DYNAMIC COMMENT='已累加手续费的成交，按交易哈希和指令序号去重，区块重复处理时不重复累加';
+
+CREATE TABLE `creator_fee` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `chain_id` int NOT NULL DEFAULT '0' COMMENT '链 ID',
+  `creator` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '代币创建者地址',
+  `accrued_fee` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '累计产生的创建者手续费（SOL）',
+  `accrued_fee_usd` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '累计产生的创建者手续费（USD，按成交时 SOL 价格）',
+  `claimed_fee` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '累计领取的手续费（SOL）',
+  `claimed_fee_usd` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '累计领取的手续费（USD，按领取时 SOL 价格）',
+  `claim_count` bigint NOT NULL DEFAULT '0' COMMENT '领取次数',
+  `last_claim_at` bigint NOT NULL DEFAULT '0' COMMENT '最近一次领取的区块时间（秒）',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `chain_id_creator_index` (`chain_id`,`creator`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci ROW_FORMAT=DYNAMIC COMMENT='创建者手续费累计表';
+
+CREATE TABLE `creator_fee_claim` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `chain_id` int NOT NULL DEFAULT '0' COMMENT '链 ID',
+  `creator` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '代币创建者地址',
+  `tx_hash` varchar(100) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '交易哈希',
+  `amount` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '领取数量（SOL）',
+  `amount_usd` decimal(64,18) NOT NULL DEFAULT '0.000000000000000000' COMMENT '领取金额（USD）',
+  `vault_ata` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '创建者手续费金库代币账户',
+  `token_account` varchar(64) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '接收代币账户',
+  `slot` bigint NOT NULL DEFAULT '0' COMMENT 'slot',
+  `block_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '区块时间',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `tx_hash_creator_index` (`tx_hash`,`creator`) USING BTREE,
+  KEY `creator_block_time_index` (`creator`,`block_time`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci ROW_FORMAT=DYNAMIC COMMENT='创建者手续费领取记录表';
@@ -0,0 +1,29 @@
+package constants
+
+// 钱包标签类型，人工标签与自动标签共用同一张 wallet_label 表
+const (
+	WalletLabelKOL     = "kol"          // KOL / 聪明钱（人工）
+	WalletLabelCEX     = "cex"          // 交易所热钱包（人工）
+	WalletLabelTeam    = "team"         // 项目方 / 团队钱包（人工）
+	WalletLabelSniper  = "sniper"       // 开盘狙击（自动）
+	WalletLabelBundler = "bundler"      // 捆绑买入（自动）
+	WalletLabelTopPnl  = "top_pnl"      // 收益排行靠前（自动）
+	WalletLabelFresh   = "fresh_wallet" // 新钱包（自动）
+)
+
+// 钱包标签来源
+const (
+	WalletLabelSourceManual = 1
+	WalletLabelSourceAuto   = 2
+)
+
+// WalletLabelPriority 同一钱包命中多个标签时，按此顺序选出展示在成交上的标签
+var WalletLabelPriority = []string{
+	WalletLabelKOL,
+	WalletLabelCEX,
+	WalletLabelTeam,
+	WalletLabelTopPnl,
+	WalletLabelSniper,
+	WalletLabelBundler,
+	WalletLabelFresh,
+}
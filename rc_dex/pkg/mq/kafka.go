@@ -0,0 +1,53 @@
+package mq
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaConf Kafka 连接配置
+type KafkaConf struct {
+	Brokers      []string `json:"Brokers"`
+	BatchTimeout int64    `json:"BatchTimeout,default=10"` // 批量发送的最长等待时间（毫秒）
+	WriteTimeout int64    `json:"WriteTimeout,default=10"` // 单次写入超时（秒）
+}
+
+// KafkaTransport 基于 kafka-go 的传输，按 Key 哈希分区，要求所有副本确认
+type KafkaTransport struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaTransport(conf KafkaConf) *KafkaTransport {
+	return &KafkaTransport{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(conf.Brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchTimeout: time.Duration(conf.BatchTimeout) * time.Millisecond,
+			WriteTimeout: time.Duration(conf.WriteTimeout) * time.Second,
+		},
+	}
+}
+
+func (t *KafkaTransport) Send(ctx context.Context, messages []Message) error {
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		headers := make([]kafka.Header, 0, len(message.Headers))
+		for key, value := range message.Headers {
+			headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+		}
+		kafkaMessages = append(kafkaMessages, kafka.Message{
+			Topic:   message.Topic,
+			Key:     []byte(message.Key),
+			Value:   message.Value,
+			Headers: headers,
+		})
+	}
+	return t.writer.WriteMessages(ctx, kafkaMessages...)
+}
+
+func (t *KafkaTransport) Close() error {
+	return t.writer.Close()
+}
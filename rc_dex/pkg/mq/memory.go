@@ -0,0 +1,60 @@
+package mq
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryTransport 内存传输，只记录发送成功的消息，用于测试和本地调试
+type MemoryTransport struct {
+	lock     sync.Mutex
+	messages []Message
+	failures []error
+	closed   bool
+}
+
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+func (t *MemoryTransport) Send(ctx context.Context, messages []Message) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return ErrTransportClosed
+	}
+	if len(t.failures) > 0 {
+		err := t.failures[0]
+		t.failures = t.failures[1:]
+		return err
+	}
+	t.messages = append(t.messages, messages...)
+	return nil
+}
+
+func (t *MemoryTransport) Close() error {
+	t.lock.Lock()
+	t.closed = true
+	t.lock.Unlock()
+	return nil
+}
+
+// FailNext 让接下来的几次 Send 依次返回给定错误，用于模拟 broker 不可用
+func (t *MemoryTransport) FailNext(errs ...error) {
+	t.lock.Lock()
+	t.failures = append(t.failures, errs...)
+	t.lock.Unlock()
+}
+
+// Messages 返回已发送成功的消息，topic 为空时返回全部
+func (t *MemoryTransport) Messages(topic string) []Message {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var result []Message
+	for _, message := range t.messages {
+		if topic == "" || message.Topic == topic {
+			result = append(result, message)
+		}
+	}
+	return result
+}
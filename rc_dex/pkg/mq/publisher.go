@@ -0,0 +1,154 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var ErrPublisherStopped = errors.New("mq publisher stopped")
+
+const (
+	minRetryBackoff = 100 * time.Millisecond
+	maxRetryBackoff = 5 * time.Second
+)
+
+// Batch 一次发布的一组消息，通常是一个 slot 的全部消息，整批发送、整批重试
+type Batch struct {
+	Name     string // 日志中标识这一批消息，如 slot
+	Messages []Message
+}
+
+// Publisher 按 Publish 的顺序逐批发送消息
+// 发送失败时按指数退避重试同一批，直到成功或服务停止，后面的批次排队等待，保证同一 Key 的消息不乱序；
+// 重试可能导致重复投递（至少一次），消费端需要按消息头中的 msg_id 去重。
+// 队列写满时 Publish 会阻塞，把 broker 不可用的压力传导给调用方，而不是丢消息
+type Publisher struct {
+	transport Transport
+	logx.Logger
+	queue        chan *Batch
+	ctx          context.Context
+	cancel       func(err error)
+	done         chan struct{}
+	flushTimeout time.Duration
+}
+
+func NewPublisher(transport Transport, queueSize int, flushTimeout time.Duration) *Publisher {
+	if queueSize <= 0 {
+		queueSize = 1000
+	}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &Publisher{
+		transport:    transport,
+		Logger:       logx.WithContext(context.Background()).WithFields(logx.Field("service", "mq-publisher")),
+		queue:        make(chan *Batch, queueSize),
+		ctx:          ctx,
+		cancel:       cancel,
+		done:         make(chan struct{}),
+		flushTimeout: flushTimeout,
+	}
+}
+
+// Publish 把一批消息放入发送队列，队列已满时等待，直到 ctx 结束或发布器停止
+func (p *Publisher) Publish(ctx context.Context, batch *Batch) error {
+	if len(batch.Messages) == 0 {
+		return nil
+	}
+	if p.ctx.Err() != nil {
+		return ErrPublisherStopped
+	}
+	select {
+	case p.queue <- batch:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-p.ctx.Done():
+		return ErrPublisherStopped
+	}
+}
+
+// Start implements service.Service.
+func (p *Publisher) Start() {
+	defer close(p.done)
+	for {
+		select {
+		case <-p.ctx.Done():
+			p.flush(nil)
+			return
+		case batch := <-p.queue:
+			if !p.send(p.ctx, batch) {
+				p.flush(batch)
+				return
+			}
+		}
+	}
+}
+
+// Stop implements service.Service. 停止前在 flushTimeout 内尽量发送完队列中剩余的消息
+func (p *Publisher) Stop() {
+	p.cancel(ErrPublisherStopped)
+	select {
+	case <-p.done:
+	case <-time.After(p.flushTimeout + time.Second):
+		p.Error("mq publisher stop timeout")
+	}
+	if err := p.transport.Close(); err != nil {
+		p.Errorf("mq publisher close transport err: %v", err)
+	}
+}
+
+// send 发送一批消息，失败后退避重试，ctx 结束时返回 false
+func (p *Publisher) send(ctx context.Context, batch *Batch) bool {
+	backoff := minRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := p.transport.Send(ctx, batch.Messages)
+		if err == nil {
+			return true
+		}
+		p.Errorf("mq publish batch %s err: %v, attempt: %d, retry after: %v", batch.Name, err, attempt, backoff)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// flush 停止时发送正在重试的一批和队列中剩余的批次
+func (p *Publisher) flush(pending *Batch) {
+	var batches []*Batch
+	if pending != nil {
+		batches = append(batches, pending)
+	}
+drain:
+	for {
+		select {
+		case batch := <-p.queue:
+			batches = append(batches, batch)
+		default:
+			break drain
+		}
+	}
+	if len(batches) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.flushTimeout)
+	defer cancel()
+	for i, batch := range batches {
+		if !p.send(ctx, batch) {
+			names := make([]string, 0, len(batches)-i)
+			for _, item := range batches[i:] {
+				names = append(names, item.Name)
+			}
+			p.Errorf("mq publisher flush timeout, unpublished batches: %s", strings.Join(names, ","))
+			return
+		}
+	}
+	p.Infof("mq publisher flushed %d batches", len(batches))
+}
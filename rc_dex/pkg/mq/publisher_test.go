@@ -0,0 +1,76 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newBatch(slot int, keys ...string) *Batch {
+	batch := &Batch{Name: strconv.Itoa(slot)}
+	for i, key := range keys {
+		batch.Messages = append(batch.Messages, Message{
+			Topic: "sol_trade",
+			Key:   key,
+			Value: []byte(strconv.Itoa(slot) + ":" + strconv.Itoa(i+1)),
+		})
+	}
+	return batch
+}
+
+func values(messages []Message) []string {
+	result := make([]string, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, string(message.Value))
+	}
+	return result
+}
+
+func TestPublisherKeepsOrderAcrossRetries(t *testing.T) {
+	transport := NewMemoryTransport()
+	transport.FailNext(errors.New("broker down"), errors.New("broker down"))
+	publisher := NewPublisher(transport, 10, time.Second)
+	go publisher.Start()
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Publish(ctx, newBatch(100, "a", "b")))
+	require.NoError(t, publisher.Publish(ctx, newBatch(101, "a")))
+	require.NoError(t, publisher.Publish(ctx, newBatch(102, "b", "a")))
+
+	require.Eventually(t, func() bool {
+		return len(transport.Messages("")) == 5
+	}, 3*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"100:1", "100:2", "101:1", "102:1", "102:2"}, values(transport.Messages("sol_trade")))
+
+	publisher.Stop()
+	require.ErrorIs(t, publisher.Publish(ctx, newBatch(103, "a")), ErrPublisherStopped)
+}
+
+func TestPublisherFlushesQueueOnStop(t *testing.T) {
+	transport := NewMemoryTransport()
+	publisher := NewPublisher(transport, 10, time.Second)
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Publish(ctx, newBatch(200, "a")))
+	require.NoError(t, publisher.Publish(ctx, newBatch(201, "a")))
+	require.NoError(t, publisher.Publish(ctx, &Batch{Name: "empty"}))
+
+	go publisher.Start()
+	publisher.Stop()
+	require.Equal(t, []string{"200:1", "201:1"}, values(transport.Messages("")))
+}
+
+func TestPublisherPublishBlocksWhenQueueFull(t *testing.T) {
+	transport := NewMemoryTransport()
+	publisher := NewPublisher(transport, 1, time.Second)
+
+	require.NoError(t, publisher.Publish(context.Background(), newBatch(300, "a")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, publisher.Publish(ctx, newBatch(301, "a")), context.DeadlineExceeded)
+}
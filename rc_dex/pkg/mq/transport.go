@@ -0,0 +1,23 @@
+package mq
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrTransportClosed = errors.New("mq transport closed")
+
+// Message 一条队列消息，同一 Key 的消息会写入同一分区，消费端按写入顺序读到
+type Message struct {
+	Topic   string
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+// Transport 消息队列的底层传输，Send 在全部消息被 broker 确认后才返回 nil，
+// 同一次调用内同一 Key 的消息保持顺序；返回错误时可能已有部分消息写入，调用方整批重试即可（至少一次）
+type Transport interface {
+	Send(ctx context.Context, messages []Message) error
+	Close() error
+}
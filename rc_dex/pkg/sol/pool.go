@@ -0,0 +1,100 @@
+package sol
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	ag_rpc "github.com/gagliardetto/solana-go/rpc"
+
+	"richcode.cc/dex/pkg/pumpfun/generated/pump_amm"
+)
+
+// getMultipleAccounts 单次请求最多查询的账户数量
+const maxMultipleAccounts = 100
+
+// PumpSwapPoolState 链上 PumpSwap 池子账户及两个金库的余额
+type PumpSwapPoolState struct {
+	Pool          *pump_amm.Pool
+	BaseReserves  uint64 // base（代币）金库余额
+	QuoteReserves uint64 // quote（SOL）金库余额
+}
+
+// GetPumpSwapPoolStates 批量读取池子账户并解析出两个金库地址，再批量读取金库余额；
+// 返回读取金库时的 slot，账户不存在或解析失败的池子不在结果中
+func GetPumpSwapPoolStates(c *ag_rpc.Client, ctx context.Context, pools []solana.PublicKey) (map[solana.PublicKey]*PumpSwapPoolState, uint64, error) {
+	states := make(map[solana.PublicKey]*PumpSwapPoolState, len(pools))
+	poolInfos, _, err := getMultipleAccounts(c, ctx, pools)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetPumpSwapPoolStates: get pool accounts err: %w", err)
+	}
+
+	vaults := make([]solana.PublicKey, 0, len(pools)*2)
+	for i, info := range poolInfos {
+		if info == nil {
+			continue
+		}
+		pool, err := pump_amm.ParseAccount_Pool(info.Data.GetBinary())
+		if err != nil {
+			continue
+		}
+		states[pools[i]] = &PumpSwapPoolState{Pool: pool}
+		vaults = append(vaults, pool.PoolBaseTokenAccount, pool.PoolQuoteTokenAccount)
+	}
+	if len(vaults) == 0 {
+		return states, 0, nil
+	}
+
+	vaultInfos, slot, err := getMultipleAccounts(c, ctx, vaults)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetPumpSwapPoolStates: get vault accounts err: %w", err)
+	}
+	balances := make(map[solana.PublicKey]uint64, len(vaults))
+	for i, info := range vaultInfos {
+		if info == nil {
+			continue
+		}
+		// Token 账户布局：mint(32) + owner(32) + amount(8) ...，Token-2022 账户前缀相同
+		data := info.Data.GetBinary()
+		if len(data) < 72 {
+			continue
+		}
+		balances[vaults[i]] = binary.LittleEndian.Uint64(data[64:72])
+	}
+
+	for pool, state := range states {
+		base, baseOk := balances[state.Pool.PoolBaseTokenAccount]
+		quote, quoteOk := balances[state.Pool.PoolQuoteTokenAccount]
+		if !baseOk || !quoteOk {
+			delete(states, pool)
+			continue
+		}
+		state.BaseReserves, state.QuoteReserves = base, quote
+	}
+	return states, slot, nil
+}
+
+// getMultipleAccounts 按单次上限分批查询账户，结果与 accounts 一一对应；返回各批次中最小的 slot
+func getMultipleAccounts(c *ag_rpc.Client, ctx context.Context, accounts []solana.PublicKey) ([]*ag_rpc.Account, uint64, error) {
+	result := make([]*ag_rpc.Account, 0, len(accounts))
+	var slot uint64
+	for start := 0; start < len(accounts); start += maxMultipleAccounts {
+		end := min(start+maxMultipleAccounts, len(accounts))
+		resp, err := c.GetMultipleAccountsWithOpts(ctx, accounts[start:end], &ag_rpc.GetMultipleAccountsOpts{
+			Encoding:   solana.EncodingBase64,
+			Commitment: ag_rpc.CommitmentConfirmed,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp == nil || len(resp.Value) != end-start {
+			return nil, 0, fmt.Errorf("unexpected accounts size, want: %v", end-start)
+		}
+		if slot == 0 || resp.Context.Slot < slot {
+			slot = resp.Context.Slot
+		}
+		result = append(result, resp.Value...)
+	}
+	return result, slot, nil
+}
@@ -0,0 +1,220 @@
+package sol
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/gagliardetto/solana-go"
+	ag_rpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/shopspring/decimal"
+
+	"richcode.cc/dex/pkg/pumpfun/generated/pump_amm"
+	"richcode.cc/dex/pkg/sol/token2022"
+	"richcode.cc/dex/pkg/transfer"
+)
+
+// TokenSecurity 代币 mint 账户上与风险相关的权限和扩展
+type TokenSecurity struct {
+	Program                string // Token / Token-2022 Program 地址
+	MintAuthority          string // 增发权限，空表示已放弃
+	FreezeAuthority        string // 冻结权限，空表示已放弃
+	TransferFeeBasisPoints uint16 // 当前 epoch 生效的转账手续费（基点）
+	TransferFeeAuthority   string // 可修改手续费的地址
+	TransferHookProgram    string // 转账时会被调用的外部程序
+	TransferHookAuthority  string // 可修改 transfer hook 程序的地址
+	PermanentDelegate      string // 永久代理，可转走或销毁任意账户中的代币
+	DefaultFrozen          bool   // 新建的代币账户默认冻结
+	Pausable               bool   // 存在暂停扩展
+	Paused                 bool   // 当前已暂停转账
+	PauseAuthority         string // 可暂停转账的地址
+	NonTransferable        bool   // 不可转让代币
+	CloseAuthority         string // 可关闭 mint 的地址
+}
+
+// GetMintInfo 读取 mint 账户（jsonParsed），返回解析后的 mint 信息及其所属的 Token Program 地址
+func GetMintInfo(c *ag_rpc.Client, ctx context.Context, address solana.PublicKey) (*token2022.Info, string, error) {
+	resp, err := c.GetAccountInfoWithOpts(ctx, address, &ag_rpc.GetAccountInfoOpts{
+		Encoding:   solana.EncodingJSONParsed,
+		Commitment: ag_rpc.CommitmentConfirmed,
+	})
+	if err != nil || resp == nil || resp.Value == nil {
+		return nil, "", fmt.Errorf("GetMintInfo:GetAccountInfoWithOpts err:%v, token address: %v", err, address)
+	}
+	if len(resp.Value.Data.GetRawJSON()) == 0 {
+		return nil, "", fmt.Errorf("GetMintInfo: token data is not json parsed, token address: %v", address)
+	}
+
+	mintResponse, err := transfer.Byte2Struct[*token2022.MintResponse](resp.Value.Data.GetRawJSON())
+	if err != nil {
+		return nil, "", fmt.Errorf("GetMintInfo:Byte2Struct err:%v, token address: %v", err, address)
+	}
+	return &mintResponse.Parsed.Info, resp.Value.Owner.String(), nil
+}
+
+// GetTokenSecurity 读取 mint 账户，解析增发/冻结权限以及 Token-2022 扩展
+func GetTokenSecurity(c *ag_rpc.Client, ctx context.Context, address solana.PublicKey) (*TokenSecurity, error) {
+	info, program, err := GetMintInfo(c, ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	security := &TokenSecurity{
+		Program:       program,
+		MintAuthority: info.MintAuthority,
+	}
+	if info.FreezeAuthority != nil {
+		security.FreezeAuthority = *info.FreezeAuthority
+	}
+
+	if ext := info.FindExtension(token2022.ExtensionTransferFeeConfig); ext != nil {
+		// 新旧费率按 epoch 切换，读不到当前 epoch 时无法确定生效的费率，整体返回错误等待下次检测
+		epochInfo, err := c.GetEpochInfo(ctx, ag_rpc.CommitmentConfirmed)
+		if err != nil || epochInfo == nil {
+			return nil, fmt.Errorf("GetTokenSecurity:GetEpochInfo err:%v, token address: %v", err, address)
+		}
+		if fee := ext.State.CurrentTransferFee(epochInfo.Epoch); fee != nil {
+			security.TransferFeeBasisPoints = fee.TransferFeeBasisPoints
+		}
+		security.TransferFeeAuthority = stringValue(ext.State.TransferFeeConfigAuthority)
+	}
+	if ext := info.FindExtension(token2022.ExtensionTransferHook); ext != nil {
+		security.TransferHookProgram = stringValue(ext.State.ProgramId)
+		security.TransferHookAuthority = stringValue(ext.State.Authority)
+	}
+	if ext := info.FindExtension(token2022.ExtensionPermanentDelegate); ext != nil {
+		security.PermanentDelegate = stringValue(ext.State.Delegate)
+	}
+	if ext := info.FindExtension(token2022.ExtensionDefaultAccountState); ext != nil {
+		security.DefaultFrozen = ext.State.AccountState == token2022.AccountStateFrozen
+	}
+	if ext := info.FindExtension(token2022.ExtensionPausableConfig); ext != nil {
+		security.Pausable = true
+		security.Paused = ext.State.Paused
+		security.PauseAuthority = stringValue(ext.State.Authority)
+	}
+	if ext := info.FindExtension(token2022.ExtensionMintCloseAuthority); ext != nil {
+		security.CloseAuthority = stringValue(ext.State.CloseAuthority)
+	}
+	security.NonTransferable = info.FindExtension(token2022.ExtensionNonTransferable) != nil
+
+	return security, nil
+}
+
+// TransferFeeRate 手续费比例，例如 500 基点返回 0.05
+func (security *TokenSecurity) TransferFeeRate() float64 {
+	return decimal.NewFromInt(int64(security.TransferFeeBasisPoints)).Div(decimal.NewFromInt(token2022.MaxFeeBasisPoints)).InexactFloat64()
+}
+
+// LpStatus 池子 LP 的销毁与锁定情况
+type LpStatus struct {
+	LpMint        string
+	LpSupply      uint64  // 池子记录的 LP 发行量（不扣除销毁）
+	MintSupply    uint64  // LP mint 当前供应量（已扣除销毁）
+	LockedAmount  uint64  // 锁仓地址持有的 LP 数量
+	BurnedPercent float64 // 已销毁的 LP 占比（百分比）
+	LockedPercent float64 // 锁仓地址持有的 LP 占比（百分比）
+}
+
+// GetPumpSwapLpStatus 读取 PumpSwap 池子账户，比较池子 LP 发行量与 LP mint 当前供应量得到销毁比例；
+// 再从 LP 最大持有者中统计 lockOwners（锁仓合约的金库地址）持有的部分
+func GetPumpSwapLpStatus(c *ag_rpc.Client, ctx context.Context, pool solana.PublicKey, lockOwners []string) (*LpStatus, error) {
+	resp, err := c.GetAccountInfoWithOpts(ctx, pool, &ag_rpc.GetAccountInfoOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: ag_rpc.CommitmentConfirmed,
+	})
+	if err != nil || resp == nil || resp.Value == nil {
+		return nil, fmt.Errorf("GetPumpSwapLpStatus:GetAccountInfoWithOpts err:%v, pool: %v", err, pool)
+	}
+	poolAccount, err := pump_amm.ParseAccount_Pool(resp.Value.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("GetPumpSwapLpStatus:ParseAccount_Pool err:%v, pool: %v", err, pool)
+	}
+
+	status := &LpStatus{
+		LpMint:   poolAccount.LpMint.String(),
+		LpSupply: poolAccount.LpSupply,
+	}
+	if status.LpSupply == 0 {
+		return status, nil
+	}
+
+	supply, err := c.GetTokenSupply(ctx, poolAccount.LpMint, ag_rpc.CommitmentConfirmed)
+	if err != nil || supply == nil || supply.Value == nil {
+		return nil, fmt.Errorf("GetPumpSwapLpStatus:GetTokenSupply err:%v, lp mint: %v", err, poolAccount.LpMint)
+	}
+	status.MintSupply, _ = strconv.ParseUint(supply.Value.Amount, 10, 64)
+	if status.MintSupply < status.LpSupply {
+		status.BurnedPercent = lpPercent(status.LpSupply-status.MintSupply, status.LpSupply)
+	}
+
+	if len(lockOwners) > 0 && status.MintSupply > 0 {
+		status.LockedAmount, err = getLockedAmount(c, ctx, poolAccount.LpMint, lockOwners)
+		if err != nil {
+			return nil, err
+		}
+		status.LockedPercent = lpPercent(status.LockedAmount, status.LpSupply)
+	}
+	return status, nil
+}
+
+// getLockedAmount 统计 LP 前 20 大持有账户中 owner 属于 lockOwners 的数量
+func getLockedAmount(c *ag_rpc.Client, ctx context.Context, lpMint solana.PublicKey, lockOwners []string) (uint64, error) {
+	largest, err := c.GetTokenLargestAccounts(ctx, lpMint, ag_rpc.CommitmentConfirmed)
+	if err != nil || largest == nil {
+		return 0, fmt.Errorf("getLockedAmount:GetTokenLargestAccounts err:%v, lp mint: %v", err, lpMint)
+	}
+	if len(largest.Value) == 0 {
+		return 0, nil
+	}
+
+	accounts := make([]solana.PublicKey, 0, len(largest.Value))
+	for _, item := range largest.Value {
+		accounts = append(accounts, item.Address)
+	}
+
+	infos, err := c.GetMultipleAccountsWithOpts(ctx, accounts, &ag_rpc.GetMultipleAccountsOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: ag_rpc.CommitmentConfirmed,
+	})
+	if err != nil || infos == nil {
+		return 0, fmt.Errorf("getLockedAmount:GetMultipleAccountsWithOpts err:%v, lp mint: %v", err, lpMint)
+	}
+
+	lockSet := make(map[string]struct{}, len(lockOwners))
+	for _, owner := range lockOwners {
+		lockSet[owner] = struct{}{}
+	}
+
+	var locked uint64
+	for _, info := range infos.Value {
+		if info == nil {
+			continue
+		}
+		// Token 账户布局：mint(32) + owner(32) + amount(8) ...
+		data := info.Data.GetBinary()
+		if len(data) < 72 {
+			continue
+		}
+		owner := solana.PublicKeyFromBytes(data[32:64]).String()
+		if _, ok := lockSet[owner]; ok {
+			locked += binary.LittleEndian.Uint64(data[64:72])
+		}
+	}
+	return locked, nil
+}
+
+func lpPercent(amount, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return decimal.NewFromUint64(amount).Div(decimal.NewFromUint64(total)).Mul(decimal.NewFromInt(100)).InexactFloat64()
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -89,6 +89,44 @@ func GetTokenTotalSupply(c *client.Client, ctx context.Context, address string)
 	return totalSupply, nil
 }
 
+// GetTokenTotalSupplies 批量读取 mint 账户，按账户中的 supply 和 decimals 计算总发行量（已除精度），每次最多读取 maxMultipleAccounts 个；
+// 账户不存在或不是 Token / Token-2022 mint 的地址不在结果中
+func GetTokenTotalSupplies(c *client.Client, ctx context.Context, addresses []string) (map[string]decimal.Decimal, error) {
+	supplies := make(map[string]decimal.Decimal, len(addresses))
+	for start := 0; start < len(addresses); start += maxMultipleAccounts {
+		batch := addresses[start:min(start+maxMultipleAccounts, len(addresses))]
+		// Token-2022 mint 的扩展数据在基础布局之后，只读取前 MintAccountSize 字节
+		accounts, err := c.GetMultipleAccountsWithConfig(ctx, batch, client.GetMultipleAccountsConfig{
+			Commitment: rpc.CommitmentConfirmed,
+			DataSlice:  &rpc.DataSlice{Offset: 0, Length: token.MintAccountSize},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTokenTotalSupplies err:%v, size: %v", err, len(batch))
+		}
+		for i, account := range accounts {
+			if i >= len(batch) {
+				break
+			}
+			if supply, ok := TokenTotalSupplyFromAccount(account); ok {
+				supplies[batch[i]] = supply
+			}
+		}
+	}
+	return supplies, nil
+}
+
+// TokenTotalSupplyFromAccount 从 mint 账户数据中读取总发行量（已除精度），Token 与 Token-2022 的基础布局一致
+func TokenTotalSupplyFromAccount(account client.AccountInfo) (decimal.Decimal, bool) {
+	if account.Owner != common.TokenProgramID && account.Owner != common.Token2022ProgramID || len(account.Data) < token.MintAccountSize {
+		return decimal.Zero, false
+	}
+	mint, err := token.MintAccountFromData(account.Data[:token.MintAccountSize])
+	if err != nil || !mint.IsInitialized {
+		return decimal.Zero, false
+	}
+	return decimal.NewFromUint64(mint.Supply).Div(decimal.New(1, int32(mint.Decimals))), true
+}
+
 func GetTokenProgram(c *client.Client, ctx context.Context, address string) (program common.PublicKey, err error) {
 	resp, err := c.GetAccountInfoWithConfig(ctx, address, client.GetAccountInfoConfig{
 		Commitment: rpc.CommitmentConfirmed,
@@ -0,0 +1,66 @@
+package sol
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/token"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// mintData 按 SPL Token mint 账户布局构造数据：36 字节 mint authority、8 字节 supply、1 字节精度、1 字节初始化标记、36 字节 freeze authority
+func mintData(supply uint64, decimals uint8, initialized bool) []byte {
+	data := make([]byte, token.MintAccountSize)
+	binary.LittleEndian.PutUint64(data[36:], supply)
+	data[44] = decimals
+	if initialized {
+		data[45] = 1
+	}
+	return data
+}
+
+func TestTokenTotalSupplyFromAccount(t *testing.T) {
+	// Token-2022 mint 在 82 字节之后还有扩展数据
+	token2022Data := append(mintData(1_000_000_000_000_000, 6, true), make([]byte, 84)...)
+
+	tests := []struct {
+		name    string
+		account client.AccountInfo
+		supply  string
+		ok      bool
+	}{
+		{
+			name:    "token mint",
+			account: client.AccountInfo{Owner: common.TokenProgramID, Data: mintData(999_999_123_456_789, 6, true)},
+			supply:  "999999123.456789", ok: true,
+		},
+		{
+			name:    "token-2022 mint with extensions",
+			account: client.AccountInfo{Owner: common.Token2022ProgramID, Data: token2022Data},
+			supply:  "1000000000", ok: true,
+		},
+		{
+			name:    "zero decimals",
+			account: client.AccountInfo{Owner: common.TokenProgramID, Data: mintData(42, 0, true)},
+			supply:  "42", ok: true,
+		},
+		{name: "not a token program", account: client.AccountInfo{Owner: common.SystemProgramID, Data: mintData(42, 0, true)}},
+		{name: "data too short", account: client.AccountInfo{Owner: common.TokenProgramID, Data: make([]byte, token.MintAccountSize-1)}},
+		{name: "uninitialized", account: client.AccountInfo{Owner: common.TokenProgramID, Data: mintData(42, 0, false)}},
+		{name: "account missing", account: client.AccountInfo{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			supply, ok := TokenTotalSupplyFromAccount(tt.account)
+			require.Equal(t, tt.ok, ok)
+			if !tt.ok {
+				require.True(t, supply.IsZero())
+				return
+			}
+			require.True(t, decimal.RequireFromString(tt.supply).Equal(supply), "supply = %v", supply)
+		})
+	}
+}
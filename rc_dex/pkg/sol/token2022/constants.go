@@ -1 +1,23 @@
 package token2022
+
+// Token-2022 jsonParsed 结果中的扩展名称
+const (
+	ExtensionTransferFeeConfig     = "transferFeeConfig"
+	ExtensionTransferHook          = "transferHook"
+	ExtensionPermanentDelegate     = "permanentDelegate"
+	ExtensionDefaultAccountState   = "defaultAccountState"
+	ExtensionPausableConfig        = "pausableConfig"
+	ExtensionMintCloseAuthority    = "mintCloseAuthority"
+	ExtensionNonTransferable       = "nonTransferable"
+	ExtensionInterestBearingConfig = "interestBearingConfig"
+	ExtensionTokenMetadata         = "tokenMetadata"
+)
+
+// AccountStateFrozen defaultAccountState 扩展中新建账户默认冻结的状态值
+const AccountStateFrozen = "frozen"
+
+// MaxFeeBasisPoints 转账手续费基点上限，10000 即 100%
+const MaxFeeBasisPoints = 10000
+
+// SecondsPerYear 计息扩展使用的一年秒数，与链上程序一致（365.24 天）
+const SecondsPerYear = 60 * 60 * 24 * 365.24
@@ -34,4 +34,27 @@ type State struct {
 	Symbol          string `json:"symbol"`
 	UpdateAuthority string `json:"updateAuthority"`
 	Uri             string `json:"uri"`
+
+	// transferFeeConfig
+	TransferFeeConfigAuthority *string      `json:"transferFeeConfigAuthority"`
+	WithdrawWithheldAuthority  *string      `json:"withdrawWithheldAuthority"`
+	WithheldAmount             uint64       `json:"withheldAmount"`
+	OlderTransferFee           *TransferFee `json:"olderTransferFee"`
+	NewerTransferFee           *TransferFee `json:"newerTransferFee"`
+	// transferHook
+	ProgramId *string `json:"programId"`
+	// permanentDelegate
+	Delegate *string `json:"delegate"`
+	// defaultAccountState
+	AccountState string `json:"accountState"`
+	// pausableConfig
+	Paused bool `json:"paused"`
+	// mintCloseAuthority
+	CloseAuthority *string `json:"closeAuthority"`
+	// interestBearingConfig，利率单位为基点（年化）
+	RateAuthority           *string `json:"rateAuthority"`
+	InitializationTimestamp int64   `json:"initializationTimestamp"`
+	PreUpdateAverageRate    int16   `json:"preUpdateAverageRate"`
+	LastUpdateTimestamp     int64   `json:"lastUpdateTimestamp"`
+	CurrentRate             int16   `json:"currentRate"`
 }
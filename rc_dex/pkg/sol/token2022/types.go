@@ -1 +1,63 @@
 package token2022
+
+import (
+	"math"
+	"math/bits"
+)
+
+// TransferFee transferFeeConfig 扩展中的一档费率，从 Epoch 开始生效
+type TransferFee struct {
+	Epoch                  uint64 `json:"epoch"`
+	MaximumFee             uint64 `json:"maximumFee"`
+	TransferFeeBasisPoints uint16 `json:"transferFeeBasisPoints"`
+}
+
+// Calculate 计算转账 amount 时扣除的手续费（向上取整，不超过 MaximumFee）
+func (fee *TransferFee) Calculate(amount uint64) uint64 {
+	if fee == nil || fee.TransferFeeBasisPoints == 0 || amount == 0 {
+		return 0
+	}
+	// amount * 基点可能超过 uint64，按 128 位计算
+	hi, lo := bits.Mul64(amount, uint64(fee.TransferFeeBasisPoints))
+	lo, carry := bits.Add64(lo, MaxFeeBasisPoints-1, 0)
+	raw, _ := bits.Div64(hi+carry, lo, MaxFeeBasisPoints)
+	return min(raw, fee.MaximumFee)
+}
+
+// FindExtension 按名称查找 mint 上的扩展，不存在时返回 nil
+func (info *Info) FindExtension(name string) *Extension {
+	if info == nil {
+		return nil
+	}
+	for i := range info.Extensions {
+		if info.Extensions[i].Extension == name {
+			return &info.Extensions[i]
+		}
+	}
+	return nil
+}
+
+// CurrentTransferFee 返回 epoch 时生效的费率：新费率到达生效 epoch 前仍使用旧费率
+func (state *State) CurrentTransferFee(epoch uint64) *TransferFee {
+	if state == nil {
+		return nil
+	}
+	if state.NewerTransferFee != nil && epoch >= state.NewerTransferFee.Epoch {
+		return state.NewerTransferFee
+	}
+	if state.OlderTransferFee != nil {
+		return state.OlderTransferFee
+	}
+	return state.NewerTransferFee
+}
+
+// InterestScale 计息扩展在 unixTimestamp 时原始数量到展示数量的倍数，与链上 amount_to_ui_amount 的算法一致：
+// 上次调整利率前按平均利率连续复利，之后按当前利率连续复利
+func (state *State) InterestScale(unixTimestamp int64) float64 {
+	if state == nil {
+		return 1
+	}
+	preUpdate := float64(state.PreUpdateAverageRate) * float64(state.LastUpdateTimestamp-state.InitializationTimestamp)
+	postUpdate := float64(state.CurrentRate) * float64(max(unixTimestamp-state.LastUpdateTimestamp, 0))
+	return math.Exp((preUpdate + postUpdate) / SecondsPerYear / MaxFeeBasisPoints)
+}
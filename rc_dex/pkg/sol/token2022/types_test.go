@@ -0,0 +1,125 @@
+package token2022
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mintJson getAccountInfo jsonParsed 返回的 Token-2022 mint，带转账手续费和计息扩展
+const mintJson = `{
+  "parsed": {
+    "info": {
+      "decimals": 6,
+      "extensions": [
+        {
+          "extension": "transferFeeConfig",
+          "state": {
+            "newerTransferFee": {"epoch": 812, "maximumFee": 1000000000, "transferFeeBasisPoints": 250},
+            "olderTransferFee": {"epoch": 800, "maximumFee": 5000000, "transferFeeBasisPoints": 100},
+            "transferFeeConfigAuthority": "6sdSwJ8mRwhhZDbkFTFwCgLqsLUW7pBCVqjRqFNrzM2n",
+            "withdrawWithheldAuthority": "6sdSwJ8mRwhhZDbkFTFwCgLqsLUW7pBCVqjRqFNrzM2n",
+            "withheldAmount": 1234
+          }
+        },
+        {
+          "extension": "interestBearingConfig",
+          "state": {
+            "currentRate": -200,
+            "initializationTimestamp": 1700000000,
+            "lastUpdateTimestamp": 1730000000,
+            "preUpdateAverageRate": 300,
+            "rateAuthority": null
+          }
+        }
+      ],
+      "freezeAuthority": null,
+      "isInitialized": true,
+      "mintAuthority": null,
+      "supply": "1000000000000000"
+    },
+    "type": "mint"
+  },
+  "program": "spl-token-2022",
+  "space": 326
+}`
+
+func TestDecodeTransferFeeConfig(t *testing.T) {
+	var mint MintResponse
+	require.NoError(t, json.Unmarshal([]byte(mintJson), &mint))
+
+	ext := mint.Parsed.Info.FindExtension(ExtensionTransferFeeConfig)
+	require.NotNil(t, ext)
+	require.Equal(t, uint64(1234), ext.State.WithheldAmount)
+	require.NotNil(t, ext.State.TransferFeeConfigAuthority)
+	require.Nil(t, mint.Parsed.Info.FindExtension(ExtensionTransferHook))
+
+	tests := []struct {
+		name   string
+		epoch  uint64
+		amount uint64
+		fee    uint64
+	}{
+		{name: "older fee before newer epoch", epoch: 811, amount: 1_000_000, fee: 10_000},
+		{name: "older maximum fee", epoch: 811, amount: 1_000_000_000, fee: 5_000_000},
+		{name: "newer fee from its epoch", epoch: 812, amount: 1_000_000, fee: 25_000},
+		{name: "newer maximum fee", epoch: 900, amount: math.MaxUint64, fee: 1_000_000_000},
+		{name: "fee rounds up", epoch: 812, amount: 1, fee: 1},
+		{name: "zero amount", epoch: 812, amount: 0, fee: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.fee, ext.State.CurrentTransferFee(tt.epoch).Calculate(tt.amount))
+		})
+	}
+
+	// 只有新费率时任何 epoch 都按新费率；没有扩展时不扣手续费
+	state := &State{NewerTransferFee: &TransferFee{Epoch: 812, TransferFeeBasisPoints: 50, MaximumFee: math.MaxUint64}}
+	require.Equal(t, uint64(5_000), state.CurrentTransferFee(0).Calculate(1_000_000))
+	require.Equal(t, uint64(0), (*State)(nil).CurrentTransferFee(812).Calculate(1_000_000))
+	// amount * 基点超过 uint64 时按 128 位计算
+	full := &TransferFee{TransferFeeBasisPoints: MaxFeeBasisPoints, MaximumFee: math.MaxUint64}
+	require.Equal(t, uint64(math.MaxUint64), full.Calculate(math.MaxUint64))
+}
+
+func TestInterestScale(t *testing.T) {
+	var mint MintResponse
+	require.NoError(t, json.Unmarshal([]byte(mintJson), &mint))
+	ext := mint.Parsed.Info.FindExtension(ExtensionInterestBearingConfig)
+	require.NotNil(t, ext)
+
+	const year = int64(SecondsPerYear)
+	tests := []struct {
+		name      string
+		state     *State
+		timestamp int64
+		scale     float64
+	}{
+		{name: "no extension", timestamp: 1730000000, scale: 1},
+		{
+			name:      "one year at 5%",
+			state:     &State{InitializationTimestamp: 1700000000, LastUpdateTimestamp: 1700000000, CurrentRate: 500},
+			timestamp: 1700000000 + year,
+			scale:     math.Exp(0.05),
+		},
+		{
+			name:      "average rate before update then current rate",
+			state:     &ext.State,
+			timestamp: 1730000000 + year,
+			scale:     math.Exp((300*float64(30000000)/SecondsPerYear - 200) / MaxFeeBasisPoints),
+		},
+		{
+			name:      "timestamp before last update",
+			state:     &ext.State,
+			timestamp: 1720000000,
+			scale:     math.Exp(300 * float64(30000000) / SecondsPerYear / MaxFeeBasisPoints),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.scale, tt.state.InterestScale(tt.timestamp), 1e-12)
+		})
+	}
+}
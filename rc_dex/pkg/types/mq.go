@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/blocto/solana-go-sdk/common"
@@ -8,12 +11,20 @@ import (
 	"richcode.cc/dex/model/solmodel"
 )
 
+// 队列消息类型
+const (
+	MqMessageTrade = "trade"
+	MqMessagePair  = "pair"
+	MqMessageToken = "token"
+)
+
 const (
 	TradeTypeSell                                      = "sell"
 	TradeTypeBuy                                       = "buy"
 	TradePumpAmmCreatePool                             = "pump_amm_create_pool"
 	TradePumpAmmSell                                   = "pump_amm_sell"
 	TradePumpAmmBuy                                    = "pump_amm_buy"
+	TradePumpAmmCollectCreatorFee                      = "pump_amm_collect_creator_fee"
 	TradeTypeAddPosition                               = "add"
 	TradeTypeRemovePosition                            = "remove"
 	TradePumpCreate                                    = "create"
@@ -97,6 +108,7 @@ type TradeWithPair struct {
 	BlockNum                     int64                 `json:"block_num"`                         // Block height
 	BlockTime                    int64                 `json:"block_time"`                        // Block time
 	TransactionIndex             int                   `json:"transaction_index"`                 // Transaction index
+	InstructionIndex             int                   `json:"instruction_index"`                 // Index of the outer instruction within the transaction; CPI trades use (outer+1)*1000+inner
 	LogIndex                     int                   `json:"log_index"`                         // Log index
 	SwapName                     string                `json:"swap_name"`                         // Trading pair version
 	CurrentTokenInPoolAmount     float64               `json:"current_token_in_pool_amount"`      // Current token amount in pool
@@ -117,6 +129,20 @@ type TradeWithPair struct {
 	Clamp              bool  `json:"clamp"` // true: clamped or in a clamp
 	Clipper            bool  `json:"-"`     // true: clamp
 
+	// In-block behaviour tag set by the sandwich/wash analyzer, see solmodel.TradeMevTag*; empty for a normal trade
+	MevTag string `json:"mev_tag"`
+	// Trading bot or frontend the trade was routed through (photon, bullx...), see the Platform config; empty when unknown
+	Platform string `json:"platform"`
+
+	// Token-2022 transfer fee withheld on the token leg; TokenAmount is already net of it
+	TokenTransferFee    float64 `json:"token_transfer_fee"`
+	TokenTransferFeeInt int64   `json:"token_transfer_fee_int"` // Not divided by decimal
+
+	// PumpSwap fees charged on the quote (SOL) leg, in SOL; CreatorFee accrues to PumpOwner
+	LpFee       float64 `json:"lp_fee"`
+	ProtocolFee float64 `json:"protocol_fee"`
+	CreatorFee  float64 `json:"creator_fee"`
+
 	// pump
 	PumpPoint                    float64   `json:"pump_point"`    // Pump score
 	PumpLaunched                 bool      `json:"pump_launched"` // Pump launched
@@ -140,4 +166,51 @@ type TradeWithPair struct {
 	PoolBaseTokenReserves  uint64                `json:"pool_base_token_reserves"`
 	PoolQuoteTokenReserves uint64                `json:"pool_quote_token_reserves"`
 	PumpAmmInfo            *solmodel.PumpAmmInfo `json:"-"`
+	CoinCreatorVaultAta    string                `json:"coin_creator_vault_ata,omitempty"` // creator fee claim: vault the fee was collected from
+}
+
+// MqMessage 队列消息体，区块落库后按 slot 发布
+// 同一 slot 的全部消息 Seq 从 1 开始连续递增，SlotSize 为该 slot 的消息总数，消费端可据此判断一个 slot 是否收齐；
+// 发布为至少一次语义，消费端按 MsgId 去重，Seq 只用于判断 slot 是否收齐
+type MqMessage struct {
+	Type     string          `json:"type"` // trade / pair / token
+	ChainId  string          `json:"chain_id"`
+	Slot     int64           `json:"slot"`
+	Seq      int             `json:"seq"`
+	SlotSize int             `json:"slot_size"`
+	Trade    *TradeWithPair  `json:"trade,omitempty"`
+	Pair     *solmodel.Pair  `json:"pair,omitempty"`
+	Token    *solmodel.Token `json:"token,omitempty"`
+
+	PairCreated bool `json:"pair_created,omitempty"` // pair 消息：本 slot 新建的交易对
+}
+
+// MsgId 消息唯一编号，由消息内容决定，重复投递和重复处理同一 slot 时不变，用于消费端去重
+// 成交取交易哈希和指令序号；代币和交易对每个 slot 至多更新一次，取 slot 和地址；内容缺失的占位消息退回 slot 内序号
+func (m *MqMessage) MsgId() string {
+	switch {
+	case m.Type == MqMessageTrade && m.Trade != nil:
+		return fmt.Sprintf("%s:%s:%s:%d", m.ChainId, m.Type, m.Trade.TxHash, m.Trade.InstructionIndex)
+	case m.Type == MqMessagePair && m.Pair != nil:
+		return fmt.Sprintf("%s:%s:%d:%s", m.ChainId, m.Type, m.Slot, m.Pair.Address)
+	case m.Type == MqMessageToken && m.Token != nil:
+		return fmt.Sprintf("%s:%s:%d:%s", m.ChainId, m.Type, m.Slot, m.Token.Address)
+	}
+	return fmt.Sprintf("%s:%d:%d", m.ChainId, m.Slot, m.Seq)
+}
+
+// Tags 取出带 tag:"true" 的字段，以 json 名为键，作为队列消息头方便消费端按头过滤
+func (t *TradeWithPair) Tags() map[string]string {
+	tags := make(map[string]string)
+	value := reflect.ValueOf(t).Elem()
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Tag.Get("tag") != "true" {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		tags[name] = fmt.Sprint(value.Field(i).Interface())
+	}
+	return tags
 }